@@ -0,0 +1,99 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/models"
+)
+
+// IBalanceService interface of representation of parser that tracks balances of subscribers,
+// only indexed approach implements it
+type IBalanceService interface {
+	GetBalanceHistory(ctx context.Context, address models.Address) ([]models.Balance, error)
+}
+
+// BalanceOutput is a balance in result of balance command in json format, amounts are decimal strings
+type BalanceOutput struct {
+	BlockNumber uint64 `json:"block_number"`
+	Balance     string `json:"balance"`
+	BalanceETH  string `json:"balance_eth"`
+}
+
+// BalanceHistoryOutput is a result of balance command with --history flag in json format
+type BalanceHistoryOutput struct {
+	Address string          `json:"address"`
+	History []BalanceOutput `json:"history"`
+}
+
+func (c *Commands) balance(ctx context.Context, args []string) error {
+	flagSet := flag.NewFlagSet("balance", flag.ContinueOnError)
+	format := flagSet.String("format", TextFormat, "output format: text or json")
+	withHistory := flagSet.Bool("history", false, "print all balances since subscription instead of current one")
+
+	positional, err := parseArgs(flagSet, args)
+	if err != nil {
+		return err
+	}
+
+	if len(positional) != 1 {
+		return &usageError{message: "balance requires exactly one address"}
+	}
+
+	err = checkFormat(*format, TextFormat, JSONFormat)
+	if err != nil {
+		return err
+	}
+
+	address, err := models.ParseAddress(positional[0])
+	if err != nil {
+		return &usageError{message: err.Error()}
+	}
+
+	balanceService, ok := c.parserService.(IBalanceService)
+	if !ok {
+		return errors.New("balance tracking is supported only by indexed approach")
+	}
+
+	history, err := balanceService.GetBalanceHistory(ctx, address)
+	if err != nil {
+		return err
+	}
+
+	if len(history) == 0 {
+		return models.ErrBalanceNotRecorded
+	}
+
+	if !*withHistory {
+		history = history[len(history)-1:]
+	}
+
+	if *format == TextFormat {
+		for _, balance := range history {
+			if *withHistory {
+				fmt.Fprintf(c.stdout, "%d\t%s\n", balance.BlockNumber, models.FormatEther(&balance.Balance))
+			} else {
+				fmt.Fprintln(c.stdout, models.FormatEther(&balance.Balance))
+			}
+		}
+
+		return nil
+	}
+
+	outputs := make([]BalanceOutput, 0, len(history))
+	for _, balance := range history {
+		outputs = append(outputs, BalanceOutput{
+			BlockNumber: balance.BlockNumber,
+			Balance:     balance.Balance.String(),
+			BalanceETH:  models.FormatEther(&balance.Balance),
+		})
+	}
+
+	if *withHistory {
+		return json.NewEncoder(c.stdout).Encode(BalanceHistoryOutput{Address: address.Checksum(), History: outputs})
+	}
+
+	return json.NewEncoder(c.stdout).Encode(outputs[0])
+}
@@ -0,0 +1,232 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/models"
+	"io"
+	"strings"
+)
+
+// Exit codes of CLI
+const (
+	// ExitCodeOK means that command succeeded
+	ExitCodeOK = 0
+	// ExitCodeFailure means that command failed, e.g. address is not subscribed or Ethereum node is not reachable
+	ExitCodeFailure = 1
+	// ExitCodeUsage means that command or its arguments are invalid
+	ExitCodeUsage = 2
+)
+
+// Output formats of commands
+const (
+	TextFormat  = "text"
+	JSONFormat  = "json"
+	CSVFormat   = "csv"
+	TableFormat = "table"
+)
+
+// Rendering of amounts in json format
+const (
+	// RawAmounts renders amounts as JSON numbers in wei
+	RawAmounts = "raw"
+	// DecimalAmounts renders amounts as decimal strings with values in ETH and gas prices in gwei
+	DecimalAmounts = "decimal"
+)
+
+// IParserService interface of representation of parser that will be using for handling commands
+type IParserService interface {
+	GetCurrentBlock(ctx context.Context) (uint64, error)
+	GetTransactions(ctx context.Context, address models.Address) ([]*models.Transaction, error)
+	Subscribe(ctx context.Context, address models.Address, ensName string) error
+}
+
+// IENSSubscriber interface of representation of parser service that resolves ENS names itself on subscription,
+// like client of API server in remote mode, then names are not resolved locally
+type IENSSubscriber interface {
+	SubscribeENSName(ctx context.Context, ensName string) (models.Address, error)
+}
+
+// IENSResolver interface of representation of resolver that converts ENS names into addresses
+type IENSResolver interface {
+	Resolve(ctx context.Context, name string) (models.Address, error)
+}
+
+// IShell interface of representation of interactive mode
+type IShell interface {
+	Run(ctx context.Context) error
+}
+
+// usageError is an error of command line arguments, it is reported with ExitCodeUsage
+type usageError struct {
+	message string
+}
+
+func (e *usageError) Error() string {
+	return e.message
+}
+
+// command is a single CLI subcommand
+type command struct {
+	name        string
+	usage       string
+	description string
+	run         func(ctx context.Context, args []string) error
+}
+
+// Commands runs CLI subcommands. Result of command is written into stdout in requested format,
+// errors are written into stderr, so stdout can be piped into other programs
+type Commands struct {
+	parserService IParserService
+	ensResolver   IENSResolver
+	shell         IShell
+
+	stdout io.Writer
+	stderr io.Writer
+
+	commands []command
+}
+
+// NewCommands returns Commands writing results into stdout and errors into stderr
+func NewCommands(parserService IParserService, ensResolver IENSResolver, shell IShell, stdout io.Writer, stderr io.Writer) *Commands {
+	c := &Commands{
+		parserService: parserService,
+		ensResolver:   ensResolver,
+		shell:         shell,
+		stdout:        stdout,
+		stderr:        stderr,
+	}
+
+	c.commands = []command{
+		{
+			name:        "subscribe",
+			usage:       "subscribe <address|ens name> [--format text|json]",
+			description: "Subscribe address for a listening new transactions",
+			run:         c.subscribe,
+		},
+		{
+			name:        "get-transactions",
+			usage:       "get-transactions <address> [--format json|csv|table] [--amounts raw|decimal]",
+			description: "Print all transactions of subscribed address since subscription",
+			run:         c.getTransactions,
+		},
+		{
+			name:        "export",
+			usage:       "export <address> [--format csv|ndjson|parquet] [--output file]",
+			description: "Export transactions of subscribed address with values in ETH and gas prices in gwei",
+			run:         c.exportTransactions,
+		},
+		{
+			name:        "balance",
+			usage:       "balance <address> [--history] [--format text|json]",
+			description: "Print current ETH balance of subscribed address (indexed approach only)",
+			run:         c.balance,
+		},
+		{
+			name:        "current-block",
+			usage:       "current-block [--format text|json]",
+			description: "Print last parsed block",
+			run:         c.currentBlock,
+		},
+		{
+			name:        "shell",
+			usage:       "shell",
+			description: "Start interactive mode",
+			run:         c.runShell,
+		},
+	}
+
+	return c
+}
+
+// Run runs command from args (subcommand name goes first) and returns exit code of the program
+func (c *Commands) Run(ctx context.Context, args []string) int {
+	if len(args) == 0 {
+		c.printUsage()
+		return ExitCodeUsage
+	}
+
+	name := args[0]
+	if name == "help" || name == "-h" || name == "--help" {
+		c.printUsage()
+		return ExitCodeOK
+	}
+
+	for _, cmd := range c.commands {
+		if cmd.name != name {
+			continue
+		}
+
+		err := cmd.run(ctx, args[1:])
+		if err == nil {
+			return ExitCodeOK
+		}
+
+		fmt.Fprintln(c.stderr, "Error: "+ErrorMessage(err))
+
+		var errUsage *usageError
+		if errors.As(err, &errUsage) {
+			fmt.Fprintln(c.stderr, "Usage: ethereum_subscriber-cli "+cmd.usage)
+			return ExitCodeUsage
+		}
+
+		return ExitCodeFailure
+	}
+
+	fmt.Fprintln(c.stderr, "Error: unknown command "+name)
+	c.printUsage()
+
+	return ExitCodeUsage
+}
+
+func (c *Commands) printUsage() {
+	fmt.Fprintln(c.stderr, "Usage: ethereum_subscriber-cli [--config path] <command> [arguments]")
+	fmt.Fprintln(c.stderr)
+	fmt.Fprintln(c.stderr, "Commands:")
+	for _, cmd := range c.commands {
+		fmt.Fprintf(c.stderr, "  %-78s %s\n", cmd.usage, cmd.description)
+	}
+}
+
+func (c *Commands) runShell(ctx context.Context, args []string) error {
+	if len(args) != 0 {
+		return &usageError{message: "shell does not accept arguments"}
+	}
+
+	return c.shell.Run(ctx)
+}
+
+// parseArgs parses flags of command and returns positional arguments.
+// Unlike flag.FlagSet.Parse flags are allowed after positional arguments (get-transactions 0x... --format csv)
+func parseArgs(flagSet *flag.FlagSet, args []string) ([]string, error) {
+	flagSet.SetOutput(io.Discard)
+
+	var positional []string
+	for {
+		err := flagSet.Parse(args)
+		if err != nil {
+			return nil, &usageError{message: err.Error()}
+		}
+
+		args = flagSet.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+// checkFormat returns usage error if format is not one of allowed
+func checkFormat(format string, allowed ...string) error {
+	for _, allowedFormat := range allowed {
+		if format == allowedFormat {
+			return nil
+		}
+	}
+
+	return &usageError{message: "unknown format " + format + ", should be one of: " + strings.Join(allowed, ", ")}
+}
@@ -0,0 +1,368 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	ethereum_jsonrpc "github.com/bluntenpassant/ethereum_subscriber/internal/app/client/ethereum-jsonrpc"
+	subscriber_api "github.com/bluntenpassant/ethereum_subscriber/internal/app/client/subscriber-api"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/models"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+const testAddress = models.Address("0x45849a974058661eb2128aceb60d2c6ed99e2a14")
+
+type mockParserService struct {
+	currentBlock uint64
+	transactions []*models.Transaction
+	err          error
+
+	subscribedAddress models.Address
+	subscribedENSName string
+}
+
+func (m *mockParserService) GetCurrentBlock(ctx context.Context) (uint64, error) {
+	return m.currentBlock, m.err
+}
+
+func (m *mockParserService) GetTransactions(ctx context.Context, address models.Address) ([]*models.Transaction, error) {
+	return m.transactions, m.err
+}
+
+func (m *mockParserService) Subscribe(ctx context.Context, address models.Address, ensName string) error {
+	m.subscribedAddress = address
+	m.subscribedENSName = ensName
+
+	return m.err
+}
+
+// mockBalanceParserService is a parser tracking balances like indexed one
+type mockBalanceParserService struct {
+	mockParserService
+	history []models.Balance
+}
+
+func (m *mockBalanceParserService) GetBalanceHistory(ctx context.Context, address models.Address) ([]models.Balance, error) {
+	return m.history, m.err
+}
+
+type mockENSResolver struct{}
+
+func (m *mockENSResolver) Resolve(ctx context.Context, name string) (models.Address, error) {
+	return testAddress, nil
+}
+
+// failingENSResolver fails every resolution, it stands for Ethereum node that is not reachable from CLI in remote mode
+type failingENSResolver struct{}
+
+func (m *failingENSResolver) Resolve(ctx context.Context, name string) (models.Address, error) {
+	return "", errors.New("ethereum node is not configured")
+}
+
+type mockShell struct {
+	started bool
+}
+
+func (m *mockShell) Run(ctx context.Context) error {
+	m.started = true
+
+	return nil
+}
+
+func TestCommands_Run(t *testing.T) {
+	type TestCase struct {
+		Name             string
+		Args             []string
+		Parser           IParserService
+		ExpectedExitCode int
+		ExpectedStdout   string
+		ExpectedStderr   string
+	}
+
+	transactions := []*models.Transaction{
+		{
+			ChainID:          1,
+			BlockNumber:      16614490,
+			TransactionIndex: 3,
+			Hash:             "0x7c5d2a5c3c1a9a1c4b7e0d0f4e5e6a1b2c3d4e5f60718293a4b5c6d7e8f90a1b",
+			From:             testAddress.String(),
+			To:               "0x00000000219ab540356cbb839cbe05303d7705fa",
+			Value:            *big.NewInt(1000000000000000000),
+			Gas:              *big.NewInt(21000),
+			GasPrice:         *big.NewInt(30000000000),
+			Nonce:            7,
+		},
+	}
+
+	balanceHistory := []models.Balance{
+		{BlockNumber: 16614489, Balance: *big.NewInt(1500000000000000000)},
+		{BlockNumber: 16614490, Balance: *big.NewInt(500000000000000000)},
+	}
+
+	testCases := []TestCase{
+		{
+			Name:             "no command",
+			Args:             []string{},
+			Parser:           &mockParserService{},
+			ExpectedExitCode: ExitCodeUsage,
+			ExpectedStderr:   "Usage: ethereum_subscriber-cli",
+		},
+		{
+			Name:             "unknown command",
+			Args:             []string{"unsubscribe"},
+			Parser:           &mockParserService{},
+			ExpectedExitCode: ExitCodeUsage,
+			ExpectedStderr:   "Error: unknown command unsubscribe",
+		},
+		{
+			Name:             "current block text",
+			Args:             []string{"current-block"},
+			Parser:           &mockParserService{currentBlock: 16614490},
+			ExpectedExitCode: ExitCodeOK,
+			ExpectedStdout:   "16614490\n",
+		},
+		{
+			Name:             "current block json",
+			Args:             []string{"current-block", "--format", "json"},
+			Parser:           &mockParserService{currentBlock: 16614490},
+			ExpectedExitCode: ExitCodeOK,
+			ExpectedStdout:   "{\"current_block\":16614490}\n",
+		},
+		{
+			Name:             "current block is not parsed",
+			Args:             []string{"current-block"},
+			Parser:           &mockParserService{},
+			ExpectedExitCode: ExitCodeFailure,
+			ExpectedStderr:   "Error: current block is not parsed yet, try again later",
+		},
+		{
+			Name:             "subscribe",
+			Args:             []string{"subscribe", "0x45849a974058661eb2128aceb60d2c6ed99e2a14"},
+			Parser:           &mockParserService{},
+			ExpectedExitCode: ExitCodeOK,
+			ExpectedStdout:   "0x45849a974058661Eb2128ACeB60d2C6eD99E2A14\n",
+		},
+		{
+			Name:             "subscribe ens name json",
+			Args:             []string{"subscribe", "--format=json", "vitalik.eth"},
+			Parser:           &mockParserService{},
+			ExpectedExitCode: ExitCodeOK,
+			ExpectedStdout:   "{\"is_ok\":true,\"address\":\"0x45849a974058661Eb2128ACeB60d2C6eD99E2A14\",\"ens_name\":\"vitalik.eth\"}\n",
+		},
+		{
+			Name:             "subscribe invalid address",
+			Args:             []string{"subscribe", "0x1234"},
+			Parser:           &mockParserService{},
+			ExpectedExitCode: ExitCodeUsage,
+			ExpectedStderr:   "Usage: ethereum_subscriber-cli subscribe",
+		},
+		{
+			Name:             "subscribe failed",
+			Args:             []string{"subscribe", "0x45849a974058661eb2128aceb60d2c6ed99e2a14"},
+			Parser:           &mockParserService{err: errors.New("unable to store subscriber")},
+			ExpectedExitCode: ExitCodeFailure,
+			ExpectedStderr:   "Error: unable to store subscriber",
+		},
+		{
+			Name:             "subscribe already subscribed",
+			Args:             []string{"subscribe", "0x45849a974058661eb2128aceb60d2c6ed99e2a14"},
+			Parser:           &mockParserService{err: models.ErrAlreadySubscribed},
+			ExpectedExitCode: ExitCodeFailure,
+			ExpectedStderr:   "Error: address is already subscribed",
+		},
+		{
+			Name:             "get transactions not subscribed",
+			Args:             []string{"get-transactions", "0x45849a974058661eb2128aceb60d2c6ed99e2a14"},
+			Parser:           &mockParserService{err: models.WrapError("error getting subscriber", models.ErrNotSubscribed)},
+			ExpectedExitCode: ExitCodeFailure,
+			ExpectedStderr:   "Error: address is not subscribed, subscribe it first",
+		},
+		{
+			Name:             "ethereum node is not reachable",
+			Args:             []string{"get-transactions", "0x45849a974058661eb2128aceb60d2c6ed99e2a14"},
+			Parser:           &mockParserService{err: &ethereum_jsonrpc.RequestError{Method: "eth_blockNumber", Err: errors.New("connection refused")}},
+			ExpectedExitCode: ExitCodeFailure,
+			ExpectedStderr:   "Error: Ethereum node is not reachable: ethereum node request eth_blockNumber failed cause: connection refused",
+		},
+		{
+			Name:             "get transactions csv",
+			Args:             []string{"get-transactions", "0x45849a974058661eb2128aceb60d2c6ed99e2a14", "--format", "csv"},
+			Parser:           &mockParserService{transactions: transactions},
+			ExpectedExitCode: ExitCodeOK,
+			ExpectedStdout: "block_number,transaction_index,hash,from,to,value,gas,gas_price,nonce,block_hash,input\n" +
+				"16614490,3,0x7c5d2a5c3c1a9a1c4b7e0d0f4e5e6a1b2c3d4e5f60718293a4b5c6d7e8f90a1b,0x45849a974058661Eb2128ACeB60d2C6eD99E2A14," +
+				"0x00000000219ab540356cBB839Cbe05303d7705Fa,1000000000000000000,21000,30000000000,7,,\n",
+		},
+		{
+			Name:             "get transactions table",
+			Args:             []string{"get-transactions", "--format", "table", "0x45849a974058661eb2128aceb60d2c6ed99e2a14"},
+			Parser:           &mockParserService{transactions: transactions},
+			ExpectedExitCode: ExitCodeOK,
+			ExpectedStdout: "BLOCK     INDEX  HASH                                                                FROM                                        TO                                          VALUE (WEI)\n" +
+				"16614490  3      0x7c5d2a5c3c1a9a1c4b7e0d0f4e5e6a1b2c3d4e5f60718293a4b5c6d7e8f90a1b  0x45849a974058661Eb2128ACeB60d2C6eD99E2A14  0x00000000219ab540356cBB839Cbe05303d7705Fa  1000000000000000000\n",
+		},
+		{
+			Name:             "get transactions decimal amounts",
+			Args:             []string{"get-transactions", "0x45849a974058661eb2128aceb60d2c6ed99e2a14", "--amounts", "decimal"},
+			Parser:           &mockParserService{transactions: transactions},
+			ExpectedExitCode: ExitCodeOK,
+			ExpectedStdout: "[\n  {\n    \"chainId\": 1,\n    \"blockHash\": \"\",\n    \"blockNumber\": 16614490,\n" +
+				"    \"from\": \"0x45849a974058661Eb2128ACeB60d2C6eD99E2A14\",\n    \"gas\": \"21000\",\n" +
+				"    \"gasPrice\": \"30000000000\",\n    \"gasPriceGwei\": \"30\",\n" +
+				"    \"hash\": \"0x7c5d2a5c3c1a9a1c4b7e0d0f4e5e6a1b2c3d4e5f60718293a4b5c6d7e8f90a1b\",\n    \"input\": \"\",\n" +
+				"    \"nonce\": 7,\n    \"to\": \"0x00000000219ab540356cBB839Cbe05303d7705Fa\",\n    \"transactionIndex\": 3,\n" +
+				"    \"value\": \"1000000000000000000\",\n    \"valueEth\": \"1\",\n    \"v\": \"0\",\n    \"r\": \"0\",\n    \"s\": \"0\"\n  }\n]\n",
+		},
+		{
+			Name:             "get transactions unknown format",
+			Args:             []string{"get-transactions", "0x45849a974058661eb2128aceb60d2c6ed99e2a14", "--format", "xml"},
+			Parser:           &mockParserService{},
+			ExpectedExitCode: ExitCodeUsage,
+			ExpectedStderr:   "Error: unknown format xml, should be one of: json, csv, table",
+		},
+		{
+			Name:             "export ndjson",
+			Args:             []string{"export", "0x45849a974058661eb2128aceb60d2c6ed99e2a14", "--format", "ndjson"},
+			Parser:           &mockParserService{transactions: transactions},
+			ExpectedExitCode: ExitCodeOK,
+			ExpectedStdout: `{"chain_id":1,"block_number":16614490,"transaction_index":3,"hash":"0x7c5d2a5c3c1a9a1c4b7e0d0f4e5e6a1b2c3d4e5f60718293a4b5c6d7e8f90a1b",` +
+				`"block_hash":"","from":"0x45849a974058661Eb2128ACeB60d2C6eD99E2A14","to":"0x00000000219ab540356cBB839Cbe05303d7705Fa",` +
+				`"value_wei":"1000000000000000000","value_eth":"1","gas":"21000","gas_price_wei":"30000000000","gas_price_gwei":"30","nonce":7,"input":""}` + "\n",
+		},
+		{
+			Name:             "export unknown format",
+			Args:             []string{"export", "0x45849a974058661eb2128aceb60d2c6ed99e2a14", "--format", "xlsx"},
+			Parser:           &mockParserService{},
+			ExpectedExitCode: ExitCodeUsage,
+			ExpectedStderr:   "Error: unknown format xlsx, should be one of: csv, ndjson, parquet",
+		},
+		{
+			Name:             "balance is not supported",
+			Args:             []string{"balance", "0x45849a974058661eb2128aceb60d2c6ed99e2a14"},
+			Parser:           &mockParserService{},
+			ExpectedExitCode: ExitCodeFailure,
+			ExpectedStderr:   "Error: balance tracking is supported only by indexed approach",
+		},
+		{
+			Name:             "balance text",
+			Args:             []string{"balance", "0x45849a974058661eb2128aceb60d2c6ed99e2a14"},
+			Parser:           &mockBalanceParserService{history: balanceHistory},
+			ExpectedExitCode: ExitCodeOK,
+			ExpectedStdout:   "0.5\n",
+		},
+		{
+			Name:             "balance history json",
+			Args:             []string{"balance", "0x45849a974058661eb2128aceb60d2c6ed99e2a14", "--history", "--format", "json"},
+			Parser:           &mockBalanceParserService{history: balanceHistory},
+			ExpectedExitCode: ExitCodeOK,
+			ExpectedStdout: `{"address":"0x45849a974058661Eb2128ACeB60d2C6eD99E2A14","history":[` +
+				`{"block_number":16614489,"balance":"1500000000000000000","balance_eth":"1.5"},` +
+				`{"block_number":16614490,"balance":"500000000000000000","balance_eth":"0.5"}]}` + "\n",
+		},
+		{
+			Name:             "get transactions without address",
+			Args:             []string{"get-transactions"},
+			Parser:           &mockParserService{},
+			ExpectedExitCode: ExitCodeUsage,
+			ExpectedStderr:   "Error: get-transactions requires exactly one address",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			stdout := &bytes.Buffer{}
+			stderr := &bytes.Buffer{}
+
+			exitCode := NewCommands(testCase.Parser, &mockENSResolver{}, &mockShell{}, stdout, stderr).Run(context.TODO(), testCase.Args)
+
+			assert.Equal(t, testCase.ExpectedExitCode, exitCode)
+			assert.Equal(t, testCase.ExpectedStdout, stdout.String())
+			assert.Contains(t, stderr.String(), testCase.ExpectedStderr)
+		})
+	}
+}
+
+func TestCommands_Run_Shell(t *testing.T) {
+	shell := &mockShell{}
+
+	exitCode := NewCommands(&mockParserService{}, &mockENSResolver{}, shell, &bytes.Buffer{}, &bytes.Buffer{}).Run(context.TODO(), []string{"shell"})
+
+	assert.Equal(t, ExitCodeOK, exitCode)
+	assert.True(t, shell.started)
+}
+
+// TestCommands_Run_Remote checks commands sent to API server, ENS names are resolved by the server
+// and errors of the server get the same messages as errors of local parser
+func TestCommands_Run_Remote(t *testing.T) {
+	type TestCase struct {
+		Name             string
+		Args             []string
+		Status           int
+		Body             string
+		ExpectedRequest  string
+		ExpectedExitCode int
+		ExpectedStdout   string
+		ExpectedStderr   string
+	}
+
+	testCases := []TestCase{
+		{
+			Name:             "subscribe ens name",
+			Args:             []string{"subscribe", "vitalik.eth"},
+			Status:           http.StatusCreated,
+			Body:             `{"address":"0x45849a974058661Eb2128ACeB60d2C6eD99E2A14","ens_name":"vitalik.eth","chain":"ethereum","chain_id":1}`,
+			ExpectedRequest:  `POST /v2/subscriptions {"address":"vitalik.eth"}`,
+			ExpectedExitCode: ExitCodeOK,
+			ExpectedStdout:   "0x45849a974058661Eb2128ACeB60d2C6eD99E2A14\n",
+		},
+		{
+			Name:             "subscribe already subscribed",
+			Args:             []string{"subscribe", testAddress.String()},
+			Status:           http.StatusConflict,
+			Body:             `{"error":{"code":"already_subscribed","message":"address is already subscribed"}}`,
+			ExpectedRequest:  `POST /v2/subscriptions {"address":"` + testAddress.String() + `"}`,
+			ExpectedExitCode: ExitCodeFailure,
+			ExpectedStderr:   "Error: address is already subscribed",
+		},
+		{
+			Name:             "get transactions not subscribed",
+			Args:             []string{"get-transactions", testAddress.String()},
+			Status:           http.StatusNotFound,
+			Body:             `{"error":{"code":"not_subscribed","message":"address is not subscribed"}}`,
+			ExpectedRequest:  "GET /v2/subscriptions/" + testAddress.String() + "/transactions ",
+			ExpectedExitCode: ExitCodeFailure,
+			ExpectedStderr:   "Error: address is not subscribed, subscribe it first",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			var request string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				request = r.Method + " " + r.URL.Path + " " + string(body)
+
+				w.WriteHeader(testCase.Status)
+				w.Write([]byte(testCase.Body))
+			}))
+			defer server.Close()
+
+			stdout := &bytes.Buffer{}
+			stderr := &bytes.Buffer{}
+
+			client := subscriber_api.NewClient(server.URL, "", "", time.Second)
+			exitCode := NewCommands(client, &failingENSResolver{}, &mockShell{}, stdout, stderr).Run(context.TODO(), testCase.Args)
+
+			assert.Equal(t, testCase.ExpectedRequest, request)
+			assert.Equal(t, testCase.ExpectedExitCode, exitCode)
+			assert.Equal(t, testCase.ExpectedStdout, stdout.String())
+			assert.Contains(t, stderr.String(), testCase.ExpectedStderr)
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/models"
+)
+
+// CurrentBlockOutput is a result of current-block command in json format
+type CurrentBlockOutput struct {
+	CurrentBlock uint64 `json:"current_block"`
+}
+
+func (c *Commands) currentBlock(ctx context.Context, args []string) error {
+	flagSet := flag.NewFlagSet("current-block", flag.ContinueOnError)
+	format := flagSet.String("format", TextFormat, "output format: text or json")
+
+	positional, err := parseArgs(flagSet, args)
+	if err != nil {
+		return err
+	}
+
+	if len(positional) != 0 {
+		return &usageError{message: "current-block does not accept arguments"}
+	}
+
+	err = checkFormat(*format, TextFormat, JSONFormat)
+	if err != nil {
+		return err
+	}
+
+	currentBlock, err := c.parserService.GetCurrentBlock(ctx)
+	if err != nil {
+		return err
+	}
+
+	if currentBlock == 0 {
+		return models.ErrCurrentBlockNotParsed
+	}
+
+	if *format == TextFormat {
+		fmt.Fprintln(c.stdout, currentBlock)
+		return nil
+	}
+
+	return json.NewEncoder(c.stdout).Encode(CurrentBlockOutput{CurrentBlock: currentBlock})
+}
@@ -0,0 +1,26 @@
+package commands
+
+import (
+	"errors"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/models"
+)
+
+// ErrorMessage returns message of error printed by CLI, domain errors get a hint what to do next
+func ErrorMessage(err error) string {
+	switch {
+	case errors.Is(err, models.ErrNotSubscribed):
+		return "address is not subscribed, subscribe it first"
+	case errors.Is(err, models.ErrAlreadySubscribed):
+		return "address is already subscribed"
+	case errors.Is(err, models.ErrQuotaExceeded):
+		return "maximum count of subscriptions is reached"
+	case errors.Is(err, models.ErrCurrentBlockNotParsed):
+		return "current block is not parsed yet, try again later"
+	case errors.Is(err, models.ErrBalanceNotRecorded):
+		return "balance of address is not recorded yet, try again later"
+	case errors.Is(err, models.ErrUpstreamRPC):
+		return "Ethereum node is not reachable: " + err.Error()
+	default:
+		return err.Error()
+	}
+}
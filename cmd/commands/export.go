@@ -0,0 +1,59 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/export"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/models"
+	"io"
+	"os"
+)
+
+func (c *Commands) exportTransactions(ctx context.Context, args []string) error {
+	flagSet := flag.NewFlagSet("export", flag.ContinueOnError)
+	format := flagSet.String("format", export.CSVFormat, "output format: csv, ndjson or parquet")
+	output := flagSet.String("output", "", "file to write export into, stdout by default")
+
+	positional, err := parseArgs(flagSet, args)
+	if err != nil {
+		return err
+	}
+
+	if len(positional) != 1 {
+		return &usageError{message: "export requires exactly one address"}
+	}
+
+	err = checkFormat(*format, export.Formats...)
+	if err != nil {
+		return err
+	}
+
+	address, err := models.ParseAddress(positional[0])
+	if err != nil {
+		return &usageError{message: err.Error()}
+	}
+
+	transactions, err := c.parserService.GetTransactions(ctx, address)
+	if err != nil {
+		return err
+	}
+
+	var out io.Writer = c.stdout
+	if *output != "" {
+		file, err := os.Create(*output)
+		if err != nil {
+			return errors.New("creating output file failed cause: " + err.Error())
+		}
+		defer file.Close()
+
+		out = file
+	}
+
+	writer, err := export.NewWriter(*format, out)
+	if err != nil {
+		return err
+	}
+
+	return export.WriteTransactions(writer, transactions)
+}
@@ -0,0 +1,116 @@
+package commands
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/models"
+	"strconv"
+	"text/tabwriter"
+)
+
+// csvHeader is a header of get-transactions output in csv format, values are in wei
+var csvHeader = []string{"block_number", "transaction_index", "hash", "from", "to", "value", "gas", "gas_price", "nonce", "block_hash", "input"}
+
+func (c *Commands) getTransactions(ctx context.Context, args []string) error {
+	flagSet := flag.NewFlagSet("get-transactions", flag.ContinueOnError)
+	format := flagSet.String("format", JSONFormat, "output format: json, csv or table")
+	amounts := flagSet.String("amounts", RawAmounts, "amounts in json format: raw (numbers in wei) or decimal (strings with ETH and gwei)")
+
+	positional, err := parseArgs(flagSet, args)
+	if err != nil {
+		return err
+	}
+
+	if len(positional) != 1 {
+		return &usageError{message: "get-transactions requires exactly one address"}
+	}
+
+	err = checkFormat(*format, JSONFormat, CSVFormat, TableFormat)
+	if err != nil {
+		return err
+	}
+
+	if *amounts != RawAmounts && *amounts != DecimalAmounts {
+		return &usageError{message: "unknown amounts " + *amounts + ", should be one of: " + RawAmounts + ", " + DecimalAmounts}
+	}
+
+	address, err := models.ParseAddress(positional[0])
+	if err != nil {
+		return &usageError{message: err.Error()}
+	}
+
+	transactions, err := c.parserService.GetTransactions(ctx, address)
+	if err != nil {
+		return err
+	}
+
+	transactions = models.ChecksumTransactionsCopy(transactions)
+
+	switch *format {
+	case CSVFormat:
+		return c.writeTransactionsCSV(transactions)
+	case TableFormat:
+		return c.writeTransactionsTable(transactions)
+	default:
+		encoder := json.NewEncoder(c.stdout)
+		encoder.SetIndent("", "  ")
+
+		if *amounts == DecimalAmounts {
+			decimalTransactions := make([]*models.DecimalTransaction, 0, len(transactions))
+			for _, tx := range transactions {
+				decimalTransactions = append(decimalTransactions, models.NewDecimalTransaction(tx))
+			}
+
+			return encoder.Encode(decimalTransactions)
+		}
+
+		return encoder.Encode(transactions)
+	}
+}
+
+func (c *Commands) writeTransactionsCSV(transactions []*models.Transaction) error {
+	writer := csv.NewWriter(c.stdout)
+
+	err := writer.Write(csvHeader)
+	if err != nil {
+		return err
+	}
+
+	for _, tx := range transactions {
+		err = writer.Write([]string{
+			strconv.FormatUint(tx.BlockNumber, 10),
+			strconv.FormatUint(tx.TransactionIndex, 10),
+			tx.Hash,
+			tx.From,
+			tx.To,
+			tx.Value.String(),
+			tx.Gas.String(),
+			tx.GasPrice.String(),
+			strconv.FormatUint(tx.Nonce, 10),
+			tx.BlockHash,
+			tx.Input,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+
+	return writer.Error()
+}
+
+// writeTransactionsTable writes short human-readable table, use json or csv format to get all fields
+func (c *Commands) writeTransactionsTable(transactions []*models.Transaction) error {
+	writer := tabwriter.NewWriter(c.stdout, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(writer, "BLOCK\tINDEX\tHASH\tFROM\tTO\tVALUE (WEI)")
+	for _, tx := range transactions {
+		fmt.Fprintf(writer, "%d\t%d\t%s\t%s\t%s\t%s\n", tx.BlockNumber, tx.TransactionIndex, tx.Hash, tx.From, tx.To, tx.Value.String())
+	}
+
+	return writer.Flush()
+}
@@ -0,0 +1,82 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/models"
+)
+
+// SubscribeOutput is a result of subscribe command in json format
+type SubscribeOutput struct {
+	IsOk    bool   `json:"is_ok"`
+	Address string `json:"address"`
+	ENSName string `json:"ens_name,omitempty"`
+}
+
+func (c *Commands) subscribe(ctx context.Context, args []string) error {
+	flagSet := flag.NewFlagSet("subscribe", flag.ContinueOnError)
+	format := flagSet.String("format", TextFormat, "output format: text or json")
+
+	positional, err := parseArgs(flagSet, args)
+	if err != nil {
+		return err
+	}
+
+	if len(positional) != 1 {
+		return &usageError{message: "subscribe requires exactly one address or ENS name"}
+	}
+
+	err = checkFormat(*format, TextFormat, JSONFormat)
+	if err != nil {
+		return err
+	}
+
+	var ensName string
+	var address models.Address
+
+	if models.IsENSName(positional[0]) {
+		ensName, err = models.ParseENSName(positional[0])
+		if err != nil {
+			return &usageError{message: err.Error()}
+		}
+
+		address, err = c.subscribeENSName(ctx, ensName)
+		if err != nil {
+			return err
+		}
+	} else {
+		address, err = models.ParseAddress(positional[0])
+		if err != nil {
+			return &usageError{message: err.Error()}
+		}
+
+		err = c.parserService.Subscribe(ctx, address, ensName)
+		if err != nil {
+			return err
+		}
+	}
+
+	if *format == TextFormat {
+		fmt.Fprintln(c.stdout, address.Checksum())
+		return nil
+	}
+
+	return json.NewEncoder(c.stdout).Encode(SubscribeOutput{IsOk: true, Address: address.Checksum(), ENSName: ensName})
+}
+
+// subscribeENSName subscribes ENS name and returns address it is resolved into. Name is resolved locally
+// unless parser service resolves names itself
+func (c *Commands) subscribeENSName(ctx context.Context, ensName string) (models.Address, error) {
+	if ensSubscriber, ok := c.parserService.(IENSSubscriber); ok {
+		return ensSubscriber.SubscribeENSName(ctx, ensName)
+	}
+
+	address, err := c.ensResolver.Resolve(ctx, ensName)
+	if err != nil {
+		return "", err
+	}
+
+	return address, c.parserService.Subscribe(ctx, address, ensName)
+}
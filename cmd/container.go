@@ -11,10 +11,17 @@ import (
 	"github.com/bluntenpassant/ethereum_subscriber/internal/app/repository/greedy_redis_repository"
 	"github.com/bluntenpassant/ethereum_subscriber/internal/app/repository/memory_repository"
 	"github.com/bluntenpassant/ethereum_subscriber/internal/app/repository/redis_repository"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/service/abi_registry"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/service/address_book"
 	"github.com/bluntenpassant/ethereum_subscriber/internal/app/service/async_parser"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/service/authenticator"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/service/ens_resolver"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/service/indexed_parser"
 	"github.com/bluntenpassant/ethereum_subscriber/internal/app/service/sync_greedy_parser"
 	"github.com/bluntenpassant/ethereum_subscriber/internal/app/service/sync_parser"
 	redis2 "github.com/redis/go-redis/v9"
+	"log/slog"
+	"time"
 )
 
 // This code defines the architecture for a service that parses user requests for transactions and other data.
@@ -24,19 +31,67 @@ import (
 // IParserService interface of representation of parser that will be using for handling user request
 type IParserService interface {
 	GetCurrentBlock(ctx context.Context) (uint64, error)
-	GetTransactions(ctx context.Context, address string) ([]*models.Transaction, error)
-	Subscribe(ctx context.Context, address string) error
+	GetTransactions(ctx context.Context, address models.Address) ([]*models.Transaction, error)
+	Subscribe(ctx context.Context, address models.Address, ensName string) error
+	Unsubscribe(ctx context.Context, address models.Address) error
+	GetSubscriber(ctx context.Context, address models.Address) (models.Subscriber, error)
+	GetSubscribers(ctx context.Context) ([]models.Subscriber, error)
+	CountSubscribers(ctx context.Context) (int, error)
 }
 
-// The Container struct holds references to all the different parser services,
-// including an asynchronous parser service and four different synchronous parser services,
+// IBalanceService interface of representation of parser that tracks balances of subscribers,
+// only indexed approach records balance history
+type IBalanceService interface {
+	GetBalance(ctx context.Context, address models.Address) (models.Balance, error)
+	GetBalanceHistory(ctx context.Context, address models.Address) ([]models.Balance, error)
+}
+
+// IBackgroundService interface of representation of parser that requires work in background
+// during the whole program lifetime, like indexing new blocks
+type IBackgroundService interface {
+	Run(ctx context.Context, interval time.Duration) error
+}
+
+// chainServices holds references to all the different parser services of a single chain,
+// including an asynchronous parser service and six different synchronous parser services,
 // each with different configurations for approach and storage.
+type chainServices struct {
+	asyncParserService            *async_parser.Parser
+	syncParserService             *sync_parser.Parser
+	syncGreedyParserService       *sync_greedy_parser.Parser
+	syncRedisParserService        *sync_parser.Parser
+	syncGreedyRedisParserService  *sync_greedy_parser.Parser
+	syncIndexedParserService      *indexed_parser.Parser
+	syncIndexedRedisParserService *indexed_parser.Parser
+
+	ethereumJsonRPCClient *ethereum_jsonrpc.Client
+}
+
+// The Container struct holds parser services of every configured chain (Ethereum, L2s and EVM sidechains)
 type Container struct {
-	asyncParserService           *async_parser.Parser
-	syncParserService            *sync_parser.Parser
-	syncGreedyParserService      *sync_greedy_parser.Parser
-	syncRedisParserService       *sync_parser.Parser
-	syncGreedyRedisParserService *sync_greedy_parser.Parser
+	// chains are configured chains, the first one is the default chain
+	chains []models.Chain
+
+	// services are parser services by chain ID
+	services map[uint64]*chainServices
+
+	ensResolver *ens_resolver.Resolver
+
+	// authenticator checks API keys of tenants kept in configured storage, keys are shared by all chains
+	authenticator *authenticator.Authenticator
+
+	// addressBook keeps labels of addresses of tenants in configured storage, labels are shared by all chains
+	addressBook *address_book.AddressBook
+
+	// abiRegistry keeps ABIs of contracts uploaded by tenants in configured storage and ABIs loaded from ABI directory
+	abiRegistry *abi_registry.Registry
+
+	// expiringRepositories are redis repositories which keys expire after data_keep_alive_duration
+	expiringRepositories []expiringRepository
+}
+
+type expiringRepository interface {
+	SetExpirationTime(expirationTime time.Duration)
 }
 
 // NewContainer function returns a pointer to a new, empty Container object.
@@ -51,103 +106,260 @@ type ModeParams struct {
 	Storage    config.StorageParam
 }
 
+// GetChains returns configured chains, the first one is the default chain
+func (c *Container) GetChains() []models.Chain {
+	return c.chains
+}
+
 // GetServiceByParams method maps different processing, approach, and storage combinations
-// to the appropriate parser service within the Container.
-func (c *Container) GetServiceByParams() map[ModeParams]IParserService {
+// to the appropriate parser service of the chain with chainID within the Container.
+// Map is empty if chain is not configured.
+func (c *Container) GetServiceByParams(chainID uint64) map[ModeParams]IParserService {
+	s, ok := c.services[chainID]
+	if !ok {
+		return map[ModeParams]IParserService{}
+	}
+
+	// Scenarios should match config.SupportedModes, so unsupported combinations are rejected by config validation
 	presentScenarioByParams := map[ModeParams]IParserService{
 		ModeParams{
 			Processing: config.SyncProcessing,
 			Approach:   config.GreedyApproach,
 			Storage:    config.RedisStorage,
-		}: c.syncGreedyRedisParserService,
+		}: s.syncGreedyRedisParserService,
 
 		ModeParams{
 			Processing: config.SyncProcessing,
 			Approach:   config.GreedyApproach,
 			Storage:    config.MemoryStorage,
-		}: c.syncGreedyParserService,
+		}: s.syncGreedyParserService,
 
 		ModeParams{
 			Processing: config.SyncProcessing,
 			Approach:   config.ReleasingApproach,
 			Storage:    config.RedisStorage,
-		}: c.syncRedisParserService,
+		}: s.syncRedisParserService,
 
 		ModeParams{
 			Processing: config.SyncProcessing,
 			Approach:   config.ReleasingApproach,
 			Storage:    config.MemoryStorage,
-		}: c.syncParserService,
+		}: s.syncParserService,
 
 		ModeParams{
 			Processing: config.AsyncProcessing,
 			Approach:   config.ReleasingApproach,
 			Storage:    config.MemoryStorage,
-		}: c.asyncParserService,
+		}: s.asyncParserService,
+
+		ModeParams{
+			Processing: config.SyncProcessing,
+			Approach:   config.IndexedApproach,
+			Storage:    config.MemoryStorage,
+		}: s.syncIndexedParserService,
+
+		ModeParams{
+			Processing: config.SyncProcessing,
+			Approach:   config.IndexedApproach,
+			Storage:    config.RedisStorage,
+		}: s.syncIndexedRedisParserService,
 	}
 
 	return presentScenarioByParams
 }
 
 // GetPresentScenarioByParams method maps different processing, approach,
-// and storage combinations to instances of the Scenarios struct.
-func (c *Container) GetPresentScenarioByParams(reader *bufio.Reader) map[ModeParams]*scenarios.Scenarios {
+// and storage combinations to instances of the Scenarios struct of the chain with chainID.
+// Map is empty if chain is not configured.
+func (c *Container) GetPresentScenarioByParams(reader *bufio.Reader, chainID uint64) map[ModeParams]*scenarios.Scenarios {
+	s, ok := c.services[chainID]
+	if !ok {
+		return map[ModeParams]*scenarios.Scenarios{}
+	}
+
 	presentScenarioByParams := map[ModeParams]*scenarios.Scenarios{
 		ModeParams{
 			Processing: config.SyncProcessing,
 			Approach:   config.GreedyApproach,
 			Storage:    config.RedisStorage,
-		}: scenarios.NewScenarios(reader, c.syncGreedyRedisParserService),
+		}: scenarios.NewScenarios(reader, s.syncGreedyRedisParserService, c.ensResolver),
 
 		ModeParams{
 			Processing: config.SyncProcessing,
 			Approach:   config.GreedyApproach,
 			Storage:    config.MemoryStorage,
-		}: scenarios.NewScenarios(reader, c.syncGreedyParserService),
+		}: scenarios.NewScenarios(reader, s.syncGreedyParserService, c.ensResolver),
 
 		ModeParams{
 			Processing: config.SyncProcessing,
 			Approach:   config.ReleasingApproach,
 			Storage:    config.RedisStorage,
-		}: scenarios.NewScenarios(reader, c.syncRedisParserService),
+		}: scenarios.NewScenarios(reader, s.syncRedisParserService, c.ensResolver),
 
 		ModeParams{
 			Processing: config.SyncProcessing,
 			Approach:   config.ReleasingApproach,
 			Storage:    config.MemoryStorage,
-		}: scenarios.NewScenarios(reader, c.syncParserService),
+		}: scenarios.NewScenarios(reader, s.syncParserService, c.ensResolver),
 
 		ModeParams{
 			Processing: config.AsyncProcessing,
 			Approach:   config.ReleasingApproach,
 			Storage:    config.MemoryStorage,
-		}: scenarios.NewScenarios(reader, c.asyncParserService),
+		}: scenarios.NewScenarios(reader, s.asyncParserService, c.ensResolver),
+
+		ModeParams{
+			Processing: config.SyncProcessing,
+			Approach:   config.IndexedApproach,
+			Storage:    config.MemoryStorage,
+		}: scenarios.NewScenarios(reader, s.syncIndexedParserService, c.ensResolver),
+
+		ModeParams{
+			Processing: config.SyncProcessing,
+			Approach:   config.IndexedApproach,
+			Storage:    config.RedisStorage,
+		}: scenarios.NewScenarios(reader, s.syncIndexedRedisParserService, c.ensResolver),
 	}
 
 	return presentScenarioByParams
 }
 
-// The Init method initializes the different repositories for subscriber and block data for the different parser services,
-// including those for memory and Redis storage.
-// The method takes a Redis client and a configuration object as inputs and sets up the repositories accordingly.
-func (c *Container) Init(redis *redis2.Client, config config.Config) {
+// The Init method initializes the different repositories for subscriber and block data for the different parser services
+// of every configured chain, including those for memory and Redis storage.
+// The method takes a Redis client, a configuration object and a logger as inputs and sets up the repositories accordingly.
+func (c *Container) Init(redis *redis2.Client, config config.Config, logger *slog.Logger) {
+	c.chains = nil
+	c.services = make(map[uint64]*chainServices)
+	c.expiringRepositories = nil
+
+	for i, chainConfig := range config.GetChains() {
+		chain := models.Chain{ID: chainConfig.ChainID, Name: chainConfig.Name, NativeCurrency: chainConfig.NativeCurrency}
+
+		c.chains = append(c.chains, chain)
+		c.services[chain.ID] = c.initChain(redis, config, chainConfig, i == 0, logger.With("chain", chain.Name))
+	}
+
+	ensRegistryAddress := models.NewAddress(config.ENS.RegistryAddress)
+	if ensRegistryAddress == "" {
+		ensRegistryAddress = ens_resolver.DefaultRegistryAddress
+	}
+
+	// ENS registry lives in Ethereum Mainnet, so names are resolved through the default chain
+	c.ensResolver = ens_resolver.NewResolver(c.services[c.chains[0].ID].ethereumJsonRPCClient, ensRegistryAddress)
+
+	c.authenticator = newAuthenticator(redis, config.Auth, config.General.Storage)
+	c.addressBook = newAddressBook(redis, config.General.Storage)
+	c.abiRegistry = newABIRegistry(redis, config.General.Storage)
+}
+
+// newAuthenticator returns authenticator keeping API keys in redis if redis storage is used, otherwise keys are kept in memory
+func newAuthenticator(redis *redis2.Client, authConfig config.Auth, storage config.StorageParam) *authenticator.Authenticator {
+	if storage == config.RedisStorage {
+		return authenticator.NewAuthenticator(authConfig, redis_repository.NewAPIKeyRepository(redis))
+	}
+
+	return authenticator.NewAuthenticator(authConfig, memory_repository.NewAPIKeyRepository())
+}
+
+// newAddressBook returns address book keeping labels in redis if redis storage is used, otherwise labels are kept in memory
+func newAddressBook(redis *redis2.Client, storage config.StorageParam) *address_book.AddressBook {
+	if storage == config.RedisStorage {
+		return address_book.NewAddressBook(redis_repository.NewAddressLabelsRepository(redis))
+	}
+
+	return address_book.NewAddressBook(memory_repository.NewAddressLabelsRepository())
+}
+
+// newABIRegistry returns ABI registry keeping uploaded ABIs in redis if redis storage is used, otherwise ABIs are kept in memory
+func newABIRegistry(redis *redis2.Client, storage config.StorageParam) *abi_registry.Registry {
+	if storage == config.RedisStorage {
+		return abi_registry.NewRegistry(redis_repository.NewContractABIRepository(redis))
+	}
+
+	return abi_registry.NewRegistry(memory_repository.NewContractABIRepository())
+}
+
+// initChain initializes repositories and parser services of a single chain, keys of redis repositories contain chain ID,
+// so chains can share one redis database. Keys of the default chain keep layout of the single chain version without chain ID
+func (c *Container) initChain(redis *redis2.Client, config config.Config, chain config.Chain, defaultChain bool, logger *slog.Logger) *chainServices {
 	asyncSubscriberRepository := memory_repository.NewSubscriberRepository()
 	asyncBlockRepository := memory_repository.NewBlockRepository()
 
 	syncGreedySubscriberRepository := greedy_memory_repository.NewSubscriberRepository()
 	syncGreedyBlockRepository := greedy_memory_repository.NewBlockRepository()
 
-	syncRedisSubscriberRepository := redis_repository.NewSubscriberRepository(redis, config.Storage.Redis.DataKeepAliveDuration)
-	syncRedisBlockRepository := redis_repository.NewBlockRepository(redis, config.Storage.Redis.DataKeepAliveDuration)
+	redisKeySpace := redis_repository.KeySpace{ChainID: chain.ChainID, Default: defaultChain}
+	syncRedisSubscriberRepository := redis_repository.NewSubscriberRepository(redisKeySpace, redis, config.Storage.Redis.DataKeepAliveDuration, logger)
+	syncRedisBlockRepository := redis_repository.NewBlockRepository(redisKeySpace, redis, config.Storage.Redis.DataKeepAliveDuration)
 
-	syncGreedyRedisSubscriberRepository := greedy_redis_repository.NewSubscriberRepository(redis, config.Storage.Redis.DataKeepAliveDuration)
-	syncGreedyRedisBlockRepository := greedy_redis_repository.NewBlockRepository(redis, config.Storage.Redis.DataKeepAliveDuration)
+	greedyKeySpace := greedy_redis_repository.KeySpace{Prefix: greedy_redis_repository.GreedyKeyPrefix, ChainID: chain.ChainID, Default: defaultChain}
+	syncGreedyRedisSubscriberRepository := greedy_redis_repository.NewSubscriberRepository(greedyKeySpace, redis, config.Storage.Redis.DataKeepAliveDuration, logger)
+	syncGreedyRedisBlockRepository := greedy_redis_repository.NewBlockRepository(greedyKeySpace, redis, config.Storage.Redis.DataKeepAliveDuration)
 
-	ethereumJsonRPCClient := ethereum_jsonrpc.NewClient(config.EthereumJsonRPC.Host, config.EthereumJsonRPC.Version)
+	syncIndexedSubscriberRepository := greedy_memory_repository.NewSubscriberRepository()
+	syncIndexedBlockRepository := greedy_memory_repository.NewBlockRepository()
+	syncIndexedBalanceRepository := greedy_memory_repository.NewBalanceRepository()
+
+	// Indexed approach keeps cursors of subscribers in redis, so it does not share keys with greedy approach
+	indexedKeySpace := greedy_redis_repository.KeySpace{Prefix: greedy_redis_repository.IndexedKeyPrefix, ChainID: chain.ChainID, Default: defaultChain}
+	syncIndexedRedisSubscriberRepository := greedy_redis_repository.NewSubscriberRepository(indexedKeySpace, redis, config.Storage.Redis.DataKeepAliveDuration, logger)
+	syncIndexedRedisBlockRepository := greedy_redis_repository.NewBlockRepository(indexedKeySpace, redis, config.Storage.Redis.DataKeepAliveDuration)
+	syncIndexedRedisBalanceRepository := greedy_redis_repository.NewBalanceRepository(indexedKeySpace, redis, config.Storage.Redis.DataKeepAliveDuration)
+
+	c.expiringRepositories = append(c.expiringRepositories, syncRedisSubscriberRepository, syncRedisBlockRepository,
+		syncGreedyRedisSubscriberRepository, syncGreedyRedisBlockRepository,
+		syncIndexedRedisSubscriberRepository, syncIndexedRedisBlockRepository, syncIndexedRedisBalanceRepository)
+
+	ethereumJsonRPCClient := ethereum_jsonrpc.NewClient(chain.ChainID, chain.Host, chain.Version, logger)
+
+	return &chainServices{
+		asyncParserService:           async_parser.NewParser(chain.ChainID, ethereumJsonRPCClient, asyncSubscriberRepository, asyncBlockRepository, logger),
+		syncParserService:            sync_parser.NewParser(chain.ChainID, ethereumJsonRPCClient, asyncSubscriberRepository, asyncBlockRepository, logger),
+		syncGreedyParserService:      sync_greedy_parser.NewParser(chain.ChainID, ethereumJsonRPCClient, syncGreedySubscriberRepository, syncGreedyBlockRepository, logger),
+		syncRedisParserService:       sync_parser.NewParser(chain.ChainID, ethereumJsonRPCClient, syncRedisSubscriberRepository, syncRedisBlockRepository, logger),
+		syncGreedyRedisParserService: sync_greedy_parser.NewParser(chain.ChainID, ethereumJsonRPCClient, syncGreedyRedisSubscriberRepository, syncGreedyRedisBlockRepository, logger),
+		syncIndexedParserService: indexed_parser.NewParser(chain.ChainID, ethereumJsonRPCClient, syncIndexedSubscriberRepository, syncIndexedBlockRepository,
+			syncIndexedBalanceRepository, logger),
+		syncIndexedRedisParserService: indexed_parser.NewParser(chain.ChainID, ethereumJsonRPCClient, syncIndexedRedisSubscriberRepository, syncIndexedRedisBlockRepository,
+			syncIndexedRedisBalanceRepository, logger),
+		ethereumJsonRPCClient: ethereumJsonRPCClient,
+	}
+}
+
+// SetDataKeepAliveDuration changes expiration time of data written into redis storage of every chain at runtime
+func (c *Container) SetDataKeepAliveDuration(dataKeepAliveDuration time.Duration) {
+	for _, repository := range c.expiringRepositories {
+		repository.SetExpirationTime(dataKeepAliveDuration)
+	}
+}
+
+// GetEthereumJsonRPCClient returns JSONRPC client of the chain with chainID shared between all parser services of the chain,
+// nil is returned if chain is not configured
+func (c *Container) GetEthereumJsonRPCClient(chainID uint64) *ethereum_jsonrpc.Client {
+	s, ok := c.services[chainID]
+	if !ok {
+		return nil
+	}
+
+	return s.ethereumJsonRPCClient
+}
+
+// GetENSResolver returns resolver of ENS names shared between all parser services of all chains
+func (c *Container) GetENSResolver() *ens_resolver.Resolver {
+	return c.ensResolver
+}
+
+// GetAuthenticator returns authenticator of API requests with API keys kept in configured storage
+func (c *Container) GetAuthenticator() *authenticator.Authenticator {
+	return c.authenticator
+}
+
+// GetAddressBook returns address book of tenants with labels kept in configured storage
+func (c *Container) GetAddressBook() *address_book.AddressBook {
+	return c.addressBook
+}
 
-	c.asyncParserService = async_parser.NewParser(ethereumJsonRPCClient, asyncSubscriberRepository, asyncBlockRepository)
-	c.syncParserService = sync_parser.NewParser(ethereumJsonRPCClient, asyncSubscriberRepository, asyncBlockRepository)
-	c.syncGreedyParserService = sync_greedy_parser.NewParser(ethereumJsonRPCClient, syncGreedySubscriberRepository, syncGreedyBlockRepository)
-	c.syncRedisParserService = sync_parser.NewParser(ethereumJsonRPCClient, syncRedisSubscriberRepository, syncRedisBlockRepository)
-	c.syncGreedyRedisParserService = sync_greedy_parser.NewParser(ethereumJsonRPCClient, syncGreedyRedisSubscriberRepository, syncGreedyRedisBlockRepository)
+// GetABIRegistry returns registry of ABIs of contracts, ABIs of directory should be loaded by LoadDir before use
+func (c *Container) GetABIRegistry() *abi_registry.Registry {
+	return c.abiRegistry
 }
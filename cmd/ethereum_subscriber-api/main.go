@@ -2,31 +2,73 @@ package main
 
 import (
 	"context"
-	"fmt"
+	"flag"
 	"github.com/bluntenpassant/ethereum_subscriber/cmd"
 	"github.com/bluntenpassant/ethereum_subscriber/config"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/graphql_handlers"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/grpc_handlers"
 	"github.com/bluntenpassant/ethereum_subscriber/internal/app/handlers"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/logger"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/metrics/state_collector"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/service/config_reloader"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/service/ens_resolver"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/service/health_checker"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/service/price_provider"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/service/rate_limiter"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/service/subscription_quota"
+	valuator_service "github.com/bluntenpassant/ethereum_subscriber/internal/app/service/valuator"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/tracing"
 	redis_driver "github.com/bluntenpassant/ethereum_subscriber/internal/drivers/redis"
+	"github.com/prometheus/client_golang/prometheus"
 	redis2 "github.com/redis/go-redis/v9"
-	"gopkg.in/yaml.v3"
-	"io/ioutil"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
 )
 
+// shutdownTimeout limits time of flushing spans and closing storages after HTTP Server stopped
+const shutdownTimeout = 5 * time.Second
+
+// ethCurrency is a native currency of chains which transactions are valued by ETH/USD price
+const ethCurrency = "ETH"
+
 func main() {
-	ctx := context.Background()
+	// ctx is cancelled on SIGINT or SIGTERM, it stops HTTP Server and all background work
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	configPath := flag.String("config", "", "path to config file, "+config.PathEnv+" environment variable is used if it is not set")
+	flag.Parse()
+
+	// Logger is not configured until config is read, so errors of config reading are written by default logger
+	log := slog.New(slog.NewTextHandler(os.Stderr, nil))
 
-	internalConfig := config.Config{}
+	// Config is built from defaults, config file and environment variables, see config.Load
+	internalConfig, err := config.Load(*configPath)
+	if err != nil {
+		log.Error("loading config failed", "error", err.Error())
+		os.Exit(1)
+	}
 
-	// Read the YAML configuration file into a byte slice
-	configData, err := ioutil.ReadFile("./config/config.local.yaml")
+	// Log level is kept in logLevel to be changed at runtime
+	logLevel := &slog.LevelVar{}
+
+	log, err = logger.New(internalConfig.Log, logLevel, os.Stderr)
 	if err != nil {
-		panic("Error reading config.yaml: " + err.Error())
+		slog.Error("configuring logger failed", "error", err.Error())
+		os.Exit(1)
 	}
+	slog.SetDefault(log)
 
-	// Unmarshal the byte slice into a Config struct
-	err = yaml.Unmarshal(configData, &internalConfig)
+	// Spans are exported only when tracing endpoint is configured, otherwise tracer provider stays no-op
+	shutdownTracing, err := tracing.Init(ctx, internalConfig.Tracing)
 	if err != nil {
-		panic("Error unmarshaling config.yaml: " + err.Error())
+		log.Error("configuring tracing failed", "error", err.Error())
+		os.Exit(1)
 	}
 
 	var redis *redis2.Client
@@ -38,34 +80,197 @@ func main() {
 			Addr:     internalConfig.Storage.Redis.Host,
 			Password: internalConfig.Storage.Redis.Password,
 			DB:       internalConfig.Storage.Redis.DB,
-		})
+		}, log)
 		if err != nil {
-			fmt.Println("Error: " + err.Error())
-			return
+			log.Error("connecting to redis failed", "error", err.Error())
+			os.Exit(1)
 		}
 	}
 
 	// Init container with all helper services and repositories for further pass into usecase layer
 	container := cmd.NewContainer()
-	container.Init(redis, internalConfig)
-
-	// Map that contains all application services by 3 main parameters that can mutate current service choice.
-	// Depends on this 3 parameters we choose convenient service for current usecase layer
-	getServiceByParams := container.GetServiceByParams()
+	container.Init(redis, internalConfig, log)
 
-	parserService, ok := getServiceByParams[cmd.ModeParams{
+	modeParams := cmd.ModeParams{
 		Approach:   internalConfig.General.Approach,
 		Processing: internalConfig.General.Processing,
 		Storage:    internalConfig.General.Storage,
-	}]
-	if !ok {
-		fmt.Println("Present scenario not found for given approach, processing and storage")
-		return
+	}
+
+	// Every configured chain has its own parser service chosen by the same approach, processing and storage,
+	// the first chain is the default one, it is used by requests without chain
+	chains := container.GetChains()
+	parserServices := make([]cmd.IParserService, 0, len(chains))
+	chainParsers := make([]handlers.ChainParser, 0, len(chains))
+	grpcChainParsers := make([]grpc_handlers.ChainParser, 0, len(chains))
+	graphqlChainParsers := make([]graphql_handlers.ChainParser, 0, len(chains))
+	quotaParsers := make(map[uint64]subscription_quota.Parser, len(chains))
+
+	for _, chain := range chains {
+		// Map that contains all application services of the chain by 3 main parameters that can mutate current service choice.
+		// Depends on this 3 parameters we choose convenient service for current usecase layer
+		parserService, ok := container.GetServiceByParams(chain.ID)[modeParams]
+		if !ok {
+			log.Error("Present scenario not found for given approach, processing and storage",
+				"approach", internalConfig.General.Approach, "processing", internalConfig.General.Processing, "storage", internalConfig.General.Storage)
+			os.Exit(1)
+		}
+
+		parserServices = append(parserServices, parserService)
+		chainParsers = append(chainParsers, handlers.ChainParser{Chain: chain, Parser: parserService})
+		grpcChainParsers = append(grpcChainParsers, grpc_handlers.ChainParser{Chain: chain, Parser: parserService})
+		graphqlChainParsers = append(graphqlChainParsers, graphql_handlers.ChainParser{Chain: chain, Parser: parserService})
+		quotaParsers[chain.ID] = parserService
+	}
+
+	defaultChainID := chains[0].ID
+	parserService := parserServices[0]
+
+	// Valuator attaches USD values to transactions in decimal amounts mode if price provider is configured,
+	// interface stays nil otherwise, so handler returns transactions without USD values.
+	// ETH/USD price values only transactions of chains with ETH as native currency
+	var valuator handlers.Valuator
+	if internalConfig.Price.Provider == config.FilePriceProvider {
+		priceProvider, err := price_provider.NewFileProvider(internalConfig.Price.File)
+		if err != nil {
+			log.Error("creating price provider failed", "error", err.Error())
+			os.Exit(1)
+		}
+
+		clients := make(map[uint64]valuator_service.EthereumJsonRPCClient)
+		for _, chain := range chains {
+			if chain.NativeCurrency == ethCurrency {
+				clients[chain.ID] = container.GetEthereumJsonRPCClient(chain.ID)
+			}
+		}
+
+		valuator = valuator_service.NewValuator(clients, priceProvider)
+	}
+
+	// ABIs of directory are shared by all tenants, tenants can also upload ABIs through API
+	abiRegistry := container.GetABIRegistry()
+	if internalConfig.ABI.Dir != "" {
+		err = abiRegistry.LoadDir(internalConfig.ABI.Dir, chains)
+		if err != nil {
+			log.Error("loading abi directory failed", "error", err.Error())
+			os.Exit(1)
+		}
+	}
+
+	// Requests of every client are limited by route, limits are kept in memory of the server
+	rateLimiter := rate_limiter.NewLimiter(internalConfig.RateLimit)
+
+	// Subscriptions of APIs and ENS watchers are checked against maximum count of subscriptions of a tenant on all chains
+	subscriptionQuota := subscription_quota.NewQuota(quotaParsers, rateLimiter)
+
+	// Background work is stopped by ctx, we wait for it before closing storages
+	background := sync.WaitGroup{}
+
+	for i, chain := range chains {
+		chainParserService := parserServices[i]
+
+		// Some approaches (like indexed) handle new blocks in background, so we launch it before serving requests
+		if backgroundService, ok := chainParserService.(cmd.IBackgroundService); ok {
+			background.Add(1)
+			go func() {
+				defer background.Done()
+				backgroundService.Run(ctx, internalConfig.Indexer.PollInterval)
+			}()
+		}
+
+		// ENS names of subscribers are re-resolved in background to detect that name points to a new address
+		if internalConfig.ENS.ReResolveInterval > 0 {
+			ensWatcher := ens_resolver.NewWatcher(container.GetENSResolver(), chainParserService, subscriptionQuota, chain.ID,
+				log.With("chain", chain.Name))
+			background.Add(1)
+			go func() {
+				defer background.Done()
+				ensWatcher.Run(ctx, internalConfig.ENS.ReResolveInterval)
+			}()
+		}
+
+		// Parser state (subscribers count, current block and indexing lag) is read from storage on every scrape of /metrics
+		prometheus.MustRegister(state_collector.NewStateCollector(chain.ID, chainParserService, log))
+	}
+
+	// Safe changes of config file (RPC hosts of chains, log level, redis data keep alive duration, rate limits) are applied at runtime
+	ethereumJsonRPCClients := make(map[uint64]config_reloader.EthereumJsonRPCClient, len(chains))
+	for _, chain := range chains {
+		ethereumJsonRPCClients[chain.ID] = container.GetEthereumJsonRPCClient(chain.ID)
+	}
+
+	configReloader := config_reloader.NewReloader(config.ResolvePath(*configPath), internalConfig,
+		ethereumJsonRPCClients, container, rateLimiter, logLevel, log)
+	background.Add(1)
+	go func() {
+		defer background.Done()
+		configReloader.Run(ctx, internalConfig.Reload.PollInterval)
+	}()
+
+	// Health checker verifies dependencies of the service for readiness probe (/readyz) on the default chain
+	healthChecker := health_checker.NewChecker(redis, container.GetEthereumJsonRPCClient(defaultChainID), parserService,
+		internalConfig.Health.MaxIndexingLag, internalConfig.Health.CheckTimeout)
+
+	// GraphQL endpoint is served by HTTP API, so its requests are authenticated and limited like the other routes
+	var graphqlHandler http.Handler
+	if internalConfig.GraphQL.Enabled {
+		graphqlHandler, err = graphql_handlers.NewHandler(graphqlChainParsers, internalConfig.GraphQL, rateLimiter, log)
+		if err != nil {
+			log.Error("creating graphql handler failed", "error", err.Error())
+			os.Exit(1)
+		}
 	}
 
 	// Init http handler. This handler acts as usecase (http://prof.mau.ac.ir/images/Uploaded_files/Clean%20Architecture_%20A%20Craftsman%E2%80%99s%20Guide%20to%20Software%20Structure%20and%20Design-Pearson%20Education%20(2018)%5B7615523%5D.PDF) layer here
-	httpHandler := handlers.NewHandler(parserService)
+	// Requests are authenticated by API keys of tenants if auth is enabled, tenants see only their own subscriptions
+	httpHandler := handlers.NewHandler(chainParsers, container.GetENSResolver(), healthChecker, configReloader, valuator,
+		container.GetAddressBook(), abiRegistry, container.GetAuthenticator(), rateLimiter, subscriptionQuota, graphqlHandler, log)
+
+	// gRPC API is served next to HTTP API by the same parsers, auth and rate limits, failure of one server stops both
+	var grpcErr error
+	if internalConfig.Grpc.Enabled {
+		grpcServer := grpc_handlers.NewServer(grpcChainParsers, container.GetENSResolver(), container.GetAuthenticator(), rateLimiter,
+			subscriptionQuota, internalConfig.Grpc.WatchPollInterval, log)
+		background.Add(1)
+		go func() {
+			defer background.Done()
+
+			grpcErr = grpcServer.Start(ctx, internalConfig.Grpc)
+			if grpcErr != nil {
+				log.Error("gRPC Server stopped", "error", grpcErr.Error())
+				stop()
+			}
+		}()
+	}
+
+	// Start blocks until ctx is cancelled and in-flight requests are drained or server fails
+	serverErr := httpHandler.Start(ctx, internalConfig.Http)
+	if serverErr != nil {
+		log.Error("HTTP Server stopped", "error", serverErr.Error())
+		stop()
+	}
+
+	background.Wait()
+
+	// ctx is already cancelled here, so resources are released with a separate deadline
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	if redis != nil {
+		err = redis.Close()
+		if err != nil {
+			log.Error("closing redis client failed", "error", err.Error())
+		}
+	}
+
+	err = shutdownTracing(shutdownCtx)
+	if err != nil {
+		log.Error("flushing spans failed", "error", err.Error())
+	}
+
+	if serverErr != nil || grpcErr != nil {
+		os.Exit(1)
+	}
 
-	fmt.Println("HTTP Server started...")
-	httpHandler.Start(internalConfig.Http)
+	log.Info("shutdown completed")
 }
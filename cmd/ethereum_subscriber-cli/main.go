@@ -3,49 +3,76 @@ package main
 import (
 	"bufio"
 	"context"
-	"fmt"
+	"flag"
 	"github.com/bluntenpassant/ethereum_subscriber/cmd"
+	"github.com/bluntenpassant/ethereum_subscriber/cmd/commands"
+	"github.com/bluntenpassant/ethereum_subscriber/cmd/scenarios"
 	"github.com/bluntenpassant/ethereum_subscriber/config"
+	subscriber_api "github.com/bluntenpassant/ethereum_subscriber/internal/app/client/subscriber-api"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/logger"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/models"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/tracing"
 	redis_driver "github.com/bluntenpassant/ethereum_subscriber/internal/drivers/redis"
 	redis2 "github.com/redis/go-redis/v9"
-	"gopkg.in/yaml.v3"
-	"io/ioutil"
-	"log"
+	"log/slog"
 	"os"
-	"strconv"
-	"strings"
 )
 
 func main() {
 	ctx := context.Background()
 
-	internalConfig := config.Config{}
+	configPath := flag.String("config", "", "path to config file, "+config.PathEnv+" environment variable is used if it is not set")
+	remoteURL := flag.String("remote", "", "URL of running ethereum_subscriber-api server to send commands to, overrides cli.remote_url")
+	chainSelector := flag.String("chain", "", "name or chain ID of the chain to work with, the first configured chain is used if it is not set")
+	apiKey := flag.String("api-key", "", "API key sent to ethereum_subscriber-api server in remote mode, overrides cli.api_key")
+	flag.Parse()
 
-	// Read the YAML configuration file into a byte slice
-	configData, err := ioutil.ReadFile("./config/config.local.yaml")
+	// Logger is not configured until config is read, so errors of config reading are written by default logger
+	log := slog.New(slog.NewTextHandler(os.Stderr, nil))
+
+	// Config is built from defaults, config file and environment variables, see config.Load
+	internalConfig, err := config.Load(*configPath)
 	if err != nil {
-		panic("Error reading config.yaml: " + err.Error())
+		log.Error("loading config failed", "error", err.Error())
+		os.Exit(1)
+	}
+
+	log, err = logger.New(internalConfig.Log, &slog.LevelVar{}, os.Stderr)
+	if err != nil {
+		slog.Error("configuring logger failed", "error", err.Error())
+		os.Exit(1)
+	}
+	slog.SetDefault(log)
+
+	if *remoteURL != "" {
+		internalConfig.CLI.RemoteURL = *remoteURL
+	}
+
+	if *apiKey != "" {
+		internalConfig.CLI.APIKey = *apiKey
 	}
 
-	// Unmarshal the byte slice into a Config struct
-	err = yaml.Unmarshal(configData, &internalConfig)
+	// Spans are exported only when tracing endpoint is configured, otherwise tracer provider stays no-op
+	shutdownTracing, err := tracing.Init(ctx, internalConfig.Tracing)
 	if err != nil {
-		panic("Error unmarshaling config.yaml: " + err.Error())
+		log.Error("configuring tracing failed", "error", err.Error())
+		os.Exit(1)
 	}
 
 	var redis *redis2.Client
 
 	// Check if our current storage is redis then we will create redis client, otherwise
 	// here could be error cause unable to connect to redis, because we are using redis.Ping() inside
-	if internalConfig.General.Storage == config.RedisStorage {
+	// In remote mode storage belongs to the API server, so CLI does not connect to it
+	if internalConfig.General.Storage == config.RedisStorage && internalConfig.CLI.RemoteURL == "" {
 		redis, err = redis_driver.NewRedisClient(ctx, &redis2.Options{
 			Addr:     internalConfig.Storage.Redis.Host,
 			Password: internalConfig.Storage.Redis.Password,
 			DB:       internalConfig.Storage.Redis.DB,
-		})
+		}, log)
 		if err != nil {
-			fmt.Println("Error: " + err.Error())
-			return
+			log.Error("connecting to redis failed", "error", err.Error())
+			os.Exit(1)
 		}
 	}
 
@@ -55,60 +82,62 @@ func main() {
 
 	// Init container with all helper services and repositories for further pass into usecase layer
 	container := cmd.NewContainer()
-	container.Init(redis, internalConfig)
+	container.Init(redis, internalConfig, log)
 
-	// Map that contains all application scenarios with services by 3 main parameters that can mutate current scenario choice.
-	// Depends on this 3 parameters we choose convenient scneario for current usecase layer.
-	presentScenariosByParams := container.GetPresentScenarioByParams(reader)
-
-	// Pass 3 main parameters for user ethereum_subscriber-cli scenario choice
-	presentScenario, ok := presentScenariosByParams[cmd.ModeParams{
+	modeParams := cmd.ModeParams{
 		Approach:   internalConfig.General.Approach,
 		Processing: internalConfig.General.Processing,
 		Storage:    internalConfig.General.Storage,
-	}]
-	if !ok {
-		fmt.Println("Present scenario not found for given approach, processing and storage")
-		return
 	}
 
-	// Init user ethereum_subscriber-cli scenaior for further showing
-	presentScenario.Init()
+	var parserService scenarios.IParserService
+	var presentScenario *scenarios.Scenarios
+
+	if internalConfig.CLI.RemoteURL != "" {
+		// Commands are sent to API server, ENS names are sent as is and resolved by the server
+		// Chain is selected by server, so chains of the server may differ from local config
+		parserService = subscriber_api.NewClient(internalConfig.CLI.RemoteURL, *chainSelector, internalConfig.CLI.APIKey,
+			internalConfig.CLI.Timeout)
+		presentScenario = scenarios.NewScenarios(reader, parserService, container.GetENSResolver())
+	} else {
+		chain, ok := models.FindChain(container.GetChains(), *chainSelector)
+		if !ok {
+			log.Error("chain is not configured", "chain", *chainSelector)
+			os.Exit(1)
+		}
 
-	content, err := os.ReadFile("./cmd/hello_text")
-	if err != nil {
-		log.Fatal(err)
+		// Map that contains all application scenarios with services by 3 main parameters that can mutate current scenario choice.
+		// Depends on this 3 parameters we choose convenient scneario for current usecase layer.
+		presentScenario, ok = container.GetPresentScenarioByParams(reader, chain.ID)[modeParams]
+		if !ok {
+			log.Error("Present scenario not found for given approach, processing and storage",
+				"approach", internalConfig.General.Approach, "processing", internalConfig.General.Processing, "storage", internalConfig.General.Storage)
+			os.Exit(1)
+		}
+
+		// Subcommands use the same parser service as scenarios
+		parserService = container.GetServiceByParams(chain.ID)[modeParams]
 	}
 
-	fmt.Printf("%s\n\n\n", content)
-
-	for {
-		// Read user input from the terminal
-		fmt.Print("Enter a name or number of method: ")
-		methodNumberOrName, _ := reader.ReadString('\n')
-		methodNumberOrName = strings.TrimRight(methodNumberOrName, "\n")
-		fmt.Println()
-
-		// If user input represented as a method number, then handle it as a number
-		if num, err := strconv.Atoi(methodNumberOrName); err == nil {
-			err = presentScenario.PresentScenarioByNum(ctx, num)
-			if err != nil {
-				fmt.Println("Error: " + err.Error())
-				fmt.Println()
-			}
-
-			fmt.Println()
-			continue
-		}
+	// Init user ethereum_subscriber-cli scenaior for further showing in interactive mode
+	presentScenario.Init()
+
+	// Run subcommand from arguments left after global flags, results are written into stdout, errors into stderr
+	exitCode := commands.NewCommands(parserService, container.GetENSResolver(), presentScenario, os.Stdout, os.Stderr).
+		Run(ctx, flag.Args())
 
-		// Otherwise, if user input is a method name, handle it as a string
-		err = presentScenario.PresentScenarioByName(ctx, methodNumberOrName)
+	if redis != nil {
+		err = redis.Close()
 		if err != nil {
-			fmt.Println("Error: " + err.Error())
-			fmt.Println()
-			continue
+			log.Error("closing redis client failed", "error", err.Error())
 		}
+	}
 
-		fmt.Println()
+	// os.Exit does not run deferred functions, so buffered spans are flushed explicitly
+	err = shutdownTracing(ctx)
+	if err != nil {
+		log.Error("flushing spans failed", "error", err.Error())
 	}
+
+	os.Exit(exitCode)
 }
@@ -0,0 +1,75 @@
+package get_balance
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/models"
+)
+
+// Current scenario name that attached to this scenario and using for spotting method based on scenario name
+const scenarioName = "GetBalance"
+
+// Current scenario number that attached to this scenario and using for spotting method based on scenario number
+const scenarioNumber = 4
+
+// IParserService interface of representation of parser that will be using for handling user request,
+// only parsers tracking balances implement it
+type IParserService interface {
+	GetBalanceHistory(ctx context.Context, address models.Address) ([]models.Balance, error)
+}
+
+// GetBalanceScenario represents scenario object for further handling
+type GetBalanceScenario struct {
+	parserService IParserService
+}
+
+// NewGetBalanceScenario just returns pointer to GetBalanceScenario object with filled service field
+func NewGetBalanceScenario(parserService IParserService) *GetBalanceScenario {
+	return &GetBalanceScenario{
+		parserService: parserService,
+	}
+}
+
+// GetScenarioName returns scenario method name that was attached for current scenario for further handling
+// generally using for routing scenarios based on user input when user type method name represented as a string
+func (s *GetBalanceScenario) GetScenarioName() string {
+	return scenarioName
+}
+
+// GetScenarioNumber returns scenario method number that was attached for current scenario for further handling
+// generally using for routing scenarios based on user input when user type method name represented as a number
+func (s *GetBalanceScenario) GetScenarioNumber() int {
+	return scenarioNumber
+}
+
+// Present represents user scenario for GetBalance method: prints current balance of subscriber and its history
+func (s *GetBalanceScenario) Present(ctx context.Context, reader *bufio.Reader) error {
+	fmt.Println("Enter subscriber address: ")
+	rawSubscriberAddress, _ := reader.ReadString('\n')
+
+	subscriberAddress, err := models.ParseAddress(rawSubscriberAddress)
+	if err != nil {
+		return err
+	}
+
+	history, err := s.parserService.GetBalanceHistory(ctx, subscriberAddress)
+	if err != nil {
+		return err
+	}
+
+	if len(history) == 0 {
+		fmt.Println("Balance is not recorded yet")
+		return nil
+	}
+
+	current := history[len(history)-1]
+	fmt.Printf("Current balance: %s ETH (block %d)\n", models.FormatEther(&current.Balance), current.BlockNumber)
+	fmt.Println()
+	fmt.Println("History:")
+	for _, balance := range history {
+		fmt.Printf("  block %d: %s ETH\n", balance.BlockNumber, models.FormatEther(&balance.Balance))
+	}
+
+	return nil
+}
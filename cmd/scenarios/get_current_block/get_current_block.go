@@ -3,8 +3,8 @@ package get_current_block
 import (
 	"bufio"
 	"context"
-	"errors"
 	"fmt"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/models"
 )
 
 // Current scenario name that attached to this scenario and using for spotting method based on scenario name
@@ -49,7 +49,7 @@ func (s *GetCurrentBlockScenario) Present(ctx context.Context, reader *bufio.Rea
 		return err
 	}
 	if currentBlock == 0 {
-		return errors.New("current block is not parsed yet")
+		return models.ErrCurrentBlockNotParsed
 	}
 
 	fmt.Println(currentBlock)
@@ -6,7 +6,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"github.com/bluntenpassant/ethereum_subscriber/internal/app/models"
-	"github.com/bluntenpassant/ethereum_subscriber/internal/app/utils"
 )
 
 // Current scenario name that attached to this scenario and using for spotting method based on scenario name
@@ -17,7 +16,7 @@ const scenarioNumber = 3
 
 // IParserService interface of representation of parser that will be using for handling user request
 type IParserService interface {
-	GetTransactions(ctx context.Context, address string) ([]*models.Transaction, error)
+	GetTransactions(ctx context.Context, address models.Address) ([]*models.Transaction, error)
 }
 
 // GetTransactionsScenario represents scenario object for further handling
@@ -47,15 +46,19 @@ func (s *GetTransactionsScenario) GetScenarioNumber() int {
 // Present represents user scenario for GetTransactionScenario method and trying to handle it based on user input
 func (s *GetTransactionsScenario) Present(ctx context.Context, reader *bufio.Reader) error {
 	fmt.Println("Enter subscriber address: ")
-	subscriberAddress, _ := reader.ReadString('\n')
-	subscriberAddress = utils.ClearString(subscriberAddress)
+	rawSubscriberAddress, _ := reader.ReadString('\n')
+
+	subscriberAddress, err := models.ParseAddress(rawSubscriberAddress)
+	if err != nil {
+		return err
+	}
 
 	transactions, err := s.parserService.GetTransactions(ctx, subscriberAddress)
 	if err != nil {
 		return err
 	}
 
-	rawTransactions, err := json.MarshalIndent(transactions, "", "  ")
+	rawTransactions, err := json.MarshalIndent(models.ChecksumTransactionsCopy(transactions), "", "  ")
 	if err != nil {
 		return err
 	}
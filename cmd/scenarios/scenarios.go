@@ -3,18 +3,35 @@ package scenarios
 import (
 	"bufio"
 	"context"
+	_ "embed"
 	"errors"
+	"fmt"
+	"github.com/bluntenpassant/ethereum_subscriber/cmd/commands"
+	"github.com/bluntenpassant/ethereum_subscriber/cmd/scenarios/get_balance"
 	"github.com/bluntenpassant/ethereum_subscriber/cmd/scenarios/get_current_block"
 	"github.com/bluntenpassant/ethereum_subscriber/cmd/scenarios/get_transactions"
 	"github.com/bluntenpassant/ethereum_subscriber/cmd/scenarios/subscribe"
 	"github.com/bluntenpassant/ethereum_subscriber/internal/app/models"
+	"io"
+	"strconv"
+	"strings"
 )
 
+// helloText is a greeting shown on start of interactive mode
+//
+//go:embed hello_text
+var helloText []byte
+
 // IParserService interface of representation of parser that will be using for handling user request
 type IParserService interface {
 	GetCurrentBlock(ctx context.Context) (uint64, error)
-	GetTransactions(ctx context.Context, address string) ([]*models.Transaction, error)
-	Subscribe(ctx context.Context, address string) error
+	GetTransactions(ctx context.Context, address models.Address) ([]*models.Transaction, error)
+	Subscribe(ctx context.Context, address models.Address, ensName string) error
+}
+
+// IENSResolver interface of representation of resolver that converts ENS names into addresses
+type IENSResolver interface {
+	Resolve(ctx context.Context, name string) (models.Address, error)
 }
 
 // Scenario represents scenario interface for further handling
@@ -30,13 +47,15 @@ type Scenarios struct {
 	scenariosByNumber map[int]Scenario
 
 	parserService IParserService
+	ensResolver   IENSResolver
 	reader        *bufio.Reader
 }
 
 // NewScenarios just returns pointer to Scenarios object with filled service field
-func NewScenarios(reader *bufio.Reader, parserService IParserService) *Scenarios {
+func NewScenarios(reader *bufio.Reader, parserService IParserService, ensResolver IENSResolver) *Scenarios {
 	return &Scenarios{
 		parserService: parserService,
+		ensResolver:   ensResolver,
 		reader:        reader,
 	}
 }
@@ -71,7 +90,7 @@ func (s *Scenarios) PresentScenarioByName(ctx context.Context, name string) erro
 func (s *Scenarios) Init() {
 	getCurrentBlockScenario := get_current_block.NewGetCurrentBlockScenario(s.parserService)
 	getTransactionsScenario := get_transactions.NewGetTransactionsScenario(s.parserService)
-	subscribeScenario := subscribe.NewSubscribeScenario(s.parserService)
+	subscribeScenario := subscribe.NewSubscribeScenario(s.parserService, s.ensResolver)
 
 	s.scenariosByName = map[string]Scenario{
 		getCurrentBlockScenario.GetScenarioName(): getCurrentBlockScenario,
@@ -84,4 +103,59 @@ func (s *Scenarios) Init() {
 		getTransactionsScenario.GetScenarioNumber(): getTransactionsScenario,
 		subscribeScenario.GetScenarioNumber():       subscribeScenario,
 	}
+
+	// Balance is tracked only by some approaches (indexed), scenario is not available for others
+	if balanceParserService, ok := s.parserService.(get_balance.IParserService); ok {
+		getBalanceScenario := get_balance.NewGetBalanceScenario(balanceParserService)
+
+		s.scenariosByName[getBalanceScenario.GetScenarioName()] = getBalanceScenario
+		s.scenariosByNumber[getBalanceScenario.GetScenarioNumber()] = getBalanceScenario
+	}
+}
+
+// Run starts interactive mode: reads name or number of method from reader and presents its scenario
+// until input is closed or ctx is done
+func (s *Scenarios) Run(ctx context.Context) error {
+	fmt.Printf("%s\n\n\n", helloText)
+
+	for {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		// Read user input from the terminal
+		fmt.Print("Enter a name or number of method: ")
+		methodNumberOrName, err := s.reader.ReadString('\n')
+		if err == io.EOF {
+			fmt.Println()
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		methodNumberOrName = strings.TrimRight(methodNumberOrName, "\n")
+		fmt.Println()
+
+		// If user input represented as a method number, then handle it as a number
+		if num, err := strconv.Atoi(methodNumberOrName); err == nil {
+			err = s.PresentScenarioByNum(ctx, num)
+			if err != nil {
+				fmt.Println("Error: " + commands.ErrorMessage(err))
+				fmt.Println()
+			}
+
+			fmt.Println()
+			continue
+		}
+
+		// Otherwise, if user input is a method name, handle it as a string
+		err = s.PresentScenarioByName(ctx, methodNumberOrName)
+		if err != nil {
+			fmt.Println("Error: " + commands.ErrorMessage(err))
+			fmt.Println()
+			continue
+		}
+
+		fmt.Println()
+	}
 }
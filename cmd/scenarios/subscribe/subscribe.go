@@ -3,11 +3,8 @@ package subscribe
 import (
 	"bufio"
 	"context"
-	"errors"
 	"fmt"
-	"github.com/bluntenpassant/ethereum_subscriber/internal/app/utils"
-	"strconv"
-	"unicode/utf8"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/models"
 )
 
 // Current scenario name that attached to this scenario and using for spotting method based on scenario name
@@ -16,23 +13,33 @@ const scenarioName = "Subscribe"
 // Current scenario name that attached to this scenario and using for spotting method based on scenario name
 const scenarioNumber = 2
 
-// Length of admissible Ethereum address length
-const addressLength = 42
-
 // IParserService interface of representation of parser that will be using for handling user request
 type IParserService interface {
-	Subscribe(ctx context.Context, address string) error
+	Subscribe(ctx context.Context, address models.Address, ensName string) error
+}
+
+// IENSSubscriber interface of representation of parser service that resolves ENS names itself on subscription,
+// like client of API server in remote mode, then names are not resolved locally
+type IENSSubscriber interface {
+	SubscribeENSName(ctx context.Context, ensName string) (models.Address, error)
+}
+
+// IENSResolver interface of representation of resolver that converts ENS names into addresses
+type IENSResolver interface {
+	Resolve(ctx context.Context, name string) (models.Address, error)
 }
 
 // SubscribeScenario represents scenario object for further handling
 type SubscribeScenario struct {
 	parserService IParserService
+	ensResolver   IENSResolver
 }
 
 // NewSubscribeScenario just returns pointer to GetTransactionsScenario object with filled service field
-func NewSubscribeScenario(parserService IParserService) *SubscribeScenario {
+func NewSubscribeScenario(parserService IParserService, ensResolver IENSResolver) *SubscribeScenario {
 	return &SubscribeScenario{
 		parserService: parserService,
+		ensResolver:   ensResolver,
 	}
 }
 
@@ -50,20 +57,53 @@ func (s *SubscribeScenario) GetScenarioNumber() int {
 
 // Present represents user scenario for GetTransactionScenario method and trying to handle it based on user input
 func (s *SubscribeScenario) Present(ctx context.Context, reader *bufio.Reader) error {
-	fmt.Println("Enter subscribe address: ")
-	subscribeAddress, _ := reader.ReadString('\n')
-	subscribeAddress = utils.ClearString(subscribeAddress)
+	fmt.Println("Enter subscribe address or ENS name: ")
+	rawSubscribeAddress, _ := reader.ReadString('\n')
 
-	if utf8.RuneCountInString(subscribeAddress) != 42 {
-		return errors.New("address length should be " + strconv.Itoa(addressLength))
-	}
+	var ensName string
+	var subscribeAddress models.Address
+	var err error
 
-	err := s.parserService.Subscribe(ctx, subscribeAddress)
-	if err != nil {
-		return err
+	if models.IsENSName(rawSubscribeAddress) {
+		ensName, err = models.ParseENSName(rawSubscribeAddress)
+		if err != nil {
+			return err
+		}
+
+		subscribeAddress, err = s.subscribeENSName(ctx, ensName)
+		if err != nil {
+			return err
+		}
+
+		fmt.Println(ensName + " resolved into " + subscribeAddress.Checksum())
+	} else {
+		subscribeAddress, err = models.ParseAddress(rawSubscribeAddress)
+		if err != nil {
+			return err
+		}
+
+		err = s.parserService.Subscribe(ctx, subscribeAddress, ensName)
+		if err != nil {
+			return err
+		}
 	}
 
 	fmt.Println("true")
 
 	return nil
 }
+
+// subscribeENSName subscribes ENS name and returns address it is resolved into. Name is resolved locally
+// unless parser service resolves names itself
+func (s *SubscribeScenario) subscribeENSName(ctx context.Context, ensName string) (models.Address, error) {
+	if ensSubscriber, ok := s.parserService.(IENSSubscriber); ok {
+		return ensSubscriber.SubscribeENSName(ctx, ensName)
+	}
+
+	address, err := s.ensResolver.Resolve(ctx, ensName)
+	if err != nil {
+		return "", err
+	}
+
+	return address, s.parserService.Subscribe(ctx, address, ensName)
+}
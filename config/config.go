@@ -14,6 +14,7 @@ var (
 var (
 	GreedyApproach    ApproachParam = "greedy"
 	ReleasingApproach ApproachParam = "releasing"
+	IndexedApproach   ApproachParam = "indexed"
 )
 
 var (
@@ -21,16 +22,49 @@ var (
 	RedisStorage  StorageParam = "redis"
 )
 
+// Price providers attaching USD value to transactions, empty provider disables valuation
+const (
+	FilePriceProvider = "file"
+)
+
 type Config struct {
 	EthereumJsonRPC EthereumJsonRPC `yaml:"ethereum_jsonrpc"`
+	Chains          []Chain         `yaml:"chains"`
 	General         General         `yaml:"general"`
 	Storage         Storage         `yaml:"storage"`
 	Http            Http            `yaml:"http"`
+	Grpc            Grpc            `yaml:"grpc"`
+	GraphQL         GraphQL         `yaml:"graphql"`
+	Indexer         Indexer         `yaml:"indexer"`
+	ENS             ENS             `yaml:"ens"`
+	Health          Health          `yaml:"health"`
+	Log             Log             `yaml:"log"`
+	Tracing         Tracing         `yaml:"tracing"`
+	Reload          Reload          `yaml:"reload"`
+	CLI             CLI             `yaml:"cli"`
+	Price           Price           `yaml:"price"`
+	Auth            Auth            `yaml:"auth"`
+	RateLimit       RateLimit       `yaml:"rate_limit"`
+	ABI             ABI             `yaml:"abi"`
 }
 
 type EthereumJsonRPC struct {
 	Host    string `yaml:"host"`
 	Version string `yaml:"version"`
+
+	// ChainName and ChainID describe the default chain served by Host
+	ChainName      string `yaml:"chain_name"`
+	ChainID        uint64 `yaml:"chain_id"`
+	NativeCurrency string `yaml:"native_currency"`
+}
+
+// Chain is an additional EVM chain watched besides the default one
+type Chain struct {
+	Name           string `yaml:"name"`
+	ChainID        uint64 `yaml:"chain_id"`
+	Host           string `yaml:"host"`
+	Version        string `yaml:"version"`
+	NativeCurrency string `yaml:"native_currency"`
 }
 
 type General struct {
@@ -39,6 +73,23 @@ type General struct {
 	Storage    StorageParam    `yaml:"storage"`
 }
 
+// String returns processing, approach and storage joined by slash, like sync/indexed/redis
+func (g General) String() string {
+	return string(g.Processing) + "/" + string(g.Approach) + "/" + string(g.Storage)
+}
+
+// SupportedModes are combinations of processing, approach and storage that have a parser service,
+// they should match present scenarios of cmd.Container
+var SupportedModes = []General{
+	{Processing: SyncProcessing, Approach: ReleasingApproach, Storage: MemoryStorage},
+	{Processing: SyncProcessing, Approach: ReleasingApproach, Storage: RedisStorage},
+	{Processing: SyncProcessing, Approach: GreedyApproach, Storage: MemoryStorage},
+	{Processing: SyncProcessing, Approach: GreedyApproach, Storage: RedisStorage},
+	{Processing: SyncProcessing, Approach: IndexedApproach, Storage: MemoryStorage},
+	{Processing: SyncProcessing, Approach: IndexedApproach, Storage: RedisStorage},
+	{Processing: AsyncProcessing, Approach: ReleasingApproach, Storage: MemoryStorage},
+}
+
 type Storage struct {
 	Redis Redis `yaml:"redis"`
 }
@@ -54,4 +105,128 @@ type Redis struct {
 type Http struct {
 	Host string `yaml:"host"`
 	Port string `yaml:"port"`
+
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout"`
+}
+
+type Grpc struct {
+	Enabled bool   `yaml:"enabled"`
+	Host    string `yaml:"host"`
+	Port    string `yaml:"port"`
+
+	// WatchPollInterval is an interval of checking new transactions of address watched by WatchTransactions
+	WatchPollInterval time.Duration `yaml:"watch_poll_interval"`
+	ShutdownTimeout   time.Duration `yaml:"shutdown_timeout"`
+}
+
+type GraphQL struct {
+	Enabled bool `yaml:"enabled"`
+
+	// MaxDepth limits nesting of selections in a query, 0 means the default limit
+	MaxDepth int `yaml:"max_depth"`
+
+	// MaxParallelism limits count of fields of a query resolved in parallel, 0 means the default limit
+	MaxParallelism int `yaml:"max_parallelism"`
+}
+
+type Indexer struct {
+	PollInterval time.Duration `yaml:"poll_interval"`
+}
+
+type ENS struct {
+	RegistryAddress   string        `yaml:"registry_address"`
+	ReResolveInterval time.Duration `yaml:"re_resolve_interval"`
+}
+
+type Health struct {
+	MaxIndexingLag uint64        `yaml:"max_indexing_lag"`
+	CheckTimeout   time.Duration `yaml:"check_timeout"`
+}
+
+type Log struct {
+	Level  string `yaml:"level"`
+	Format string `yaml:"format"`
+}
+
+type Tracing struct {
+	Endpoint    string  `yaml:"endpoint"`
+	Insecure    bool    `yaml:"insecure"`
+	ServiceName string  `yaml:"service_name"`
+	SampleRatio float64 `yaml:"sample_ratio"`
+}
+
+type Reload struct {
+	PollInterval time.Duration `yaml:"poll_interval"`
+}
+
+type CLI struct {
+	RemoteURL string        `yaml:"remote_url"`
+	Timeout   time.Duration `yaml:"timeout"`
+	APIKey    string        `yaml:"api_key"`
+}
+
+type Price struct {
+	Provider string `yaml:"provider"`
+	File     string `yaml:"file"`
+}
+
+// ABI describes directory with ABIs of contracts shared by all tenants, empty Dir means tenants use only uploaded ABIs
+type ABI struct {
+	Dir string `yaml:"dir"`
+}
+
+type Auth struct {
+	Enabled  bool   `yaml:"enabled"`
+	AdminKey string `yaml:"admin_key"`
+}
+
+type RateLimit struct {
+	Enabled bool `yaml:"enabled"`
+	// RequestsPerSecond and Burst limit every route that has no own limit in Routes
+	RequestsPerSecond float64 `yaml:"requests_per_second"`
+	Burst             int     `yaml:"burst"`
+	// Routes are limits by route template, like /get_transactions/{address}
+	Routes map[string]Limit `yaml:"routes"`
+
+	MaxSubscriptionsPerTenant int `yaml:"max_subscriptions_per_tenant"`
+}
+
+// Limit allows RequestsPerSecond requests on average with bursts up to Burst requests
+type Limit struct {
+	RequestsPerSecond float64 `yaml:"requests_per_second"`
+	Burst             int     `yaml:"burst"`
+}
+
+// GetLimit returns limit of the route by its template, routes without own limit get the default one
+func (r RateLimit) GetLimit(route string) Limit {
+	if limit, ok := r.Routes[route]; ok {
+		return limit
+	}
+
+	return Limit{RequestsPerSecond: r.RequestsPerSecond, Burst: r.Burst}
+}
+
+// GetChains returns all watched chains, the default chain described by ethereum_jsonrpc goes first.
+// Empty version and native currency of additional chains are taken from the default chain
+func (c Config) GetChains() []Chain {
+	chains := []Chain{{
+		Name:           c.EthereumJsonRPC.ChainName,
+		ChainID:        c.EthereumJsonRPC.ChainID,
+		Host:           c.EthereumJsonRPC.Host,
+		Version:        c.EthereumJsonRPC.Version,
+		NativeCurrency: c.EthereumJsonRPC.NativeCurrency,
+	}}
+
+	for _, chain := range c.Chains {
+		if chain.Version == "" {
+			chain.Version = c.EthereumJsonRPC.Version
+		}
+		if chain.NativeCurrency == "" {
+			chain.NativeCurrency = c.EthereumJsonRPC.NativeCurrency
+		}
+
+		chains = append(chains, chain)
+	}
+
+	return chains
 }
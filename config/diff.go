@@ -0,0 +1,41 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+)
+
+// Diff returns paths of parameters (like storage.redis.host) that have different values in a and b
+func Diff(a Config, b Config) []string {
+	return diffValues(reflect.ValueOf(a), reflect.ValueOf(b), "")
+}
+
+func diffValues(a reflect.Value, b reflect.Value, prefix string) []string {
+	var changed []string
+
+	valueType := a.Type()
+	for i := 0; i < valueType.NumField(); i++ {
+		field := valueType.Field(i)
+
+		key := strings.Split(field.Tag.Get("yaml"), ",")[0]
+		if key == "" || key == "-" {
+			continue
+		}
+
+		path := key
+		if prefix != "" {
+			path = prefix + "." + key
+		}
+
+		if field.Type.Kind() == reflect.Struct {
+			changed = append(changed, diffValues(a.Field(i), b.Field(i), path)...)
+			continue
+		}
+
+		if !reflect.DeepEqual(a.Field(i).Interface(), b.Field(i).Interface()) {
+			changed = append(changed, path)
+		}
+	}
+
+	return changed
+}
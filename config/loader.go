@@ -0,0 +1,387 @@
+package config
+
+import (
+	_ "embed"
+	"errors"
+	"gopkg.in/yaml.v3"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// EnvPrefix is a prefix of environment variables overriding config fields.
+// Name of variable is built from yaml keys of the field, e.g. storage.redis.host is ETHSUB_STORAGE_REDIS_HOST
+const EnvPrefix = "ETHSUB"
+
+// PathEnv is an environment variable with path to config file, it is used when --config flag is not set
+const PathEnv = EnvPrefix + "_CONFIG"
+
+// DefaultPath is a path of config file that is read when path is not set and the file exists,
+// so binaries launched from repository root keep using local config
+const DefaultPath = "./config/config.local.yaml"
+
+// defaultConfig is embedded into binaries and provides defaults for every field,
+// so binaries can be launched from any directory without config file
+//
+//go:embed config.local.yaml
+var defaultConfig []byte
+
+// Load builds config in the following order, every next step overrides previous one:
+// 1. defaults (embedded config.local.yaml)
+// 2. config file from path, PathEnv or DefaultPath
+// 3. environment variables with EnvPrefix
+// Resulting config is validated.
+func Load(path string) (Config, error) {
+	return load(path, os.LookupEnv)
+}
+
+func load(path string, lookupEnv func(key string) (string, bool)) (Config, error) {
+	config := Config{}
+
+	err := yaml.Unmarshal(defaultConfig, &config)
+	if err != nil {
+		return Config{}, errors.New("unmarshaling default config failed cause: " + err.Error())
+	}
+
+	path = resolvePath(path, lookupEnv)
+	if path != "" {
+		configData, err := os.ReadFile(path)
+		if err != nil {
+			return Config{}, errors.New("reading config file failed cause: " + err.Error())
+		}
+
+		err = yaml.Unmarshal(configData, &config)
+		if err != nil {
+			return Config{}, errors.New("unmarshaling config file " + path + " failed cause: " + err.Error())
+		}
+	}
+
+	err = applyEnv(reflect.ValueOf(&config).Elem(), EnvPrefix, lookupEnv)
+	if err != nil {
+		return Config{}, err
+	}
+
+	err = config.Validate()
+	if err != nil {
+		return Config{}, err
+	}
+
+	return config, nil
+}
+
+// ResolvePath returns path of config file that is read by Load for given path,
+// empty string means that only defaults and environment variables are used
+func ResolvePath(path string) string {
+	return resolvePath(path, os.LookupEnv)
+}
+
+func resolvePath(path string, lookupEnv func(key string) (string, bool)) string {
+	if path == "" {
+		path, _ = lookupEnv(PathEnv)
+	}
+
+	if path == "" {
+		if _, err := os.Stat(DefaultPath); err == nil {
+			path = DefaultPath
+		}
+	}
+
+	return path
+}
+
+// applyEnv walks through struct fields recursively and sets every field that has environment variable.
+// Name of variable is prefix and upper-cased yaml key joined by underscore
+func applyEnv(value reflect.Value, prefix string, lookupEnv func(key string) (string, bool)) error {
+	valueType := value.Type()
+
+	for i := 0; i < valueType.NumField(); i++ {
+		field := valueType.Field(i)
+
+		key := strings.Split(field.Tag.Get("yaml"), ",")[0]
+		if key == "" || key == "-" {
+			continue
+		}
+
+		name := prefix + "_" + strings.ToUpper(key)
+
+		if field.Type.Kind() == reflect.Struct {
+			err := applyEnv(value.Field(i), name, lookupEnv)
+			if err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		raw, ok := lookupEnv(name)
+		if !ok {
+			continue
+		}
+
+		err := setField(value.Field(i), raw)
+		if err != nil {
+			return errors.New("invalid value of " + name + " cause: " + err.Error())
+		}
+	}
+
+	return nil
+}
+
+// setField parses raw value of environment variable according to field type
+func setField(field reflect.Value, raw string) error {
+	// time.Duration is int64 too, so it is checked before kinds
+	if field.Type() == reflect.TypeOf(time.Duration(0)) {
+		duration, err := time.ParseDuration(raw)
+		if err != nil {
+			return err
+		}
+
+		field.SetInt(int64(duration))
+
+		return nil
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(raw)
+	case reflect.Bool:
+		value, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+		field.SetBool(value)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		value, err := strconv.ParseInt(raw, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetInt(value)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		value, err := strconv.ParseUint(raw, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetUint(value)
+	case reflect.Float32, reflect.Float64:
+		value, err := strconv.ParseFloat(raw, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetFloat(value)
+	// Lists and maps like chains and rate_limit.routes are written in JSON (or YAML flow style),
+	// they are decoded the same way as in config file, so entries of maps are merged with defaults
+	case reflect.Slice, reflect.Map:
+		err := yaml.Unmarshal([]byte(raw), field.Addr().Interface())
+		if err != nil {
+			return err
+		}
+	default:
+		return errors.New("unsupported field type " + field.Type().String())
+	}
+
+	return nil
+}
+
+// Validate checks that config values are known and consistent, all found problems are returned together
+func (c Config) Validate() error {
+	var errs []error
+
+	if !isOneOf(c.General.Processing, SyncProcessing, AsyncProcessing) {
+		errs = append(errs, errors.New("general.processing: unknown value \""+string(c.General.Processing)+
+			"\", should be one of: "+joinValues(SyncProcessing, AsyncProcessing)))
+	}
+
+	if !isOneOf(c.General.Approach, ReleasingApproach, GreedyApproach, IndexedApproach) {
+		errs = append(errs, errors.New("general.approach: unknown value \""+string(c.General.Approach)+
+			"\", should be one of: "+joinValues(ReleasingApproach, GreedyApproach, IndexedApproach)))
+	}
+
+	if !isOneOf(c.General.Storage, MemoryStorage, RedisStorage) {
+		errs = append(errs, errors.New("general.storage: unknown value \""+string(c.General.Storage)+
+			"\", should be one of: "+joinValues(MemoryStorage, RedisStorage)))
+	}
+
+	errs = append(errs, c.validateMode()...)
+
+	if c.EthereumJsonRPC.Host == "" {
+		errs = append(errs, errors.New("ethereum_jsonrpc.host: should not be empty"))
+	}
+
+	errs = append(errs, c.validateChains()...)
+
+	if c.General.Storage == RedisStorage && c.Storage.Redis.Host == "" {
+		errs = append(errs, errors.New("storage.redis.host: should not be empty with redis storage"))
+	}
+
+	if port, err := strconv.ParseUint(c.Http.Port, 10, 16); err != nil || port == 0 {
+		errs = append(errs, errors.New("http.port: \""+c.Http.Port+"\" is not a valid port"))
+	}
+
+	if c.Grpc.Enabled {
+		if port, err := strconv.ParseUint(c.Grpc.Port, 10, 16); err != nil || port == 0 {
+			errs = append(errs, errors.New("grpc.port: \""+c.Grpc.Port+"\" is not a valid port"))
+		} else if c.Grpc.Port == c.Http.Port {
+			errs = append(errs, errors.New("grpc.port: should differ from http.port"))
+		}
+
+		if c.Grpc.WatchPollInterval <= 0 {
+			errs = append(errs, errors.New("grpc.watch_poll_interval: should be positive"))
+		}
+	}
+
+	if c.GraphQL.MaxDepth < 0 {
+		errs = append(errs, errors.New("graphql.max_depth: should not be negative"))
+	}
+
+	if c.GraphQL.MaxParallelism < 0 {
+		errs = append(errs, errors.New("graphql.max_parallelism: should not be negative"))
+	}
+
+	if c.Tracing.SampleRatio < 0 || c.Tracing.SampleRatio > 1 {
+		errs = append(errs, errors.New("tracing.sample_ratio: should be in range [0, 1]"))
+	}
+
+	if c.Price.Provider != "" && !isOneOf(c.Price.Provider, FilePriceProvider) {
+		errs = append(errs, errors.New("price.provider: unknown value \""+c.Price.Provider+
+			"\", should be one of: "+joinValues(FilePriceProvider)))
+	}
+
+	if c.Price.Provider == FilePriceProvider && c.Price.File == "" {
+		errs = append(errs, errors.New("price.file: should not be empty with file provider"))
+	}
+
+	if c.Auth.Enabled && c.Auth.AdminKey == "" {
+		errs = append(errs, errors.New("auth.admin_key: should not be empty with enabled auth"))
+	}
+
+	errs = append(errs, c.validateRateLimit()...)
+
+	return errors.Join(errs...)
+}
+
+// validateMode checks that combination of known processing, approach and storage is one of SupportedModes
+func (c Config) validateMode() []error {
+	if !isOneOf(c.General.Processing, SyncProcessing, AsyncProcessing) || !isOneOf(c.General.Approach, ReleasingApproach, GreedyApproach, IndexedApproach) ||
+		!isOneOf(c.General.Storage, MemoryStorage, RedisStorage) {
+		return nil
+	}
+
+	supported := make([]string, 0, len(SupportedModes))
+	for _, mode := range SupportedModes {
+		if mode == c.General {
+			return nil
+		}
+
+		supported = append(supported, mode.String())
+	}
+
+	return []error{errors.New("general: " + c.General.String() + " is not supported, should be one of: " + strings.Join(supported, ", "))}
+}
+
+// validateChains checks that every chain has a host and chains are distinguishable by name and id.
+// Name should not be a number, because chain is selected by name or id in API and CLI
+func (c Config) validateChains() []error {
+	var errs []error
+
+	names := make(map[string]bool)
+	ids := make(map[uint64]bool)
+
+	for i, chain := range c.GetChains() {
+		path := "ethereum_jsonrpc"
+		if i > 0 {
+			path = "chains[" + strconv.Itoa(i-1) + "]"
+		}
+
+		if chain.Name == "" {
+			errs = append(errs, errors.New(path+": chain name should not be empty"))
+		} else if _, err := strconv.ParseUint(chain.Name, 10, 64); err == nil {
+			errs = append(errs, errors.New(path+": chain name \""+chain.Name+"\" should not be a number"))
+		}
+
+		if chain.ChainID == 0 {
+			errs = append(errs, errors.New(path+": chain_id should not be 0"))
+		}
+
+		if i > 0 && chain.Host == "" {
+			errs = append(errs, errors.New(path+".host: should not be empty"))
+		}
+
+		if names[chain.Name] || ids[chain.ChainID] {
+			errs = append(errs, errors.New(path+": chain \""+chain.Name+"\" ("+strconv.FormatUint(chain.ChainID, 10)+") is configured twice"))
+		}
+
+		names[chain.Name] = true
+		ids[chain.ChainID] = true
+	}
+
+	return errs
+}
+
+// validateRateLimit checks limits only when rate limiting is enabled, so disabled section may keep zero values
+func (c Config) validateRateLimit() []error {
+	var errs []error
+
+	if c.RateLimit.MaxSubscriptionsPerTenant < 0 {
+		errs = append(errs, errors.New("rate_limit.max_subscriptions_per_tenant: should not be negative"))
+	}
+
+	if !c.RateLimit.Enabled {
+		return errs
+	}
+
+	errs = append(errs, validateLimit("rate_limit", Limit{RequestsPerSecond: c.RateLimit.RequestsPerSecond, Burst: c.RateLimit.Burst})...)
+
+	routes := make([]string, 0, len(c.RateLimit.Routes))
+	for route := range c.RateLimit.Routes {
+		routes = append(routes, route)
+	}
+	// Map order is random, errors are sorted to be stable
+	sort.Strings(routes)
+
+	for _, route := range routes {
+		path := "rate_limit.routes[" + route + "]"
+		if !strings.HasPrefix(route, "/") {
+			errs = append(errs, errors.New(path+": route should start with /"))
+		}
+
+		errs = append(errs, validateLimit(path, c.RateLimit.Routes[route])...)
+	}
+
+	return errs
+}
+
+func validateLimit(path string, limit Limit) []error {
+	var errs []error
+
+	if limit.RequestsPerSecond <= 0 {
+		errs = append(errs, errors.New(path+".requests_per_second: should be positive"))
+	}
+
+	if limit.Burst < 1 {
+		errs = append(errs, errors.New(path+".burst: should be at least 1"))
+	}
+
+	return errs
+}
+
+func isOneOf[T ~string](value T, allowed ...T) bool {
+	for _, allowedValue := range allowed {
+		if value == allowedValue {
+			return true
+		}
+	}
+
+	return false
+}
+
+func joinValues[T ~string](values ...T) string {
+	names := make([]string, 0, len(values))
+	for _, value := range values {
+		names = append(names, string(value))
+	}
+
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,212 @@
+package config
+
+import (
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestLoad(t *testing.T) {
+	type TestCase struct {
+		Name        string
+		File        string
+		Env         map[string]string
+		Check       func(t *testing.T, config Config)
+		ExpectedErr string
+	}
+
+	testCases := []TestCase{
+		{
+			Name: "defaults",
+			Check: func(t *testing.T, config Config) {
+				assert.Equal(t, SyncProcessing, config.General.Processing)
+				assert.Equal(t, MemoryStorage, config.General.Storage)
+				assert.Equal(t, "8080", config.Http.Port)
+				assert.Equal(t, 3*time.Hour, config.Storage.Redis.DataKeepAliveDuration)
+			},
+		},
+		{
+			Name: "file overrides defaults",
+			File: "general:\n  approach: indexed\nhttp:\n  port: 9090\n",
+			Check: func(t *testing.T, config Config) {
+				assert.Equal(t, IndexedApproach, config.General.Approach)
+				assert.Equal(t, "9090", config.Http.Port)
+				// Fields missing in file keep defaults
+				assert.Equal(t, SyncProcessing, config.General.Processing)
+				assert.Equal(t, "0.0.0.0", config.Http.Host)
+			},
+		},
+		{
+			Name: "additional chains",
+			File: "chains:\n  - name: arbitrum\n    chain_id: 42161\n    host: http://localhost:8547\n" +
+				"  - name: polygon\n    chain_id: 137\n    host: http://localhost:8548\n    native_currency: POL\n",
+			Check: func(t *testing.T, config Config) {
+				assert.Equal(t, []Chain{
+					{Name: "ethereum", ChainID: 1, Host: "https://cloudflare-eth.com", Version: "2.0", NativeCurrency: "ETH"},
+					{Name: "arbitrum", ChainID: 42161, Host: "http://localhost:8547", Version: "2.0", NativeCurrency: "ETH"},
+					{Name: "polygon", ChainID: 137, Host: "http://localhost:8548", Version: "2.0", NativeCurrency: "POL"},
+				}, config.GetChains())
+			},
+		},
+		{
+			Name: "env overrides file",
+			File: "storage:\n  redis:\n    host: redis:6379\n",
+			Env: map[string]string{
+				"ETHSUB_GENERAL_STORAGE":                        "redis",
+				"ETHSUB_STORAGE_REDIS_HOST":                     "10.0.0.1:6379",
+				"ETHSUB_STORAGE_REDIS_DB":                       "2",
+				"ETHSUB_STORAGE_REDIS_DATA_KEEP_ALIVE_DURATION": "30m",
+				"ETHSUB_HEALTH_MAX_INDEXING_LAG":                "5",
+				"ETHSUB_TRACING_INSECURE":                       "false",
+				"ETHSUB_TRACING_SAMPLE_RATIO":                   "0.25",
+				"ETHSUB_ETHEREUM_JSONRPC_HOST":                  "http://localhost:8545",
+			},
+			Check: func(t *testing.T, config Config) {
+				assert.Equal(t, RedisStorage, config.General.Storage)
+				assert.Equal(t, "10.0.0.1:6379", config.Storage.Redis.Host)
+				assert.Equal(t, 2, config.Storage.Redis.DB)
+				assert.Equal(t, 30*time.Minute, config.Storage.Redis.DataKeepAliveDuration)
+				assert.Equal(t, uint64(5), config.Health.MaxIndexingLag)
+				assert.Equal(t, false, config.Tracing.Insecure)
+				assert.Equal(t, 0.25, config.Tracing.SampleRatio)
+				assert.Equal(t, "http://localhost:8545", config.EthereumJsonRPC.Host)
+			},
+		},
+		{
+			Name: "auth from env",
+			Env:  map[string]string{"ETHSUB_AUTH_ENABLED": "true", "ETHSUB_AUTH_ADMIN_KEY": "secret"},
+			Check: func(t *testing.T, config Config) {
+				assert.Equal(t, Auth{Enabled: true, AdminKey: "secret"}, config.Auth)
+			},
+		},
+		{
+			Name: "rate limit routes are merged with defaults",
+			File: "rate_limit:\n  enabled: true\n  routes:\n    /get_transactions/{address}:\n      requests_per_second: 2\n      burst: 4\n" +
+				"    /subscribe/{address}:\n      requests_per_second: 1\n      burst: 1\n",
+			Env: map[string]string{"ETHSUB_RATE_LIMIT_MAX_SUBSCRIPTIONS_PER_TENANT": "100"},
+			Check: func(t *testing.T, config Config) {
+				assert.Equal(t, true, config.RateLimit.Enabled)
+				assert.Equal(t, 100, config.RateLimit.MaxSubscriptionsPerTenant)
+				assert.Equal(t, Limit{RequestsPerSecond: 2, Burst: 4}, config.RateLimit.GetLimit("/get_transactions/{address}"))
+				assert.Equal(t, Limit{RequestsPerSecond: 1, Burst: 1}, config.RateLimit.GetLimit("/subscribe/{address}"))
+				assert.Equal(t, Limit{RequestsPerSecond: 0.2, Burst: 2}, config.RateLimit.GetLimit("/export/{address}"))
+				assert.Equal(t, Limit{RequestsPerSecond: 10, Burst: 20}, config.RateLimit.GetLimit("/get_current_block"))
+			},
+		},
+		{
+			Name: "lists and maps from env",
+			Env: map[string]string{
+				"ETHSUB_CHAINS":            `[{"name":"arbitrum","chain_id":42161,"host":"http://localhost:8547"}]`,
+				"ETHSUB_RATE_LIMIT_ROUTES": `{"/get_transactions/{address}":{"requests_per_second":2,"burst":4}}`,
+			},
+			Check: func(t *testing.T, config Config) {
+				assert.Equal(t, []Chain{{Name: "arbitrum", ChainID: 42161, Host: "http://localhost:8547"}}, config.Chains)
+				assert.Equal(t, Limit{RequestsPerSecond: 2, Burst: 4}, config.RateLimit.GetLimit("/get_transactions/{address}"))
+				// Routes from env are merged with defaults like routes from file
+				assert.Equal(t, Limit{RequestsPerSecond: 0.2, Burst: 2}, config.RateLimit.GetLimit("/export/{address}"))
+			},
+		},
+		{
+			Name: "env list replaces list from file",
+			File: "chains:\n  - name: arbitrum\n    chain_id: 42161\n    host: http://localhost:8547\n",
+			Env:  map[string]string{"ETHSUB_CHAINS": `[]`},
+			Check: func(t *testing.T, config Config) {
+				assert.Empty(t, config.Chains)
+			},
+		},
+		{
+			Name:        "invalid env list",
+			Env:         map[string]string{"ETHSUB_CHAINS": `[{"name":"arbitrum"`},
+			ExpectedErr: "invalid value of ETHSUB_CHAINS",
+		},
+		{
+			Name:        "invalid env value",
+			Env:         map[string]string{"ETHSUB_INDEXER_POLL_INTERVAL": "often"},
+			ExpectedErr: "invalid value of ETHSUB_INDEXER_POLL_INTERVAL",
+		},
+		{
+			Name:        "unknown processing",
+			File:        "general:\n  processing: parallel\n",
+			ExpectedErr: "general.processing: unknown value \"parallel\", should be one of: sync, async",
+		},
+		{
+			Name:        "unknown approach and storage",
+			Env:         map[string]string{"ETHSUB_GENERAL_APPROACH": "lazy", "ETHSUB_GENERAL_STORAGE": "postgres"},
+			ExpectedErr: "general.approach: unknown value \"lazy\", should be one of: releasing, greedy, indexed\ngeneral.storage: unknown value \"postgres\", should be one of: memory, redis",
+		},
+		{
+			Name: "unsupported combination",
+			Env:  map[string]string{"ETHSUB_GENERAL_PROCESSING": "async", "ETHSUB_GENERAL_APPROACH": "indexed", "ETHSUB_GENERAL_STORAGE": "redis"},
+			ExpectedErr: "general: async/indexed/redis is not supported, should be one of: sync/releasing/memory, sync/releasing/redis, " +
+				"sync/greedy/memory, sync/greedy/redis, sync/indexed/memory, sync/indexed/redis, async/releasing/memory",
+		},
+		{
+			Name:        "invalid port",
+			Env:         map[string]string{"ETHSUB_HTTP_PORT": "http"},
+			ExpectedErr: "http.port: \"http\" is not a valid port",
+		},
+		{
+			Name: "duplicate chain",
+			File: "chains:\n  - name: arbitrum\n    chain_id: 42161\n    host: http://localhost:8547\n" +
+				"  - name: arbitrum\n    chain_id: 42170\n    host: http://localhost:8548\n",
+			ExpectedErr: "chains[1]: chain \"arbitrum\" (42170) is configured twice",
+		},
+		{
+			Name:        "auth without admin key",
+			Env:         map[string]string{"ETHSUB_AUTH_ENABLED": "true"},
+			ExpectedErr: "auth.admin_key: should not be empty with enabled auth",
+		},
+		{
+			Name: "invalid rate limits",
+			File: "rate_limit:\n  enabled: true\n  burst: 0\n  max_subscriptions_per_tenant: -1\n  routes:\n    get_current_block:\n      requests_per_second: 0\n      burst: 1\n",
+			ExpectedErr: "rate_limit.max_subscriptions_per_tenant: should not be negative\nrate_limit.burst: should be at least 1\n" +
+				"rate_limit.routes[get_current_block]: route should start with /\nrate_limit.routes[get_current_block].requests_per_second: should be positive",
+		},
+		{
+			Name:        "grpc port collides with http port",
+			File:        "grpc:\n  enabled: true\n  port: 8080\n  watch_poll_interval: 0s\n",
+			ExpectedErr: "grpc.port: should differ from http.port\ngrpc.watch_poll_interval: should be positive",
+		},
+		{
+			Name:        "negative graphql limits",
+			Env:         map[string]string{"ETHSUB_GRAPHQL_MAX_DEPTH": "-1", "ETHSUB_GRAPHQL_MAX_PARALLELISM": "-1"},
+			ExpectedErr: "graphql.max_depth: should not be negative\ngraphql.max_parallelism: should not be negative",
+		},
+		{
+			Name:        "unknown price provider",
+			Env:         map[string]string{"ETHSUB_PRICE_PROVIDER": "coingecko"},
+			ExpectedErr: "price.provider: unknown value \"coingecko\", should be one of: file",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			path := ""
+			if testCase.File != "" {
+				path = filepath.Join(t.TempDir(), "config.yaml")
+				assert.NoError(t, os.WriteFile(path, []byte(testCase.File), 0o600))
+			}
+
+			lookupEnv := func(key string) (string, bool) {
+				value, ok := testCase.Env[key]
+				return value, ok
+			}
+
+			config, err := load(path, lookupEnv)
+			if testCase.ExpectedErr != "" {
+				assert.ErrorContains(t, err, testCase.ExpectedErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			testCase.Check(t, config)
+		})
+	}
+}
+
+func TestLoad_MissingFile(t *testing.T) {
+	_, err := load(filepath.Join(t.TempDir(), "missing.yaml"), func(string) (string, bool) { return "", false })
+	assert.ErrorContains(t, err, "reading config file failed")
+}
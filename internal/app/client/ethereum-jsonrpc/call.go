@@ -0,0 +1,76 @@
+package ethereum_jsonrpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/client/ethereum-jsonrpc/models"
+)
+
+// callRPCName is the name of the JSON-RPC method for executing a message call without creating a transaction.
+const callRPCName = "eth_call"
+
+// latestBlockTag is a block tag that points to the latest mined block.
+const latestBlockTag = "latest"
+
+// CallReq represents the request for the Call method.
+// It contains the address of the contract to call and ABI encoded call data.
+type CallReq struct {
+	// To is the address of the contract the call is directed to
+	To string
+
+	// Data is ABI encoded method selector and arguments
+	Data models.HexBytes
+}
+
+// Validate checks if the contract address field in the request is empty.
+// If the contract address field is empty, it returns an error.
+func (r *CallReq) Validate() error {
+	if r.To == "" {
+		return errors.New("contract address field is empty")
+	}
+
+	return nil
+}
+
+// callObject represents the transaction call object passed to "eth_call" JSON-RPC method
+type callObject struct {
+	To   string          `json:"to"`
+	Data models.HexBytes `json:"data"`
+}
+
+// CallResp represents the response of the Call method.
+// It contains ABI encoded return value of the called contract method.
+type CallResp struct {
+	Result models.HexBytes
+}
+
+// Call is a method of the Client struct that sends a JSON-RPC request to execute a contract method call
+// on the latest block without creating a transaction.
+// It takes a CallReq as input and returns a CallResp and error.
+func (c *Client) Call(ctx context.Context, req *CallReq) (*CallResp, error) {
+	// Validate the input request
+	err := req.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	// Send the JSON-RPC request
+	rawReqResp, err := c.sendJSONRPCRequest(ctx, callRPCName, []interface{}{
+		callObject{To: req.To, Data: req.Data},
+		latestBlockTag,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Unmarshal the JSON-RPC response into CallResp
+	var callResp CallResp
+	err = json.Unmarshal(rawReqResp, &callResp.Result)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return the response
+	return &callResp, nil
+}
@@ -2,11 +2,18 @@ package ethereum_jsonrpc
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/metrics"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"io/ioutil"
+	"log/slog"
 	"net/http"
 	"sync/atomic"
+	"time"
 )
 
 // jsonRPCReq represents a JSON-RPC request to be sent to Ethereum node.
@@ -48,72 +55,156 @@ type RpcError struct {
 	Message string `json:"message"`
 }
 
+// ErrRequestFailed is matched by every error of request to Ethereum node
+var ErrRequestFailed = errors.New("ethereum node request failed")
+
+// RequestError is an error of JSON-RPC request to Ethereum node
+type RequestError struct {
+	// Method is a JSON-RPC method of the failed request
+	Method string
+
+	// Err is an error of transport or an error returned by the node
+	Err error
+}
+
+func (e *RequestError) Error() string {
+	return "ethereum node request " + e.Method + " failed cause: " + e.Err.Error()
+}
+
+func (e *RequestError) Unwrap() error {
+	return e.Err
+}
+
+// Is makes every RequestError match ErrRequestFailed
+func (e *RequestError) Is(target error) bool {
+	return target == ErrRequestFailed
+}
+
 // Client represents a client that can send JSON RPC requests to an Ethereum node
 type Client struct {
-	// Host is the address of the Ethereum node to connect to
-	Host string
+	// chainID is the ID of the chain served by the node, it labels chain head metric
+	chainID uint64
+
+	// host is the address of the Ethereum node to connect to, it can be changed at runtime by SetHost
+	host atomic.Pointer[string]
 
 	// JsonRPC is the version of the JSON-RPC protocol to use
 	JsonRPC string
 	// CurrentReqID is an atomic counter used to generate unique identifiers for requests
 	CurrentReqID atomic.Uint32
+
+	// logger is used for logging every request on debug level
+	logger *slog.Logger
 }
 
-// NewClient creates a new client that can send JSON RPC requests to an Ethereum node
-func NewClient(Host string, JsonRPC string) *Client {
-	return &Client{
-		Host:    Host,
+// NewClient creates a new client that can send JSON RPC requests to a node of the chain with chainID
+func NewClient(chainID uint64, Host string, JsonRPC string, logger *slog.Logger) *Client {
+	client := &Client{
+		chainID: chainID,
 		JsonRPC: JsonRPC,
+		logger:  logger,
 	}
+	client.SetHost(Host)
+
+	return client
+}
+
+// SetHost switches client to another Ethereum node, requests that are already sent are finished with the previous node
+func (c *Client) SetHost(host string) {
+	c.host.Store(&host)
+}
+
+// Host returns the address of the Ethereum node requests are sent to
+func (c *Client) Host() string {
+	return *c.host.Load()
 }
 
 // sendJSONRPCRequest sends a JSON-RPC request to the Ethereum node defined in the Client struct.
 // The method takes a method name and an array of parameters and returns the raw result in JSON format or an error.
-func (c *Client) sendJSONRPCRequest(method string, params []interface{}) (json.RawMessage, error) {
+// Every request is recorded in metrics with its method, status and latency and logged on debug level.
+// Request is cancelled when ctx is done. Every request is traced with a client span, attrs are added to the span.
+// Errors are returned as RequestError, so callers can tell failures of the node by errors.Is(err, ErrRequestFailed).
+func (c *Client) sendJSONRPCRequest(ctx context.Context, method string, params []interface{}, attrs ...attribute.KeyValue) (json.RawMessage, error) {
+	start := time.Now()
+	id := int(c.CurrentReqID.Add(1))
+
+	ctx, span := tracing.StartWithKind(ctx, "json-rpc "+method, trace.SpanKindClient,
+		append(attrs, attribute.String("rpc.system", "jsonrpc"), attribute.String("rpc.method", method), attribute.Int("rpc.jsonrpc.request_id", id))...)
+	defer span.End()
+
+	result, status, err := c.doJSONRPCRequest(ctx, id, method, params)
+
+	duration := time.Since(start)
+	metrics.ObserveRPCRequest(method, status, duration)
+
+	span.SetAttributes(attribute.String("rpc.status", status))
+	tracing.Error(span, err)
+
+	logAttrs := []any{"method", method, "rpc_id", id, "status", status, "duration", duration}
+	if err != nil {
+		logAttrs = append(logAttrs, "error", err.Error())
+	}
+	c.logger.DebugContext(ctx, "json-rpc request", logAttrs...)
+
+	if err != nil {
+		return nil, &RequestError{Method: method, Err: err}
+	}
+
+	return result, nil
+}
+
+// doJSONRPCRequest does the actual work of sendJSONRPCRequest and additionally returns request status for metrics.
+func (c *Client) doJSONRPCRequest(ctx context.Context, id int, method string, params []interface{}) (json.RawMessage, string, error) {
 	// Create a JSON-RPC request struct with the provided method name, parameters and a unique ID.
 	request := jsonRPCReq{
-		JsonRPC: c.JsonRPC,                  // JSON-RPC version string
-		Method:  method,                     // Name of the method to call
-		Params:  params,                     // Method parameters
-		ID:      int(c.CurrentReqID.Add(1)), // Unique request ID
+		JsonRPC: c.JsonRPC, // JSON-RPC version string
+		Method:  method,    // Name of the method to call
+		Params:  params,    // Method parameters
+		ID:      id,        // Unique request ID
 	}
 
 	// Marshal the JSON-RPC request struct into a JSON payload.
 	payload, err := json.Marshal(request)
 	if err != nil {
-		return nil, err
+		return nil, metrics.RPCStatusTransportError, err
 	}
 
 	// Send a POST request to the Ethereum node with the JSON payload.
-	resp, err := http.Post(c.Host, "application/json", bytes.NewBuffer(payload))
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, c.Host(), bytes.NewBuffer(payload))
+	if err != nil {
+		return nil, metrics.RPCStatusTransportError, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(httpReq)
 	if err != nil {
-		return nil, err
+		return nil, metrics.RPCStatusTransportError, err
 	}
 	defer resp.Body.Close()
 
 	// Read the response body.
 	body, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
-		return nil, err
+		return nil, metrics.RPCStatusTransportError, err
 	}
 
 	// Check if the status code of the response is not OK (200).
 	if resp.StatusCode != http.StatusOK {
-		return nil, errors.New(string(body))
+		return nil, metrics.RPCStatusTransportError, errors.New(string(body))
 	}
 
 	// Unmarshal the response body into a JSON-RPC response struct.
 	var rpcResp jsonRPCResp
 	err = json.Unmarshal(body, &rpcResp)
 	if err != nil {
-		return nil, err
+		return nil, metrics.RPCStatusDecodeError, err
 	}
 
 	// Check if the response contains an error message.
 	if rpcResp.Error.Message != "" {
-		return nil, errors.New(rpcResp.Error.Message)
+		return nil, metrics.RPCStatusRPCError, errors.New(rpcResp.Error.Message)
 	}
 
 	// Return the raw result from the JSON-RPC response.
-	return rpcResp.Result, nil
+	return rpcResp.Result, metrics.RPCStatusOK, nil
 }
@@ -0,0 +1,58 @@
+package ethereum_jsonrpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/client/ethereum-jsonrpc/models"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// getBalanceRPCName is the name of the JSON-RPC method for getting the balance of a specific address.
+const getBalanceRPCName = "eth_getBalance"
+
+// GetBalanceReq represents the request for the GetBalance method.
+// It contains the address of the account and the block number at which the balance should be retrieved.
+type GetBalanceReq struct {
+	Address     string
+	BlockNumber models.HexUint64
+}
+
+// Validate checks if the address field in the request is empty.
+// If the address field is empty, it returns an error.
+func (r *GetBalanceReq) Validate() error {
+	if r.Address == "" {
+		return errors.New("address field is empty")
+	}
+
+	return nil
+}
+
+// GetBalanceResp represents the response of the GetBalance method.
+// It contains the balance of the account in wei.
+type GetBalanceResp struct {
+	Balance models.HexBigInt
+}
+
+// GetBalance is a method of the Client struct that sends a JSON-RPC request to retrieve the balance of a specific address
+// at the end of the specified block.
+func (c *Client) GetBalance(ctx context.Context, req *GetBalanceReq) (*GetBalanceResp, error) {
+	err := req.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	rawReqResp, err := c.sendJSONRPCRequest(ctx, getBalanceRPCName, []interface{}{req.Address, req.BlockNumber},
+		attribute.Int64("block_number", int64(req.BlockNumber)))
+	if err != nil {
+		return nil, err
+	}
+
+	var getBalanceResp GetBalanceResp
+	err = json.Unmarshal(rawReqResp, &getBalanceResp.Balance)
+	if err != nil {
+		return nil, err
+	}
+
+	return &getBalanceResp, nil
+}
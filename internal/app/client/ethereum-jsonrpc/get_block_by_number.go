@@ -1,8 +1,10 @@
 package ethereum_jsonrpc
 
 import (
+	"context"
 	"encoding/json"
 	"github.com/bluntenpassant/ethereum_subscriber/internal/app/client/ethereum-jsonrpc/models"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 // Defining the constant getBlockByNumberRPCName which is the name of the JSON-RPC method to be called.
@@ -83,9 +85,10 @@ type Transaction struct {
 // GetBlockByNumber method retrieves a block from the Ethereum blockchain, using its block number as the identifier.
 // The method takes a GetBlockByNumberReq struct as an input argument, which contains the block number and a boolean flag indicating whether or not
 // to retrieve the full transaction details for each transaction within the block.
-func (c *Client) GetBlockByNumber(req *GetBlockByNumberReq) (*GetBlockByNumberResp, error) {
+func (c *Client) GetBlockByNumber(ctx context.Context, req *GetBlockByNumberReq) (*GetBlockByNumberResp, error) {
 	// send the JSON-RPC request to the Ethereum client
-	rawReqResp, err := c.sendJSONRPCRequest(getBlockByNumberRPCName, []interface{}{req.BlockNumber, req.IsGetFullTx})
+	rawReqResp, err := c.sendJSONRPCRequest(ctx, getBlockByNumberRPCName, []interface{}{req.BlockNumber, req.IsGetFullTx},
+		attribute.Int64("block_number", int64(req.BlockNumber)))
 	if err != nil {
 		// if there was an error, return it
 		return nil, err
@@ -0,0 +1,45 @@
+package ethereum_jsonrpc
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/client/ethereum-jsonrpc/models"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// GetBlockHeaderByNumberResp represents the response payload for the "eth_getBlockByNumber" JSON-RPC method
+// requested without transactions
+type GetBlockHeaderByNumberResp struct {
+	// BlockHeader contains information about the block without its transactions
+	BlockHeader BlockHeader
+}
+
+// BlockHeader represents the information about a block in the Ethereum blockchain without transactions
+type BlockHeader struct {
+	// Number is the block number
+	Number models.HexBigInt `json:"number"`
+
+	// Hash is the block hash
+	Hash string `json:"hash"`
+
+	// Timestamp is the unix timestamp in seconds when the block was collated
+	Timestamp models.HexUint64 `json:"timestamp"`
+}
+
+// GetBlockHeaderByNumber method retrieves a block from the Ethereum blockchain by its number without transactions,
+// it is much cheaper than GetBlockByNumber when only block fields like timestamp are required
+func (c *Client) GetBlockHeaderByNumber(ctx context.Context, blockNumber models.HexUint64) (*GetBlockHeaderByNumberResp, error) {
+	rawReqResp, err := c.sendJSONRPCRequest(ctx, getBlockByNumberRPCName, []interface{}{blockNumber, false},
+		attribute.Int64("block_number", int64(blockNumber)))
+	if err != nil {
+		return nil, err
+	}
+
+	var getBlockHeaderByNumberResp GetBlockHeaderByNumberResp
+	err = json.Unmarshal(rawReqResp, &getBlockHeaderByNumberResp.BlockHeader)
+	if err != nil {
+		return nil, err
+	}
+
+	return &getBlockHeaderByNumberResp, nil
+}
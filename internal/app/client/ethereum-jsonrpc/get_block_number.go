@@ -1,8 +1,10 @@
 package ethereum_jsonrpc
 
 import (
+	"context"
 	"encoding/json"
 	"github.com/bluntenpassant/ethereum_subscriber/internal/app/client/ethereum-jsonrpc/models"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/metrics"
 )
 
 // getBlockNumberRPCName is a constant that stores the name of the JSON-RPC method "eth_blockNumber".
@@ -16,9 +18,9 @@ type GetBlockNumberResp struct {
 
 // GetBlockNumber makes a JSON-RPC call to the Ethereum node to retrieve the latest block number on the blockchain.
 // It returns the block number in hexadecimal representation and an error if the call fails.
-func (c *Client) GetBlockNumber() (*GetBlockNumberResp, error) {
+func (c *Client) GetBlockNumber(ctx context.Context) (*GetBlockNumberResp, error) {
 	// Send a JSON-RPC request to the Ethereum node with method name "eth_blockNumber" and no parameters.
-	rawReqResp, err := c.sendJSONRPCRequest(getBlockNumberRPCName, []interface{}{})
+	rawReqResp, err := c.sendJSONRPCRequest(ctx, getBlockNumberRPCName, []interface{}{})
 	if err != nil {
 		// If the JSON-RPC request fails, return the error.
 		return nil, err
@@ -31,6 +33,9 @@ func (c *Client) GetBlockNumber() (*GetBlockNumberResp, error) {
 		return nil, err
 	}
 
+	// Remember the head block for indexing lag metric.
+	metrics.SetChainHead(c.chainID, uint64(getTxClientResp.BlockNumber))
+
 	// Return the GetBlockNumberResp struct and a nil error.
 	return &getTxClientResp, nil
 }
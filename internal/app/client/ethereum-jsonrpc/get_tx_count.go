@@ -1,9 +1,11 @@
 package ethereum_jsonrpc
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"github.com/bluntenpassant/ethereum_subscriber/internal/app/client/ethereum-jsonrpc/models"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 // getTxCountRPCName is the name of the JSON-RPC method for getting the transaction count of a specific address.
@@ -36,7 +38,7 @@ type GetTxCountResp struct {
 // It takes a GetTxCountReq as input and returns a GetTxCountResp and error.
 // If the address field in the request is empty, it returns an error.
 // If the request is successful, it returns the nonce of the account specified in the request.
-func (c *Client) GetTxCount(req *GetTxCountReq) (*GetTxCountResp, error) {
+func (c *Client) GetTxCount(ctx context.Context, req *GetTxCountReq) (*GetTxCountResp, error) {
 	// Validate the input request
 	err := req.Validate()
 	if err != nil {
@@ -44,7 +46,8 @@ func (c *Client) GetTxCount(req *GetTxCountReq) (*GetTxCountResp, error) {
 	}
 
 	// Send the JSON-RPC request
-	rawReqResp, err := c.sendJSONRPCRequest(getTxCountRPCName, []interface{}{req.Address, req.EndBlock})
+	rawReqResp, err := c.sendJSONRPCRequest(ctx, getTxCountRPCName, []interface{}{req.Address, req.EndBlock},
+		attribute.Int64("block_number", int64(req.EndBlock)))
 	if err != nil {
 		return nil, err
 	}
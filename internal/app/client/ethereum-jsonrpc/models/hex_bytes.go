@@ -0,0 +1,49 @@
+package models
+
+import (
+	"encoding/hex"
+	"errors"
+)
+
+// HexBytes represent type Data in Ethereum network - arbitrary length byte array
+// encoded as a hexadecimal string with 0x prefix, like contract call input or output
+type HexBytes []byte
+
+// UnmarshalJSON converts a JSON encoded string representation of hexadecimal data
+// into a byte slice and stores it in the `HexBytes` type.
+func (h *HexBytes) UnmarshalJSON(b []byte) error {
+	// Convert the incoming byte slice to a string
+	str := string(b)
+
+	// Remove the quotes from the string, if present
+	if len(str) >= 2 && str[0] == '"' && str[len(str)-1] == '"' {
+		str = str[1 : len(str)-1]
+	}
+
+	// Data should always have 0x prefix, even if it is empty
+	if len(str) < 2 || str[:2] != "0x" {
+		return errors.New("cannot convert hex value to bytes: " + str)
+	}
+
+	// Try to decode the hexadecimal string
+	data, err := hex.DecodeString(str[2:])
+	if err != nil {
+		// Return the error if the string could not be decoded
+		return err
+	}
+
+	// Assign the decoded bytes to the HexBytes type
+	*h = data
+
+	// Return nil if there were no errors
+	return nil
+}
+
+// MarshalJSON converts a `HexBytes` type into its JSON encoded string representation as hexadecimal data.
+func (h HexBytes) MarshalJSON() ([]byte, error) {
+	// Format the bytes as a hexadecimal string with 0x prefix
+	hexString := "\"0x" + hex.EncodeToString(h) + "\""
+
+	// Return the hexadecimal string as a byte slice
+	return []byte(hexString), nil
+}
@@ -0,0 +1,174 @@
+package subscriber_api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/models"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Client represents a client of running ethereum_subscriber-api server.
+// It implements the same methods as parser services, so CLI can work with subscriptions of the server
+type Client struct {
+	// baseURL is the address of ethereum_subscriber-api server, like http://localhost:8080
+	baseURL string
+
+	// chain is a name or ID of the chain requests are sent for, empty means the default chain of the server
+	chain string
+
+	// apiKey is sent as bearer token if server requires authentication, empty means no authentication
+	apiKey string
+
+	httpClient *http.Client
+}
+
+// NewClient creates a new client of ethereum_subscriber-api server located at baseURL working with the chain
+// selected by name or chain ID, empty chain means the default chain of the server.
+// API key is required if server has enabled authentication, subscriptions are shared with other clients of the same tenant.
+// Timeout limits every request, 0 means no limit
+func NewClient(baseURL string, chain string, apiKey string, timeout time.Duration) *Client {
+	return &Client{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		chain:      chain,
+		apiKey:     apiKey,
+		httpClient: &http.Client{Timeout: timeout},
+	}
+}
+
+// v2PathPrefix is a prefix of routes of API v2, their errors are written in JSON envelope with stable code
+const v2PathPrefix = "/v2/"
+
+// codeErrors are domain errors by stable codes of API v2 errors, so errors of the server are matched by errors.Is
+// the same way as errors of parser services
+var codeErrors = map[string]error{
+	"not_subscribed":              models.ErrNotSubscribed,
+	"already_subscribed":          models.ErrAlreadySubscribed,
+	"subscriptions_limit_reached": models.ErrQuotaExceeded,
+	"not_ready":                   models.ErrCurrentBlockNotParsed,
+	"upstream_error":              models.ErrUpstreamRPC,
+}
+
+// statusErrors are domain errors by statuses of API v1 errors, API v1 is used only by balance routes,
+// they have no counterparts in API v2
+var statusErrors = map[int]error{
+	http.StatusNotFound:           models.ErrNotSubscribed,
+	http.StatusBadGateway:         models.ErrUpstreamRPC,
+	http.StatusServiceUnavailable: models.ErrBalanceNotRecorded,
+}
+
+// errorResp is an error envelope of API v2
+type errorResp struct {
+	Error struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// responseError is an error responded by the server, its message is kept as is and domain error is kept in the chain
+type responseError struct {
+	message string
+	cause   error
+}
+
+func (e *responseError) Error() string {
+	return e.message
+}
+
+func (e *responseError) Unwrap() error {
+	return e.cause
+}
+
+// get sends GET request to path of the server and decodes JSON response into resp
+func (c *Client) get(ctx context.Context, path string, resp interface{}) error {
+	return c.do(ctx, http.MethodGet, path, nil, resp)
+}
+
+// post sends POST request with JSON body to path of the server and decodes JSON response into resp
+func (c *Client) post(ctx context.Context, path string, body interface{}, resp interface{}) error {
+	rawBody, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	return c.do(ctx, http.MethodPost, path, rawBody, resp)
+}
+
+// do sends request to path of the server and decodes JSON response into resp.
+// Server responds with error and non 2xx status on failure, it is returned as error matching domain error of its code.
+// Trace context of ctx is propagated to the server
+func (c *Client) do(ctx context.Context, method string, path string, body []byte, resp interface{}) error {
+	httpReq, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	if body != nil {
+		httpReq.Header.Set("Content-Type", "application/json")
+	}
+
+	if c.chain != "" {
+		query := httpReq.URL.Query()
+		query.Set("chain", c.chain)
+		httpReq.URL.RawQuery = query.Encode()
+	}
+
+	if c.apiKey != "" {
+		httpReq.Header.Set("Authorization", "Bearer "+c.apiKey)
+	}
+
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(httpReq.Header))
+
+	httpResp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return errors.New("request to ethereum_subscriber-api failed cause: " + err.Error())
+	}
+	defer httpResp.Body.Close()
+
+	respBody, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return err
+	}
+
+	if httpResp.StatusCode < http.StatusOK || httpResp.StatusCode >= http.StatusMultipleChoices {
+		return newResponseError(path, httpResp.StatusCode, respBody)
+	}
+
+	return json.Unmarshal(respBody, resp)
+}
+
+// newResponseError returns error of response of the route. Errors of API v2 are decoded from JSON envelope,
+// errors of API v1 and errors of middlewares of API v1 routes are plain text
+func newResponseError(path string, status int, body []byte) error {
+	message := strings.TrimSpace(string(body))
+	cause := statusErrors[status]
+
+	if strings.HasPrefix(path, v2PathPrefix) {
+		var resp errorResp
+		if json.Unmarshal(body, &resp) == nil && resp.Error.Code != "" {
+			message = resp.Error.Message
+			cause = codeErrors[resp.Error.Code]
+		} else {
+			cause = nil
+		}
+	}
+
+	if message == "" {
+		message = "ethereum_subscriber-api responded with status " + strconv.Itoa(status)
+	}
+
+	return &responseError{message: message, cause: cause}
+}
+
+// escapePath escapes address or ENS name to be used as a path segment
+func escapePath(segment string) string {
+	return url.PathEscape(segment)
+}
@@ -0,0 +1,208 @@
+package subscriber_api
+
+import (
+	"context"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/models"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+const testAddress = models.Address("0x45849a974058661eb2128aceb60d2c6ed99e2a14")
+
+func TestClient_Subscribe(t *testing.T) {
+	type TestCase struct {
+		Name          string
+		ENSName       string
+		Status        int
+		Body          string
+		ExpectedBody  string
+		ExpectedError string
+		ExpectedIs    error
+	}
+
+	testCases := []TestCase{
+		{
+			Name:         "address",
+			Status:       http.StatusCreated,
+			Body:         `{"address":"0x45849a974058661Eb2128ACeB60d2C6eD99E2A14","chain":"ethereum","chain_id":1}`,
+			ExpectedBody: `{"address":"0x45849a974058661eb2128aceb60d2c6ed99e2a14"}`,
+		},
+		{
+			Name:         "ens name",
+			ENSName:      "vitalik.eth",
+			Status:       http.StatusCreated,
+			Body:         `{"address":"0x45849a974058661Eb2128ACeB60d2C6eD99E2A14","ens_name":"vitalik.eth","chain":"ethereum","chain_id":1}`,
+			ExpectedBody: `{"address":"vitalik.eth"}`,
+		},
+		{
+			Name:          "already subscribed",
+			Status:        http.StatusConflict,
+			Body:          `{"error":{"code":"already_subscribed","message":"address is already subscribed"}}`,
+			ExpectedBody:  `{"address":"0x45849a974058661eb2128aceb60d2c6ed99e2a14"}`,
+			ExpectedError: "address is already subscribed",
+			ExpectedIs:    models.ErrAlreadySubscribed,
+		},
+		{
+			Name:          "quota is reached",
+			Status:        http.StatusForbidden,
+			Body:          `{"error":{"code":"subscriptions_limit_reached","message":"maximum count of subscriptions 2 is reached"}}`,
+			ExpectedBody:  `{"address":"0x45849a974058661eb2128aceb60d2c6ed99e2a14"}`,
+			ExpectedError: "maximum count of subscriptions 2 is reached",
+			ExpectedIs:    models.ErrQuotaExceeded,
+		},
+		{
+			Name:          "plain text error",
+			Status:        http.StatusInternalServerError,
+			Body:          "internal error\n",
+			ExpectedBody:  `{"address":"0x45849a974058661eb2128aceb60d2c6ed99e2a14"}`,
+			ExpectedError: "internal error",
+		},
+		{
+			Name:          "empty error",
+			Status:        http.StatusBadGateway,
+			ExpectedBody:  `{"address":"0x45849a974058661eb2128aceb60d2c6ed99e2a14"}`,
+			ExpectedError: "ethereum_subscriber-api responded with status 502",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			var request string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				request = r.Method + " " + r.URL.Path + " " + string(body)
+				w.WriteHeader(testCase.Status)
+				w.Write([]byte(testCase.Body))
+			}))
+			defer server.Close()
+
+			err := NewClient(server.URL+"/", "", "", time.Second).Subscribe(context.TODO(), testAddress, testCase.ENSName)
+
+			assert.Equal(t, "POST /v2/subscriptions "+testCase.ExpectedBody, request)
+			if testCase.ExpectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, testCase.ExpectedError)
+			}
+
+			if testCase.ExpectedIs != nil {
+				assert.ErrorIs(t, err, testCase.ExpectedIs)
+			}
+		})
+	}
+}
+
+func TestClient_SubscribeENSName(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"address":"0x45849a974058661Eb2128ACeB60d2C6eD99E2A14","ens_name":"vitalik.eth","chain":"ethereum","chain_id":1}`))
+	}))
+	defer server.Close()
+
+	address, err := NewClient(server.URL, "", "", time.Second).SubscribeENSName(context.TODO(), "vitalik.eth")
+
+	assert.NoError(t, err)
+	assert.Equal(t, testAddress, address)
+}
+
+func TestClient_GetTransactions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v2/subscriptions/0x45849a974058661eb2128aceb60d2c6ed99e2a14/transactions", r.URL.Path)
+		assert.Equal(t, "raw", r.URL.Query().Get("amounts"))
+		w.Write([]byte(`{"transactions":[{"blockNumber":16614490,"hash":"0x7c5d","value":1000000000000000000}]}`))
+	}))
+	defer server.Close()
+
+	transactions, err := NewClient(server.URL, "", "", time.Second).GetTransactions(context.TODO(), testAddress)
+
+	assert.NoError(t, err)
+	if assert.Len(t, transactions, 1) {
+		assert.Equal(t, uint64(16614490), transactions[0].BlockNumber)
+		assert.Equal(t, "1000000000000000000", transactions[0].Value.String())
+	}
+}
+
+func TestClient_Chain(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v2/blocks/current", r.URL.Path)
+		assert.Equal(t, "arbitrum", r.URL.Query().Get("chain"))
+		w.Write([]byte(`{"current_block":16614490}`))
+	}))
+	defer server.Close()
+
+	currentBlock, err := NewClient(server.URL, "arbitrum", "", time.Second).GetCurrentBlock(context.TODO())
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(16614490), currentBlock)
+}
+
+func TestClient_APIKey(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer ethsub_key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte("api key is not provided"))
+			return
+		}
+		w.Write([]byte(`{"current_block":16614490}`))
+	}))
+	defer server.Close()
+
+	currentBlock, err := NewClient(server.URL, "", "ethsub_key", time.Second).GetCurrentBlock(context.TODO())
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(16614490), currentBlock)
+
+	_, err = NewClient(server.URL, "", "", time.Second).GetCurrentBlock(context.TODO())
+	assert.EqualError(t, err, "api key is not provided")
+}
+
+func TestClient_Errors(t *testing.T) {
+	type TestCase struct {
+		Name       string
+		Status     int
+		Body       string
+		Call       func(client *Client) error
+		ExpectedIs error
+	}
+
+	getTransactions := func(client *Client) error {
+		_, err := client.GetTransactions(context.TODO(), testAddress)
+		return err
+	}
+
+	getBalance := func(client *Client) error {
+		_, err := client.GetBalance(context.TODO(), testAddress)
+		return err
+	}
+
+	testCases := []TestCase{
+		{Name: "not subscribed", Status: http.StatusNotFound, Call: getTransactions,
+			Body: `{"error":{"code":"not_subscribed","message":"address is not subscribed"}}`, ExpectedIs: models.ErrNotSubscribed},
+		{Name: "upstream error", Status: http.StatusBadGateway, Call: getTransactions,
+			Body: `{"error":{"code":"upstream_error","message":"request failed"}}`, ExpectedIs: models.ErrUpstreamRPC},
+		{Name: "current block is not parsed", Status: http.StatusServiceUnavailable, Call: func(client *Client) error {
+			_, err := client.GetCurrentBlock(context.TODO())
+			return err
+		}, Body: `{"error":{"code":"not_ready","message":"current block is not parsed yet"}}`, ExpectedIs: models.ErrCurrentBlockNotParsed},
+		{Name: "balance of not subscribed", Status: http.StatusNotFound, Call: getBalance,
+			Body: "address is not subscribed\n", ExpectedIs: models.ErrNotSubscribed},
+		{Name: "balance is not recorded", Status: http.StatusServiceUnavailable, Call: getBalance,
+			Body: "balance of address is not recorded yet\n", ExpectedIs: models.ErrBalanceNotRecorded},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(testCase.Status)
+				w.Write([]byte(testCase.Body))
+			}))
+			defer server.Close()
+
+			err := testCase.Call(NewClient(server.URL, "", "", time.Second))
+			assert.ErrorIs(t, err, testCase.ExpectedIs)
+		})
+	}
+}
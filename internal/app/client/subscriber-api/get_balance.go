@@ -0,0 +1,62 @@
+package subscriber_api
+
+import (
+	"context"
+	"errors"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/models"
+	"math/big"
+)
+
+// balanceResp is a balance in responses of /get_balance/{address} and /get_balance_history/{address} methods
+type balanceResp struct {
+	BlockNumber uint64 `json:"blockNumber"`
+	Balance     string `json:"balance"`
+}
+
+// getBalanceHistoryResp is a response of /get_balance_history/{address} method
+type getBalanceHistoryResp struct {
+	History []balanceResp `json:"history"`
+}
+
+// GetBalance returns the last recorded balance of subscribed address, server should use indexed approach
+func (c *Client) GetBalance(ctx context.Context, address models.Address) (models.Balance, error) {
+	var resp balanceResp
+
+	err := c.get(ctx, "/get_balance/"+escapePath(address.String()), &resp)
+	if err != nil {
+		return models.Balance{}, err
+	}
+
+	return resp.toModel()
+}
+
+// GetBalanceHistory returns balances of subscribed address since subscription, the first recorded balance goes first
+func (c *Client) GetBalanceHistory(ctx context.Context, address models.Address) ([]models.Balance, error) {
+	var resp getBalanceHistoryResp
+
+	err := c.get(ctx, "/get_balance_history/"+escapePath(address.String()), &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	history := make([]models.Balance, 0, len(resp.History))
+	for _, balance := range resp.History {
+		balanceModel, err := balance.toModel()
+		if err != nil {
+			return nil, err
+		}
+
+		history = append(history, balanceModel)
+	}
+
+	return history, nil
+}
+
+func (r balanceResp) toModel() (models.Balance, error) {
+	balance, ok := new(big.Int).SetString(r.Balance, 10)
+	if !ok {
+		return models.Balance{}, errors.New("invalid balance " + r.Balance + " in response of ethereum_subscriber-api")
+	}
+
+	return models.Balance{BlockNumber: r.BlockNumber, Balance: *balance}, nil
+}
@@ -0,0 +1,20 @@
+package subscriber_api
+
+import "context"
+
+// getCurrentBlockResp is a response of /v2/blocks/current method
+type getCurrentBlockResp struct {
+	CurrentBlock uint64 `json:"current_block"`
+}
+
+// GetCurrentBlock returns last block parsed by the server
+func (c *Client) GetCurrentBlock(ctx context.Context) (uint64, error) {
+	var resp getCurrentBlockResp
+
+	err := c.get(ctx, "/v2/blocks/current", &resp)
+	if err != nil {
+		return 0, err
+	}
+
+	return resp.CurrentBlock, nil
+}
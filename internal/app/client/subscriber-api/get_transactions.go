@@ -0,0 +1,23 @@
+package subscriber_api
+
+import (
+	"context"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/models"
+)
+
+// getTransactionsResp is a response of /v2/subscriptions/{address}/transactions method in raw amounts mode
+type getTransactionsResp struct {
+	Transactions []*models.Transaction `json:"transactions"`
+}
+
+// GetTransactions returns transactions of subscribed address since subscription, last transaction goes first
+func (c *Client) GetTransactions(ctx context.Context, address models.Address) ([]*models.Transaction, error) {
+	var resp getTransactionsResp
+
+	err := c.get(ctx, "/v2/subscriptions/"+escapePath(address.String())+"/transactions?amounts=raw", &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Transactions, nil
+}
@@ -0,0 +1,47 @@
+package subscriber_api
+
+import (
+	"context"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/models"
+)
+
+// createSubscriptionReq is a request of POST /v2/subscriptions method
+type createSubscriptionReq struct {
+	Address string `json:"address"`
+}
+
+// subscriptionResp is a response of POST /v2/subscriptions method
+type subscriptionResp struct {
+	Address string `json:"address"`
+}
+
+// Subscribe subscribes address on the server. If ensName is not empty, the name is sent instead of address,
+// so the server resolves it and keeps it with subscription for re-resolving
+func (c *Client) Subscribe(ctx context.Context, address models.Address, ensName string) error {
+	target := address.String()
+	if ensName != "" {
+		target = ensName
+	}
+
+	_, err := c.subscribe(ctx, target)
+
+	return err
+}
+
+// SubscribeENSName subscribes ENS name on the server, the name is resolved by the server, so CLI does not need
+// access to Ethereum node. Address the name is resolved into is returned
+func (c *Client) SubscribeENSName(ctx context.Context, ensName string) (models.Address, error) {
+	return c.subscribe(ctx, ensName)
+}
+
+// subscribe subscribes address or ENS name and returns subscribed address
+func (c *Client) subscribe(ctx context.Context, target string) (models.Address, error) {
+	var resp subscriptionResp
+
+	err := c.post(ctx, "/v2/subscriptions", createSubscriptionReq{Address: target}, &resp)
+	if err != nil {
+		return "", err
+	}
+
+	return models.ParseAddress(resp.Address)
+}
@@ -0,0 +1,192 @@
+package export
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/models"
+	"github.com/parquet-go/parquet-go"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Formats of export
+const (
+	CSVFormat     = "csv"
+	NDJSONFormat  = "ndjson"
+	ParquetFormat = "parquet"
+)
+
+// Formats are all supported formats of export, first one is default
+var Formats = []string{CSVFormat, NDJSONFormat, ParquetFormat}
+
+// parquetRowGroupSize limits rows buffered by Parquet writer, full row group is flushed into underlying writer,
+// so export is streamed instead of being kept in memory until Close
+const parquetRowGroupSize = 10000
+
+// Record is a row of exported transaction history. Amounts are kept as decimal strings,
+// so wei values bigger than 64 bits are not truncated. Value is also provided in ETH
+// and gas price in gwei for reading in spreadsheets
+type Record struct {
+	ChainID          uint64 `json:"chain_id" parquet:"chain_id"`
+	BlockNumber      uint64 `json:"block_number" parquet:"block_number"`
+	TransactionIndex uint64 `json:"transaction_index" parquet:"transaction_index"`
+	Hash             string `json:"hash" parquet:"hash"`
+	BlockHash        string `json:"block_hash" parquet:"block_hash"`
+	From             string `json:"from" parquet:"from"`
+	To               string `json:"to" parquet:"to"`
+	ValueWei         string `json:"value_wei" parquet:"value_wei"`
+	ValueETH         string `json:"value_eth" parquet:"value_eth"`
+	Gas              string `json:"gas" parquet:"gas"`
+	GasPriceWei      string `json:"gas_price_wei" parquet:"gas_price_wei"`
+	GasPriceGwei     string `json:"gas_price_gwei" parquet:"gas_price_gwei"`
+	Nonce            uint64 `json:"nonce" parquet:"nonce"`
+	Input            string `json:"input" parquet:"input"`
+}
+
+// csvHeader is a header of export in csv format, columns go in the same order as fields of Record
+var csvHeader = []string{
+	"chain_id", "block_number", "transaction_index", "hash", "block_hash", "from", "to", "value_wei", "value_eth",
+	"gas", "gas_price_wei", "gas_price_gwei", "nonce", "input",
+}
+
+// NewRecord converts transaction into exported record, addresses are EIP-55 checksum encoded
+func NewRecord(tx *models.Transaction) Record {
+	return Record{
+		ChainID:          tx.ChainID,
+		BlockNumber:      tx.BlockNumber,
+		TransactionIndex: tx.TransactionIndex,
+		Hash:             tx.Hash,
+		BlockHash:        tx.BlockHash,
+		From:             models.NewAddress(tx.From).Checksum(),
+		To:               models.NewAddress(tx.To).Checksum(),
+		ValueWei:         tx.Value.String(),
+		ValueETH:         models.FormatEther(&tx.Value),
+		Gas:              tx.Gas.String(),
+		GasPriceWei:      tx.GasPrice.String(),
+		GasPriceGwei:     models.FormatGwei(&tx.GasPrice),
+		Nonce:            tx.Nonce,
+		Input:            tx.Input,
+	}
+}
+
+// Writer writes records one by one into underlying writer, so export can be streamed.
+// Close must be called after last record, it flushes buffered rows and writes footer of Parquet file,
+// it does not close underlying writer
+type Writer interface {
+	Write(record Record) error
+	Close() error
+}
+
+// NewWriter returns Writer of given format writing into w
+func NewWriter(format string, w io.Writer) (Writer, error) {
+	switch format {
+	case CSVFormat:
+		return &csvWriter{writer: csv.NewWriter(w)}, nil
+	case NDJSONFormat:
+		return &ndjsonWriter{encoder: json.NewEncoder(w)}, nil
+	case ParquetFormat:
+		return &parquetWriter{writer: parquet.NewGenericWriter[Record](w, parquet.MaxRowsPerRowGroup(parquetRowGroupSize))}, nil
+	default:
+		return nil, errors.New("unknown format " + format + ", should be one of: " + strings.Join(Formats, ", "))
+	}
+}
+
+// ContentType returns MIME type of export in given format
+func ContentType(format string) string {
+	switch format {
+	case CSVFormat:
+		return "text/csv"
+	case NDJSONFormat:
+		return "application/x-ndjson"
+	default:
+		return "application/vnd.apache.parquet"
+	}
+}
+
+// WriteTransactions writes transactions in chronological order (first transaction goes first) into w and closes it
+func WriteTransactions(w Writer, transactions []*models.Transaction) error {
+	for i := len(transactions) - 1; i >= 0; i-- {
+		err := w.Write(NewRecord(transactions[i]))
+		if err != nil {
+			return err
+		}
+	}
+
+	return w.Close()
+}
+
+type csvWriter struct {
+	writer        *csv.Writer
+	headerWritten bool
+}
+
+func (w *csvWriter) Write(record Record) error {
+	if !w.headerWritten {
+		err := w.writer.Write(csvHeader)
+		if err != nil {
+			return err
+		}
+
+		w.headerWritten = true
+	}
+
+	return w.writer.Write([]string{
+		strconv.FormatUint(record.ChainID, 10),
+		strconv.FormatUint(record.BlockNumber, 10),
+		strconv.FormatUint(record.TransactionIndex, 10),
+		record.Hash,
+		record.BlockHash,
+		record.From,
+		record.To,
+		record.ValueWei,
+		record.ValueETH,
+		record.Gas,
+		record.GasPriceWei,
+		record.GasPriceGwei,
+		strconv.FormatUint(record.Nonce, 10),
+		record.Input,
+	})
+}
+
+func (w *csvWriter) Close() error {
+	// Header is written even if there are no records, so empty export is a valid csv file
+	if !w.headerWritten {
+		err := w.writer.Write(csvHeader)
+		if err != nil {
+			return err
+		}
+
+		w.headerWritten = true
+	}
+
+	w.writer.Flush()
+
+	return w.writer.Error()
+}
+
+type ndjsonWriter struct {
+	encoder *json.Encoder
+}
+
+func (w *ndjsonWriter) Write(record Record) error {
+	return w.encoder.Encode(record)
+}
+
+func (w *ndjsonWriter) Close() error {
+	return nil
+}
+
+type parquetWriter struct {
+	writer *parquet.GenericWriter[Record]
+}
+
+func (w *parquetWriter) Write(record Record) error {
+	_, err := w.writer.Write([]Record{record})
+	return err
+}
+
+func (w *parquetWriter) Close() error {
+	return w.writer.Close()
+}
@@ -0,0 +1,103 @@
+package export
+
+import (
+	"bytes"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/models"
+	"github.com/parquet-go/parquet-go"
+	"github.com/stretchr/testify/assert"
+	"math/big"
+	"testing"
+)
+
+var testTransactions = []*models.Transaction{
+	{
+		ChainID:          1,
+		BlockNumber:      16614491,
+		TransactionIndex: 0,
+		Hash:             "0x2b",
+		From:             "0x00000000219ab540356cbb839cbe05303d7705fa",
+		To:               "0x45849a974058661eb2128aceb60d2c6ed99e2a14",
+		Value:            *big.NewInt(250000000000000000),
+		Gas:              *big.NewInt(21000),
+		GasPrice:         *big.NewInt(30500000000),
+		Nonce:            1,
+	},
+	{
+		ChainID:          1,
+		BlockNumber:      16614490,
+		TransactionIndex: 3,
+		Hash:             "0x1a",
+		From:             "0x45849a974058661eb2128aceb60d2c6ed99e2a14",
+		To:               "0x00000000219ab540356cbb839cbe05303d7705fa",
+		Value:            *big.NewInt(1000000000000000000),
+		Gas:              *big.NewInt(21000),
+		GasPrice:         *big.NewInt(30000000000),
+		Nonce:            7,
+	},
+}
+
+func TestWriteTransactions(t *testing.T) {
+	type TestCase struct {
+		Name         string
+		Format       string
+		Transactions []*models.Transaction
+		Expected     string
+	}
+
+	testCases := []TestCase{
+		{
+			Name:         "csv",
+			Format:       CSVFormat,
+			Transactions: testTransactions,
+			Expected: "chain_id,block_number,transaction_index,hash,block_hash,from,to,value_wei,value_eth,gas,gas_price_wei,gas_price_gwei,nonce,input\n" +
+				"1,16614490,3,0x1a,,0x45849a974058661Eb2128ACeB60d2C6eD99E2A14,0x00000000219ab540356cBB839Cbe05303d7705Fa,1000000000000000000,1,21000,30000000000,30,7,\n" +
+				"1,16614491,0,0x2b,,0x00000000219ab540356cBB839Cbe05303d7705Fa,0x45849a974058661Eb2128ACeB60d2C6eD99E2A14,250000000000000000,0.25,21000,30500000000,30.5,1,\n",
+		},
+		{
+			Name:     "empty csv",
+			Format:   CSVFormat,
+			Expected: "chain_id,block_number,transaction_index,hash,block_hash,from,to,value_wei,value_eth,gas,gas_price_wei,gas_price_gwei,nonce,input\n",
+		},
+		{
+			Name:         "ndjson",
+			Format:       NDJSONFormat,
+			Transactions: testTransactions[1:],
+			Expected: `{"chain_id":1,"block_number":16614490,"transaction_index":3,"hash":"0x1a","block_hash":"",` +
+				`"from":"0x45849a974058661Eb2128ACeB60d2C6eD99E2A14","to":"0x00000000219ab540356cBB839Cbe05303d7705Fa",` +
+				`"value_wei":"1000000000000000000","value_eth":"1","gas":"21000","gas_price_wei":"30000000000","gas_price_gwei":"30","nonce":7,"input":""}` + "\n",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+
+			writer, err := NewWriter(testCase.Format, buf)
+			assert.NoError(t, err)
+
+			err = WriteTransactions(writer, testCase.Transactions)
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.Expected, buf.String())
+		})
+	}
+}
+
+func TestWriteTransactions_Parquet(t *testing.T) {
+	buf := &bytes.Buffer{}
+
+	writer, err := NewWriter(ParquetFormat, buf)
+	assert.NoError(t, err)
+
+	err = WriteTransactions(writer, testTransactions)
+	assert.NoError(t, err)
+
+	records, err := parquet.Read[Record](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	assert.NoError(t, err)
+	assert.Equal(t, []Record{NewRecord(testTransactions[1]), NewRecord(testTransactions[0])}, records)
+}
+
+func TestNewWriter_UnknownFormat(t *testing.T) {
+	_, err := NewWriter("xlsx", &bytes.Buffer{})
+
+	assert.EqualError(t, err, "unknown format xlsx, should be one of: csv, ndjson, parquet")
+}
@@ -0,0 +1,74 @@
+package graphql_handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"math/big"
+	"strconv"
+)
+
+// BigInt is a GraphQL scalar of unsigned integers that may not fit into Int, it is serialized as a decimal string,
+// so JavaScript clients do not lose precision of amounts in wei
+type BigInt struct {
+	big.Int
+}
+
+func newBigInt(x *big.Int) BigInt {
+	var b BigInt
+	b.Set(x)
+
+	return b
+}
+
+func newBigIntFromUint64(x uint64) BigInt {
+	var b BigInt
+	b.SetUint64(x)
+
+	return b
+}
+
+func (BigInt) ImplementsGraphQLType(name string) bool {
+	return name == "BigInt"
+}
+
+// UnmarshalGraphQL accepts decimal strings and integers, integers of variables are decoded from JSON as float64
+func (b *BigInt) UnmarshalGraphQL(input interface{}) error {
+	switch input := input.(type) {
+	case string:
+		if _, ok := b.SetString(input, 10); !ok {
+			return errors.New("BigInt should be a decimal string, got " + strconv.Quote(input))
+		}
+	case int32:
+		b.SetInt64(int64(input))
+	case int:
+		b.SetInt64(int64(input))
+	case float64:
+		if input != math.Trunc(input) {
+			return errors.New("BigInt should be an integer, got " + strconv.FormatFloat(input, 'f', -1, 64))
+		}
+
+		big.NewFloat(input).Int(&b.Int)
+	default:
+		return errors.New("BigInt should be a decimal string or an integer")
+	}
+
+	return nil
+}
+
+func (b BigInt) MarshalJSON() ([]byte, error) {
+	return json.Marshal(b.String())
+}
+
+// blockNumber returns BigInt as block number, nil BigInt is returned as def
+func blockNumber(b *BigInt, def uint64) (uint64, bool) {
+	if b == nil {
+		return def, true
+	}
+
+	if b.Sign() < 0 || !b.IsUint64() {
+		return 0, false
+	}
+
+	return b.Uint64(), true
+}
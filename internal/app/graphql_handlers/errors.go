@@ -0,0 +1,58 @@
+package graphql_handlers
+
+import (
+	"errors"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/models"
+)
+
+// Stable codes of errors returned in extensions of GraphQL errors, they are the same as codes of REST API v2
+const (
+	codeInvalidRequest = "invalid_request"
+	codeInvalidAddress = "invalid_address"
+	codeUnknownChain   = "unknown_chain"
+	codeNotSubscribed  = "not_subscribed"
+	codeNotReady       = "not_ready"
+	codeUpstreamError  = "upstream_error"
+	codeRateLimited    = "rate_limited"
+	codeInternal       = "internal"
+)
+
+// resolverError is an error of resolver with stable code, graphql-go puts Extensions into the error of response
+type resolverError struct {
+	code string
+	err  error
+}
+
+func newResolverError(code string, err error) *resolverError {
+	return &resolverError{code: code, err: err}
+}
+
+func (e *resolverError) Error() string {
+	return e.err.Error()
+}
+
+func (e *resolverError) Unwrap() error {
+	return e.err
+}
+
+func (e *resolverError) Extensions() map[string]interface{} {
+	return map[string]interface{}{"code": e.code}
+}
+
+// parserErr returns error returned by parser with its code, errors that are not domain ones are internal
+func parserErr(err error) error {
+	return newResolverError(parserErrCode(err), err)
+}
+
+func parserErrCode(err error) string {
+	switch {
+	case errors.Is(err, models.ErrNotSubscribed):
+		return codeNotSubscribed
+	case errors.Is(err, models.ErrCurrentBlockNotParsed), errors.Is(err, models.ErrBalanceNotRecorded):
+		return codeNotReady
+	case errors.Is(err, models.ErrUpstreamRPC):
+		return codeUpstreamError
+	default:
+		return codeInternal
+	}
+}
@@ -0,0 +1,131 @@
+package graphql_handlers
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"github.com/bluntenpassant/ethereum_subscriber/config"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/models"
+	"github.com/graph-gophers/graphql-go"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+//go:embed schema.graphql
+var schemaString string
+
+// maxRequestSize limits body of GraphQL request, queries are short, so it protects only from abuse
+const maxRequestSize = 1 << 20
+
+// defaultMaxDepth limits nesting of selections if graphql.max_depth is not set
+const defaultMaxDepth = 6
+
+// defaultMaxParallelism limits count of fields resolved in parallel if graphql.max_parallelism is not set
+const defaultMaxParallelism = 4
+
+type Parser interface {
+	GetCurrentBlock(ctx context.Context) (uint64, error)
+	GetTransactions(ctx context.Context, address models.Address) ([]*models.Transaction, error)
+	GetSubscriber(ctx context.Context, address models.Address) (models.Subscriber, error)
+	GetSubscribers(ctx context.Context) ([]models.Subscriber, error)
+}
+
+// BalanceParser is implemented by parsers that track balances of subscribers (indexed approach)
+type BalanceParser interface {
+	GetBalance(ctx context.Context, address models.Address) (models.Balance, error)
+	GetBalanceHistory(ctx context.Context, address models.Address) ([]models.Balance, error)
+}
+
+// RateLimiter charges clients for expensive fields of a query, like the HTTP API charges them for requests
+type RateLimiter interface {
+	Allow(client string, route string) (bool, time.Duration)
+}
+
+// ChainParser is a parser of a single chain, chain is selected in queries by chain argument
+type ChainParser struct {
+	Chain  models.Chain
+	Parser Parser
+}
+
+// Handler serves GraphQL queries over HTTP. It is mounted into HTTP API, so requests pass its middlewares:
+// tenant of API key is attached to request context, so parsers look up subscriptions of the tenant and
+// resolvers drop subscriptions of other tenants from lists returned by parsers
+type Handler struct {
+	schema *graphql.Schema
+	logger *slog.Logger
+}
+
+// NewHandler returns Handler resolving queries with parsers of all chains, the first parser is used when chain is not selected.
+// Every field repeating work of a REST route (transactions and balances of subscriber) takes a token of the route from rateLimiter
+func NewHandler(parsers []ChainParser, graphqlConfig config.GraphQL, rateLimiter RateLimiter, logger *slog.Logger) (*Handler, error) {
+	maxDepth := graphqlConfig.MaxDepth
+	if maxDepth == 0 {
+		maxDepth = defaultMaxDepth
+	}
+
+	maxParallelism := graphqlConfig.MaxParallelism
+	if maxParallelism == 0 {
+		maxParallelism = defaultMaxParallelism
+	}
+
+	opts := []graphql.SchemaOpt{graphql.MaxDepth(maxDepth), graphql.MaxParallelism(maxParallelism)}
+
+	schema, err := graphql.ParseSchema(schemaString, &resolver{parsers: parsers, rateLimiter: rateLimiter}, opts...)
+	if err != nil {
+		return nil, errors.New("parsing graphql schema failed cause: " + err.Error())
+	}
+
+	return &Handler{schema: schema, logger: logger}, nil
+}
+
+// request is a body of GraphQL request sent over HTTP
+type request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// ServeHTTP executes query from JSON body. Failures of resolvers are returned in errors of GraphQL response
+// with 200 status and stable code in extensions, only malformed requests get 400
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req request
+
+	err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestSize)).Decode(&req)
+	if err != nil {
+		h.sendErrResponse(w, errors.New("invalid request body cause: "+err.Error()))
+		return
+	}
+
+	if req.Query == "" {
+		h.sendErrResponse(w, errors.New("query is not provided"))
+		return
+	}
+
+	resp := h.schema.Exec(r.Context(), req.Query, req.OperationName, req.Variables)
+
+	respRaw, err := json.Marshal(resp)
+	if err != nil {
+		h.logger.ErrorContext(r.Context(), "marshaling graphql response failed", "error", err.Error())
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(respRaw)
+}
+
+// sendErrResponse writes error of malformed request in the shape of GraphQL response
+func (h *Handler) sendErrResponse(w http.ResponseWriter, err error) {
+	respRaw, _ := json.Marshal(map[string]interface{}{
+		"errors": []map[string]interface{}{
+			{"message": err.Error(), "extensions": map[string]string{"code": codeInvalidRequest}},
+		},
+	})
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusBadRequest)
+	w.Write(respRaw)
+}
@@ -0,0 +1,358 @@
+package graphql_handlers
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/bluntenpassant/ethereum_subscriber/config"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/models"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/service/rate_limiter"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/tenant"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"log/slog"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const (
+	subscriberAddress = models.Address("0x45849a974058661eb2128aceb60d2c6ed99e2a14")
+	otherAddress      = models.Address("0x00000000219ab540356cbb839cbe05303d7705fa")
+)
+
+type mockParser struct {
+	currentBlock uint64
+	subscribers  []models.Subscriber
+	transactions []*models.Transaction
+}
+
+func (m *mockParser) GetCurrentBlock(ctx context.Context) (uint64, error) {
+	return m.currentBlock, nil
+}
+
+func (m *mockParser) GetTransactions(ctx context.Context, address models.Address) ([]*models.Transaction, error) {
+	if _, err := m.GetSubscriber(ctx, address); err != nil {
+		return nil, err
+	}
+
+	return m.transactions, nil
+}
+
+func (m *mockParser) GetSubscriber(ctx context.Context, address models.Address) (models.Subscriber, error) {
+	for _, subscriber := range m.subscribers {
+		if subscriber.Key() == tenant.Key(ctx, address) {
+			return subscriber, nil
+		}
+	}
+
+	return models.Subscriber{}, models.ErrNotSubscribed
+}
+
+func (m *mockParser) GetSubscribers(ctx context.Context) ([]models.Subscriber, error) {
+	return m.subscribers, nil
+}
+
+// mockBalanceParser is a parser of indexed approach that tracks balances
+type mockBalanceParser struct {
+	mockParser
+	history []models.Balance
+}
+
+func (m *mockBalanceParser) GetBalance(ctx context.Context, address models.Address) (models.Balance, error) {
+	if len(m.history) == 0 {
+		return models.Balance{}, models.ErrBalanceNotRecorded
+	}
+
+	return m.history[len(m.history)-1], nil
+}
+
+func (m *mockBalanceParser) GetBalanceHistory(ctx context.Context, address models.Address) ([]models.Balance, error) {
+	return m.history, nil
+}
+
+func newTestHandler(t *testing.T) *Handler {
+	transactions := []*models.Transaction{
+		{ChainID: 1, BlockNumber: 300, Hash: "0x03", From: subscriberAddress.String(), To: subscriberAddress.String()},
+		{ChainID: 1, BlockNumber: 200, Hash: "0x02", From: subscriberAddress.String(), To: otherAddress.String(),
+			Value: *big.NewInt(1500000000000000000), GasPrice: *big.NewInt(30000000000)},
+		{ChainID: 1, BlockNumber: 100, Hash: "0x01", From: otherAddress.String(), To: subscriberAddress.String(),
+			Value: *big.NewInt(1)},
+	}
+
+	ethereumParser := &mockBalanceParser{
+		mockParser: mockParser{
+			currentBlock: 300,
+			subscribers:  []models.Subscriber{{ChainID: 1, Address: subscriberAddress, ENSName: "vitalik.eth"}},
+			transactions: transactions,
+		},
+		history: []models.Balance{{BlockNumber: 100, Balance: *big.NewInt(1)}, {BlockNumber: 200, Balance: *big.NewInt(2)}},
+	}
+
+	arbitrumParser := &mockParser{subscribers: []models.Subscriber{{ChainID: 42161, Address: otherAddress}}}
+
+	handler, err := NewHandler([]ChainParser{
+		{Chain: models.Chain{ID: 1, Name: "ethereum", NativeCurrency: "ETH"}, Parser: ethereumParser},
+		{Chain: models.Chain{ID: 42161, Name: "arbitrum", NativeCurrency: "ETH"}, Parser: arbitrumParser},
+	}, config.GraphQL{Enabled: true, MaxDepth: 6}, rate_limiter.NewLimiter(config.RateLimit{}), slog.New(slog.NewTextHandler(io.Discard, nil)))
+	assert.NoError(t, err)
+
+	return handler
+}
+
+func TestHandler_ServeHTTP(t *testing.T) {
+	type TestCase struct {
+		Name           string
+		Body           string
+		ExpectedStatus int
+		ExpectedBody   string
+	}
+
+	testCases := []TestCase{
+		{
+			Name:           "chains and current block",
+			Body:           `{"query":"{ chains { id name } currentBlock arbitrum: currentBlock(chain: \"42161\") }"}`,
+			ExpectedStatus: http.StatusOK,
+			ExpectedBody: `{"errors":[{"message":"current block is not parsed yet","path":["arbitrum"],"extensions":{"code":"not_ready"}}],` +
+				`"data":null}`,
+		},
+		{
+			Name:           "subscribers with balances on all chains",
+			Body:           `{"query":"{ subscribers { address ensName balance { blockNumber balanceEth } } arbitrum: subscribers(chain: \"arbitrum\") { address ensName balanceHistory { balance } } }"}`,
+			ExpectedStatus: http.StatusOK,
+			ExpectedBody: `{"data":{"subscribers":[{"address":"0x45849a974058661Eb2128ACeB60d2C6eD99E2A14","ensName":"vitalik.eth",` +
+				`"balance":{"blockNumber":"200","balanceEth":"0.000000000000000002"}}],` +
+				`"arbitrum":[{"address":"0x00000000219ab540356cBB839Cbe05303d7705Fa","ensName":null,"balanceHistory":null}]}}`,
+		},
+		{
+			Name:           "transactions with selected fields",
+			Body:           `{"query":"{ subscriber(address: \"0x45849a974058661eb2128aceb60d2c6ed99e2a14\") { transactions { hash direction value valueEth } } }"}`,
+			ExpectedStatus: http.StatusOK,
+			ExpectedBody: `{"data":{"subscriber":{"transactions":[` +
+				`{"hash":"0x03","direction":"SELF","value":"0","valueEth":"0"},` +
+				`{"hash":"0x02","direction":"OUTGOING","value":"1500000000000000000","valueEth":"1.5"},` +
+				`{"hash":"0x01","direction":"INCOMING","value":"1","valueEth":"0.000000000000000001"}]}}}`,
+		},
+		{
+			Name: "transactions filtered by block range and direction",
+			Body: `{"query":"query($from: BigInt) { subscriber(address: \"0x45849a974058661eb2128aceb60d2c6ed99e2a14\") ` +
+				`{ transactions(fromBlock: $from, toBlock: \"250\", direction: OUTGOING) { hash blockNumber to gasPriceGwei } } }","variables":{"from":150}}`,
+			ExpectedStatus: http.StatusOK,
+			ExpectedBody: `{"data":{"subscriber":{"transactions":[` +
+				`{"hash":"0x02","blockNumber":"200","to":"0x00000000219ab540356cBB839Cbe05303d7705Fa","gasPriceGwei":"30"}]}}}`,
+		},
+		{
+			Name:           "transactions filtered by counterparty with limit",
+			Body:           `{"query":"{ subscriber(address: \"0x45849a974058661eb2128aceb60d2c6ed99e2a14\") { transactions(counterparty: \"0x00000000219ab540356cbb839cbe05303d7705fa\", first: 1) { hash } } }"}`,
+			ExpectedStatus: http.StatusOK,
+			ExpectedBody:   `{"data":{"subscriber":{"transactions":[{"hash":"0x02"}]}}}`,
+		},
+		{
+			Name:           "not subscribed address",
+			Body:           `{"query":"{ subscriber(address: \"0x00000000219ab540356cbb839cbe05303d7705fa\") { address } }"}`,
+			ExpectedStatus: http.StatusOK,
+			ExpectedBody:   `{"data":{"subscriber":null}}`,
+		},
+		{
+			Name:           "invalid address",
+			Body:           `{"query":"{ subscriber(address: \"0x1234\") { address } }"}`,
+			ExpectedStatus: http.StatusOK,
+			ExpectedBody: `{"errors":[{"message":"address length should be 42","path":["subscriber"],"extensions":{"code":"invalid_address"}}],` +
+				`"data":{"subscriber":null}}`,
+		},
+		{
+			Name:           "unknown chain",
+			Body:           `{"query":"{ subscribers(chain: \"base\") { address } }"}`,
+			ExpectedStatus: http.StatusOK,
+			ExpectedBody:   `{"errors":[{"message":"unknown chain base","path":["subscribers"],"extensions":{"code":"unknown_chain"}}],"data":null}`,
+		},
+		{
+			Name:           "malformed body",
+			Body:           `{"query":`,
+			ExpectedStatus: http.StatusBadRequest,
+			ExpectedBody:   `{"errors":[{"extensions":{"code":"invalid_request"},"message":"invalid request body cause: unexpected EOF"}]}`,
+		},
+	}
+
+	handler := newTestHandler(t)
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(testCase.Body)))
+
+			assert.Equal(t, testCase.ExpectedStatus, recorder.Code)
+			assert.JSONEq(t, testCase.ExpectedBody, recorder.Body.String())
+		})
+	}
+}
+
+func TestHandler_MaxDepth(t *testing.T) {
+	type TestCase struct {
+		Name     string
+		MaxDepth int
+		Body     string
+	}
+
+	testCases := []TestCase{
+		// Query has depth 3: subscribers, transactions and their fields
+		{Name: "configured", MaxDepth: 2, Body: `{"query":"{ subscribers { transactions { hash } } }"}`},
+		// Query has depth 7, it exceeds the default limit
+		{Name: "default", Body: `{"query":"{ __schema { types { fields { type { ofType { ofType { name } } } } } } }"}`},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			handler, err := NewHandler(nil, config.GraphQL{MaxDepth: testCase.MaxDepth}, rate_limiter.NewLimiter(config.RateLimit{}),
+				slog.New(slog.NewTextHandler(io.Discard, nil)))
+			assert.NoError(t, err)
+
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(testCase.Body)))
+
+			var resp struct {
+				Errors []struct {
+					Message string `json:"message"`
+				} `json:"errors"`
+				Data json.RawMessage `json:"data"`
+			}
+			assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &resp))
+			assert.Len(t, resp.Errors, 1)
+			assert.Empty(t, resp.Data)
+		})
+	}
+}
+
+func TestHandler_Tenants(t *testing.T) {
+	// Both tenants subscribed to the same address, parser returns subscriptions of all tenants
+	parser := &mockParser{
+		subscribers: []models.Subscriber{
+			{TenantID: "tenant_a", ChainID: 1, Address: subscriberAddress, ENSName: "vitalik.eth"},
+			{TenantID: "tenant_b", ChainID: 1, Address: subscriberAddress},
+			{TenantID: "tenant_b", ChainID: 1, Address: otherAddress},
+		},
+		transactions: []*models.Transaction{{ChainID: 1, BlockNumber: 100, Hash: "0x01", From: otherAddress.String(), To: subscriberAddress.String()}},
+	}
+
+	handler, err := NewHandler([]ChainParser{{Chain: models.Chain{ID: 1, Name: "ethereum", NativeCurrency: "ETH"}, Parser: parser}},
+		config.GraphQL{Enabled: true, MaxDepth: 6}, rate_limiter.NewLimiter(config.RateLimit{}), slog.New(slog.NewTextHandler(io.Discard, nil)))
+	assert.NoError(t, err)
+
+	type TestCase struct {
+		Name         string
+		TenantID     string
+		ExpectedBody string
+	}
+
+	testCases := []TestCase{
+		{Name: "tenant a", TenantID: "tenant_a",
+			ExpectedBody: `{"data":{"subscribers":[{"address":"0x45849a974058661Eb2128ACeB60d2C6eD99E2A14","ensName":"vitalik.eth","transactions":[{"hash":"0x01"}]}]}}`},
+		{Name: "tenant b", TenantID: "tenant_b",
+			ExpectedBody: `{"data":{"subscribers":[{"address":"0x00000000219ab540356cBB839Cbe05303d7705Fa","ensName":null,"transactions":[{"hash":"0x01"}]},` +
+				`{"address":"0x45849a974058661Eb2128ACeB60d2C6eD99E2A14","ensName":null,"transactions":[{"hash":"0x01"}]}]}}`},
+		{Name: "tenant without subscriptions", TenantID: "tenant_c", ExpectedBody: `{"data":{"subscribers":[]}}`},
+	}
+
+	body := `{"query":"{ subscribers { address ensName transactions { hash } } }"}`
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(body))
+			req = req.WithContext(tenant.WithID(req.Context(), testCase.TenantID))
+
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, req)
+
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			assert.JSONEq(t, testCase.ExpectedBody, recorder.Body.String())
+		})
+	}
+}
+
+func TestHandler_SubscribersPages(t *testing.T) {
+	parser := &mockParser{
+		subscribers: []models.Subscriber{
+			{TenantID: "tenant_a", ChainID: 1, Address: subscriberAddress},
+			{TenantID: "tenant_b", ChainID: 1, Address: "0x1111111111111111111111111111111111111111"},
+			{TenantID: "tenant_a", ChainID: 1, Address: otherAddress},
+			{TenantID: "tenant_a", ChainID: 1, Address: "0x2222222222222222222222222222222222222222"},
+		},
+	}
+
+	handler, err := NewHandler([]ChainParser{{Chain: models.Chain{ID: 1, Name: "ethereum", NativeCurrency: "ETH"}, Parser: parser}},
+		config.GraphQL{Enabled: true}, rate_limiter.NewLimiter(config.RateLimit{}), slog.New(slog.NewTextHandler(io.Discard, nil)))
+	assert.NoError(t, err)
+
+	type TestCase struct {
+		Name         string
+		Query        string
+		ExpectedBody string
+	}
+
+	testCases := []TestCase{
+		{Name: "first page", Query: `{ subscribers(first: 2) { address } }`,
+			ExpectedBody: `{"data":{"subscribers":[{"address":"0x00000000219ab540356cBB839Cbe05303d7705Fa"},{"address":"0x2222222222222222222222222222222222222222"}]}}`},
+		{Name: "next page", Query: `{ subscribers(first: 2, after: \"0x2222222222222222222222222222222222222222\") { address } }`,
+			ExpectedBody: `{"data":{"subscribers":[{"address":"0x45849a974058661Eb2128ACeB60d2C6eD99E2A14"}]}}`},
+		{Name: "too large page", Query: `{ subscribers(first: 101) { address } }`,
+			ExpectedBody: `{"errors":[{"message":"first should be in range [0, 100]","path":["subscribers"],"extensions":{"code":"invalid_request"}}],"data":null}`},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(`{"query":"`+testCase.Query+`"}`))
+			req = req.WithContext(tenant.WithID(req.Context(), "tenant_a"))
+
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, req)
+
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			assert.JSONEq(t, testCase.ExpectedBody, recorder.Body.String())
+		})
+	}
+}
+
+// TestHandler_RateLimit checks that every resolved transactions field takes a token of /get_transactions/{address}
+func TestHandler_RateLimit(t *testing.T) {
+	parser := &mockParser{
+		subscribers: []models.Subscriber{{ChainID: 1, Address: subscriberAddress}, {ChainID: 1, Address: otherAddress}},
+	}
+
+	rateLimiter := rate_limiter.NewLimiter(config.RateLimit{
+		Enabled:           true,
+		RequestsPerSecond: 100,
+		Burst:             100,
+		Routes:            map[string]config.Limit{transactionsRoute: {RequestsPerSecond: 0.001, Burst: 1}},
+	})
+
+	handler, err := NewHandler([]ChainParser{{Chain: models.Chain{ID: 1, Name: "ethereum", NativeCurrency: "ETH"}, Parser: parser}},
+		config.GraphQL{Enabled: true}, rateLimiter, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	assert.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(`{"query":"{ subscribers { address transactions { hash } } }"}`))
+	req = req.WithContext(rate_limiter.WithClient(req.Context(), "key:a"))
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, req)
+
+	var resp struct {
+		Errors []struct {
+			Path       []interface{}     `json:"path"`
+			Extensions map[string]string `json:"extensions"`
+		} `json:"errors"`
+	}
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &resp))
+
+	// One of subscribers takes the only token, transactions of the other one are over limit
+	if assert.Len(t, resp.Errors, 1) {
+		assert.Equal(t, "rate_limited", resp.Errors[0].Extensions["code"])
+		if assert.Len(t, resp.Errors[0].Path, 3) {
+			assert.Equal(t, "transactions", resp.Errors[0].Path[2])
+		}
+	}
+
+	// Bucket is per client
+	allowed, _ := rateLimiter.Allow("key:b", transactionsRoute)
+	assert.True(t, allowed)
+}
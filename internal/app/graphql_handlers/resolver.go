@@ -0,0 +1,473 @@
+package graphql_handlers
+
+import (
+	"context"
+	"errors"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/models"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/service/rate_limiter"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/tenant"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxSubscribersPage is a maximum count of subscriptions returned by subscribers field
+const maxSubscribersPage = 100
+
+// Routes of HTTP API whose work is repeated by expensive fields, a field takes a token of the route from rate limiter
+const (
+	transactionsRoute   = "/get_transactions/{address}"
+	balanceRoute        = "/get_balance/{address}"
+	balanceHistoryRoute = "/get_balance_history/{address}"
+)
+
+// resolver is a root resolver of Query type
+type resolver struct {
+	// parsers are parsers of configured chains, the first one is the default chain
+	parsers     []ChainParser
+	rateLimiter RateLimiter
+}
+
+type chainArgs struct {
+	Chain *string
+}
+
+type subscribersArgs struct {
+	Chain *string
+	// First has default value in schema
+	First int32
+	After *string
+}
+
+type subscriberArgs struct {
+	Address string
+	Chain   *string
+}
+
+func (r *resolver) Chains() []*chainResolver {
+	chains := make([]*chainResolver, 0, len(r.parsers))
+	for _, chainParser := range r.parsers {
+		chains = append(chains, &chainResolver{chain: chainParser.Chain})
+	}
+
+	return chains
+}
+
+func (r *resolver) CurrentBlock(ctx context.Context, args chainArgs) (BigInt, error) {
+	chainParser, err := r.getChainParser(args.Chain)
+	if err != nil {
+		return BigInt{}, err
+	}
+
+	currentBlock, err := chainParser.Parser.GetCurrentBlock(ctx)
+	if err != nil {
+		return BigInt{}, parserErr(err)
+	}
+
+	if currentBlock == 0 {
+		return BigInt{}, parserErr(models.ErrCurrentBlockNotParsed)
+	}
+
+	return newBigIntFromUint64(currentBlock), nil
+}
+
+func (r *resolver) Subscribers(ctx context.Context, args subscribersArgs) ([]*subscriberResolver, error) {
+	chainParser, err := r.getChainParser(args.Chain)
+	if err != nil {
+		return nil, err
+	}
+
+	if args.First < 0 || args.First > maxSubscribersPage {
+		return nil, newResolverError(codeInvalidRequest, errors.New("first should be in range [0, "+strconv.Itoa(maxSubscribersPage)+"]"))
+	}
+
+	var after models.Address
+	if args.After != nil {
+		after, err = parseAddress(*args.After)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	subscribers, err := chainParser.Parser.GetSubscribers(ctx)
+	if err != nil {
+		return nil, parserErr(err)
+	}
+
+	// Parser returns subscriptions of all tenants
+	tenantID := tenant.FromContext(ctx)
+
+	page := make([]models.Subscriber, 0, len(subscribers))
+	for _, subscriber := range subscribers {
+		if subscriber.TenantID != tenantID || (after != "" && subscriber.Address <= after) {
+			continue
+		}
+
+		page = append(page, subscriber)
+	}
+
+	sort.Slice(page, func(i, j int) bool {
+		return page[i].Address < page[j].Address
+	})
+
+	if len(page) > int(args.First) {
+		page = page[:args.First]
+	}
+
+	resolvers := make([]*subscriberResolver, 0, len(page))
+	for _, subscriber := range page {
+		resolvers = append(resolvers, r.newSubscriberResolver(subscriber, chainParser))
+	}
+
+	return resolvers, nil
+}
+
+// Subscriber returns null instead of not_subscribed error, so client can check subscription of address
+func (r *resolver) Subscriber(ctx context.Context, args subscriberArgs) (*subscriberResolver, error) {
+	chainParser, err := r.getChainParser(args.Chain)
+	if err != nil {
+		return nil, err
+	}
+
+	address, err := parseAddress(args.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	subscriber, err := chainParser.Parser.GetSubscriber(ctx, address)
+	if err != nil {
+		if errors.Is(err, models.ErrNotSubscribed) {
+			return nil, nil
+		}
+
+		return nil, parserErr(err)
+	}
+
+	return r.newSubscriberResolver(subscriber, chainParser), nil
+}
+
+func (r *resolver) newSubscriberResolver(subscriber models.Subscriber, chainParser ChainParser) *subscriberResolver {
+	return &subscriberResolver{subscriber: subscriber, chainParser: chainParser, rateLimiter: r.rateLimiter}
+}
+
+// getChainParser returns parser of the chain selected by name or chain ID, parser of the default chain is returned if selector is not set
+func (r *resolver) getChainParser(selector *string) (ChainParser, error) {
+	chains := make([]models.Chain, 0, len(r.parsers))
+	for _, chainParser := range r.parsers {
+		chains = append(chains, chainParser.Chain)
+	}
+
+	var chainSelector string
+	if selector != nil {
+		chainSelector = *selector
+	}
+
+	chain, ok := models.FindChain(chains, chainSelector)
+	if ok {
+		for _, chainParser := range r.parsers {
+			if chainParser.Chain.ID == chain.ID {
+				return chainParser, nil
+			}
+		}
+	}
+
+	return ChainParser{}, newResolverError(codeUnknownChain, errors.New("unknown chain "+chainSelector))
+}
+
+func parseAddress(rawAddress string) (models.Address, error) {
+	address, err := models.ParseAddress(rawAddress)
+	if err != nil {
+		return "", newResolverError(codeInvalidAddress, err)
+	}
+
+	return address, nil
+}
+
+type chainResolver struct {
+	chain models.Chain
+}
+
+func (r *chainResolver) ID() BigInt {
+	return newBigIntFromUint64(r.chain.ID)
+}
+
+func (r *chainResolver) Name() string {
+	return r.chain.Name
+}
+
+func (r *chainResolver) NativeCurrency() string {
+	return r.chain.NativeCurrency
+}
+
+type subscriberResolver struct {
+	subscriber  models.Subscriber
+	chainParser ChainParser
+	rateLimiter RateLimiter
+}
+
+type transactionsArgs struct {
+	FromBlock    *BigInt
+	ToBlock      *BigInt
+	Direction    *string
+	Counterparty *string
+	First        *int32
+}
+
+func (r *subscriberResolver) Address() string {
+	return r.subscriber.Address.Checksum()
+}
+
+func (r *subscriberResolver) EnsName() *string {
+	if r.subscriber.ENSName == "" {
+		return nil
+	}
+
+	return &r.subscriber.ENSName
+}
+
+func (r *subscriberResolver) Chain() *chainResolver {
+	return &chainResolver{chain: r.chainParser.Chain}
+}
+
+// Transactions returns transactions of subscriber filtered by arguments, filters are applied to transactions
+// returned by parser, so block range does not reduce scanning of the chain by approaches that do not index blocks
+func (r *subscriberResolver) Transactions(ctx context.Context, args transactionsArgs) ([]*transactionResolver, error) {
+	filter, err := newTransactionsFilter(args)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.charge(ctx, transactionsRoute)
+	if err != nil {
+		return nil, err
+	}
+
+	transactions, err := r.chainParser.Parser.GetTransactions(ctx, r.subscriber.Address)
+	if err != nil {
+		return nil, parserErr(err)
+	}
+
+	resolvers := make([]*transactionResolver, 0, len(transactions))
+	for _, transaction := range transactions {
+		if filter.limit >= 0 && len(resolvers) >= filter.limit {
+			break
+		}
+
+		if !filter.match(transaction, r.subscriber.Address) {
+			continue
+		}
+
+		resolvers = append(resolvers, &transactionResolver{transaction: transaction, subscriber: r.subscriber.Address})
+	}
+
+	return resolvers, nil
+}
+
+// Balance returns null if parser of the configured approach does not track balances
+func (r *subscriberResolver) Balance(ctx context.Context) (*balanceResolver, error) {
+	balanceParser, ok := r.chainParser.Parser.(BalanceParser)
+	if !ok {
+		return nil, nil
+	}
+
+	err := r.charge(ctx, balanceRoute)
+	if err != nil {
+		return nil, err
+	}
+
+	balance, err := balanceParser.GetBalance(ctx, r.subscriber.Address)
+	if err != nil {
+		return nil, parserErr(err)
+	}
+
+	return &balanceResolver{balance: balance}, nil
+}
+
+// BalanceHistory returns null if parser of the configured approach does not track balances
+func (r *subscriberResolver) BalanceHistory(ctx context.Context) (*[]*balanceResolver, error) {
+	balanceParser, ok := r.chainParser.Parser.(BalanceParser)
+	if !ok {
+		return nil, nil
+	}
+
+	err := r.charge(ctx, balanceHistoryRoute)
+	if err != nil {
+		return nil, err
+	}
+
+	history, err := balanceParser.GetBalanceHistory(ctx, r.subscriber.Address)
+	if err != nil {
+		return nil, parserErr(err)
+	}
+
+	resolvers := make([]*balanceResolver, 0, len(history))
+	for _, balance := range history {
+		resolvers = append(resolvers, &balanceResolver{balance: balance})
+	}
+
+	return &resolvers, nil
+}
+
+// charge takes a token of the route from bucket of the client of request, so a query resolving an expensive field
+// for many subscribers costs like the same count of requests to the route
+func (r *subscriberResolver) charge(ctx context.Context, route string) error {
+	allowed, retryAfter := r.rateLimiter.Allow(rate_limiter.ClientFromContext(ctx), route)
+	if !allowed {
+		return newResolverError(codeRateLimited, errors.New("rate limit of "+route+" is exceeded, retry after "+retryAfter.Round(time.Millisecond).String()))
+	}
+
+	return nil
+}
+
+// transactionsFilter is a parsed filter of transactions argument of Subscriber
+type transactionsFilter struct {
+	fromBlock    uint64
+	toBlock      uint64
+	direction    string
+	counterparty models.Address
+	// limit is a maximum count of transactions, -1 means no limit
+	limit int
+}
+
+func newTransactionsFilter(args transactionsArgs) (transactionsFilter, error) {
+	filter := transactionsFilter{limit: -1}
+
+	var ok bool
+
+	filter.fromBlock, ok = blockNumber(args.FromBlock, 0)
+	if !ok {
+		return transactionsFilter{}, newResolverError(codeInvalidRequest, errors.New("fromBlock should be a block number"))
+	}
+
+	filter.toBlock, ok = blockNumber(args.ToBlock, ^uint64(0))
+	if !ok {
+		return transactionsFilter{}, newResolverError(codeInvalidRequest, errors.New("toBlock should be a block number"))
+	}
+
+	if filter.fromBlock > filter.toBlock {
+		return transactionsFilter{}, newResolverError(codeInvalidRequest, errors.New("fromBlock should not be greater than toBlock"))
+	}
+
+	if args.Direction != nil {
+		filter.direction = strings.ToLower(*args.Direction)
+	}
+
+	if args.Counterparty != nil {
+		counterparty, err := parseAddress(*args.Counterparty)
+		if err != nil {
+			return transactionsFilter{}, err
+		}
+
+		filter.counterparty = counterparty
+	}
+
+	if args.First != nil {
+		if *args.First < 0 {
+			return transactionsFilter{}, newResolverError(codeInvalidRequest, errors.New("first should not be negative"))
+		}
+
+		filter.limit = int(*args.First)
+	}
+
+	return filter, nil
+}
+
+// match checks that transaction of subscriber passes the filter
+func (f transactionsFilter) match(transaction *models.Transaction, subscriber models.Address) bool {
+	if transaction.BlockNumber < f.fromBlock || transaction.BlockNumber > f.toBlock {
+		return false
+	}
+
+	if f.direction != "" && transaction.Direction(subscriber) != f.direction {
+		return false
+	}
+
+	if f.counterparty != "" && transaction.Counterparty(subscriber) != f.counterparty {
+		return false
+	}
+
+	return true
+}
+
+type transactionResolver struct {
+	transaction *models.Transaction
+	subscriber  models.Address
+}
+
+func (r *transactionResolver) ChainId() BigInt {
+	return newBigIntFromUint64(r.transaction.ChainID)
+}
+
+func (r *transactionResolver) Hash() string {
+	return r.transaction.Hash
+}
+
+func (r *transactionResolver) BlockHash() string {
+	return r.transaction.BlockHash
+}
+
+func (r *transactionResolver) BlockNumber() BigInt {
+	return newBigIntFromUint64(r.transaction.BlockNumber)
+}
+
+func (r *transactionResolver) TransactionIndex() BigInt {
+	return newBigIntFromUint64(r.transaction.TransactionIndex)
+}
+
+func (r *transactionResolver) From() string {
+	return models.NewAddress(r.transaction.From).Checksum()
+}
+
+func (r *transactionResolver) To() string {
+	return models.NewAddress(r.transaction.To).Checksum()
+}
+
+// Direction returns value of Direction enum
+func (r *transactionResolver) Direction() string {
+	return strings.ToUpper(r.transaction.Direction(r.subscriber))
+}
+
+func (r *transactionResolver) Value() BigInt {
+	return newBigInt(&r.transaction.Value)
+}
+
+func (r *transactionResolver) ValueEth() string {
+	return models.FormatEther(&r.transaction.Value)
+}
+
+func (r *transactionResolver) Gas() BigInt {
+	return newBigInt(&r.transaction.Gas)
+}
+
+func (r *transactionResolver) GasPrice() BigInt {
+	return newBigInt(&r.transaction.GasPrice)
+}
+
+func (r *transactionResolver) GasPriceGwei() string {
+	return models.FormatGwei(&r.transaction.GasPrice)
+}
+
+func (r *transactionResolver) Nonce() BigInt {
+	return newBigIntFromUint64(r.transaction.Nonce)
+}
+
+func (r *transactionResolver) Input() string {
+	return r.transaction.Input
+}
+
+type balanceResolver struct {
+	balance models.Balance
+}
+
+func (r *balanceResolver) BlockNumber() BigInt {
+	return newBigIntFromUint64(r.balance.BlockNumber)
+}
+
+func (r *balanceResolver) Balance() BigInt {
+	return newBigInt(&r.balance.Balance)
+}
+
+func (r *balanceResolver) BalanceEth() string {
+	return models.FormatEther(&r.balance.Balance)
+}
@@ -0,0 +1,35 @@
+package grpc_handlers
+
+import (
+	"context"
+	"errors"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/models"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// parserErrStatus returns status of error returned by parser, errors that are not domain ones are internal
+func parserErrStatus(err error) error {
+	return status.Error(parserErrCode(err), err.Error())
+}
+
+func parserErrCode(err error) codes.Code {
+	switch {
+	case errors.Is(err, models.ErrNotSubscribed):
+		return codes.NotFound
+	case errors.Is(err, models.ErrAlreadySubscribed):
+		return codes.AlreadyExists
+	case errors.Is(err, models.ErrQuotaExceeded):
+		return codes.ResourceExhausted
+	case errors.Is(err, models.ErrCurrentBlockNotParsed), errors.Is(err, models.ErrBalanceNotRecorded):
+		return codes.Unavailable
+	case errors.Is(err, models.ErrUpstreamRPC):
+		return codes.Unavailable
+	case errors.Is(err, context.Canceled):
+		return codes.Canceled
+	case errors.Is(err, context.DeadlineExceeded):
+		return codes.DeadlineExceeded
+	default:
+		return codes.Internal
+	}
+}
@@ -0,0 +1,187 @@
+package grpc_handlers
+
+import (
+	"context"
+	"errors"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/logger"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/models"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/tenant"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"math"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Metadata keys read and written by the server, keys of gRPC metadata are lowercase
+const (
+	authorizationKey = "authorization"
+	apiKeyKey        = "x-api-key"
+	requestIDKey     = "x-request-id"
+	retryAfterKey    = "retry-after"
+)
+
+// maxRequestIDLength limits request ID provided by client, longer IDs are replaced by generated ones
+const maxRequestIDLength = 64
+
+// apiKeyIDCtxKey is a key of ID of API key of authenticated call in call context
+type apiKeyIDCtxKey struct{}
+
+// wrappedStream replaces context of server stream with context prepared by streamInterceptor
+type wrappedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (w *wrappedStream) Context() context.Context {
+	return w.ctx
+}
+
+// unaryInterceptor prepares context of unary call (see prepareCall) and logs the call after it is handled
+func (s *Server) unaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	start := time.Now()
+
+	ctx, err := s.prepareCall(ctx, info.FullMethod, grpc.SetHeader)
+
+	var resp interface{}
+	if err == nil {
+		resp, err = handler(ctx, req)
+	}
+
+	s.logCall(ctx, info.FullMethod, err, start)
+
+	return resp, err
+}
+
+// streamInterceptor prepares context of stream (see prepareCall) and logs the stream after it is finished.
+// Contexts of streams are cancelled when streamsCtx is done, so server does not wait for streams on shutdown
+func (s *Server) streamInterceptor(streamsCtx context.Context) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+
+		ctx, cancel := context.WithCancel(ss.Context())
+		defer cancel()
+
+		stopAfter := context.AfterFunc(streamsCtx, cancel)
+		defer stopAfter()
+
+		ctx, err := s.prepareCall(ctx, info.FullMethod, func(ctx context.Context, md metadata.MD) error {
+			return ss.SetHeader(md)
+		})
+		if err == nil {
+			err = handler(srv, &wrappedStream{ServerStream: ss, ctx: ctx})
+		}
+
+		s.logCall(ctx, info.FullMethod, err, start)
+
+		return err
+	}
+}
+
+// prepareCall attaches request ID to call context and returns it in header, authenticates call if auth is enabled
+// and limits calls of every client by method like HTTP API does. Tenant of API key is attached to call context,
+// so parsers and repositories see only subscriptions of the tenant
+func (s *Server) prepareCall(ctx context.Context, fullMethod string, setHeader func(context.Context, metadata.MD) error) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	requestID := getMetadataValue(md, requestIDKey)
+	if !isValidRequestID(requestID) {
+		requestID = logger.NewRequestID()
+	}
+
+	ctx = logger.WithRequestID(ctx, requestID)
+	setHeader(ctx, metadata.Pairs(requestIDKey, requestID))
+
+	if s.authenticator.Enabled() {
+		rawKey := getRawKey(md)
+		if rawKey == "" {
+			return ctx, status.Error(codes.Unauthenticated, "api key is not provided")
+		}
+
+		key, err := s.authenticator.Authenticate(ctx, rawKey)
+		if err != nil {
+			if errors.Is(err, models.ErrInvalidAPIKey) {
+				return ctx, status.Error(codes.Unauthenticated, err.Error())
+			}
+
+			return ctx, status.Error(codes.Internal, err.Error())
+		}
+
+		ctx = tenant.WithID(ctx, key.TenantID)
+		ctx = context.WithValue(ctx, apiKeyIDCtxKey{}, key.ID)
+	}
+
+	allowed, retryAfter := s.rateLimiter.Allow(getClient(ctx), fullMethod)
+	if !allowed {
+		// retry-after is in whole seconds like Retry-After header of HTTP API
+		setHeader(ctx, metadata.Pairs(retryAfterKey, strconv.Itoa(int(math.Max(1, math.Ceil(retryAfter.Seconds()))))))
+
+		return ctx, status.Error(codes.ResourceExhausted, "rate limit is exceeded, retry after "+retryAfter.Round(time.Millisecond).String())
+	}
+
+	return ctx, nil
+}
+
+func (s *Server) logCall(ctx context.Context, fullMethod string, err error, start time.Time) {
+	s.logger.InfoContext(ctx, "grpc call", "method", fullMethod, "code", status.Code(err).String(), "duration", time.Since(start))
+}
+
+// getRawKey returns API key from authorization metadata with Bearer scheme or from x-api-key metadata
+func getRawKey(md metadata.MD) string {
+	if authorization := getMetadataValue(md, authorizationKey); authorization != "" {
+		token, ok := strings.CutPrefix(authorization, "Bearer ")
+		if ok {
+			return strings.TrimSpace(token)
+		}
+	}
+
+	return strings.TrimSpace(getMetadataValue(md, apiKeyKey))
+}
+
+// getClient returns ID of API key of authenticated call, otherwise IP address of the peer
+func getClient(ctx context.Context) string {
+	if keyID, ok := ctx.Value(apiKeyIDCtxKey{}).(string); ok {
+		return "key:" + keyID
+	}
+
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return "ip:unknown"
+	}
+
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		host = p.Addr.String()
+	}
+
+	return "ip:" + host
+}
+
+func getMetadataValue(md metadata.MD, key string) string {
+	values := md.Get(key)
+	if len(values) == 0 {
+		return ""
+	}
+
+	return values[0]
+}
+
+// isValidRequestID checks that request ID provided by client is not empty, not too long and contains only printable ASCII
+func isValidRequestID(requestID string) bool {
+	if requestID == "" || len(requestID) > maxRequestIDLength {
+		return false
+	}
+
+	for _, c := range requestID {
+		if c < '!' || c > '~' {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,144 @@
+package grpc_handlers
+
+import (
+	"context"
+	"github.com/bluntenpassant/ethereum_subscriber/config"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/grpc_handlers/subscriberpb"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/models"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+	"log/slog"
+	"net"
+	"time"
+)
+
+type Parser interface {
+	GetCurrentBlock(ctx context.Context) (uint64, error)
+	GetTransactions(ctx context.Context, address models.Address) ([]*models.Transaction, error)
+	Subscribe(ctx context.Context, address models.Address, ensName string) error
+	Unsubscribe(ctx context.Context, address models.Address) error
+	GetSubscribers(ctx context.Context) ([]models.Subscriber, error)
+}
+
+// ChainParser is a parser of a single chain, chain is selected in requests by chain field
+type ChainParser struct {
+	Chain  models.Chain
+	Parser Parser
+}
+
+type ENSResolver interface {
+	Resolve(ctx context.Context, name string) (models.Address, error)
+}
+
+// SubscriptionQuota subscribes addresses within maximum count of subscriptions of a tenant on all chains
+type SubscriptionQuota interface {
+	Subscribe(ctx context.Context, chainID uint64, address models.Address, ensName string) error
+}
+
+type Authenticator interface {
+	Enabled() bool
+	Authenticate(ctx context.Context, rawKey string) (models.APIKey, error)
+}
+
+type RateLimiter interface {
+	Allow(client string, route string) (bool, time.Duration)
+}
+
+// Server serves gRPC API backed by the same parsers, authenticator and rate limiter as HTTP API
+type Server struct {
+	subscriberpb.UnimplementedSubscriberServer
+
+	// parsers are parsers of configured chains, the first one is the default chain
+	parsers           []ChainParser
+	ensResolver       ENSResolver
+	authenticator     Authenticator
+	rateLimiter       RateLimiter
+	subscriptionQuota SubscriptionQuota
+	watchPollInterval time.Duration
+	logger            *slog.Logger
+}
+
+// NewServer returns Server of gRPC API serving parsers of all chains, the first parser is used when chain is not selected.
+// WatchTransactions checks new transactions of watched address every watchPollInterval
+func NewServer(parsers []ChainParser, ensResolver ENSResolver, authenticator Authenticator, rateLimiter RateLimiter,
+	subscriptionQuota SubscriptionQuota, watchPollInterval time.Duration, logger *slog.Logger) *Server {
+	return &Server{
+		parsers:           parsers,
+		ensResolver:       ensResolver,
+		authenticator:     authenticator,
+		rateLimiter:       rateLimiter,
+		subscriptionQuota: subscriptionQuota,
+		watchPollInterval: watchPollInterval,
+		logger:            logger,
+	}
+}
+
+// defaultShutdownTimeout is used by Start when shutdown timeout is not configured
+const defaultShutdownTimeout = 15 * time.Second
+
+// Start serves gRPC calls until server fails or ctx is done. When ctx is done streams are cancelled right away,
+// because they last until client cancels them, and unary calls are given shutdown timeout to finish,
+// calls that are not finished in time get their contexts cancelled
+func (s *Server) Start(ctx context.Context, grpcConfig config.Grpc) error {
+	listener, err := net.Listen("tcp", grpcConfig.Host+":"+grpcConfig.Port)
+	if err != nil {
+		return err
+	}
+
+	return s.serve(ctx, listener, grpcConfig.ShutdownTimeout)
+}
+
+func (s *Server) serve(ctx context.Context, listener net.Listener, shutdownTimeout time.Duration) error {
+	// Contexts of all streams are cancelled with streamsCtx on shutdown
+	streamsCtx, cancelStreams := context.WithCancel(context.Background())
+	defer cancelStreams()
+
+	srv := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(s.unaryInterceptor),
+		grpc.ChainStreamInterceptor(s.streamInterceptor(streamsCtx)),
+	)
+	subscriberpb.RegisterSubscriberServer(srv, s)
+	// Reflection lets tools like grpcurl call the service without proto file
+	reflection.Register(srv)
+
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- srv.Serve(listener)
+	}()
+
+	s.logger.Info("gRPC Server started", "addr", listener.Addr().String())
+
+	select {
+	case err := <-serveErr:
+		return err
+	case <-ctx.Done():
+	}
+
+	if shutdownTimeout <= 0 {
+		shutdownTimeout = defaultShutdownTimeout
+	}
+
+	s.logger.Info("gRPC Server is shutting down", "timeout", shutdownTimeout)
+
+	cancelStreams()
+
+	stopped := make(chan struct{})
+	go func() {
+		srv.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-time.After(shutdownTimeout):
+		s.logger.Warn("in-flight calls are not finished in shutdown timeout, cancelling them")
+
+		// Stop closes all connections and cancels contexts of the rest calls, GracefulStop returns right after it
+		srv.Stop()
+		<-stopped
+	}
+
+	s.logger.Info("gRPC Server stopped")
+
+	return nil
+}
@@ -0,0 +1,439 @@
+package grpc_handlers
+
+import (
+	"context"
+	"github.com/bluntenpassant/ethereum_subscriber/config"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/grpc_handlers/subscriberpb"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/models"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/service/rate_limiter"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/service/subscription_quota"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/tenant"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"io"
+	"log/slog"
+	"math/big"
+	"net"
+	"sync"
+	"testing"
+	"time"
+)
+
+const (
+	testAddress = models.Address("0x45849a974058661eb2128aceb60d2c6ed99e2a14")
+	testAPIKey  = "esk_test"
+)
+
+// mockParser keeps subscriptions of all tenants, transactions are shared by all subscribers
+type mockParser struct {
+	mx           sync.Mutex
+	currentBlock uint64
+	subscribers  map[models.SubscriberKey]models.Subscriber
+	transactions []*models.Transaction
+}
+
+func newMockParser() *mockParser {
+	return &mockParser{subscribers: make(map[models.SubscriberKey]models.Subscriber)}
+}
+
+func (m *mockParser) GetCurrentBlock(ctx context.Context) (uint64, error) {
+	return m.currentBlock, nil
+}
+
+func (m *mockParser) GetTransactions(ctx context.Context, address models.Address) ([]*models.Transaction, error) {
+	m.mx.Lock()
+	defer m.mx.Unlock()
+
+	if _, ok := m.subscribers[tenant.Key(ctx, address)]; !ok {
+		return nil, models.ErrNotSubscribed
+	}
+
+	return append([]*models.Transaction(nil), m.transactions...), nil
+}
+
+func (m *mockParser) Subscribe(ctx context.Context, address models.Address, ensName string) error {
+	m.mx.Lock()
+	defer m.mx.Unlock()
+
+	key := tenant.Key(ctx, address)
+	if _, ok := m.subscribers[key]; ok {
+		return models.ErrAlreadySubscribed
+	}
+
+	m.subscribers[key] = models.Subscriber{Address: address, TenantID: tenant.FromContext(ctx), ENSName: ensName}
+
+	return nil
+}
+
+func (m *mockParser) Unsubscribe(ctx context.Context, address models.Address) error {
+	m.mx.Lock()
+	defer m.mx.Unlock()
+
+	key := tenant.Key(ctx, address)
+	if _, ok := m.subscribers[key]; !ok {
+		return models.ErrNotSubscribed
+	}
+
+	delete(m.subscribers, key)
+
+	return nil
+}
+
+func (m *mockParser) GetSubscribers(ctx context.Context) ([]models.Subscriber, error) {
+	m.mx.Lock()
+	defer m.mx.Unlock()
+
+	subscribers := make([]models.Subscriber, 0, len(m.subscribers))
+	for _, subscriber := range m.subscribers {
+		subscribers = append(subscribers, subscriber)
+	}
+
+	return subscribers, nil
+}
+
+func (m *mockParser) GetSubscriber(ctx context.Context, address models.Address) (models.Subscriber, error) {
+	m.mx.Lock()
+	defer m.mx.Unlock()
+
+	subscriber, ok := m.subscribers[tenant.Key(ctx, address)]
+	if !ok {
+		return models.Subscriber{}, models.ErrNotSubscribed
+	}
+
+	return subscriber, nil
+}
+
+func (m *mockParser) CountSubscribers(ctx context.Context) (int, error) {
+	m.mx.Lock()
+	defer m.mx.Unlock()
+
+	count := 0
+	for key := range m.subscribers {
+		if key.TenantID == tenant.FromContext(ctx) {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+// addTransaction adds transaction that is returned first, like parsers return the last transaction first
+func (m *mockParser) addTransaction(transaction *models.Transaction) {
+	m.mx.Lock()
+	defer m.mx.Unlock()
+
+	m.transactions = append([]*models.Transaction{transaction}, m.transactions...)
+}
+
+type mockENSResolver struct{}
+
+func (m *mockENSResolver) Resolve(ctx context.Context, name string) (models.Address, error) {
+	return testAddress, nil
+}
+
+// mockAuthenticator accepts only testAPIKey of tenant_a
+type mockAuthenticator struct {
+	enabled bool
+}
+
+func (m *mockAuthenticator) Enabled() bool {
+	return m.enabled
+}
+
+func (m *mockAuthenticator) Authenticate(ctx context.Context, rawKey string) (models.APIKey, error) {
+	if rawKey != testAPIKey {
+		return models.APIKey{}, models.ErrInvalidAPIKey
+	}
+
+	return models.APIKey{ID: "key-a", TenantID: "tenant_a"}, nil
+}
+
+// startTestServer serves server over in-memory connection until test is finished
+func startTestServer(t *testing.T, server *Server) subscriberpb.SubscriberClient {
+	listener := bufconn.Listen(1024 * 1024)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan struct{})
+	go func() {
+		server.serve(ctx, listener, time.Second)
+		close(stopped)
+	}()
+
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	assert.NoError(t, err)
+
+	t.Cleanup(func() {
+		conn.Close()
+		cancel()
+		<-stopped
+	})
+
+	return subscriberpb.NewSubscriberClient(conn)
+}
+
+func newTestServer(parser *mockParser, authEnabled bool, rateLimit config.RateLimit) *Server {
+	arbitrumParser := newMockParser()
+	parsers := []ChainParser{
+		{Chain: models.Chain{ID: 1, Name: "ethereum", NativeCurrency: "ETH"}, Parser: parser},
+		{Chain: models.Chain{ID: 42161, Name: "arbitrum", NativeCurrency: "ETH"}, Parser: arbitrumParser},
+	}
+
+	rateLimiter := rate_limiter.NewLimiter(rateLimit)
+	subscriptionQuota := subscription_quota.NewQuota(map[uint64]subscription_quota.Parser{1: parser, 42161: arbitrumParser}, rateLimiter)
+
+	return NewServer(parsers, &mockENSResolver{}, &mockAuthenticator{enabled: authEnabled}, rateLimiter, subscriptionQuota,
+		10*time.Millisecond, slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+func TestServer_Subscribe(t *testing.T) {
+	type TestCase struct {
+		Name             string
+		Request          *subscriberpb.SubscribeRequest
+		ExpectedCode     codes.Code
+		ExpectedResponse *subscriberpb.SubscribeResponse
+	}
+
+	testCases := []TestCase{
+		{
+			Name:    "address",
+			Request: &subscriberpb.SubscribeRequest{Address: string(testAddress)},
+			ExpectedResponse: &subscriberpb.SubscribeResponse{Address: "0x45849a974058661Eb2128ACeB60d2C6eD99E2A14",
+				Chain: "ethereum", ChainId: 1},
+		},
+		{
+			Name:         "already subscribed",
+			Request:      &subscriberpb.SubscribeRequest{Address: string(testAddress)},
+			ExpectedCode: codes.AlreadyExists,
+		},
+		{
+			Name:    "ens name on another chain",
+			Request: &subscriberpb.SubscribeRequest{Chain: "42161", Address: "vitalik.eth"},
+			ExpectedResponse: &subscriberpb.SubscribeResponse{Address: "0x45849a974058661Eb2128ACeB60d2C6eD99E2A14",
+				EnsName: "vitalik.eth", Chain: "arbitrum", ChainId: 42161},
+		},
+		{
+			Name:         "invalid address",
+			Request:      &subscriberpb.SubscribeRequest{Address: "0x1234"},
+			ExpectedCode: codes.InvalidArgument,
+		},
+		{
+			Name:         "unknown chain",
+			Request:      &subscriberpb.SubscribeRequest{Chain: "base", Address: string(testAddress)},
+			ExpectedCode: codes.InvalidArgument,
+		},
+		{
+			Name:         "quota is reached",
+			Request:      &subscriberpb.SubscribeRequest{Address: "0x00000000219ab540356cbb839cbe05303d7705fa"},
+			ExpectedCode: codes.ResourceExhausted,
+		},
+		{
+			Name:         "already subscribed at quota",
+			Request:      &subscriberpb.SubscribeRequest{Address: string(testAddress)},
+			ExpectedCode: codes.AlreadyExists,
+		},
+	}
+
+	// Two subscriptions on all chains are allowed, cases are run in order on the same server
+	client := startTestServer(t, newTestServer(newMockParser(), false, config.RateLimit{MaxSubscriptionsPerTenant: 2}))
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			resp, err := client.Subscribe(context.Background(), testCase.Request)
+			assert.Equal(t, testCase.ExpectedCode, status.Code(err))
+
+			if testCase.ExpectedResponse != nil {
+				assert.Equal(t, testCase.ExpectedResponse.String(), resp.String())
+			}
+		})
+	}
+}
+
+func TestServer_GetTransactions(t *testing.T) {
+	parser := newMockParser()
+	parser.addTransaction(&models.Transaction{ChainID: 1, BlockNumber: 16614490, Hash: "0x01", From: string(testAddress),
+		Value: *big.NewInt(1000000000000000000), Gas: *big.NewInt(21000), GasPrice: *big.NewInt(30000000000)})
+	client := startTestServer(t, newTestServer(parser, false, config.RateLimit{}))
+
+	ctx := context.Background()
+
+	_, err := client.GetTransactions(ctx, &subscriberpb.GetTransactionsRequest{Address: string(testAddress)})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = client.Subscribe(ctx, &subscriberpb.SubscribeRequest{Address: string(testAddress)})
+	assert.NoError(t, err)
+
+	resp, err := client.GetTransactions(ctx, &subscriberpb.GetTransactionsRequest{Address: string(testAddress)})
+	assert.NoError(t, err)
+	assert.Len(t, resp.GetTransactions(), 1)
+	assert.Equal(t, "0x45849a974058661Eb2128ACeB60d2C6eD99E2A14", resp.GetTransactions()[0].GetFrom())
+	assert.Equal(t, "1000000000000000000", resp.GetTransactions()[0].GetValue())
+	assert.Equal(t, "30000000000", resp.GetTransactions()[0].GetGasPrice())
+
+	_, err = client.Unsubscribe(ctx, &subscriberpb.UnsubscribeRequest{Address: string(testAddress)})
+	assert.NoError(t, err)
+
+	_, err = client.Unsubscribe(ctx, &subscriberpb.UnsubscribeRequest{Address: string(testAddress)})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = client.GetCurrentBlock(ctx, &subscriberpb.GetCurrentBlockRequest{})
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+}
+
+func TestServer_WatchTransactions(t *testing.T) {
+	type TestCase struct {
+		Name            string
+		IncludeExisting bool
+		ExpectedHashes  []string
+	}
+
+	testCases := []TestCase{
+		{Name: "only new transactions", ExpectedHashes: []string{"0x03", "0x04"}},
+		{Name: "existing transactions first", IncludeExisting: true, ExpectedHashes: []string{"0x01", "0x02", "0x03", "0x04"}},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			parser := newMockParser()
+			parser.addTransaction(&models.Transaction{BlockNumber: 1, BlockHash: "0xb1", Hash: "0x01"})
+			parser.addTransaction(&models.Transaction{BlockNumber: 2, BlockHash: "0xb2", Hash: "0x02"})
+			client := startTestServer(t, newTestServer(parser, false, config.RateLimit{}))
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			_, err := client.Subscribe(ctx, &subscriberpb.SubscribeRequest{Address: string(testAddress)})
+			assert.NoError(t, err)
+
+			stream, err := client.WatchTransactions(ctx, &subscriberpb.WatchTransactionsRequest{Address: string(testAddress),
+				IncludeExisting: testCase.IncludeExisting})
+			assert.NoError(t, err)
+
+			// Header is sent after existing transactions are checked, so new transactions are not taken for existing ones
+			_, err = stream.Header()
+			assert.NoError(t, err)
+			time.Sleep(50 * time.Millisecond)
+
+			// Transaction of the last checked block is new too
+			parser.addTransaction(&models.Transaction{BlockNumber: 2, BlockHash: "0xb2", Hash: "0x03"})
+			parser.addTransaction(&models.Transaction{BlockNumber: 3, BlockHash: "0xb3", Hash: "0x04"})
+
+			var hashes []string
+			for len(hashes) < len(testCase.ExpectedHashes) {
+				transaction, err := stream.Recv()
+				if !assert.NoError(t, err) {
+					return
+				}
+
+				hashes = append(hashes, transaction.GetHash())
+			}
+
+			assert.Equal(t, testCase.ExpectedHashes, hashes)
+		})
+	}
+}
+
+func TestServer_WatchTransactions_RateLimit(t *testing.T) {
+	parser := newMockParser()
+	// The call takes the only token, so checks of new transactions are skipped for 10 seconds
+	client := startTestServer(t, newTestServer(parser, false, config.RateLimit{
+		Enabled:           true,
+		RequestsPerSecond: 10,
+		Burst:             10,
+		Routes: map[string]config.Limit{
+			subscriberpb.Subscriber_WatchTransactions_FullMethodName: {RequestsPerSecond: 0.1, Burst: 1},
+		},
+	}))
+
+	_, err := client.Subscribe(context.Background(), &subscriberpb.SubscribeRequest{Address: string(testAddress)})
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	stream, err := client.WatchTransactions(ctx, &subscriberpb.WatchTransactionsRequest{Address: string(testAddress)})
+	assert.NoError(t, err)
+
+	_, err = stream.Header()
+	assert.NoError(t, err)
+
+	parser.addTransaction(&models.Transaction{BlockNumber: 1, BlockHash: "0xb1", Hash: "0x01"})
+
+	_, err = stream.Recv()
+	assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+}
+
+func TestWatchCursor(t *testing.T) {
+	type TestCase struct {
+		Name        string
+		Transaction *models.Transaction
+		ExpectedNew bool
+	}
+
+	testCases := []TestCase{
+		{Name: "older block", Transaction: &models.Transaction{BlockNumber: 9, BlockHash: "0xb9", Hash: "0x03"}},
+		{Name: "sent transaction", Transaction: &models.Transaction{BlockNumber: 10, BlockHash: "0xb10", Hash: "0x01"}},
+		{Name: "new transaction of the last block", Transaction: &models.Transaction{BlockNumber: 10, BlockHash: "0xb10", Hash: "0x04"},
+			ExpectedNew: true},
+		{Name: "transaction of the last block replaced on reorg", Transaction: &models.Transaction{BlockNumber: 10, BlockHash: "0xc10", Hash: "0x01"},
+			ExpectedNew: true},
+		{Name: "newer block", Transaction: &models.Transaction{BlockNumber: 11, BlockHash: "0xb11", Hash: "0x05"}, ExpectedNew: true},
+	}
+
+	cursor := watchCursor{}
+	cursor.add(&models.Transaction{BlockNumber: 9, BlockHash: "0xb9", Hash: "0x02"})
+	cursor.add(&models.Transaction{BlockNumber: 10, BlockHash: "0xb10", Hash: "0x01"})
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			assert.Equal(t, testCase.ExpectedNew, cursor.isNew(testCase.Transaction))
+		})
+	}
+
+	// Only transactions of the last block are remembered
+	assert.Len(t, cursor.hashes, 1)
+}
+
+func TestServer_Auth(t *testing.T) {
+	parser := newMockParser()
+	client := startTestServer(t, newTestServer(parser, true, config.RateLimit{
+		Enabled:           true,
+		RequestsPerSecond: 10,
+		Burst:             10,
+		Routes: map[string]config.Limit{
+			subscriberpb.Subscriber_GetCurrentBlock_FullMethodName: {RequestsPerSecond: 0.5, Burst: 1},
+		},
+	}))
+
+	_, err := client.Subscribe(context.Background(), &subscriberpb.SubscribeRequest{Address: string(testAddress)})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer esk_unknown")
+	_, err = client.Subscribe(ctx, &subscriberpb.SubscribeRequest{Address: string(testAddress)})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	ctx = metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+testAPIKey)
+	_, err = client.Subscribe(ctx, &subscriberpb.SubscribeRequest{Address: string(testAddress)})
+	assert.NoError(t, err)
+
+	subscribers, err := parser.GetSubscribers(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, subscribers, 1)
+	assert.Equal(t, "tenant_a", subscribers[0].TenantID)
+
+	var header metadata.MD
+	_, err = client.GetCurrentBlock(ctx, &subscriberpb.GetCurrentBlockRequest{})
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+
+	_, err = client.GetCurrentBlock(ctx, &subscriberpb.GetCurrentBlockRequest{}, grpc.Header(&header))
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	assert.Equal(t, []string{"2"}, header.Get("retry-after"))
+}
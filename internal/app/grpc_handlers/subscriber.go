@@ -0,0 +1,301 @@
+package grpc_handlers
+
+import (
+	"context"
+	"errors"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/grpc_handlers/subscriberpb"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/models"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"time"
+)
+
+func (s *Server) Subscribe(ctx context.Context, req *subscriberpb.SubscribeRequest) (*subscriberpb.SubscribeResponse, error) {
+	chainParser, err := s.getChainParser(req.GetChain())
+	if err != nil {
+		return nil, err
+	}
+
+	address, ensName, err := s.resolveSubscriptionAddress(ctx, req.GetAddress())
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.subscriptionQuota.Subscribe(ctx, chainParser.Chain.ID, address, ensName)
+	if err != nil {
+		return nil, parserErrStatus(err)
+	}
+
+	return &subscriberpb.SubscribeResponse{
+		Address: address.Checksum(),
+		EnsName: ensName,
+		Chain:   chainParser.Chain.Name,
+		ChainId: chainParser.Chain.ID,
+	}, nil
+}
+
+func (s *Server) Unsubscribe(ctx context.Context, req *subscriberpb.UnsubscribeRequest) (*subscriberpb.UnsubscribeResponse, error) {
+	chainParser, address, err := s.getSubscriptionParams(req.GetChain(), req.GetAddress())
+	if err != nil {
+		return nil, err
+	}
+
+	err = chainParser.Parser.Unsubscribe(ctx, address)
+	if err != nil {
+		return nil, parserErrStatus(err)
+	}
+
+	return &subscriberpb.UnsubscribeResponse{}, nil
+}
+
+func (s *Server) GetTransactions(ctx context.Context, req *subscriberpb.GetTransactionsRequest) (*subscriberpb.GetTransactionsResponse, error) {
+	chainParser, address, err := s.getSubscriptionParams(req.GetChain(), req.GetAddress())
+	if err != nil {
+		return nil, err
+	}
+
+	transactions, err := chainParser.Parser.GetTransactions(ctx, address)
+	if err != nil {
+		return nil, parserErrStatus(err)
+	}
+
+	resp := &subscriberpb.GetTransactionsResponse{Transactions: make([]*subscriberpb.Transaction, 0, len(transactions))}
+	for _, transaction := range transactions {
+		resp.Transactions = append(resp.Transactions, newTransaction(transaction))
+	}
+
+	return resp, nil
+}
+
+func (s *Server) GetCurrentBlock(ctx context.Context, req *subscriberpb.GetCurrentBlockRequest) (*subscriberpb.GetCurrentBlockResponse, error) {
+	chainParser, err := s.getChainParser(req.GetChain())
+	if err != nil {
+		return nil, err
+	}
+
+	currentBlock, err := chainParser.Parser.GetCurrentBlock(ctx)
+	if err != nil {
+		return nil, parserErrStatus(err)
+	}
+
+	if currentBlock == 0 {
+		return nil, parserErrStatus(models.ErrCurrentBlockNotParsed)
+	}
+
+	return &subscriberpb.GetCurrentBlockResponse{CurrentBlock: currentBlock}, nil
+}
+
+// WatchTransactions checks transactions of address every watch poll interval and sends the ones that were not sent yet,
+// transactions are sent from the oldest one. Header is sent after existing transactions are checked.
+// Every check after the first one is charged to rate limit of the client like a call of WatchTransactions,
+// checks over limit are skipped. Failures of Ethereum node do not stop the stream, they are retried with the next check
+func (s *Server) WatchTransactions(req *subscriberpb.WatchTransactionsRequest, stream subscriberpb.Subscriber_WatchTransactionsServer) error {
+	ctx := stream.Context()
+
+	chainParser, address, err := s.getSubscriptionParams(req.GetChain(), req.GetAddress())
+	if err != nil {
+		return err
+	}
+
+	// cursor remembers transactions that are already sent or skipped as existing ones
+	cursor := watchCursor{}
+
+	ticker := time.NewTicker(s.watchPollInterval)
+	defer ticker.Stop()
+
+	// The first check is charged by the interceptor with the call itself
+	err = s.checkWatchedTransactions(ctx, req, stream, chainParser.Parser, address, &cursor)
+	for err == nil {
+		select {
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		case <-ticker.C:
+		}
+
+		if s.allowWatchCheck(ctx) {
+			err = s.checkWatchedTransactions(ctx, req, stream, chainParser.Parser, address, &cursor)
+		}
+	}
+
+	return err
+}
+
+// checkWatchedTransactions sends transactions of address that are newer than cursor and moves cursor.
+// Transactions found by the first successful check are existing ones, they are sent only if requested
+func (s *Server) checkWatchedTransactions(ctx context.Context, req *subscriberpb.WatchTransactionsRequest,
+	stream subscriberpb.Subscriber_WatchTransactionsServer, parser Parser, address models.Address, cursor *watchCursor) error {
+	transactions, err := parser.GetTransactions(ctx, address)
+	if err != nil && !errors.Is(err, models.ErrUpstreamRPC) {
+		return parserErrStatus(err)
+	}
+
+	if err != nil {
+		s.logger.WarnContext(ctx, "checking transactions of watched address failed", "address", address, "error", err.Error())
+	}
+
+	// Header is sent right after the first successful check, so client knows that transactions
+	// added from now on are streamed as new ones
+	existing := err == nil && !cursor.checked
+	if existing {
+		cursor.checked = true
+
+		err = stream.SendHeader(nil)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Parsers return the last transaction first
+	for i := len(transactions) - 1; i >= 0; i-- {
+		transaction := transactions[i]
+		if !cursor.isNew(transaction) {
+			continue
+		}
+
+		cursor.add(transaction)
+		if existing && !req.GetIncludeExisting() {
+			continue
+		}
+
+		err = stream.Send(newTransaction(transaction))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// allowWatchCheck charges a check of watched transactions to rate limit of the client, so streams
+// do not poll parsers more often than the client may call WatchTransactions
+func (s *Server) allowWatchCheck(ctx context.Context) bool {
+	allowed, retryAfter := s.rateLimiter.Allow(getClient(ctx), subscriberpb.Subscriber_WatchTransactions_FullMethodName)
+	if !allowed {
+		s.logger.DebugContext(ctx, "check of watched transactions is skipped by rate limit", "retry_after", retryAfter)
+	}
+
+	return allowed
+}
+
+// watchCursor is the last block with sent transactions. Parsers add transactions block by block,
+// so only hashes of the last block are remembered and memory of stream does not grow with transactions of address
+type watchCursor struct {
+	// checked is set by the first successful check, transactions found by it are existing ones
+	checked     bool
+	blockNumber uint64
+	blockHash   string
+	hashes      map[string]bool
+}
+
+// isNew reports whether transaction is not sent yet. Transactions of older blocks are sent already,
+// block with the same number but another hash replaced the last block on reorg, so its transactions are new
+func (c *watchCursor) isNew(transaction *models.Transaction) bool {
+	switch {
+	case transaction.BlockNumber > c.blockNumber:
+		return true
+	case transaction.BlockNumber < c.blockNumber:
+		return false
+	}
+
+	return transaction.BlockHash != c.blockHash || !c.hashes[transaction.Hash]
+}
+
+// add moves cursor to block of transaction and remembers transaction
+func (c *watchCursor) add(transaction *models.Transaction) {
+	if c.hashes == nil || transaction.BlockNumber != c.blockNumber || transaction.BlockHash != c.blockHash {
+		c.blockNumber = transaction.BlockNumber
+		c.blockHash = transaction.BlockHash
+		c.hashes = make(map[string]bool)
+	}
+
+	c.hashes[transaction.Hash] = true
+}
+
+// getChainParser returns parser of the chain selected by name or chain ID, parser of the default chain is returned if selector is empty
+func (s *Server) getChainParser(selector string) (ChainParser, error) {
+	chains := make([]models.Chain, 0, len(s.parsers))
+	for _, chainParser := range s.parsers {
+		chains = append(chains, chainParser.Chain)
+	}
+
+	chain, ok := models.FindChain(chains, selector)
+	if ok {
+		for _, chainParser := range s.parsers {
+			if chainParser.Chain.ID == chain.ID {
+				return chainParser, nil
+			}
+		}
+	}
+
+	return ChainParser{}, status.Error(codes.InvalidArgument, "unknown chain "+selector)
+}
+
+// getSubscriptionParams returns parser of selected chain and parsed address of subscription
+func (s *Server) getSubscriptionParams(chain string, rawAddress string) (ChainParser, models.Address, error) {
+	chainParser, err := s.getChainParser(chain)
+	if err != nil {
+		return ChainParser{}, "", err
+	}
+
+	if rawAddress == "" {
+		return ChainParser{}, "", status.Error(codes.InvalidArgument, "address is not provided")
+	}
+
+	address, err := models.ParseAddress(rawAddress)
+	if err != nil {
+		return ChainParser{}, "", status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return chainParser, address, nil
+}
+
+// resolveSubscriptionAddress parses address of subscription or resolves ENS name into address,
+// ENS name is returned too, it is empty if address is passed
+func (s *Server) resolveSubscriptionAddress(ctx context.Context, rawAddress string) (models.Address, string, error) {
+	if rawAddress == "" {
+		return "", "", status.Error(codes.InvalidArgument, "address is not provided")
+	}
+
+	if !models.IsENSName(rawAddress) {
+		address, err := models.ParseAddress(rawAddress)
+		if err != nil {
+			return "", "", status.Error(codes.InvalidArgument, err.Error())
+		}
+
+		return address, "", nil
+	}
+
+	ensName, err := models.ParseENSName(rawAddress)
+	if err != nil {
+		return "", "", status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	address, err := s.ensResolver.Resolve(ctx, ensName)
+	if err != nil {
+		if errors.Is(err, models.ErrUpstreamRPC) {
+			return "", "", status.Error(codes.Unavailable, err.Error())
+		}
+
+		return "", "", status.Error(codes.FailedPrecondition, err.Error())
+	}
+
+	return address, ensName, nil
+}
+
+// newTransaction converts transaction of parser into transaction of gRPC API, amounts are rendered in wei
+func newTransaction(transaction *models.Transaction) *subscriberpb.Transaction {
+	return &subscriberpb.Transaction{
+		ChainId:          transaction.ChainID,
+		Hash:             transaction.Hash,
+		BlockNumber:      transaction.BlockNumber,
+		BlockHash:        transaction.BlockHash,
+		TransactionIndex: transaction.TransactionIndex,
+		From:             models.NewAddress(transaction.From).Checksum(),
+		To:               models.NewAddress(transaction.To).Checksum(),
+		Value:            transaction.Value.String(),
+		Gas:              transaction.Gas.String(),
+		GasPrice:         transaction.GasPrice.String(),
+		Nonce:            transaction.Nonce,
+		Input:            transaction.Input,
+	}
+}
@@ -0,0 +1,295 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: internal/app/grpc_handlers/subscriberpb/subscriber.proto
+
+package subscriberpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	Subscriber_Subscribe_FullMethodName         = "/ethereum_subscriber.v1.Subscriber/Subscribe"
+	Subscriber_Unsubscribe_FullMethodName       = "/ethereum_subscriber.v1.Subscriber/Unsubscribe"
+	Subscriber_GetTransactions_FullMethodName   = "/ethereum_subscriber.v1.Subscriber/GetTransactions"
+	Subscriber_GetCurrentBlock_FullMethodName   = "/ethereum_subscriber.v1.Subscriber/GetCurrentBlock"
+	Subscriber_WatchTransactions_FullMethodName = "/ethereum_subscriber.v1.Subscriber/WatchTransactions"
+)
+
+// SubscriberClient is the client API for Subscriber service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type SubscriberClient interface {
+	// Subscribe subscribes address or ENS name, ENS name is resolved into address once
+	Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (*SubscribeResponse, error)
+	// Unsubscribe removes subscription of address together with its transactions
+	Unsubscribe(ctx context.Context, in *UnsubscribeRequest, opts ...grpc.CallOption) (*UnsubscribeResponse, error)
+	// GetTransactions returns transactions of subscribed address, the last transaction goes first
+	GetTransactions(ctx context.Context, in *GetTransactionsRequest, opts ...grpc.CallOption) (*GetTransactionsResponse, error)
+	// GetCurrentBlock returns the last block handled by parser
+	GetCurrentBlock(ctx context.Context, in *GetCurrentBlockRequest, opts ...grpc.CallOption) (*GetCurrentBlockResponse, error)
+	// WatchTransactions streams new transactions of subscribed address until client cancels the call
+	WatchTransactions(ctx context.Context, in *WatchTransactionsRequest, opts ...grpc.CallOption) (Subscriber_WatchTransactionsClient, error)
+}
+
+type subscriberClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSubscriberClient(cc grpc.ClientConnInterface) SubscriberClient {
+	return &subscriberClient{cc}
+}
+
+func (c *subscriberClient) Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (*SubscribeResponse, error) {
+	out := new(SubscribeResponse)
+	err := c.cc.Invoke(ctx, Subscriber_Subscribe_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *subscriberClient) Unsubscribe(ctx context.Context, in *UnsubscribeRequest, opts ...grpc.CallOption) (*UnsubscribeResponse, error) {
+	out := new(UnsubscribeResponse)
+	err := c.cc.Invoke(ctx, Subscriber_Unsubscribe_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *subscriberClient) GetTransactions(ctx context.Context, in *GetTransactionsRequest, opts ...grpc.CallOption) (*GetTransactionsResponse, error) {
+	out := new(GetTransactionsResponse)
+	err := c.cc.Invoke(ctx, Subscriber_GetTransactions_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *subscriberClient) GetCurrentBlock(ctx context.Context, in *GetCurrentBlockRequest, opts ...grpc.CallOption) (*GetCurrentBlockResponse, error) {
+	out := new(GetCurrentBlockResponse)
+	err := c.cc.Invoke(ctx, Subscriber_GetCurrentBlock_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *subscriberClient) WatchTransactions(ctx context.Context, in *WatchTransactionsRequest, opts ...grpc.CallOption) (Subscriber_WatchTransactionsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Subscriber_ServiceDesc.Streams[0], Subscriber_WatchTransactions_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &subscriberWatchTransactionsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Subscriber_WatchTransactionsClient interface {
+	Recv() (*Transaction, error)
+	grpc.ClientStream
+}
+
+type subscriberWatchTransactionsClient struct {
+	grpc.ClientStream
+}
+
+func (x *subscriberWatchTransactionsClient) Recv() (*Transaction, error) {
+	m := new(Transaction)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// SubscriberServer is the server API for Subscriber service.
+// All implementations must embed UnimplementedSubscriberServer
+// for forward compatibility
+type SubscriberServer interface {
+	// Subscribe subscribes address or ENS name, ENS name is resolved into address once
+	Subscribe(context.Context, *SubscribeRequest) (*SubscribeResponse, error)
+	// Unsubscribe removes subscription of address together with its transactions
+	Unsubscribe(context.Context, *UnsubscribeRequest) (*UnsubscribeResponse, error)
+	// GetTransactions returns transactions of subscribed address, the last transaction goes first
+	GetTransactions(context.Context, *GetTransactionsRequest) (*GetTransactionsResponse, error)
+	// GetCurrentBlock returns the last block handled by parser
+	GetCurrentBlock(context.Context, *GetCurrentBlockRequest) (*GetCurrentBlockResponse, error)
+	// WatchTransactions streams new transactions of subscribed address until client cancels the call
+	WatchTransactions(*WatchTransactionsRequest, Subscriber_WatchTransactionsServer) error
+	mustEmbedUnimplementedSubscriberServer()
+}
+
+// UnimplementedSubscriberServer must be embedded to have forward compatible implementations.
+type UnimplementedSubscriberServer struct {
+}
+
+func (UnimplementedSubscriberServer) Subscribe(context.Context, *SubscribeRequest) (*SubscribeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Subscribe not implemented")
+}
+func (UnimplementedSubscriberServer) Unsubscribe(context.Context, *UnsubscribeRequest) (*UnsubscribeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Unsubscribe not implemented")
+}
+func (UnimplementedSubscriberServer) GetTransactions(context.Context, *GetTransactionsRequest) (*GetTransactionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTransactions not implemented")
+}
+func (UnimplementedSubscriberServer) GetCurrentBlock(context.Context, *GetCurrentBlockRequest) (*GetCurrentBlockResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCurrentBlock not implemented")
+}
+func (UnimplementedSubscriberServer) WatchTransactions(*WatchTransactionsRequest, Subscriber_WatchTransactionsServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchTransactions not implemented")
+}
+func (UnimplementedSubscriberServer) mustEmbedUnimplementedSubscriberServer() {}
+
+// UnsafeSubscriberServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SubscriberServer will
+// result in compilation errors.
+type UnsafeSubscriberServer interface {
+	mustEmbedUnimplementedSubscriberServer()
+}
+
+func RegisterSubscriberServer(s grpc.ServiceRegistrar, srv SubscriberServer) {
+	s.RegisterService(&Subscriber_ServiceDesc, srv)
+}
+
+func _Subscriber_Subscribe_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubscribeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SubscriberServer).Subscribe(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Subscriber_Subscribe_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SubscriberServer).Subscribe(ctx, req.(*SubscribeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Subscriber_Unsubscribe_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnsubscribeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SubscriberServer).Unsubscribe(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Subscriber_Unsubscribe_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SubscriberServer).Unsubscribe(ctx, req.(*UnsubscribeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Subscriber_GetTransactions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTransactionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SubscriberServer).GetTransactions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Subscriber_GetTransactions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SubscriberServer).GetTransactions(ctx, req.(*GetTransactionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Subscriber_GetCurrentBlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCurrentBlockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SubscriberServer).GetCurrentBlock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Subscriber_GetCurrentBlock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SubscriberServer).GetCurrentBlock(ctx, req.(*GetCurrentBlockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Subscriber_WatchTransactions_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchTransactionsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SubscriberServer).WatchTransactions(m, &subscriberWatchTransactionsServer{stream})
+}
+
+type Subscriber_WatchTransactionsServer interface {
+	Send(*Transaction) error
+	grpc.ServerStream
+}
+
+type subscriberWatchTransactionsServer struct {
+	grpc.ServerStream
+}
+
+func (x *subscriberWatchTransactionsServer) Send(m *Transaction) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// Subscriber_ServiceDesc is the grpc.ServiceDesc for Subscriber service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Subscriber_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "ethereum_subscriber.v1.Subscriber",
+	HandlerType: (*SubscriberServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Subscribe",
+			Handler:    _Subscriber_Subscribe_Handler,
+		},
+		{
+			MethodName: "Unsubscribe",
+			Handler:    _Subscriber_Unsubscribe_Handler,
+		},
+		{
+			MethodName: "GetTransactions",
+			Handler:    _Subscriber_GetTransactions_Handler,
+		},
+		{
+			MethodName: "GetCurrentBlock",
+			Handler:    _Subscriber_GetCurrentBlock_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchTransactions",
+			Handler:       _Subscriber_WatchTransactions_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "internal/app/grpc_handlers/subscriberpb/subscriber.proto",
+}
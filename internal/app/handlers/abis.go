@@ -0,0 +1,161 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/models"
+	"net/http"
+	"time"
+)
+
+// ABIs are kept per chain like subscriptions, so chain is selected by chain query parameter
+
+// maxABISize limits size of request body with uploaded ABI in bytes
+const maxABISize = 1 << 20
+
+type SetABIReq struct {
+	// JSON ABI of the contract or compiler artifact (Hardhat, Truffle or Foundry) with abi field
+	ABI json.RawMessage `json:"abi"`
+}
+
+type ContractABIResp struct {
+	// Contract address in EIP-55 checksum encoding
+	Address string `json:"address"`
+	Chain   string `json:"chain"`
+	ChainID uint64 `json:"chain_id"`
+	// Source is directory for ABI shared by all tenants or api for ABI uploaded by the tenant
+	Source string `json:"source"`
+	// Methods are canonical signatures of functions that are decoded, like transfer(address,uint256)
+	Methods   []string  `json:"methods"`
+	UpdatedAt time.Time `json:"updated_at"`
+	// ABI is returned only for a single contract
+	ABI json.RawMessage `json:"abi,omitempty"`
+}
+
+type GetABIsResp struct {
+	ABIs []ContractABIResp `json:"abis"`
+}
+
+func (h *Handler) setABI(w http.ResponseWriter, r *http.Request) {
+	chainParser, address, ok := h.getABIContract(w, r)
+	if !ok {
+		return
+	}
+
+	req := SetABIReq{}
+	err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxABISize)).Decode(&req)
+	if err != nil {
+		h.sendV2ErrResponse(w, CodeInvalidRequest, errors.New("invalid request body cause: "+err.Error()), http.StatusBadRequest)
+		return
+	}
+
+	if len(req.ABI) == 0 {
+		h.sendV2ErrResponse(w, CodeInvalidRequest, errors.New("abi is not provided"), http.StatusBadRequest)
+		return
+	}
+
+	contractABI, err := h.abiRegistry.SetABI(r.Context(), chainParser.Chain.ID, address, req.ABI)
+	if err != nil {
+		h.sendABIErrResponse(w, err)
+		return
+	}
+
+	h.sendV2Response(w, newContractABIResp(chainParser.Chain, contractABI, true))
+}
+
+func (h *Handler) getABIs(w http.ResponseWriter, r *http.Request) {
+	chainParser, err := h.getChainParser(r)
+	if err != nil {
+		h.sendV2ErrResponse(w, CodeUnknownChain, err, http.StatusBadRequest)
+		return
+	}
+
+	contractABIs, err := h.abiRegistry.ListABIs(r.Context(), chainParser.Chain.ID)
+	if err != nil {
+		h.sendV2ErrResponse(w, CodeInternal, err, http.StatusInternalServerError)
+		return
+	}
+
+	resp := GetABIsResp{ABIs: make([]ContractABIResp, 0, len(contractABIs))}
+	for _, contractABI := range contractABIs {
+		resp.ABIs = append(resp.ABIs, newContractABIResp(chainParser.Chain, contractABI, false))
+	}
+
+	h.sendV2Response(w, resp)
+}
+
+func (h *Handler) getABI(w http.ResponseWriter, r *http.Request) {
+	chainParser, address, ok := h.getABIContract(w, r)
+	if !ok {
+		return
+	}
+
+	contractABI, err := h.abiRegistry.GetABI(r.Context(), chainParser.Chain.ID, address)
+	if err != nil {
+		h.sendABIErrResponse(w, err)
+		return
+	}
+
+	h.sendV2Response(w, newContractABIResp(chainParser.Chain, contractABI, true))
+}
+
+func (h *Handler) deleteABI(w http.ResponseWriter, r *http.Request) {
+	chainParser, address, ok := h.getABIContract(w, r)
+	if !ok {
+		return
+	}
+
+	err := h.abiRegistry.DeleteABI(r.Context(), chainParser.Chain.ID, address)
+	if err != nil {
+		h.sendABIErrResponse(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// getABIContract returns chain and contract address of ABI route, error response is written if any of them is invalid
+func (h *Handler) getABIContract(w http.ResponseWriter, r *http.Request) (ChainParser, models.Address, bool) {
+	chainParser, err := h.getChainParser(r)
+	if err != nil {
+		h.sendV2ErrResponse(w, CodeUnknownChain, err, http.StatusBadRequest)
+		return ChainParser{}, "", false
+	}
+
+	address, ok := h.getV2PathAddress(w, r)
+	if !ok {
+		return ChainParser{}, "", false
+	}
+
+	return chainParser, address, true
+}
+
+// sendABIErrResponse writes error returned by ABI registry, contract without ABI gives 404 and invalid ABI gives 400
+func (h *Handler) sendABIErrResponse(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, models.ErrABINotFound):
+		h.sendV2ErrResponse(w, CodeABINotFound, err, http.StatusNotFound)
+	case errors.Is(err, models.ErrInvalidABI):
+		h.sendV2ErrResponse(w, CodeInvalidRequest, err, http.StatusBadRequest)
+	default:
+		h.sendV2ErrResponse(w, CodeInternal, err, http.StatusInternalServerError)
+	}
+}
+
+// newContractABIResp returns response with ABI of the contract, ABI itself is included only if withABI is set
+func newContractABIResp(chain models.Chain, contractABI models.ContractABI, withABI bool) ContractABIResp {
+	resp := ContractABIResp{
+		Address:   contractABI.Address.Checksum(),
+		Chain:     chain.Name,
+		ChainID:   chain.ID,
+		Source:    contractABI.Source,
+		Methods:   contractABI.Methods,
+		UpdatedAt: contractABI.UpdatedAt,
+	}
+
+	if withABI {
+		resp.ABI = contractABI.ABI
+	}
+
+	return resp
+}
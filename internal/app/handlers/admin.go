@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"encoding/json"
+	"gopkg.in/yaml.v3"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// maskedSecret replaces secrets in effective config
+const maskedSecret = "******"
+
+type ConfigResp struct {
+	// Effective config with the same keys as in config file, secrets are masked
+	Config map[string]interface{} `json:"config"`
+	// Time of the last reload or start of the service
+	ReloadedAt time.Time `json:"reloaded_at"`
+	// Error of the last reload, empty if it was successful
+	ReloadError string `json:"reload_error,omitempty"`
+}
+
+func (h *Handler) adminConfig(w http.ResponseWriter, r *http.Request) {
+	status := h.configProvider.Status()
+
+	effectiveConfig := status.Config
+	if effectiveConfig.Storage.Redis.Password != "" {
+		effectiveConfig.Storage.Redis.Password = maskedSecret
+	}
+	if effectiveConfig.Auth.AdminKey != "" {
+		effectiveConfig.Auth.AdminKey = maskedSecret
+	}
+	if effectiveConfig.CLI.APIKey != "" {
+		effectiveConfig.CLI.APIKey = maskedSecret
+	}
+	effectiveConfig.EthereumJsonRPC.Host = maskURL(effectiveConfig.EthereumJsonRPC.Host)
+	// Chains are copied, so masking does not change config of the reloader
+	effectiveConfig.Chains = append(effectiveConfig.Chains[:0:0], effectiveConfig.Chains...)
+	for i := range effectiveConfig.Chains {
+		effectiveConfig.Chains[i].Host = maskURL(effectiveConfig.Chains[i].Host)
+	}
+	effectiveConfig.Tracing.Endpoint = maskEndpoint(effectiveConfig.Tracing.Endpoint)
+
+	// Config is converted through yaml to keep keys of config file
+	configRaw, err := yaml.Marshal(effectiveConfig)
+	if err != nil {
+		h.sendErrResponse(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	resp := ConfigResp{ReloadedAt: status.ReloadedAt, ReloadError: status.ReloadError}
+
+	err = yaml.Unmarshal(configRaw, &resp.Config)
+	if err != nil {
+		h.sendErrResponse(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	respRaw, err := json.Marshal(resp)
+	if err != nil {
+		h.sendErrResponse(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	h.sendOKResponse(w, respRaw)
+}
+
+// maskURL keeps only scheme and host of RPC URL, because providers put credentials into user info, path or query,
+// e.g. https://mainnet.infura.io/v3/<key>. Masked part is replaced by maskedSecret
+func maskURL(rawURL string) string {
+	if rawURL == "" {
+		return ""
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return maskedSecret
+	}
+
+	masked := u.Scheme + "://" + u.Host
+	if u.User != nil || (u.Path != "" && u.Path != "/") || u.RawQuery != "" || u.Fragment != "" {
+		masked += "/" + maskedSecret
+	}
+
+	return masked
+}
+
+// maskEndpoint masks OTLP endpoint of tracing like maskURL, endpoint is usually set without scheme (localhost:4318)
+func maskEndpoint(endpoint string) string {
+	if endpoint == "" || strings.Contains(endpoint, "://") {
+		return maskURL(endpoint)
+	}
+
+	return strings.TrimPrefix(maskURL("//"+endpoint), "://")
+}
@@ -0,0 +1,192 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/models"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/tenant"
+	"github.com/gorilla/mux"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// apiKeyHeader is an alternative to Authorization header for clients that can not set bearer token
+const apiKeyHeader = "X-API-Key"
+
+// adminPathPrefix is a prefix of routes that require admin key
+const adminPathPrefix = "/admin/"
+
+// publicPaths are served without authentication, so probes, scrapers and docs keep working without keys
+var publicPaths = map[string]bool{
+	"/healthz": true,
+	"/readyz":  true,
+	"/metrics": true,
+	"/docs":    true,
+}
+
+type Authenticator interface {
+	Enabled() bool
+	IsAdmin(rawKey string) bool
+	Authenticate(ctx context.Context, rawKey string) (models.APIKey, error)
+	CreateKey(ctx context.Context, tenantID string) (models.APIKey, string, error)
+	ListKeys(ctx context.Context) ([]models.APIKey, error)
+	RevokeKey(ctx context.Context, id string) error
+}
+
+// apiKeyIDCtxKey is a key of ID of API key of authenticated request in request context
+type apiKeyIDCtxKey struct{}
+
+// authMiddleware authenticates requests if auth is enabled. Admin routes require admin key,
+// other routes require API key of a tenant, tenant is attached to request context, so parsers
+// and repositories see only subscriptions of the tenant. Public routes are not authenticated.
+// Without auth admin routes are not served at all, because anyone could read config and manage keys
+func (h *Handler) authMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !h.authenticator.Enabled() && strings.HasPrefix(r.URL.Path, adminPathPrefix) {
+			h.notFound(w, r)
+			return
+		}
+
+		if !h.authenticator.Enabled() || isPublicPath(r.URL.Path) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		rawKey := getRawKey(r)
+		if rawKey == "" {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			h.sendRouteErrResponse(w, r, CodeUnauthorized, errors.New("api key is not provided"), http.StatusUnauthorized)
+			return
+		}
+
+		if strings.HasPrefix(r.URL.Path, adminPathPrefix) {
+			if !h.authenticator.IsAdmin(rawKey) {
+				h.sendRouteErrResponse(w, r, CodeForbidden, errors.New("admin key is required"), http.StatusForbidden)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		key, err := h.authenticator.Authenticate(r.Context(), rawKey)
+		if err != nil {
+			if !errors.Is(err, models.ErrInvalidAPIKey) {
+				h.sendRouteErrResponse(w, r, CodeInternal, err, http.StatusInternalServerError)
+				return
+			}
+
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			h.sendRouteErrResponse(w, r, CodeUnauthorized, err, http.StatusUnauthorized)
+			return
+		}
+
+		ctx := tenant.WithID(r.Context(), key.TenantID)
+		ctx = context.WithValue(ctx, apiKeyIDCtxKey{}, key.ID)
+
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// isPublicPath reports whether path is served without authentication and rate limiting
+func isPublicPath(path string) bool {
+	return publicPaths[path] || strings.HasPrefix(path, "/static/")
+}
+
+// getRawKey returns key from Authorization bearer token or X-API-Key header
+func getRawKey(r *http.Request) string {
+	if authorization := r.Header.Get("Authorization"); authorization != "" {
+		token, ok := strings.CutPrefix(authorization, "Bearer ")
+		if ok {
+			return strings.TrimSpace(token)
+		}
+	}
+
+	return strings.TrimSpace(r.Header.Get(apiKeyHeader))
+}
+
+type CreateAPIKeyReq struct {
+	// ID of the tenant owning the key, tenants see only their own subscriptions
+	TenantID string `json:"tenant_id"`
+}
+
+type APIKeyResp struct {
+	ID        string    `json:"id"`
+	TenantID  string    `json:"tenant_id"`
+	CreatedAt time.Time `json:"created_at"`
+	// Raw key, returned only on creation
+	APIKey string `json:"api_key,omitempty"`
+}
+
+type GetAPIKeysResp struct {
+	Keys []APIKeyResp `json:"keys"`
+}
+
+func (h *Handler) createAPIKey(w http.ResponseWriter, r *http.Request) {
+	req := CreateAPIKeyReq{}
+
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		h.sendErrResponse(w, errors.New("invalid request body cause: "+err.Error()), http.StatusBadRequest)
+		return
+	}
+
+	key, rawKey, err := h.authenticator.CreateKey(r.Context(), req.TenantID)
+	if err != nil {
+		h.sendErrResponse(w, err, http.StatusBadRequest)
+		return
+	}
+
+	resp := newAPIKeyResp(key)
+	resp.APIKey = rawKey
+
+	respRaw, err := json.Marshal(resp)
+	if err != nil {
+		h.sendErrResponse(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	h.sendOKResponse(w, respRaw)
+}
+
+func (h *Handler) getAPIKeys(w http.ResponseWriter, r *http.Request) {
+	keys, err := h.authenticator.ListKeys(r.Context())
+	if err != nil {
+		h.sendErrResponse(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	resp := GetAPIKeysResp{Keys: make([]APIKeyResp, 0, len(keys))}
+	for _, key := range keys {
+		resp.Keys = append(resp.Keys, newAPIKeyResp(key))
+	}
+
+	respRaw, err := json.Marshal(resp)
+	if err != nil {
+		h.sendErrResponse(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	h.sendOKResponse(w, respRaw)
+}
+
+func (h *Handler) revokeAPIKey(w http.ResponseWriter, r *http.Request) {
+	err := h.authenticator.RevokeKey(r.Context(), mux.Vars(r)["id"])
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, models.ErrAPIKeyNotFound) {
+			status = http.StatusNotFound
+		}
+
+		h.sendErrResponse(w, err, status)
+		return
+	}
+
+	h.sendOKResponse(w, []byte(`{"is_ok":true}`))
+}
+
+func newAPIKeyResp(key models.APIKey) APIKeyResp {
+	return APIKeyResp{ID: key.ID, TenantID: key.TenantID, CreatedAt: key.CreatedAt}
+}
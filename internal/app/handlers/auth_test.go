@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHandler_AuthMiddleware(t *testing.T) {
+	type TestCase struct {
+		Name   string
+		Method string
+		Path   string
+		// Key is raw key sent in Authorization header, empty if request is sent without key
+		Key            string
+		APIKeyHeader   bool
+		ExpectedStatus int
+	}
+
+	h, tenantAKey := newOpenAPITestHandler(t, false)
+	_, tenantBKey, err := h.authenticator.CreateKey(context.Background(), "tenant_b")
+	assert.NoError(t, err)
+
+	subscribed := subscribedAddress.String()
+
+	// Cases are run in order on the same handler, subscribedAddress is subscribed by tenant_a
+	testCases := []TestCase{
+		{Name: "without key", Method: http.MethodGet, Path: "/get_transactions/" + subscribed, ExpectedStatus: http.StatusUnauthorized},
+		{Name: "unknown key", Method: http.MethodGet, Path: "/get_transactions/" + subscribed, Key: "esk_unknown",
+			ExpectedStatus: http.StatusUnauthorized},
+		{Name: "admin key on tenant route", Method: http.MethodGet, Path: "/get_transactions/" + subscribed, Key: testAdminKey,
+			ExpectedStatus: http.StatusUnauthorized},
+		{Name: "public route without key", Method: http.MethodGet, Path: "/healthz", ExpectedStatus: http.StatusOK},
+		{Name: "admin route without key", Method: http.MethodGet, Path: "/admin/keys", ExpectedStatus: http.StatusUnauthorized},
+		{Name: "admin route with tenant key", Method: http.MethodGet, Path: "/admin/keys", Key: tenantAKey, ExpectedStatus: http.StatusForbidden},
+		{Name: "admin route with admin key", Method: http.MethodGet, Path: "/admin/keys", Key: testAdminKey, ExpectedStatus: http.StatusOK},
+		{Name: "own transactions", Method: http.MethodGet, Path: "/get_transactions/" + subscribed, Key: tenantAKey, ExpectedStatus: http.StatusOK},
+		{Name: "own transactions with x-api-key", Method: http.MethodGet, Path: "/get_transactions/" + subscribed, Key: tenantAKey, APIKeyHeader: true,
+			ExpectedStatus: http.StatusOK},
+		{Name: "transactions of another tenant", Method: http.MethodGet, Path: "/get_transactions/" + subscribed, Key: tenantBKey,
+			ExpectedStatus: http.StatusNotFound},
+		{Name: "balance of another tenant", Method: http.MethodGet, Path: "/get_balance/" + subscribed, Key: tenantBKey,
+			ExpectedStatus: http.StatusNotFound},
+		{Name: "subscription of another tenant", Method: http.MethodGet, Path: "/v2/subscriptions/" + subscribed, Key: tenantBKey,
+			ExpectedStatus: http.StatusNotFound},
+		{Name: "transactions of subscription of another tenant", Method: http.MethodGet, Path: "/v2/subscriptions/" + subscribed + "/transactions",
+			Key: tenantBKey, ExpectedStatus: http.StatusNotFound},
+		{Name: "unsubscribe of another tenant", Method: http.MethodDelete, Path: "/v2/subscriptions/" + subscribed, Key: tenantBKey,
+			ExpectedStatus: http.StatusNotFound},
+		{Name: "address subscribed by another tenant", Method: http.MethodGet, Path: "/subscribe/" + subscribed, Key: tenantBKey,
+			ExpectedStatus: http.StatusOK},
+		{Name: "transactions of own subscription", Method: http.MethodGet, Path: "/get_transactions/" + subscribed, Key: tenantBKey,
+			ExpectedStatus: http.StatusOK},
+		{Name: "subscription of another tenant is kept", Method: http.MethodGet, Path: "/v2/subscriptions/" + subscribed, Key: tenantAKey,
+			ExpectedStatus: http.StatusOK},
+	}
+
+	router := h.newRouter()
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			req := httptest.NewRequest(testCase.Method, "http://localhost:8080"+testCase.Path, nil)
+			switch {
+			case testCase.Key != "" && testCase.APIKeyHeader:
+				req.Header.Set(apiKeyHeader, testCase.Key)
+			case testCase.Key != "":
+				req.Header.Set("Authorization", "Bearer "+testCase.Key)
+			}
+
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, req)
+			assert.Equal(t, testCase.ExpectedStatus, recorder.Code, recorder.Body.String())
+
+			if testCase.ExpectedStatus == http.StatusUnauthorized {
+				assert.Equal(t, "Bearer", recorder.Header().Get("WWW-Authenticate"))
+			}
+		})
+	}
+}
+
+func TestGetRawKey(t *testing.T) {
+	type TestCase struct {
+		Name     string
+		Headers  map[string]string
+		Expected string
+	}
+
+	testCases := []TestCase{
+		{Name: "bearer token", Headers: map[string]string{"Authorization": "Bearer esk_a "}, Expected: "esk_a"},
+		{Name: "x-api-key", Headers: map[string]string{apiKeyHeader: "esk_b"}, Expected: "esk_b"},
+		{Name: "bearer token first", Headers: map[string]string{"Authorization": "Bearer esk_a", apiKeyHeader: "esk_b"}, Expected: "esk_a"},
+		{Name: "other scheme", Headers: map[string]string{"Authorization": "Basic dXNlcjpwYXNz"}, Expected: ""},
+		{Name: "no key", Expected: ""},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/get_current_block", nil)
+			for name, value := range testCase.Headers {
+				req.Header.Set(name, value)
+			}
+
+			assert.Equal(t, testCase.Expected, getRawKey(req))
+		})
+	}
+}
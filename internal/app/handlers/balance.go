@@ -0,0 +1,125 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/models"
+	"github.com/gorilla/mux"
+	"net/http"
+)
+
+// BalanceParser is implemented by parsers that track balances of subscribers (indexed approach)
+type BalanceParser interface {
+	GetBalance(ctx context.Context, address models.Address) (models.Balance, error)
+	GetBalanceHistory(ctx context.Context, address models.Address) ([]models.Balance, error)
+}
+
+// errBalanceNotSupported is returned when parser of configured approach does not track balances
+var errBalanceNotSupported = errors.New("balance tracking is supported only by indexed approach")
+
+type BalanceResp struct {
+	// BlockNumber is the number of the block after which balance was recorded
+	BlockNumber uint64 `json:"blockNumber"`
+	// Balance is the balance in wei as a decimal string
+	Balance string `json:"balance"`
+	// BalanceETH is the balance in ETH as a decimal string
+	BalanceETH string `json:"balanceEth"`
+}
+
+type GetBalanceResp struct {
+	Address string `json:"address"`
+	BalanceResp
+}
+
+type GetBalanceHistoryResp struct {
+	Address string        `json:"address"`
+	History []BalanceResp `json:"history"`
+}
+
+func (h *Handler) getBalance(w http.ResponseWriter, r *http.Request) {
+	balanceParser, subscriberAddress, ok := h.parseBalanceRequest(w, r)
+	if !ok {
+		return
+	}
+
+	balance, err := balanceParser.GetBalance(r.Context(), subscriberAddress)
+	if err != nil {
+		h.sendErrResponse(w, err, parserErrStatus(err))
+		return
+	}
+
+	resp := GetBalanceResp{Address: subscriberAddress.Checksum(), BalanceResp: newBalanceResp(balance)}
+
+	respRaw, err := json.Marshal(resp)
+	if err != nil {
+		h.sendErrResponse(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	h.sendOKResponse(w, respRaw)
+}
+
+func (h *Handler) getBalanceHistory(w http.ResponseWriter, r *http.Request) {
+	balanceParser, subscriberAddress, ok := h.parseBalanceRequest(w, r)
+	if !ok {
+		return
+	}
+
+	history, err := balanceParser.GetBalanceHistory(r.Context(), subscriberAddress)
+	if err != nil {
+		h.sendErrResponse(w, err, parserErrStatus(err))
+		return
+	}
+
+	resp := GetBalanceHistoryResp{Address: subscriberAddress.Checksum(), History: make([]BalanceResp, 0, len(history))}
+	for _, balance := range history {
+		resp.History = append(resp.History, newBalanceResp(balance))
+	}
+
+	respRaw, err := json.Marshal(resp)
+	if err != nil {
+		h.sendErrResponse(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	h.sendOKResponse(w, respRaw)
+}
+
+// parseBalanceRequest checks that parser tracks balances and parses address of request,
+// error response is sent if ok is false
+func (h *Handler) parseBalanceRequest(w http.ResponseWriter, r *http.Request) (BalanceParser, models.Address, bool) {
+	parser, err := h.getParser(r)
+	if err != nil {
+		h.sendErrResponse(w, err, http.StatusBadRequest)
+		return nil, "", false
+	}
+
+	balanceParser, ok := parser.(BalanceParser)
+	if !ok {
+		h.sendErrResponse(w, errBalanceNotSupported, http.StatusNotImplemented)
+		return nil, "", false
+	}
+
+	address, ok := mux.Vars(r)["address"]
+	if !ok {
+		h.sendErrResponse(w, errors.New("address is not provided"), http.StatusBadRequest)
+		return nil, "", false
+	}
+
+	subscriberAddress, err := models.ParseAddress(address)
+	if err != nil {
+		h.sendErrResponse(w, err, http.StatusBadRequest)
+		return nil, "", false
+	}
+
+	return balanceParser, subscriberAddress, true
+}
+
+func newBalanceResp(balance models.Balance) BalanceResp {
+	return BalanceResp{
+		BlockNumber: balance.BlockNumber,
+		Balance:     balance.Balance.String(),
+		BalanceETH:  models.FormatEther(&balance.Balance),
+	}
+}
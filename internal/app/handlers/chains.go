@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/models"
+	"net/http"
+)
+
+// ChainParser is a parser of a single chain, chain is selected in requests by chain query parameter
+type ChainParser struct {
+	Chain  models.Chain
+	Parser Parser
+}
+
+type GetChainsResp struct {
+	// Configured chains, the first one is used when chain is not selected
+	Chains []models.Chain `json:"chains"`
+}
+
+func (h *Handler) getChains(w http.ResponseWriter, r *http.Request) {
+	resp := GetChainsResp{Chains: make([]models.Chain, 0, len(h.parsers))}
+	for _, chainParser := range h.parsers {
+		resp.Chains = append(resp.Chains, chainParser.Chain)
+	}
+
+	respRaw, err := json.Marshal(resp)
+	if err != nil {
+		h.sendErrResponse(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	h.sendOKResponse(w, respRaw)
+}
+
+// getParser returns parser of the chain selected by name or chain ID in chain query parameter,
+// parser of the default chain is returned if parameter is not set
+func (h *Handler) getParser(r *http.Request) (Parser, error) {
+	chainParser, err := h.getChainParser(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return chainParser.Parser, nil
+}
+
+// getChainParser returns the chain selected by chain query parameter together with its parser
+func (h *Handler) getChainParser(r *http.Request) (ChainParser, error) {
+	selector := r.URL.Query().Get("chain")
+
+	chains := make([]models.Chain, 0, len(h.parsers))
+	for _, chainParser := range h.parsers {
+		chains = append(chains, chainParser.Chain)
+	}
+
+	chain, ok := models.FindChain(chains, selector)
+	if !ok {
+		return ChainParser{}, errors.New("unknown chain " + selector)
+	}
+
+	for _, chainParser := range h.parsers {
+		if chainParser.Chain.ID == chain.ID {
+			return chainParser, nil
+		}
+	}
+
+	return ChainParser{}, errors.New("unknown chain " + selector)
+}
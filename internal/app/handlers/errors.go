@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/models"
+	"net/http"
+	"strings"
+)
+
+// v2PathPrefix is a prefix of routes of API v2, their errors are written in JSON envelope
+const v2PathPrefix = "/v2/"
+
+// Stable codes of API v2 errors, clients should rely on them instead of messages
+const (
+	CodeInvalidRequest            = "invalid_request"
+	CodeInvalidAddress            = "invalid_address"
+	CodeUnknownChain              = "unknown_chain"
+	CodeENSNotResolved            = "ens_not_resolved"
+	CodeNotSubscribed             = "not_subscribed"
+	CodeAlreadySubscribed         = "already_subscribed"
+	CodeSubscriptionsLimitReached = "subscriptions_limit_reached"
+	CodeLabelsNotFound            = "labels_not_found"
+	CodeABINotFound               = "abi_not_found"
+	CodeNotReady                  = "not_ready"
+	CodeUpstreamError             = "upstream_error"
+	CodeUnauthorized              = "unauthorized"
+	CodeForbidden                 = "forbidden"
+	CodeRateLimited               = "rate_limited"
+	CodeNotFound                  = "not_found"
+	CodeMethodNotAllowed          = "method_not_allowed"
+	CodeInternal                  = "internal"
+)
+
+type ErrorResp struct {
+	Error APIError `json:"error"`
+}
+
+type APIError struct {
+	// Stable code of the error, like not_subscribed
+	Code string `json:"code"`
+	// Human readable description, it may change between versions
+	Message string `json:"message"`
+}
+
+// sendV2ErrResponse writes error in JSON envelope of API v2
+func (h *Handler) sendV2ErrResponse(w http.ResponseWriter, code string, err error, status int) {
+	respRaw, marshalErr := json.Marshal(ErrorResp{Error: APIError{Code: code, Message: err.Error()}})
+	if marshalErr != nil {
+		h.sendErrResponse(w, marshalErr, http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(respRaw)
+}
+
+// sendRouteErrResponse writes error of middlewares shared by both API versions, routes of API v2 get JSON envelope
+func (h *Handler) sendRouteErrResponse(w http.ResponseWriter, r *http.Request, code string, err error, status int) {
+	if strings.HasPrefix(r.URL.Path, v2PathPrefix) {
+		h.sendV2ErrResponse(w, code, err, status)
+		return
+	}
+
+	h.sendErrResponse(w, err, status)
+}
+
+// parserErrCode returns code and HTTP status of error returned by parser, errors that are not domain ones are internal
+func parserErrCode(err error) (string, int) {
+	switch {
+	case errors.Is(err, models.ErrNotSubscribed):
+		return CodeNotSubscribed, http.StatusNotFound
+	case errors.Is(err, models.ErrAlreadySubscribed):
+		return CodeAlreadySubscribed, http.StatusConflict
+	case errors.Is(err, models.ErrQuotaExceeded):
+		return CodeSubscriptionsLimitReached, http.StatusForbidden
+	case errors.Is(err, models.ErrCurrentBlockNotParsed), errors.Is(err, models.ErrBalanceNotRecorded):
+		return CodeNotReady, http.StatusServiceUnavailable
+	case errors.Is(err, models.ErrUpstreamRPC):
+		return CodeUpstreamError, http.StatusBadGateway
+	default:
+		return CodeInternal, http.StatusInternalServerError
+	}
+}
+
+// parserErrStatus returns HTTP status of error returned by parser, it is used by routes of API v1
+func parserErrStatus(err error) int {
+	_, status := parserErrCode(err)
+
+	return status
+}
+
+// sendParserErrResponse writes error returned by parser in JSON envelope of API v2
+func (h *Handler) sendParserErrResponse(w http.ResponseWriter, err error) {
+	code, status := parserErrCode(err)
+
+	h.sendV2ErrResponse(w, code, err, status)
+}
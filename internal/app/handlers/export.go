@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/export"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/models"
+	"github.com/gorilla/mux"
+	"net/http"
+	"time"
+)
+
+// TransactionsIterator is implemented by parsers that keep transaction history in storage (greedy and indexed approaches),
+// export reads history through it transaction by transaction instead of loading it at once
+type TransactionsIterator interface {
+	ForEachTransaction(ctx context.Context, address models.Address, fn func(tx *models.Transaction) error) error
+}
+
+func (h *Handler) exportTransactions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	vars := mux.Vars(r)
+	address, ok := vars["address"]
+	if !ok {
+		h.sendErrResponse(w, errors.New("address is not provided"), http.StatusBadRequest)
+		return
+	}
+
+	parser, err := h.getParser(r)
+	if err != nil {
+		h.sendErrResponse(w, err, http.StatusBadRequest)
+		return
+	}
+
+	subscriberAddress, err := models.ParseAddress(address)
+	if err != nil {
+		h.sendErrResponse(w, err, http.StatusBadRequest)
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = export.CSVFormat
+	}
+
+	writer, err := export.NewWriter(format, w)
+	if err != nil {
+		h.sendErrResponse(w, err, http.StatusBadRequest)
+		return
+	}
+
+	// Export of a long history outlives WriteTimeout of the server, so write deadline is cleared for this response
+	err = http.NewResponseController(w).SetWriteDeadline(time.Time{})
+	if err != nil && !errors.Is(err, http.ErrNotSupported) {
+		h.logger.WarnContext(ctx, "clearing write deadline of export failed", "error", err.Error())
+	}
+
+	// Headers are sent with the first record, so failure before it is still returned as error response
+	started := false
+	start := func() {
+		if started {
+			return
+		}
+		started = true
+
+		w.Header().Set("Content-Type", export.ContentType(format))
+		w.Header().Set("Content-Disposition", `attachment; filename="`+subscriberAddress.String()+`.`+format+`"`)
+		w.WriteHeader(http.StatusOK)
+	}
+
+	iterator, ok := parser.(TransactionsIterator)
+	if ok {
+		err = iterator.ForEachTransaction(ctx, subscriberAddress, func(tx *models.Transaction) error {
+			start()
+			return writer.Write(export.NewRecord(tx))
+		})
+		if err == nil {
+			start()
+			err = writer.Close()
+		}
+	} else {
+		// Parsers without stored history (sync and async approaches) return all transactions at once
+		var transactions []*models.Transaction
+		transactions, err = parser.GetTransactions(ctx, subscriberAddress)
+		if err == nil {
+			start()
+			err = export.WriteTransactions(writer, transactions)
+		}
+	}
+
+	if err != nil {
+		if !started {
+			h.sendErrResponse(w, err, parserErrStatus(err))
+			return
+		}
+
+		// Response is already started, so failure can be only logged, client gets truncated file
+		h.logger.ErrorContext(ctx, "export of transactions failed", "address", subscriberAddress.String(), "error", err.Error())
+	}
+}
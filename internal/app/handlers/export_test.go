@@ -0,0 +1,123 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/export"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/models"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// iteratorParser yields transactions of mockParser one by one in chronological order like parsers with stored history.
+// It waits delay before every transaction and fails after failAfter transactions if failAfter is set
+type iteratorParser struct {
+	*mockParser
+	delay     time.Duration
+	failAfter int
+}
+
+func (p *iteratorParser) ForEachTransaction(ctx context.Context, address models.Address, fn func(tx *models.Transaction) error) error {
+	txs, err := p.GetTransactions(ctx, address)
+	if err != nil {
+		return err
+	}
+
+	for i := len(txs) - 1; i >= 0; i-- {
+		if p.failAfter > 0 && len(txs)-1-i == p.failAfter {
+			return errors.New("connection refused")
+		}
+
+		time.Sleep(p.delay)
+
+		err = fn(txs[i])
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// exportedHashes returns hashes of transactions exported in ndjson format
+func exportedHashes(t *testing.T, body string) []string {
+	hashes := make([]string, 0)
+	for _, line := range strings.Split(strings.TrimSpace(body), "\n") {
+		if line == "" {
+			continue
+		}
+
+		var record export.Record
+		assert.NoError(t, json.Unmarshal([]byte(line), &record))
+		hashes = append(hashes, record.Hash)
+	}
+
+	return hashes
+}
+
+func TestHandler_ExportTransactions(t *testing.T) {
+	type TestCase struct {
+		Name           string
+		Address        models.Address
+		FailAfter      int
+		ExpectedStatus int
+		ExpectedHashes []string
+	}
+
+	testCases := []TestCase{
+		{Name: "streamed in chronological order", Address: subscribedAddress, ExpectedStatus: http.StatusOK, ExpectedHashes: []string{"0x01", "0x02"}},
+		{Name: "not subscribed", Address: unsubscribedAddress, ExpectedStatus: http.StatusNotFound},
+		{Name: "storage failed in the middle", Address: subscribedAddress, FailAfter: 1, ExpectedStatus: http.StatusOK, ExpectedHashes: []string{"0x01"}},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			h, rawKey := newOpenAPITestHandler(t, false)
+			h.parsers[0].Parser = &iteratorParser{mockParser: h.parsers[0].Parser.(*mockParser), failAfter: testCase.FailAfter}
+
+			req := httptest.NewRequest(http.MethodGet, "http://localhost:8080/export/"+testCase.Address.String()+"?format=ndjson", nil)
+			req.Header.Set("Authorization", "Bearer "+rawKey)
+
+			recorder := httptest.NewRecorder()
+			h.newRouter().ServeHTTP(recorder, req)
+			assert.Equal(t, testCase.ExpectedStatus, recorder.Code, recorder.Body.String())
+
+			if testCase.ExpectedStatus == http.StatusOK {
+				assert.Equal(t, export.ContentType(export.NDJSONFormat), recorder.Header().Get("Content-Type"))
+				assert.Equal(t, testCase.ExpectedHashes, exportedHashes(t, recorder.Body.String()))
+			}
+		})
+	}
+}
+
+func TestHandler_ExportTransactions_WriteTimeout(t *testing.T) {
+	h, rawKey := newOpenAPITestHandler(t, false)
+	h.parsers[0].Parser = &iteratorParser{mockParser: h.parsers[0].Parser.(*mockParser), delay: 100 * time.Millisecond}
+
+	// Export takes longer than WriteTimeout of the server, it must not be cut off
+	server := httptest.NewUnstartedServer(h.newRouter())
+	server.Config.WriteTimeout = 50 * time.Millisecond
+	server.Start()
+	defer server.Close()
+
+	req, err := http.NewRequest(http.MethodGet, server.URL+"/export/"+subscribedAddress.String()+"?format=ndjson", nil)
+	assert.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+rawKey)
+
+	resp, err := server.Client().Do(req)
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, []string{"0x01", "0x02"}, exportedHashes(t, string(body)))
+}
@@ -5,59 +5,86 @@ import (
 	"encoding/json"
 	"errors"
 	"github.com/bluntenpassant/ethereum_subscriber/internal/app/models"
-	"github.com/bluntenpassant/ethereum_subscriber/internal/app/utils"
 	"github.com/gorilla/mux"
 	"net/http"
 )
 
-// swagger:parameters getTransactions
-type _ struct {
-	// Address
-	// in:path
-	Address string `json:"address"`
-}
+// Modes of rendering amounts in transactions
+const (
+	// rawAmounts renders amounts as JSON numbers in wei, JavaScript clients lose precision of them
+	rawAmounts = "raw"
+	// decimalAmounts renders amounts as decimal strings with values in ETH and gas prices in gwei
+	decimalAmounts = "decimal"
+)
 
-// swagger:model GetTransactionsResp
 type GetTransactionsResp struct {
-	Transactions []*models.Transaction `json:"transactions"`
+	Transactions []TransactionResp `json:"transactions"`
+}
+
+type GetDecimalTransactionsResp struct {
+	Transactions []DecimalTransactionResp `json:"transactions"`
 }
 
-// swagger:operation GET /get_transactions/{address} getTransactions
-// ---
-// summary: Get list of transaction by address that already listening
-// description: Returns all history of transactions for a given address since subscribe until memory storage is cleaned.
-// parameters:
-// - name: address
-//   in: path
-//   description: Ethereum address
-//   type: string
-//   required: true
-// responses:
-//   200:
-//     description: A list of transactions for the specified address
-//     schema:
-//       $ref: "#/definitions/GetTransactionsResp"
+type TransactionResp struct {
+	*models.Transaction
+	CounterpartyInfo
+	// DecodedCall is a call of contract decoded by its ABI, it is null if contract has no ABI or input is not a call of its function
+	DecodedCall *models.DecodedCall `json:"decodedCall"`
+}
+
+type DecimalTransactionResp struct {
+	*models.DecimalTransaction
+	CounterpartyInfo
+	DecodedCall *models.DecodedCall `json:"decodedCall"`
+}
+
+// CounterpartyInfo describes transaction relative to the subscriber whose transactions are requested
+type CounterpartyInfo struct {
+	// Direction is incoming, outgoing or self
+	Direction string `json:"direction"`
+	// Counterparty is the other side of transaction in EIP-55 checksum encoding, it is the subscriber itself for self transactions
+	// and empty for contract creation
+	Counterparty string `json:"counterparty"`
+	// CounterpartyLabels are labels of counterparty in address book of the tenant
+	CounterpartyLabels []string `json:"counterpartyLabels"`
+}
 
 func (h *Handler) getTransactions(w http.ResponseWriter, r *http.Request) {
-	ctx := context.Background()
+	ctx := r.Context()
 
 	vars := mux.Vars(r)
 	address, ok := vars["address"]
 	if !ok {
 		h.sendErrResponse(w, errors.New("address is not provided"), http.StatusBadRequest)
+		return
+	}
+
+	// v1 keeps raw amounts by default for backward compatibility
+	amounts, err := getAmounts(r, rawAmounts)
+	if err != nil {
+		h.sendErrResponse(w, err, http.StatusBadRequest)
+		return
 	}
 
-	address = utils.ClearString(address)
+	parser, err := h.getParser(r)
+	if err != nil {
+		h.sendErrResponse(w, err, http.StatusBadRequest)
+		return
+	}
 
-	transactions, err := h.parser.GetTransactions(ctx, address)
+	subscriberAddress, err := models.ParseAddress(address)
 	if err != nil {
 		h.sendErrResponse(w, err, http.StatusBadRequest)
 		return
 	}
 
-	resp := GetTransactionsResp{Transactions: transactions}
+	transactions, err := parser.GetTransactions(ctx, subscriberAddress)
+	if err != nil {
+		h.sendErrResponse(w, err, parserErrStatus(err))
+		return
+	}
 
-	respRaw, err := json.Marshal(resp)
+	respRaw, err := json.Marshal(h.transactionsResp(ctx, subscriberAddress, transactions, amounts))
 	if err != nil {
 		h.sendErrResponse(w, err, http.StatusInternalServerError)
 		return
@@ -65,3 +92,111 @@ func (h *Handler) getTransactions(w http.ResponseWriter, r *http.Request) {
 
 	h.sendOKResponse(w, respRaw)
 }
+
+// getAmounts returns mode of rendering amounts from amounts query parameter, defaultAmounts is used without the parameter
+func getAmounts(r *http.Request, defaultAmounts string) (string, error) {
+	amounts := r.URL.Query().Get("amounts")
+	if amounts == "" {
+		return defaultAmounts, nil
+	}
+
+	if amounts != rawAmounts && amounts != decimalAmounts {
+		return "", errors.New("unknown amounts " + amounts + ", should be one of: raw, decimal")
+	}
+
+	return amounts, nil
+}
+
+// transactionsResp returns response with checksum encoded transactions of subscriber rendered in amounts mode
+func (h *Handler) transactionsResp(ctx context.Context, subscriber models.Address, transactions []*models.Transaction, amounts string) interface{} {
+	counterparties := h.counterparties(ctx, subscriber, transactions)
+	calls := h.decodedCalls(ctx, transactions)
+	transactions = models.ChecksumTransactionsCopy(transactions)
+
+	if amounts == decimalAmounts {
+		resp := GetDecimalTransactionsResp{Transactions: make([]DecimalTransactionResp, 0, len(transactions))}
+		for i, tx := range h.decimalTransactions(ctx, transactions) {
+			resp.Transactions = append(resp.Transactions, DecimalTransactionResp{DecimalTransaction: tx, CounterpartyInfo: counterparties[i],
+				DecodedCall: calls[i]})
+		}
+
+		return resp
+	}
+
+	resp := GetTransactionsResp{Transactions: make([]TransactionResp, 0, len(transactions))}
+	for i, tx := range transactions {
+		resp.Transactions = append(resp.Transactions, TransactionResp{Transaction: tx, CounterpartyInfo: counterparties[i], DecodedCall: calls[i]})
+	}
+
+	return resp
+}
+
+// counterparties returns counterparty of every transaction relative to subscriber with labels from address book.
+// Transactions are returned without labels if address book fails, like transactions without USD values
+func (h *Handler) counterparties(ctx context.Context, subscriber models.Address, transactions []*models.Transaction) []CounterpartyInfo {
+	addresses := make([]models.Address, 0, len(transactions))
+	for _, tx := range transactions {
+		addresses = append(addresses, tx.Counterparty(subscriber))
+	}
+
+	labels, err := h.addressBook.LookupLabels(ctx, addresses)
+	if err != nil {
+		h.logger.WarnContext(ctx, "lookup of counterparty labels failed", "error", err.Error())
+	}
+
+	counterparties := make([]CounterpartyInfo, 0, len(transactions))
+	for i, tx := range transactions {
+		counterpartyLabels, ok := labels[addresses[i]]
+		if !ok {
+			counterpartyLabels = []string{}
+		}
+
+		counterparties = append(counterparties, CounterpartyInfo{
+			Direction:          tx.Direction(subscriber),
+			Counterparty:       addresses[i].Checksum(),
+			CounterpartyLabels: counterpartyLabels,
+		})
+	}
+
+	return counterparties
+}
+
+// decodedCalls returns calls of transactions decoded by ABIs of contracts, they are nil for transactions to contracts without ABI.
+// Transactions are returned without decoded calls if ABI registry fails, like transactions without USD values
+func (h *Handler) decodedCalls(ctx context.Context, transactions []*models.Transaction) []*models.DecodedCall {
+	calls, err := h.abiRegistry.DecodeCalls(ctx, transactions)
+	if err != nil {
+		h.logger.WarnContext(ctx, "decoding of contract calls failed", "error", err.Error())
+		return make([]*models.DecodedCall, len(transactions))
+	}
+
+	return calls
+}
+
+// decimalTransactions converts transactions into decimal representation and values them in USD if valuator is configured.
+// Transaction is returned without USD value if it can not be valued, e.g. price provider has no price at its block
+func (h *Handler) decimalTransactions(ctx context.Context, transactions []*models.Transaction) []*models.DecimalTransaction {
+	decimalTransactions := make([]*models.DecimalTransaction, 0, len(transactions))
+
+	var valuesUSD []string
+	var valueErrs []error
+	if h.valuator != nil {
+		valuesUSD, valueErrs = h.valuator.ValuesUSD(ctx, transactions)
+	}
+
+	for i, tx := range transactions {
+		decimalTx := models.NewDecimalTransaction(tx)
+
+		if h.valuator != nil {
+			if valueErrs[i] != nil {
+				h.logger.WarnContext(ctx, "valuation of transaction failed", "hash", tx.Hash, "error", valueErrs[i].Error())
+			} else {
+				decimalTx.ValueUSD = valuesUSD[i]
+			}
+		}
+
+		decimalTransactions = append(decimalTransactions, decimalTx)
+	}
+
+	return decimalTransactions
+}
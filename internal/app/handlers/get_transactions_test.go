@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestHandler_GetTransactions_RawAmounts checks raw amounts mode, it is the default of the first version of API
+// and is requested explicitly in the second one
+func TestHandler_GetTransactions_RawAmounts(t *testing.T) {
+	type TestCase struct {
+		Name string
+		Path string
+	}
+
+	transactionsPath := "/get_transactions/" + subscribedAddress.String()
+	subscriptionTransactionsPath := "/v2/subscriptions/" + subscribedAddress.String() + "/transactions"
+
+	testCases := []TestCase{
+		{Name: "without amounts", Path: transactionsPath},
+		{Name: "raw amounts", Path: transactionsPath + "?amounts=raw"},
+		{Name: "raw amounts v2", Path: subscriptionTransactionsPath + "?amounts=raw"},
+	}
+
+	h, rawKey := newOpenAPITestHandler(t, false)
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "http://localhost:8080"+testCase.Path, nil)
+			req.Header.Set("Authorization", "Bearer "+rawKey)
+
+			recorder := httptest.NewRecorder()
+			h.newRouter().ServeHTTP(recorder, req)
+			assert.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+
+			var resp struct {
+				Transactions []struct {
+					Value    json.RawMessage `json:"value"`
+					GasPrice json.RawMessage `json:"gasPrice"`
+					ValueEth *string         `json:"valueEth"`
+				} `json:"transactions"`
+			}
+			assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &resp))
+
+			// Amounts are JSON numbers in wei, they are not quoted
+			assert.Len(t, resp.Transactions, 2)
+			assert.Equal(t, json.RawMessage(new(big.Int).Lsh(big.NewInt(1), 70).String()), resp.Transactions[0].Value)
+			assert.Equal(t, json.RawMessage("30000000000"), resp.Transactions[0].GasPrice)
+			assert.Nil(t, resp.Transactions[0].ValueEth)
+		})
+	}
+}
+
+func TestHandler_GetSubscriptionTransactions_DecimalAmountsByDefault(t *testing.T) {
+	h, rawKey := newOpenAPITestHandler(t, false)
+
+	req := httptest.NewRequest(http.MethodGet, "http://localhost:8080/v2/subscriptions/"+subscribedAddress.String()+"/transactions", nil)
+	req.Header.Set("Authorization", "Bearer "+rawKey)
+
+	recorder := httptest.NewRecorder()
+	h.newRouter().ServeHTTP(recorder, req)
+	assert.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+
+	var resp struct {
+		Transactions []struct {
+			Value    json.RawMessage `json:"value"`
+			ValueEth *string         `json:"valueEth"`
+		} `json:"transactions"`
+	}
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &resp))
+
+	// Amounts are decimal strings
+	assert.Len(t, resp.Transactions, 2)
+	assert.Equal(t, json.RawMessage(`"`+new(big.Int).Lsh(big.NewInt(1), 70).String()+`"`), resp.Transactions[0].Value)
+	assert.Equal(t, "1180.591620717411303424", *resp.Transactions[0].ValueEth)
+}
@@ -1,65 +1,235 @@
-// Ethereum Subscriber Api:
-// version: 0.0.1
-// title: Ethereum Subscriber Api
-// Schemes: http, https
-// Host: localhost:8080
-// BasePath: /
-// Produces:
-// - application/json
-//
-// swagger:meta
-
 package handlers
 
 import (
 	"context"
+	"embed"
+	"encoding/json"
+	"errors"
 	"github.com/bluntenpassant/ethereum_subscriber/config"
 	"github.com/bluntenpassant/ethereum_subscriber/internal/app/models"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/service/config_reloader"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/service/health_checker"
 	"github.com/go-openapi/runtime/middleware"
 	"github.com/gorilla/mux"
-	"log"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"log/slog"
+	"net"
 	"net/http"
+	"sync"
 	"time"
 )
 
+// staticFiles are embedded to serve API docs regardless of working directory
+//
+//go:embed openapi.yaml
+var staticFiles embed.FS
+
 type Parser interface {
 	GetCurrentBlock(ctx context.Context) (uint64, error)
-	GetTransactions(ctx context.Context, address string) ([]*models.Transaction, error)
-	Subscribe(ctx context.Context, address string) error
+	GetTransactions(ctx context.Context, address models.Address) ([]*models.Transaction, error)
+	Subscribe(ctx context.Context, address models.Address, ensName string) error
+	Unsubscribe(ctx context.Context, address models.Address) error
+	GetSubscriber(ctx context.Context, address models.Address) (models.Subscriber, error)
+	GetSubscribers(ctx context.Context) ([]models.Subscriber, error)
+}
+
+type ENSResolver interface {
+	Resolve(ctx context.Context, name string) (models.Address, error)
+}
+
+// SubscriptionQuota subscribes addresses within maximum count of subscriptions of a tenant on all chains
+type SubscriptionQuota interface {
+	Subscribe(ctx context.Context, chainID uint64, address models.Address, ensName string) error
+}
+
+type HealthChecker interface {
+	Check(ctx context.Context) health_checker.Report
+}
+
+type ConfigProvider interface {
+	Status() config_reloader.Status
+}
+
+type Valuator interface {
+	ValuesUSD(ctx context.Context, transactions []*models.Transaction) ([]string, []error)
+}
+
+type AddressBook interface {
+	SetLabels(ctx context.Context, address models.Address, labels []string) (models.AddressLabels, error)
+	GetLabels(ctx context.Context, address models.Address) (models.AddressLabels, error)
+	ListLabels(ctx context.Context) ([]models.AddressLabels, error)
+	DeleteLabels(ctx context.Context, address models.Address) error
+	LookupLabels(ctx context.Context, addresses []models.Address) (map[models.Address][]string, error)
+}
+
+type ABIRegistry interface {
+	SetABI(ctx context.Context, chainID uint64, address models.Address, raw json.RawMessage) (models.ContractABI, error)
+	GetABI(ctx context.Context, chainID uint64, address models.Address) (models.ContractABI, error)
+	ListABIs(ctx context.Context, chainID uint64) ([]models.ContractABI, error)
+	DeleteABI(ctx context.Context, chainID uint64, address models.Address) error
+	DecodeCalls(ctx context.Context, transactions []*models.Transaction) ([]*models.DecodedCall, error)
 }
 
 type Handler struct {
-	parser Parser
+	// parsers are parsers of configured chains, the first one is the default chain
+	parsers        []ChainParser
+	ensResolver    ENSResolver
+	healthChecker  HealthChecker
+	configProvider ConfigProvider
+	valuator       Valuator
+	addressBook    AddressBook
+	abiRegistry    ABIRegistry
+	authenticator  Authenticator
+	rateLimiter    RateLimiter
+	// subscriptionQuota subscribes addresses of all routes, so tenants do not exceed maximum count of subscriptions
+	subscriptionQuota SubscriptionQuota
+	// graphqlHandler serves /graphql, it is nil if GraphQL endpoint is disabled
+	graphqlHandler http.Handler
+	logger         *slog.Logger
+
+	// inFlight counts requests that are being handled, server waits for them before Start returns
+	inFlight sync.WaitGroup
 }
 
-func NewHandler(parser Parser) *Handler {
+// NewHandler returns Handler of HTTP API serving parsers of all chains, the first parser is used when chain is not selected.
+// Valuator may be nil if price provider is not configured, then transactions are returned without USD values.
+// GraphQL handler may be nil if GraphQL endpoint is disabled
+func NewHandler(parsers []ChainParser, ensResolver ENSResolver, healthChecker HealthChecker, configProvider ConfigProvider, valuator Valuator,
+	addressBook AddressBook, abiRegistry ABIRegistry, authenticator Authenticator, rateLimiter RateLimiter, subscriptionQuota SubscriptionQuota,
+	graphqlHandler http.Handler, logger *slog.Logger) *Handler {
 	return &Handler{
-		parser: parser,
+		parsers:           parsers,
+		ensResolver:       ensResolver,
+		healthChecker:     healthChecker,
+		configProvider:    configProvider,
+		valuator:          valuator,
+		addressBook:       addressBook,
+		abiRegistry:       abiRegistry,
+		authenticator:     authenticator,
+		rateLimiter:       rateLimiter,
+		subscriptionQuota: subscriptionQuota,
+		graphqlHandler:    graphqlHandler,
+		logger:            logger,
 	}
 }
 
-func (h *Handler) Start(httpConfig config.Http) {
+// defaultShutdownTimeout is used by Start when shutdown timeout is not configured
+const defaultShutdownTimeout = 15 * time.Second
+
+// newRouter returns router of all routes of HTTP API, they are documented in openapi.yaml
+func (h *Handler) newRouter() *mux.Router {
 	r := mux.NewRouter()
+	r.Use(h.tracingMiddleware, h.loggingMiddleware, h.metricsMiddleware, h.authMiddleware, h.rateLimitMiddleware)
+	r.Handle("/metrics", promhttp.Handler())
 	r.HandleFunc("/subscribe/{address}", h.subscribe)
 	r.HandleFunc("/get_current_block", h.getCurrentBlock)
 	r.HandleFunc("/get_transactions/{address}", h.getTransactions)
+	r.HandleFunc("/export/{address}", h.exportTransactions)
+	r.HandleFunc("/get_balance/{address}", h.getBalance)
+	r.HandleFunc("/get_balance_history/{address}", h.getBalanceHistory)
+	r.HandleFunc("/chains", h.getChains)
+	r.HandleFunc("/healthz", h.healthz)
+	r.HandleFunc("/readyz", h.readyz)
+	r.HandleFunc("/admin/config", h.adminConfig).Methods(http.MethodGet)
+	r.HandleFunc("/admin/keys", h.createAPIKey).Methods(http.MethodPost)
+	r.HandleFunc("/admin/keys", h.getAPIKeys).Methods(http.MethodGet)
+	r.HandleFunc("/admin/keys/{id}", h.revokeAPIKey).Methods(http.MethodDelete)
+	h.registerV2Routes(r)
+	if h.graphqlHandler != nil {
+		r.Handle("/graphql", h.graphqlHandler).Methods(http.MethodPost)
+	}
+	r.NotFoundHandler = http.HandlerFunc(h.notFound)
+	r.MethodNotAllowedHandler = http.HandlerFunc(h.methodNotAllowed)
 
 	// This will serve files under http://localhost:8000/static/<filename>
-	r.PathPrefix("/static/").Handler(http.StripPrefix("/static/", http.FileServer(http.Dir("./internal/app/handlers"))))
+	r.PathPrefix("/static/").Handler(http.StripPrefix("/static/", http.FileServer(http.FS(staticFiles))))
 
-	opts := middleware.SwaggerUIOpts{SpecURL: "/static/swagger.yaml"}
+	opts := middleware.SwaggerUIOpts{SpecURL: "/static/openapi.yaml"}
 	sh := middleware.SwaggerUI(opts, nil)
 	r.Handle("/docs", sh)
 
+	return r
+}
+
+// Start serves HTTP requests until server fails or ctx is done.
+// When ctx is done server stops accepting new connections and waits for in-flight requests during shutdown timeout,
+// requests that are not finished in time get their contexts cancelled, so parsers stop scanning blocks.
+// Start returns only after all handlers returned, so it is safe to close storages after it
+func (h *Handler) Start(ctx context.Context, httpConfig config.Http) error {
+	listener, err := net.Listen("tcp", httpConfig.Host+":"+httpConfig.Port)
+	if err != nil {
+		return err
+	}
+
+	return h.serve(ctx, listener, httpConfig.ShutdownTimeout)
+}
+
+func (h *Handler) serve(ctx context.Context, listener net.Listener, shutdownTimeout time.Duration) error {
+	r := h.newRouter()
+
+	// Contexts of all requests are derived from requestsCtx, it is cancelled if requests are not finished in shutdown timeout
+	requestsCtx, cancelRequests := context.WithCancel(context.Background())
+	defer cancelRequests()
+
 	srv := &http.Server{
-		Handler: r,
-		Addr:    httpConfig.Host + ":" + httpConfig.Port,
+		Handler: h.trackInFlight(r),
 		// Good practice: enforce timeouts for servers you create!
 		WriteTimeout: 15 * time.Second,
 		ReadTimeout:  15 * time.Second,
+		BaseContext: func(net.Listener) context.Context {
+			return requestsCtx
+		},
+	}
+
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- srv.Serve(listener)
+	}()
+
+	h.logger.Info("HTTP Server started", "addr", listener.Addr().String())
+
+	select {
+	case err := <-serveErr:
+		return err
+	case <-ctx.Done():
+	}
+
+	if shutdownTimeout <= 0 {
+		shutdownTimeout = defaultShutdownTimeout
+	}
+
+	h.logger.Info("HTTP Server is shutting down", "timeout", shutdownTimeout)
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	err := srv.Shutdown(shutdownCtx)
+	if err != nil {
+		h.logger.Warn("in-flight requests are not finished in shutdown timeout, cancelling them", "error", err.Error())
+
+		cancelRequests()
+		err = srv.Close()
+		if err != nil {
+			return errors.New("closing HTTP Server failed cause: " + err.Error())
+		}
 	}
 
-	log.Fatal(srv.ListenAndServe())
+	// Close does not wait for handlers, so we wait until cancelled handlers return
+	h.inFlight.Wait()
+
+	h.logger.Info("HTTP Server stopped")
+
+	return nil
+}
+
+// trackInFlight counts requests that are being handled in inFlight
+func (h *Handler) trackInFlight(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h.inFlight.Add(1)
+		defer h.inFlight.Done()
+
+		next.ServeHTTP(w, r)
+	})
 }
 
 func (h *Handler) sendOKResponse(w http.ResponseWriter, resp []byte) {
@@ -69,6 +239,7 @@ func (h *Handler) sendOKResponse(w http.ResponseWriter, resp []byte) {
 }
 
 func (h *Handler) sendErrResponse(w http.ResponseWriter, err error, status int) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
 	w.WriteHeader(status)
 	w.Write([]byte(err.Error()))
 }
@@ -0,0 +1,107 @@
+package handlers
+
+import (
+	"context"
+	"github.com/bluntenpassant/ethereum_subscriber/config"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/repository/memory_repository"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/service/authenticator"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/service/rate_limiter"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestHandler_Start_Shutdown(t *testing.T) {
+	type TestCase struct {
+		Name            string
+		ShutdownTimeout time.Duration
+		// HandlerDuration is how long request is handled if its context is not cancelled
+		HandlerDuration   time.Duration
+		ExpectedCancelled bool
+	}
+
+	testCases := []TestCase{
+		{Name: "request finishes in shutdown timeout", ShutdownTimeout: 5 * time.Second, HandlerDuration: 100 * time.Millisecond},
+		{Name: "request is cancelled after shutdown timeout", ShutdownTimeout: 100 * time.Millisecond, HandlerDuration: time.Minute,
+			ExpectedCancelled: true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			started := make(chan struct{})
+			var cancelled, returned atomic.Bool
+
+			// Slow request is served by GraphQL route, it reports whether its context was cancelled
+			slowHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				defer returned.Store(true)
+				close(started)
+
+				select {
+				case <-time.After(testCase.HandlerDuration):
+				case <-r.Context().Done():
+					cancelled.Store(true)
+					// Cleanup after cancellation takes time, Start should wait for it
+					time.Sleep(50 * time.Millisecond)
+					return
+				}
+
+				w.Write([]byte(`{}`))
+			})
+
+			logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+			h := NewHandler(nil, nil, nil, nil, nil, nil, nil, authenticator.NewAuthenticator(config.Auth{}, memory_repository.NewAPIKeyRepository()),
+				rate_limiter.NewLimiter(config.RateLimit{}), nil, slowHandler, logger)
+
+			listener, err := net.Listen("tcp", "127.0.0.1:0")
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			stopped := make(chan error, 1)
+			go func() {
+				stopped <- h.serve(ctx, listener, testCase.ShutdownTimeout)
+			}()
+
+			respErr := make(chan error, 1)
+			go func() {
+				resp, err := http.Post("http://"+listener.Addr().String()+"/graphql", "application/json", strings.NewReader(`{}`))
+				if err == nil {
+					resp.Body.Close()
+				}
+				respErr <- err
+			}()
+
+			<-started
+			shutdownAt := time.Now()
+			cancel()
+
+			select {
+			case err = <-stopped:
+				assert.NoError(t, err)
+			case <-time.After(testCase.ShutdownTimeout + 5*time.Second):
+				t.Fatal("server is not stopped")
+			}
+
+			// Start returns only after handler returned
+			assert.True(t, returned.Load())
+			assert.Equal(t, testCase.ExpectedCancelled, cancelled.Load())
+			assert.Less(t, time.Since(shutdownAt), testCase.ShutdownTimeout+time.Second)
+
+			err = <-respErr
+			if testCase.ExpectedCancelled {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
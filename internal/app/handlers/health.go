@@ -0,0 +1,40 @@
+package handlers
+
+import (
+	"encoding/json"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/service/health_checker"
+	"net/http"
+)
+
+type HealthResp struct {
+	Status string `json:"status"`
+}
+
+func (h *Handler) healthz(w http.ResponseWriter, r *http.Request) {
+	respRaw, err := json.Marshal(HealthResp{Status: health_checker.StatusOK})
+	if err != nil {
+		h.sendErrResponse(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	h.sendOKResponse(w, respRaw)
+}
+
+func (h *Handler) readyz(w http.ResponseWriter, r *http.Request) {
+	report := h.healthChecker.Check(r.Context())
+
+	respRaw, err := json.Marshal(report)
+	if err != nil {
+		h.sendErrResponse(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	status := http.StatusOK
+	if report.Status != health_checker.StatusOK {
+		status = http.StatusServiceUnavailable
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(respRaw)
+}
@@ -0,0 +1,120 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/models"
+	"github.com/gorilla/mux"
+	"net/http"
+	"time"
+)
+
+// Labels are kept in address book of the tenant and shared by all chains, so label routes do not accept chain parameter
+
+type SetLabelsReq struct {
+	// Labels of the address, like name of exchange, ID of customer or name of contract. They replace current labels
+	Labels []string `json:"labels"`
+}
+
+type AddressLabelsResp struct {
+	// Labelled address in EIP-55 checksum encoding
+	Address   string    `json:"address"`
+	Labels    []string  `json:"labels"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type GetAddressLabelsResp struct {
+	Labels []AddressLabelsResp `json:"labels"`
+}
+
+func (h *Handler) setLabels(w http.ResponseWriter, r *http.Request) {
+	address, ok := h.getV2PathAddress(w, r)
+	if !ok {
+		return
+	}
+
+	req := SetLabelsReq{}
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		h.sendV2ErrResponse(w, CodeInvalidRequest, errors.New("invalid request body cause: "+err.Error()), http.StatusBadRequest)
+		return
+	}
+
+	labels, err := h.addressBook.SetLabels(r.Context(), address, req.Labels)
+	if err != nil {
+		h.sendV2ErrResponse(w, CodeInvalidRequest, err, http.StatusBadRequest)
+		return
+	}
+
+	h.sendV2Response(w, newAddressLabelsResp(labels))
+}
+
+func (h *Handler) getAllLabels(w http.ResponseWriter, r *http.Request) {
+	labels, err := h.addressBook.ListLabels(r.Context())
+	if err != nil {
+		h.sendV2ErrResponse(w, CodeInternal, err, http.StatusInternalServerError)
+		return
+	}
+
+	resp := GetAddressLabelsResp{Labels: make([]AddressLabelsResp, 0, len(labels))}
+	for _, addressLabels := range labels {
+		resp.Labels = append(resp.Labels, newAddressLabelsResp(addressLabels))
+	}
+
+	h.sendV2Response(w, resp)
+}
+
+func (h *Handler) getLabels(w http.ResponseWriter, r *http.Request) {
+	address, ok := h.getV2PathAddress(w, r)
+	if !ok {
+		return
+	}
+
+	labels, err := h.addressBook.GetLabels(r.Context(), address)
+	if err != nil {
+		h.sendLabelsErrResponse(w, err)
+		return
+	}
+
+	h.sendV2Response(w, newAddressLabelsResp(labels))
+}
+
+func (h *Handler) deleteLabels(w http.ResponseWriter, r *http.Request) {
+	address, ok := h.getV2PathAddress(w, r)
+	if !ok {
+		return
+	}
+
+	err := h.addressBook.DeleteLabels(r.Context(), address)
+	if err != nil {
+		h.sendLabelsErrResponse(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// getV2PathAddress returns address of v2 route with address in path, error response is written if it is invalid
+func (h *Handler) getV2PathAddress(w http.ResponseWriter, r *http.Request) (models.Address, bool) {
+	address, err := models.ParseAddress(mux.Vars(r)["address"])
+	if err != nil {
+		h.sendV2ErrResponse(w, CodeInvalidAddress, err, http.StatusBadRequest)
+		return "", false
+	}
+
+	return address, true
+}
+
+// sendLabelsErrResponse writes error returned by address book, address without labels gives 404
+func (h *Handler) sendLabelsErrResponse(w http.ResponseWriter, err error) {
+	if errors.Is(err, models.ErrLabelsNotFound) {
+		h.sendV2ErrResponse(w, CodeLabelsNotFound, err, http.StatusNotFound)
+		return
+	}
+
+	h.sendV2ErrResponse(w, CodeInternal, err, http.StatusInternalServerError)
+}
+
+func newAddressLabelsResp(labels models.AddressLabels) AddressLabelsResp {
+	return AddressLabelsResp{Address: labels.Address.Checksum(), Labels: labels.Labels, UpdatedAt: labels.UpdatedAt}
+}
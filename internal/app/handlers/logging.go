@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/logger"
+	"github.com/gorilla/mux"
+	"net/http"
+	"time"
+)
+
+// requestIDHeader is a header that carries request ID in requests and responses
+const requestIDHeader = "X-Request-ID"
+
+// maxRequestIDLength limits request ID provided by client, longer IDs are replaced by generated ones
+const maxRequestIDLength = 64
+
+// loggingMiddleware attaches request ID to request context and logs every request after it is handled.
+// Request ID is taken from X-Request-ID header if client provided it, otherwise it is generated,
+// in both cases it is returned in X-Request-ID response header. Every log record made with request context
+// (parsers, JSON-RPC client, redis commands) contains the same request ID
+func (h *Handler) loggingMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+
+		requestID := r.Header.Get(requestIDHeader)
+		if !isValidRequestID(requestID) {
+			requestID = logger.NewRequestID()
+		}
+
+		w.Header().Set(requestIDHeader, requestID)
+		r = r.WithContext(logger.WithRequestID(r.Context(), requestID))
+
+		recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+
+		next.ServeHTTP(recorder, r)
+
+		route := unknownRoute
+		if currentRoute := mux.CurrentRoute(r); currentRoute != nil {
+			if template, err := currentRoute.GetPathTemplate(); err == nil {
+				route = template
+			}
+		}
+
+		h.logger.InfoContext(r.Context(), "http request", "method", r.Method, "route", route, "path", r.URL.Path,
+			"status", recorder.status, "duration", time.Since(start))
+	})
+}
+
+// isValidRequestID checks that request ID provided by client is not empty, not too long and contains only printable ASCII
+func isValidRequestID(requestID string) bool {
+	if requestID == "" || len(requestID) > maxRequestIDLength {
+		return false
+	}
+
+	for _, c := range requestID {
+		if c < '!' || c > '~' {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/metrics"
+	"github.com/gorilla/mux"
+	"net/http"
+	"time"
+)
+
+// unknownRoute is a route label for requests that did not match any route,
+// raw path is not used as a label to keep metrics cardinality bounded
+const unknownRoute = "unknown"
+
+// statusRecorder remembers status code written by handler
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// Unwrap returns the wrapped writer, so http.ResponseController reaches deadlines and flushing of the connection
+func (r *statusRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
+
+// metricsMiddleware records every HTTP request with its route template (like /get_transactions/{address}),
+// method, status code and latency
+func (h *Handler) metricsMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+
+		next.ServeHTTP(recorder, r)
+
+		route := unknownRoute
+		if currentRoute := mux.CurrentRoute(r); currentRoute != nil {
+			if template, err := currentRoute.GetPathTemplate(); err == nil {
+				route = template
+			}
+		}
+
+		metrics.ObserveHTTPRequest(route, r.Method, recorder.status, time.Since(start))
+	})
+}
@@ -0,0 +1,539 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"github.com/bluntenpassant/ethereum_subscriber/config"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/graphql_handlers"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/models"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/repository/memory_repository"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/service/abi_registry"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/service/address_book"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/service/authenticator"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/service/config_reloader"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/service/health_checker"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/service/rate_limiter"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/service/subscription_quota"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/tenant"
+	"github.com/bluntenpassant/ethereum_subscriber/pkg/client"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"log/slog"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+const (
+	testAdminKey = "esk_admin"
+
+	subscribedAddress   = models.Address("0x45849a974058661eb2128aceb60d2c6ed99e2a14")
+	unsubscribedAddress = models.Address("0x00000000219ab540356cbb839cbe05303d7705fa")
+
+	transferABI = `[{"type":"function","name":"transfer","inputs":[{"name":"to","type":"address"},{"name":"value","type":"uint256"}]}]`
+)
+
+// mockParser keeps subscribers of all tenants in memory like parsers do, subscribedAddress is subscribed by tenant_a.
+// It returns the same transactions and balances for every subscriber
+type mockParser struct {
+	mx           sync.Mutex
+	currentBlock uint64
+	subscribers  map[models.SubscriberKey]models.Subscriber
+	transactions []*models.Transaction
+	history      []models.Balance
+	// countErr is returned by CountSubscribers, it imitates failure of storage
+	countErr error
+}
+
+func newMockParser() *mockParser {
+	return &mockParser{
+		currentBlock: 16614490,
+		subscribers: map[models.SubscriberKey]models.Subscriber{
+			{TenantID: "tenant_a", Address: subscribedAddress}: {ChainID: 1, Address: subscribedAddress, TenantID: "tenant_a", ENSName: "vitalik.eth"},
+		},
+		transactions: []*models.Transaction{
+			{ChainID: 1, BlockHash: "0xb1", BlockNumber: 16614490, Hash: "0x02", From: subscribedAddress.String(),
+				To: unsubscribedAddress.String(), Gas: *big.NewInt(21000), Input: "0xa9059cbb" +
+					"00000000000000000000000028c6c06298d514db089934071355e5743bf21d60" +
+					"00000000000000000000000000000000000000000000000000000000000f4240", GasPrice: *big.NewInt(30000000000),
+				Value: *new(big.Int).Lsh(big.NewInt(1), 70), V: *big.NewInt(37), R: *big.NewInt(1), S: *big.NewInt(2)},
+			{ChainID: 1, BlockHash: "0xb0", BlockNumber: 16614480, Hash: "0x01", From: unsubscribedAddress.String(),
+				Value: *big.NewInt(1000000000000000000), Input: "0x60806040"},
+		},
+		history: []models.Balance{{BlockNumber: 16614480, Balance: *big.NewInt(1)}, {BlockNumber: 16614490, Balance: *big.NewInt(2)}},
+	}
+}
+
+func (m *mockParser) GetCurrentBlock(ctx context.Context) (uint64, error) {
+	return m.currentBlock, nil
+}
+
+func (m *mockParser) GetTransactions(ctx context.Context, address models.Address) ([]*models.Transaction, error) {
+	if _, err := m.GetSubscriber(ctx, address); err != nil {
+		return nil, err
+	}
+
+	return m.transactions, nil
+}
+
+func (m *mockParser) Subscribe(ctx context.Context, address models.Address, ensName string) error {
+	m.mx.Lock()
+	defer m.mx.Unlock()
+
+	key := tenant.Key(ctx, address)
+	if _, ok := m.subscribers[key]; ok {
+		return models.ErrAlreadySubscribed
+	}
+
+	m.subscribers[key] = models.Subscriber{ChainID: 1, Address: address, TenantID: key.TenantID, ENSName: ensName}
+
+	return nil
+}
+
+func (m *mockParser) Unsubscribe(ctx context.Context, address models.Address) error {
+	m.mx.Lock()
+	defer m.mx.Unlock()
+
+	key := tenant.Key(ctx, address)
+	if _, ok := m.subscribers[key]; !ok {
+		return models.ErrNotSubscribed
+	}
+
+	delete(m.subscribers, key)
+
+	return nil
+}
+
+func (m *mockParser) GetSubscriber(ctx context.Context, address models.Address) (models.Subscriber, error) {
+	m.mx.Lock()
+	defer m.mx.Unlock()
+
+	subscriber, ok := m.subscribers[tenant.Key(ctx, address)]
+	if !ok {
+		return models.Subscriber{}, models.ErrNotSubscribed
+	}
+
+	return subscriber, nil
+}
+
+func (m *mockParser) GetSubscribers(ctx context.Context) ([]models.Subscriber, error) {
+	m.mx.Lock()
+	defer m.mx.Unlock()
+
+	subscribers := make([]models.Subscriber, 0, len(m.subscribers))
+	for _, subscriber := range m.subscribers {
+		subscribers = append(subscribers, subscriber)
+	}
+
+	return subscribers, nil
+}
+
+func (m *mockParser) CountSubscribers(ctx context.Context) (int, error) {
+	m.mx.Lock()
+	defer m.mx.Unlock()
+
+	if m.countErr != nil {
+		return 0, m.countErr
+	}
+
+	count := 0
+	for key := range m.subscribers {
+		if key.TenantID == tenant.FromContext(ctx) {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+func (m *mockParser) GetBalance(ctx context.Context, address models.Address) (models.Balance, error) {
+	if _, err := m.GetSubscriber(ctx, address); err != nil {
+		return models.Balance{}, err
+	}
+
+	return m.history[len(m.history)-1], nil
+}
+
+func (m *mockParser) GetBalanceHistory(ctx context.Context, address models.Address) ([]models.Balance, error) {
+	if _, err := m.GetSubscriber(ctx, address); err != nil {
+		return nil, err
+	}
+
+	return m.history, nil
+}
+
+type mockENSResolver struct{}
+
+func (m *mockENSResolver) Resolve(ctx context.Context, name string) (models.Address, error) {
+	if name != "vitalik.eth" {
+		return "", errors.New("name " + name + " is not resolved")
+	}
+
+	return unsubscribedAddress, nil
+}
+
+type mockHealthChecker struct{}
+
+func (m *mockHealthChecker) Check(ctx context.Context) health_checker.Report {
+	return health_checker.Report{
+		Status: health_checker.StatusFail,
+		Components: map[string]health_checker.ComponentStatus{
+			"ethereum_jsonrpc": {Status: health_checker.StatusOK},
+			"indexing_lag":     {Status: health_checker.StatusFail, Details: "lag is 20 blocks", Error: "lag is over 10 blocks"},
+		},
+	}
+}
+
+type mockConfigProvider struct{}
+
+func (m *mockConfigProvider) Status() config_reloader.Status {
+	return config_reloader.Status{ReloadedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), ReloadError: "invalid config"}
+}
+
+type mockValuator struct{}
+
+func (m *mockValuator) ValuesUSD(ctx context.Context, transactions []*models.Transaction) ([]string, []error) {
+	values := make([]string, len(transactions))
+	for i := range values {
+		values[i] = "2300.15"
+	}
+
+	return values, make([]error, len(transactions))
+}
+
+// newOpenAPITestHandler returns handler with enabled auth and GraphQL endpoint and raw API key of a tenant,
+// the tenant has labelled unsubscribedAddress and uploaded its ABI. All requests are rate limited if rateLimited is set
+func newOpenAPITestHandler(t *testing.T, rateLimited bool) (*Handler, string) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	keyAuthenticator := authenticator.NewAuthenticator(config.Auth{Enabled: true, AdminKey: testAdminKey}, memory_repository.NewAPIKeyRepository())
+	_, rawKey, err := keyAuthenticator.CreateKey(context.Background(), "tenant_a")
+	assert.NoError(t, err)
+
+	parser := newMockParser()
+	chain := models.Chain{ID: 1, Name: "ethereum", NativeCurrency: "ETH"}
+
+	// Burst 0 rejects every request
+	rateLimiter := rate_limiter.NewLimiter(config.RateLimit{Enabled: rateLimited, RequestsPerSecond: 1})
+
+	graphqlHandler, err := graphql_handlers.NewHandler([]graphql_handlers.ChainParser{{Chain: chain, Parser: parser}},
+		config.GraphQL{Enabled: true, MaxDepth: 6}, rateLimiter, logger)
+	assert.NoError(t, err)
+
+	addressBook := address_book.NewAddressBook(memory_repository.NewAddressLabelsRepository())
+	_, err = addressBook.SetLabels(tenant.WithID(context.Background(), "tenant_a"), unsubscribedAddress, []string{"deposit contract"})
+	assert.NoError(t, err)
+
+	abiRegistry := abi_registry.NewRegistry(memory_repository.NewContractABIRepository())
+	_, err = abiRegistry.SetABI(tenant.WithID(context.Background(), "tenant_a"), chain.ID, unsubscribedAddress, []byte(transferABI))
+	assert.NoError(t, err)
+
+	subscriptionQuota := subscription_quota.NewQuota(map[uint64]subscription_quota.Parser{chain.ID: parser}, rateLimiter)
+
+	return NewHandler([]ChainParser{{Chain: chain, Parser: parser}}, &mockENSResolver{}, &mockHealthChecker{}, &mockConfigProvider{}, &mockValuator{},
+		addressBook, abiRegistry, keyAuthenticator, rateLimiter, subscriptionQuota, graphqlHandler, logger), rawKey
+}
+
+func loadOpenAPISpec(t *testing.T) *openapi3.T {
+	spec, err := openapi3.NewLoader().LoadFromData(openAPISpec(t))
+	assert.NoError(t, err)
+	assert.NoError(t, spec.Validate(context.Background()))
+
+	return spec
+}
+
+func openAPISpec(t *testing.T) []byte {
+	spec, err := staticFiles.ReadFile("openapi.yaml")
+	assert.NoError(t, err)
+
+	return spec
+}
+
+func init() {
+	// Exported files are validated only by content type, their schemas are plain strings
+	openapi3filter.RegisterBodyDecoder("text/csv", openapi3filter.FileBodyDecoder)
+	openapi3filter.RegisterBodyDecoder("application/x-ndjson", openapi3filter.FileBodyDecoder)
+	openapi3filter.RegisterBodyDecoder("application/vnd.apache.parquet", openapi3filter.FileBodyDecoder)
+}
+
+func TestOpenAPI_Responses(t *testing.T) {
+	type TestCase struct {
+		Name           string
+		Method         string
+		Path           string
+		Body           string
+		Key            string
+		RateLimited    bool
+		ExpectedStatus int
+	}
+
+	const tenantKey = "tenant"
+
+	testCases := []TestCase{
+		{Name: "subscribe", Method: http.MethodGet, Path: "/subscribe/" + unsubscribedAddress.String(), Key: tenantKey, ExpectedStatus: http.StatusOK},
+		{Name: "subscribe ens name", Method: http.MethodGet, Path: "/subscribe/vitalik.eth", Key: tenantKey, ExpectedStatus: http.StatusOK},
+		{Name: "subscribe invalid address", Method: http.MethodGet, Path: "/subscribe/0x1234", Key: tenantKey, ExpectedStatus: http.StatusBadRequest},
+		{Name: "subscribe twice", Method: http.MethodGet, Path: "/subscribe/" + subscribedAddress.String(), Key: tenantKey, ExpectedStatus: http.StatusConflict},
+		{Name: "subscribe without key", Method: http.MethodGet, Path: "/subscribe/" + unsubscribedAddress.String(), ExpectedStatus: http.StatusUnauthorized},
+		{Name: "subscribe rate limited", Method: http.MethodGet, Path: "/subscribe/" + unsubscribedAddress.String(), Key: tenantKey, RateLimited: true,
+			ExpectedStatus: http.StatusTooManyRequests},
+		{Name: "current block", Method: http.MethodGet, Path: "/get_current_block", Key: tenantKey, ExpectedStatus: http.StatusOK},
+		{Name: "current block unknown chain", Method: http.MethodGet, Path: "/get_current_block?chain=base", Key: tenantKey, ExpectedStatus: http.StatusBadRequest},
+		{Name: "transactions", Method: http.MethodGet, Path: "/get_transactions/" + subscribedAddress.String() + "?amounts=decimal", Key: tenantKey,
+			ExpectedStatus: http.StatusOK},
+		{Name: "transactions raw amounts", Method: http.MethodGet, Path: "/get_transactions/" + subscribedAddress.String(), Key: tenantKey,
+			ExpectedStatus: http.StatusOK},
+		{Name: "transactions not subscribed", Method: http.MethodGet, Path: "/get_transactions/" + unsubscribedAddress.String() + "?amounts=decimal",
+			Key: tenantKey, ExpectedStatus: http.StatusNotFound},
+		{Name: "export csv", Method: http.MethodGet, Path: "/export/" + subscribedAddress.String(), Key: tenantKey, ExpectedStatus: http.StatusOK},
+		{Name: "export ndjson", Method: http.MethodGet, Path: "/export/" + subscribedAddress.String() + "?format=ndjson", Key: tenantKey, ExpectedStatus: http.StatusOK},
+		{Name: "export parquet", Method: http.MethodGet, Path: "/export/" + subscribedAddress.String() + "?format=parquet", Key: tenantKey, ExpectedStatus: http.StatusOK},
+		{Name: "balance", Method: http.MethodGet, Path: "/get_balance/" + subscribedAddress.String(), Key: tenantKey, ExpectedStatus: http.StatusOK},
+		{Name: "balance history", Method: http.MethodGet, Path: "/get_balance_history/" + subscribedAddress.String(), Key: tenantKey, ExpectedStatus: http.StatusOK},
+		{Name: "chains", Method: http.MethodGet, Path: "/chains", Key: tenantKey, ExpectedStatus: http.StatusOK},
+		{Name: "subscriptions", Method: http.MethodGet, Path: "/v2/subscriptions", Key: tenantKey, ExpectedStatus: http.StatusOK},
+		{Name: "create subscription", Method: http.MethodPost, Path: "/v2/subscriptions", Body: `{"address":"vitalik.eth"}`, Key: tenantKey,
+			ExpectedStatus: http.StatusCreated},
+		{Name: "create subscription twice", Method: http.MethodPost, Path: "/v2/subscriptions", Body: `{"address":"` + subscribedAddress.String() + `"}`,
+			Key: tenantKey, ExpectedStatus: http.StatusConflict},
+		{Name: "create subscription not resolved", Method: http.MethodPost, Path: "/v2/subscriptions", Body: `{"address":"nobody.eth"}`, Key: tenantKey,
+			ExpectedStatus: http.StatusUnprocessableEntity},
+		{Name: "create subscription without key", Method: http.MethodPost, Path: "/v2/subscriptions", Body: `{"address":"vitalik.eth"}`,
+			ExpectedStatus: http.StatusUnauthorized},
+		{Name: "subscription", Method: http.MethodGet, Path: "/v2/subscriptions/" + subscribedAddress.String(), Key: tenantKey, ExpectedStatus: http.StatusOK},
+		{Name: "subscription not subscribed", Method: http.MethodGet, Path: "/v2/subscriptions/" + unsubscribedAddress.String(), Key: tenantKey,
+			ExpectedStatus: http.StatusNotFound},
+		{Name: "delete subscription", Method: http.MethodDelete, Path: "/v2/subscriptions/" + subscribedAddress.String(), Key: tenantKey,
+			ExpectedStatus: http.StatusNoContent},
+		{Name: "subscription transactions", Method: http.MethodGet, Path: "/v2/subscriptions/" + subscribedAddress.String() + "/transactions?amounts=decimal",
+			Key: tenantKey, ExpectedStatus: http.StatusOK},
+		{Name: "labels", Method: http.MethodGet, Path: "/v2/labels", Key: tenantKey, ExpectedStatus: http.StatusOK},
+		{Name: "address labels", Method: http.MethodGet, Path: "/v2/labels/" + unsubscribedAddress.String(), Key: tenantKey, ExpectedStatus: http.StatusOK},
+		{Name: "address labels not found", Method: http.MethodGet, Path: "/v2/labels/" + subscribedAddress.String(), Key: tenantKey,
+			ExpectedStatus: http.StatusNotFound},
+		{Name: "set labels", Method: http.MethodPut, Path: "/v2/labels/" + subscribedAddress.String(), Body: `{"labels":["exchange","binance"]}`,
+			Key: tenantKey, ExpectedStatus: http.StatusOK},
+		{Name: "set empty label", Method: http.MethodPut, Path: "/v2/labels/" + subscribedAddress.String(), Body: `{"labels":[" "]}`,
+			Key: tenantKey, ExpectedStatus: http.StatusBadRequest},
+		{Name: "set labels invalid address", Method: http.MethodPut, Path: "/v2/labels/0x1234", Body: `{"labels":["exchange"]}`,
+			Key: tenantKey, ExpectedStatus: http.StatusBadRequest},
+		{Name: "delete labels", Method: http.MethodDelete, Path: "/v2/labels/" + unsubscribedAddress.String(), Key: tenantKey,
+			ExpectedStatus: http.StatusNoContent},
+		{Name: "delete labels not found", Method: http.MethodDelete, Path: "/v2/labels/" + subscribedAddress.String(), Key: tenantKey,
+			ExpectedStatus: http.StatusNotFound},
+		{Name: "abis", Method: http.MethodGet, Path: "/v2/abis", Key: tenantKey, ExpectedStatus: http.StatusOK},
+		{Name: "abis unknown chain", Method: http.MethodGet, Path: "/v2/abis?chain=base", Key: tenantKey, ExpectedStatus: http.StatusBadRequest},
+		{Name: "abi", Method: http.MethodGet, Path: "/v2/abis/" + unsubscribedAddress.String(), Key: tenantKey, ExpectedStatus: http.StatusOK},
+		{Name: "abi not found", Method: http.MethodGet, Path: "/v2/abis/" + subscribedAddress.String(), Key: tenantKey,
+			ExpectedStatus: http.StatusNotFound},
+		{Name: "set abi", Method: http.MethodPut, Path: "/v2/abis/" + subscribedAddress.String(), Body: `{"abi":` + transferABI + `}`,
+			Key: tenantKey, ExpectedStatus: http.StatusOK},
+		{Name: "set invalid abi", Method: http.MethodPut, Path: "/v2/abis/" + subscribedAddress.String(), Body: `{"abi":[{"type":"event"}]}`,
+			Key: tenantKey, ExpectedStatus: http.StatusBadRequest},
+		{Name: "delete abi", Method: http.MethodDelete, Path: "/v2/abis/" + unsubscribedAddress.String(), Key: tenantKey,
+			ExpectedStatus: http.StatusNoContent},
+		{Name: "delete abi not found", Method: http.MethodDelete, Path: "/v2/abis/" + subscribedAddress.String(), Key: tenantKey,
+			ExpectedStatus: http.StatusNotFound},
+		{Name: "current block v2", Method: http.MethodGet, Path: "/v2/blocks/current", Key: tenantKey, ExpectedStatus: http.StatusOK},
+		{Name: "current block v2 rate limited", Method: http.MethodGet, Path: "/v2/blocks/current", Key: tenantKey, RateLimited: true,
+			ExpectedStatus: http.StatusTooManyRequests},
+		{Name: "graphql", Method: http.MethodPost, Path: "/graphql", Body: `{"query":"{ subscribers { address transactions { hash value } } }"}`, Key: tenantKey,
+			ExpectedStatus: http.StatusOK},
+		{Name: "graphql malformed", Method: http.MethodPost, Path: "/graphql", Body: `{"query":"{ chains { name } }","variables":[]}`, Key: tenantKey,
+			ExpectedStatus: http.StatusBadRequest},
+		{Name: "healthz", Method: http.MethodGet, Path: "/healthz", ExpectedStatus: http.StatusOK},
+		{Name: "readyz", Method: http.MethodGet, Path: "/readyz", ExpectedStatus: http.StatusServiceUnavailable},
+		{Name: "metrics", Method: http.MethodGet, Path: "/metrics", ExpectedStatus: http.StatusOK},
+		{Name: "admin config", Method: http.MethodGet, Path: "/admin/config", Key: testAdminKey, ExpectedStatus: http.StatusOK},
+		{Name: "admin config with tenant key", Method: http.MethodGet, Path: "/admin/config", Key: tenantKey, ExpectedStatus: http.StatusForbidden},
+		{Name: "create key", Method: http.MethodPost, Path: "/admin/keys", Body: `{"tenant_id":"tenant_b"}`, Key: testAdminKey, ExpectedStatus: http.StatusOK},
+		{Name: "create key without tenant", Method: http.MethodPost, Path: "/admin/keys", Body: `{}`, Key: testAdminKey, ExpectedStatus: http.StatusBadRequest},
+		{Name: "keys", Method: http.MethodGet, Path: "/admin/keys", Key: testAdminKey, ExpectedStatus: http.StatusOK},
+		{Name: "revoke unknown key", Method: http.MethodDelete, Path: "/admin/keys/unknown", Key: testAdminKey, ExpectedStatus: http.StatusNotFound},
+	}
+
+	spec := loadOpenAPISpec(t)
+	specRouter, err := gorillamux.NewRouter(spec)
+	assert.NoError(t, err)
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			handler, rawKey := newOpenAPITestHandler(t, testCase.RateLimited)
+
+			newRequest := func() *http.Request {
+				req := httptest.NewRequest(testCase.Method, "http://localhost:8080"+testCase.Path, strings.NewReader(testCase.Body))
+				if testCase.Body != "" {
+					req.Header.Set("Content-Type", "application/json")
+				}
+
+				switch testCase.Key {
+				case tenantKey:
+					req.Header.Set("Authorization", "Bearer "+rawKey)
+				case testAdminKey:
+					req.Header.Set("Authorization", "Bearer "+testAdminKey)
+				}
+
+				return req
+			}
+
+			recorder := httptest.NewRecorder()
+			handler.newRouter().ServeHTTP(recorder, newRequest())
+			assert.Equal(t, testCase.ExpectedStatus, recorder.Code, recorder.Body.String())
+
+			req := newRequest()
+			route, pathParams, err := specRouter.FindRoute(req)
+			assert.NoError(t, err)
+
+			requestInput := &openapi3filter.RequestValidationInput{
+				Request:    req,
+				PathParams: pathParams,
+				Route:      route,
+				Options:    &openapi3filter.Options{AuthenticationFunc: openapi3filter.NoopAuthenticationFunc},
+			}
+
+			// Requests that are rejected by handlers are not valid by the spec too
+			if testCase.ExpectedStatus != http.StatusBadRequest {
+				assert.NoError(t, openapi3filter.ValidateRequest(context.Background(), requestInput))
+			}
+
+			err = openapi3filter.ValidateResponse(context.Background(), &openapi3filter.ResponseValidationInput{
+				RequestValidationInput: requestInput,
+				Status:                 recorder.Code,
+				Header:                 recorder.Header(),
+				Body:                   io.NopCloser(bytes.NewReader(recorder.Body.Bytes())),
+				Options:                &openapi3filter.Options{IncludeResponseStatus: true},
+			})
+			assert.NoError(t, err)
+		})
+	}
+}
+
+// TestOpenAPI_Routes checks that every route of HTTP API is described in the spec
+func TestOpenAPI_Routes(t *testing.T) {
+	spec := loadOpenAPISpec(t)
+	handler, _ := newOpenAPITestHandler(t, false)
+
+	err := handler.newRouter().Walk(func(route *mux.Route, router *mux.Router, ancestors []*mux.Route) error {
+		template, err := route.GetPathTemplate()
+		if err != nil {
+			return nil
+		}
+
+		// Subrouters have no handlers, docs are not a part of API
+		if route.GetHandler() == nil || template == "/docs" || template == "/static/" {
+			return nil
+		}
+
+		methods, err := route.GetMethods()
+		if err != nil {
+			// Routes of API v1 accept any method, they are described as GET
+			methods = []string{http.MethodGet}
+		}
+
+		pathItem := spec.Paths.Find(template)
+		if !assert.NotNil(t, pathItem, "route %s is not described", template) {
+			return nil
+		}
+
+		for _, method := range methods {
+			assert.NotNil(t, pathItem.GetOperation(method), "route %s %s is not described", method, template)
+		}
+
+		return nil
+	})
+	assert.NoError(t, err)
+}
+
+// TestOpenAPI_Client checks that generated client decodes responses of the handler
+func TestOpenAPI_Client(t *testing.T) {
+	handler, rawKey := newOpenAPITestHandler(t, false)
+
+	server := httptest.NewServer(handler.newRouter())
+	defer server.Close()
+
+	apiClient, err := client.NewClientWithResponses(server.URL, client.WithRequestEditorFn(func(ctx context.Context, req *http.Request) error {
+		req.Header.Set("Authorization", "Bearer "+rawKey)
+		return nil
+	}))
+	assert.NoError(t, err)
+
+	ctx := context.Background()
+
+	created, err := apiClient.CreateSubscriptionWithResponse(ctx, nil, client.CreateSubscriptionJSONRequestBody{Address: "vitalik.eth"})
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, created.StatusCode())
+	assert.Equal(t, client.SubscriptionResp{Address: "0x00000000219ab540356cBB839Cbe05303d7705Fa", EnsName: stringPtr("vitalik.eth"),
+		Chain: "ethereum", ChainId: 1}, *created.JSON201)
+
+	duplicate, err := apiClient.CreateSubscriptionWithResponse(ctx, nil, client.CreateSubscriptionJSONRequestBody{Address: "vitalik.eth"})
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusConflict, duplicate.StatusCode())
+	assert.Equal(t, client.AlreadySubscribed, duplicate.JSONDefault.Error.Code)
+
+	decimalAmounts := client.GetSubscriptionTransactionsParamsAmountsDecimal
+	transactions, err := apiClient.GetSubscriptionTransactionsWithResponse(ctx, subscribedAddress.String(),
+		&client.GetSubscriptionTransactionsParams{Amounts: &decimalAmounts})
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, transactions.StatusCode())
+
+	decimal, err := transactions.JSON200.AsGetDecimalTransactionsResp()
+	assert.NoError(t, err)
+	assert.Len(t, decimal.Transactions, 2)
+	assert.Equal(t, new(big.Int).Lsh(big.NewInt(1), 70).String(), decimal.Transactions[0].Value)
+	assert.Equal(t, "30000000000", decimal.Transactions[0].GasPrice)
+	assert.Equal(t, "1180.591620717411303424", decimal.Transactions[0].ValueEth)
+	assert.Equal(t, "2300.15", *decimal.Transactions[0].ValueUsd)
+	assert.Equal(t, client.Outgoing, decimal.Transactions[0].Direction)
+	assert.Equal(t, "0x00000000219ab540356cBB839Cbe05303d7705Fa", decimal.Transactions[0].Counterparty)
+	assert.Equal(t, []string{"deposit contract"}, decimal.Transactions[0].CounterpartyLabels)
+	assert.Equal(t, "transfer(address,uint256)", decimal.Transactions[0].DecodedCall.Signature)
+	assert.Equal(t, "1000000", decimal.Transactions[0].DecodedCall.Arguments[1].Value)
+	assert.Nil(t, decimal.Transactions[1].DecodedCall)
+	assert.Equal(t, client.Incoming, decimal.Transactions[1].Direction)
+	assert.Equal(t, []string{"deposit contract"}, decimal.Transactions[1].CounterpartyLabels)
+
+	rawAmounts := client.GetTransactionsParamsAmountsRaw
+	rawTransactions, err := apiClient.GetTransactionsWithResponse(ctx, subscribedAddress.String(), &client.GetTransactionsParams{Amounts: &rawAmounts})
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rawTransactions.StatusCode())
+
+	raw, err := rawTransactions.JSON200.AsGetRawTransactionsResp()
+	assert.NoError(t, err)
+	assert.Len(t, raw.Transactions, 2)
+	assert.Equal(t, new(big.Int).Lsh(big.NewInt(1), 70).String(), raw.Transactions[0].Value.String())
+	assert.Equal(t, "30000000000", raw.Transactions[0].GasPrice.String())
+	assert.Equal(t, client.Outgoing, raw.Transactions[0].Direction)
+
+	labels, err := apiClient.SetLabelsWithResponse(ctx, subscribedAddress.String(), client.SetLabelsJSONRequestBody{Labels: []string{" exchange ", "exchange"}})
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, labels.StatusCode())
+	assert.Equal(t, []string{"exchange"}, labels.JSON200.Labels)
+
+	deleted, err := apiClient.DeleteLabelsWithResponse(ctx, unsubscribedAddress.String())
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, deleted.StatusCode())
+
+	abis, err := apiClient.GetABIsWithResponse(ctx, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, abis.StatusCode())
+	assert.Len(t, abis.JSON200.Abis, 1)
+	assert.Equal(t, []string{"transfer(address,uint256)"}, abis.JSON200.Abis[0].Methods)
+
+	missing, err := apiClient.GetLabelsWithResponse(ctx, unsubscribedAddress.String())
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, missing.StatusCode())
+	assert.Equal(t, client.LabelsNotFound, missing.JSONDefault.Error.Code)
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
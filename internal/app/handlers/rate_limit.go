@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"errors"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/service/rate_limiter"
+	"github.com/gorilla/mux"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type RateLimiter interface {
+	Allow(client string, route string) (bool, time.Duration)
+}
+
+// rateLimitMiddleware limits requests of every client by route template, requests over limit get 429 with Retry-After header.
+// Client is API key of authenticated request, otherwise IP address of the peer, it is attached to context of allowed request.
+// Public and admin routes are not limited
+func (h *Handler) rateLimitMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if isPublicPath(r.URL.Path) || strings.HasPrefix(r.URL.Path, adminPathPrefix) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		route := unknownRoute
+		if currentRoute := mux.CurrentRoute(r); currentRoute != nil {
+			if template, err := currentRoute.GetPathTemplate(); err == nil {
+				route = template
+			}
+		}
+
+		client := getClient(r)
+
+		allowed, retryAfter := h.rateLimiter.Allow(client, route)
+		if !allowed {
+			// Retry-After is in whole seconds, it is rounded up, so client retrying after it is not rejected again
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Max(1, math.Ceil(retryAfter.Seconds())))))
+			h.sendRouteErrResponse(w, r, CodeRateLimited, errors.New("rate limit is exceeded, retry after "+retryAfter.Round(time.Millisecond).String()),
+				http.StatusTooManyRequests)
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(rate_limiter.WithClient(r.Context(), client)))
+	})
+}
+
+// getClient returns ID of API key attached by authMiddleware or IP address of the peer.
+// X-Forwarded-For is not trusted, because any client can set it to bypass the limits
+func getClient(r *http.Request) string {
+	if keyID, ok := r.Context().Value(apiKeyIDCtxKey{}).(string); ok {
+		return "key:" + keyID
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+
+	return "ip:" + host
+}
@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// recordingRateLimiter records checked buckets and rejects requests while retryAfter is set
+type recordingRateLimiter struct {
+	mx         sync.Mutex
+	buckets    []string
+	retryAfter time.Duration
+}
+
+func (l *recordingRateLimiter) Allow(client string, route string) (bool, time.Duration) {
+	l.mx.Lock()
+	defer l.mx.Unlock()
+
+	l.buckets = append(l.buckets, client+" "+route)
+
+	return l.retryAfter == 0, l.retryAfter
+}
+
+func TestHandler_RateLimitMiddleware(t *testing.T) {
+	type TestCase struct {
+		Name               string
+		Path               string
+		Key                string
+		RetryAfter         time.Duration
+		ExpectedStatus     int
+		ExpectedRetryAfter string
+		// ExpectedBucket is client and route template charged for request, empty if request is not limited
+		ExpectedBucket string
+	}
+
+	h, rawKey := newOpenAPITestHandler(t, false)
+	keys, err := h.authenticator.ListKeys(context.Background())
+	assert.NoError(t, err)
+	keyClient := "key:" + keys[0].ID
+
+	subscribed := subscribedAddress.String()
+
+	testCases := []TestCase{
+		{Name: "allowed", Path: "/get_transactions/" + subscribed, Key: rawKey, ExpectedStatus: http.StatusOK,
+			ExpectedBucket: keyClient + " /get_transactions/{address}"},
+		{Name: "route template", Path: "/get_transactions/" + unsubscribedAddress.String(), Key: rawKey, ExpectedStatus: http.StatusNotFound,
+			ExpectedBucket: keyClient + " /get_transactions/{address}"},
+		{Name: "route template of api v2", Path: "/v2/subscriptions/" + subscribed + "/transactions", Key: rawKey, ExpectedStatus: http.StatusOK,
+			ExpectedBucket: keyClient + " /v2/subscriptions/{address}/transactions"},
+		{Name: "rejected", Path: "/get_current_block", Key: rawKey, RetryAfter: 1200 * time.Millisecond, ExpectedStatus: http.StatusTooManyRequests,
+			ExpectedRetryAfter: "2", ExpectedBucket: keyClient + " /get_current_block"},
+		{Name: "retry after less than second", Path: "/get_current_block", Key: rawKey, RetryAfter: 300 * time.Millisecond,
+			ExpectedStatus: http.StatusTooManyRequests, ExpectedRetryAfter: "1", ExpectedBucket: keyClient + " /get_current_block"},
+		{Name: "public route", Path: "/healthz", RetryAfter: time.Second, ExpectedStatus: http.StatusOK},
+		{Name: "docs", Path: "/static/openapi.yaml", RetryAfter: time.Second, ExpectedStatus: http.StatusOK},
+		{Name: "admin route", Path: "/admin/keys", Key: testAdminKey, RetryAfter: time.Second, ExpectedStatus: http.StatusOK},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			rateLimiter := &recordingRateLimiter{retryAfter: testCase.RetryAfter}
+			h.rateLimiter = rateLimiter
+
+			req := httptest.NewRequest(http.MethodGet, "http://localhost:8080"+testCase.Path, nil)
+			if testCase.Key != "" {
+				req.Header.Set("Authorization", "Bearer "+testCase.Key)
+			}
+
+			recorder := httptest.NewRecorder()
+			h.newRouter().ServeHTTP(recorder, req)
+			assert.Equal(t, testCase.ExpectedStatus, recorder.Code, recorder.Body.String())
+			assert.Equal(t, testCase.ExpectedRetryAfter, recorder.Header().Get("Retry-After"))
+
+			var expectedBuckets []string
+			if testCase.ExpectedBucket != "" {
+				expectedBuckets = []string{testCase.ExpectedBucket}
+			}
+			assert.Equal(t, expectedBuckets, rateLimiter.buckets)
+		})
+	}
+}
+
+func TestGetClient(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/get_current_block", nil)
+	req.RemoteAddr = "10.0.0.1:52000"
+	// X-Forwarded-For is set by client, so it is ignored
+	req.Header.Set("X-Forwarded-For", "10.0.0.2")
+	assert.Equal(t, "ip:10.0.0.1", getClient(req))
+
+	req = req.WithContext(context.WithValue(req.Context(), apiKeyIDCtxKey{}, "9f1c2a7be04d5c31"))
+	assert.Equal(t, "key:9f1c2a7be04d5c31", getClient(req))
+}
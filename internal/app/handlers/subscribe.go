@@ -4,50 +4,51 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
-	"github.com/bluntenpassant/ethereum_subscriber/internal/app/utils"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/models"
 	"github.com/gorilla/mux"
 	"net/http"
 )
 
-// swagger:model SubscribeResp
 type SubscribeResp struct {
 	IsOK bool `json:"is_ok"`
+	// Subscribed address in EIP-55 checksum encoding, useful when subscription was made by ENS name
+	Address string `json:"address"`
 }
 
-// swagger:operation GET /subscribe/{address} subscribe
-// ---
-// summary: Subscribe address for a listening new transactions
-// description: Set up listening for address. Transactions available for getting through /get_transactions/{address} method
-// parameters:
-// - name: address
-//   in: path
-//   description: Ethereum address
-//   type: string
-//   required: true
-// responses:
-//   200:
-//     description: Is everything ok status
-//     schema:
-//       $ref: "#/definitions/SubscribeResp"
-
 func (h *Handler) subscribe(w http.ResponseWriter, r *http.Request) {
-	ctx := context.Background()
+	ctx := r.Context()
 
 	vars := mux.Vars(r)
 	address, ok := vars["address"]
 	if !ok {
 		h.sendErrResponse(w, errors.New("address is not provided"), http.StatusBadRequest)
+		return
 	}
 
-	address = utils.ClearString(address)
-
-	err := h.parser.Subscribe(ctx, address)
+	chainParser, err := h.getChainParser(r)
 	if err != nil {
 		h.sendErrResponse(w, err, http.StatusBadRequest)
 		return
 	}
 
-	resp := SubscribeResp{IsOK: true}
+	subscriberAddress, ensName, code, err := h.resolveSubscriptionAddress(ctx, address)
+	if err != nil {
+		status := http.StatusBadRequest
+		if code == CodeUpstreamError {
+			status = http.StatusBadGateway
+		}
+
+		h.sendErrResponse(w, err, status)
+		return
+	}
+
+	err = h.subscriptionQuota.Subscribe(ctx, chainParser.Chain.ID, subscriberAddress, ensName)
+	if err != nil {
+		h.sendErrResponse(w, err, parserErrStatus(err))
+		return
+	}
+
+	resp := SubscribeResp{IsOK: true, Address: subscriberAddress.Checksum()}
 
 	respRaw, err := json.Marshal(resp)
 	if err != nil {
@@ -57,3 +58,32 @@ func (h *Handler) subscribe(w http.ResponseWriter, r *http.Request) {
 
 	h.sendOKResponse(w, respRaw)
 }
+
+// resolveSubscriptionAddress parses address of subscription or resolves ENS name into address.
+// ENS name is returned too, it is empty if address is passed. Code of API v2 error is returned with error
+func (h *Handler) resolveSubscriptionAddress(ctx context.Context, address string) (models.Address, string, string, error) {
+	if !models.IsENSName(address) {
+		subscriberAddress, err := models.ParseAddress(address)
+		if err != nil {
+			return "", "", CodeInvalidAddress, err
+		}
+
+		return subscriberAddress, "", "", nil
+	}
+
+	ensName, err := models.ParseENSName(address)
+	if err != nil {
+		return "", "", CodeInvalidAddress, err
+	}
+
+	subscriberAddress, err := h.ensResolver.Resolve(ctx, ensName)
+	if err != nil {
+		if errors.Is(err, models.ErrUpstreamRPC) {
+			return "", "", CodeUpstreamError, err
+		}
+
+		return "", "", CodeENSNotResolved, err
+	}
+
+	return subscriberAddress, ensName, "", nil
+}
@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/bluntenpassant/ethereum_subscriber/config"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/service/rate_limiter"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/service/subscription_quota"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestHandler_Subscribe_Quota(t *testing.T) {
+	type TestCase struct {
+		Name           string
+		Method         string
+		Path           string
+		Body           string
+		CountErr       error
+		ExpectedStatus int
+		ExpectedCode   string
+	}
+
+	const otherAddress = "0xdef1c0ded9bec7f1a1670819833240f027b25eff"
+
+	// tenant_a has subscribedAddress, so one more subscription is allowed, cases are run in order on the same handler
+	testCases := []TestCase{
+		{Name: "within quota", Method: http.MethodPost, Path: "/v2/subscriptions", Body: `{"address":"` + unsubscribedAddress.String() + `"}`,
+			ExpectedStatus: http.StatusCreated},
+		{Name: "already subscribed at quota", Method: http.MethodPost, Path: "/v2/subscriptions", Body: `{"address":"` + unsubscribedAddress.String() + `"}`,
+			ExpectedStatus: http.StatusConflict, ExpectedCode: CodeAlreadySubscribed},
+		{Name: "already subscribed at quota v1", Method: http.MethodGet, Path: "/subscribe/" + subscribedAddress.String(),
+			ExpectedStatus: http.StatusConflict},
+		{Name: "quota is reached", Method: http.MethodPost, Path: "/v2/subscriptions", Body: `{"address":"` + otherAddress + `"}`,
+			ExpectedStatus: http.StatusForbidden, ExpectedCode: CodeSubscriptionsLimitReached},
+		{Name: "quota is reached v1", Method: http.MethodGet, Path: "/subscribe/" + otherAddress,
+			ExpectedStatus: http.StatusForbidden},
+		{Name: "counting failed", Method: http.MethodPost, Path: "/v2/subscriptions", Body: `{"address":"` + otherAddress + `"}`,
+			CountErr: errors.New("connection refused"), ExpectedStatus: http.StatusInternalServerError, ExpectedCode: CodeInternal},
+	}
+
+	h, rawKey := newOpenAPITestHandler(t, false)
+	parser := h.parsers[0].Parser.(*mockParser)
+	h.subscriptionQuota = subscription_quota.NewQuota(map[uint64]subscription_quota.Parser{h.parsers[0].Chain.ID: parser},
+		rate_limiter.NewLimiter(config.RateLimit{MaxSubscriptionsPerTenant: 2}))
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			parser.mx.Lock()
+			parser.countErr = testCase.CountErr
+			parser.mx.Unlock()
+
+			req := httptest.NewRequest(testCase.Method, "http://localhost:8080"+testCase.Path, strings.NewReader(testCase.Body))
+			req.Header.Set("Authorization", "Bearer "+rawKey)
+
+			recorder := httptest.NewRecorder()
+			h.newRouter().ServeHTTP(recorder, req)
+			assert.Equal(t, testCase.ExpectedStatus, recorder.Code, recorder.Body.String())
+
+			if testCase.ExpectedCode != "" {
+				var resp ErrorResp
+				assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &resp))
+				assert.Equal(t, testCase.ExpectedCode, resp.Error.Code)
+			}
+		})
+	}
+}
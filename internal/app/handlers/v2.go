@@ -0,0 +1,266 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/models"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/tenant"
+	"github.com/gorilla/mux"
+	"net/http"
+	"net/url"
+	"sort"
+)
+
+// API v2 follows REST: subscriptions are resources created by POST and removed by DELETE, missing subscription is 404,
+// and every error is a JSON envelope with stable code (see ErrorResp). Chain is selected by chain query parameter like in v1
+
+// registerV2Routes adds routes of API v2 to router
+func (h *Handler) registerV2Routes(r *mux.Router) {
+	v2 := r.PathPrefix("/v2").Subrouter()
+	v2.HandleFunc("/subscriptions", h.createSubscription).Methods(http.MethodPost)
+	v2.HandleFunc("/subscriptions", h.getSubscriptions).Methods(http.MethodGet)
+	v2.HandleFunc("/subscriptions/{address}", h.getSubscription).Methods(http.MethodGet)
+	v2.HandleFunc("/subscriptions/{address}", h.deleteSubscription).Methods(http.MethodDelete)
+	v2.HandleFunc("/subscriptions/{address}/transactions", h.getSubscriptionTransactions).Methods(http.MethodGet)
+	v2.HandleFunc("/blocks/current", h.getCurrentBlockV2).Methods(http.MethodGet)
+	v2.HandleFunc("/labels", h.getAllLabels).Methods(http.MethodGet)
+	v2.HandleFunc("/labels/{address}", h.getLabels).Methods(http.MethodGet)
+	v2.HandleFunc("/labels/{address}", h.setLabels).Methods(http.MethodPut)
+	v2.HandleFunc("/labels/{address}", h.deleteLabels).Methods(http.MethodDelete)
+	v2.HandleFunc("/abis", h.getABIs).Methods(http.MethodGet)
+	v2.HandleFunc("/abis/{address}", h.getABI).Methods(http.MethodGet)
+	v2.HandleFunc("/abis/{address}", h.setABI).Methods(http.MethodPut)
+	v2.HandleFunc("/abis/{address}", h.deleteABI).Methods(http.MethodDelete)
+}
+
+type CreateSubscriptionReq struct {
+	// Ethereum address or ENS name, mixed case address should have valid EIP-55 checksum
+	Address string `json:"address"`
+}
+
+type SubscriptionResp struct {
+	// Subscribed address in EIP-55 checksum encoding
+	Address string `json:"address"`
+	// ENS name the subscription was made by, empty if it was made by address
+	ENSName string `json:"ens_name,omitempty"`
+	Chain   string `json:"chain"`
+	ChainID uint64 `json:"chain_id"`
+}
+
+type GetSubscriptionsResp struct {
+	Subscriptions []SubscriptionResp `json:"subscriptions"`
+}
+
+func (h *Handler) createSubscription(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req := CreateSubscriptionReq{}
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		h.sendV2ErrResponse(w, CodeInvalidRequest, errors.New("invalid request body cause: "+err.Error()), http.StatusBadRequest)
+		return
+	}
+
+	if req.Address == "" {
+		h.sendV2ErrResponse(w, CodeInvalidRequest, errors.New("address is not provided"), http.StatusBadRequest)
+		return
+	}
+
+	chainParser, err := h.getChainParser(r)
+	if err != nil {
+		h.sendV2ErrResponse(w, CodeUnknownChain, err, http.StatusBadRequest)
+		return
+	}
+
+	address, ensName, code, err := h.resolveSubscriptionAddress(ctx, req.Address)
+	if err != nil {
+		status := http.StatusBadRequest
+		switch code {
+		case CodeENSNotResolved:
+			status = http.StatusUnprocessableEntity
+		case CodeUpstreamError:
+			status = http.StatusBadGateway
+		}
+
+		h.sendV2ErrResponse(w, code, err, status)
+		return
+	}
+
+	err = h.subscriptionQuota.Subscribe(ctx, chainParser.Chain.ID, address, ensName)
+	if err != nil {
+		h.sendParserErrResponse(w, err)
+		return
+	}
+
+	respRaw, err := json.Marshal(SubscriptionResp{
+		Address: address.Checksum(),
+		ENSName: ensName,
+		Chain:   chainParser.Chain.Name,
+		ChainID: chainParser.Chain.ID,
+	})
+	if err != nil {
+		h.sendV2ErrResponse(w, CodeInternal, err, http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Location", "/v2/subscriptions/"+address.Checksum()+"?"+url.Values{"chain": {chainParser.Chain.Name}}.Encode())
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	w.Write(respRaw)
+}
+
+func (h *Handler) getSubscriptions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	chainParser, err := h.getChainParser(r)
+	if err != nil {
+		h.sendV2ErrResponse(w, CodeUnknownChain, err, http.StatusBadRequest)
+		return
+	}
+
+	subscribers, err := chainParser.Parser.GetSubscribers(ctx)
+	if err != nil {
+		h.sendParserErrResponse(w, err)
+		return
+	}
+
+	// Parser returns subscriptions of all tenants
+	tenantID := tenant.FromContext(ctx)
+
+	resp := GetSubscriptionsResp{Subscriptions: []SubscriptionResp{}}
+	for _, subscriber := range subscribers {
+		if subscriber.TenantID != tenantID {
+			continue
+		}
+
+		resp.Subscriptions = append(resp.Subscriptions, newSubscriptionResp(chainParser.Chain, subscriber))
+	}
+
+	sort.Slice(resp.Subscriptions, func(i, j int) bool {
+		return resp.Subscriptions[i].Address < resp.Subscriptions[j].Address
+	})
+
+	h.sendV2Response(w, resp)
+}
+
+func (h *Handler) getSubscription(w http.ResponseWriter, r *http.Request) {
+	chainParser, address, ok := h.getV2SubscriptionParams(w, r)
+	if !ok {
+		return
+	}
+
+	subscriber, err := chainParser.Parser.GetSubscriber(r.Context(), address)
+	if err != nil {
+		h.sendParserErrResponse(w, err)
+		return
+	}
+
+	h.sendV2Response(w, newSubscriptionResp(chainParser.Chain, subscriber))
+}
+
+func (h *Handler) deleteSubscription(w http.ResponseWriter, r *http.Request) {
+	chainParser, address, ok := h.getV2SubscriptionParams(w, r)
+	if !ok {
+		return
+	}
+
+	err := chainParser.Parser.Unsubscribe(r.Context(), address)
+	if err != nil {
+		h.sendParserErrResponse(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) getSubscriptionTransactions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Raw amounts lose precision in JavaScript clients, so v2 renders decimal amounts unless raw are requested
+	amounts, err := getAmounts(r, decimalAmounts)
+	if err != nil {
+		h.sendV2ErrResponse(w, CodeInvalidRequest, err, http.StatusBadRequest)
+		return
+	}
+
+	chainParser, address, ok := h.getV2SubscriptionParams(w, r)
+	if !ok {
+		return
+	}
+
+	transactions, err := chainParser.Parser.GetTransactions(ctx, address)
+	if err != nil {
+		h.sendParserErrResponse(w, err)
+		return
+	}
+
+	h.sendV2Response(w, h.transactionsResp(ctx, address, transactions, amounts))
+}
+
+func (h *Handler) getCurrentBlockV2(w http.ResponseWriter, r *http.Request) {
+	chainParser, err := h.getChainParser(r)
+	if err != nil {
+		h.sendV2ErrResponse(w, CodeUnknownChain, err, http.StatusBadRequest)
+		return
+	}
+
+	currentBlock, err := chainParser.Parser.GetCurrentBlock(r.Context())
+	if err != nil {
+		h.sendParserErrResponse(w, err)
+		return
+	}
+
+	if currentBlock == 0 {
+		h.sendParserErrResponse(w, models.ErrCurrentBlockNotParsed)
+		return
+	}
+
+	h.sendV2Response(w, GetCurrentBlockResp{CurrentBlock: currentBlock})
+}
+
+// getV2SubscriptionParams returns chain and address of subscription route, error response is written if they are invalid
+func (h *Handler) getV2SubscriptionParams(w http.ResponseWriter, r *http.Request) (ChainParser, models.Address, bool) {
+	chainParser, err := h.getChainParser(r)
+	if err != nil {
+		h.sendV2ErrResponse(w, CodeUnknownChain, err, http.StatusBadRequest)
+		return ChainParser{}, "", false
+	}
+
+	address, err := models.ParseAddress(mux.Vars(r)["address"])
+	if err != nil {
+		h.sendV2ErrResponse(w, CodeInvalidAddress, err, http.StatusBadRequest)
+		return ChainParser{}, "", false
+	}
+
+	return chainParser, address, true
+}
+
+// sendV2Response writes resp as JSON with 200 status
+func (h *Handler) sendV2Response(w http.ResponseWriter, resp interface{}) {
+	respRaw, err := json.Marshal(resp)
+	if err != nil {
+		h.sendV2ErrResponse(w, CodeInternal, err, http.StatusInternalServerError)
+		return
+	}
+
+	h.sendOKResponse(w, respRaw)
+}
+
+// notFound writes 404 for requests that did not match any route, in JSON envelope for API v2
+func (h *Handler) notFound(w http.ResponseWriter, r *http.Request) {
+	h.sendRouteErrResponse(w, r, CodeNotFound, errors.New("route "+r.URL.Path+" is not found"), http.StatusNotFound)
+}
+
+// methodNotAllowed writes 405 for requests with method that is not served by matched route, in JSON envelope for API v2
+func (h *Handler) methodNotAllowed(w http.ResponseWriter, r *http.Request) {
+	h.sendRouteErrResponse(w, r, CodeMethodNotAllowed, errors.New("method "+r.Method+" is not allowed"), http.StatusMethodNotAllowed)
+}
+
+func newSubscriptionResp(chain models.Chain, subscriber models.Subscriber) SubscriptionResp {
+	return SubscriptionResp{
+		Address: subscriber.Address.Checksum(),
+		ENSName: subscriber.ENSName,
+		Chain:   chain.Name,
+		ChainID: chain.ID,
+	}
+}
@@ -0,0 +1,120 @@
+// Package logger configures structured logging of the service (log/slog)
+// and carries request ID through context, so every log record made while handling a request
+// can be correlated with the request.
+package logger
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"github.com/bluntenpassant/ethereum_subscriber/config"
+	"go.opentelemetry.io/otel/trace"
+	"io"
+	"log/slog"
+	"strings"
+)
+
+// RequestIDKey is a name of log attribute that contains request ID
+const RequestIDKey = "request_id"
+
+// TraceIDKey is a name of log attribute that contains OpenTelemetry trace ID, it is added only when tracing is enabled
+const TraceIDKey = "trace_id"
+
+// Supported log formats
+const (
+	TextFormat = "text"
+	JSONFormat = "json"
+)
+
+// requestIDLength is a length of generated request ID in bytes
+const requestIDLength = 8
+
+type requestIDCtxKey struct{}
+
+// New returns logger writing records with configured level and format to w.
+// Empty level and format are treated as info and text.
+// Configured level is set into level, and later changes of level are applied to the logger at runtime.
+// Returned logger adds request ID from context to every record made through *Context methods (like InfoContext)
+func New(logConfig config.Log, level *slog.LevelVar, w io.Writer) (*slog.Logger, error) {
+	parsedLevel, err := ParseLevel(logConfig.Level)
+	if err != nil {
+		return nil, err
+	}
+
+	level.Set(parsedLevel)
+
+	options := &slog.HandlerOptions{Level: level}
+
+	var handler slog.Handler
+	switch strings.ToLower(logConfig.Format) {
+	case "", TextFormat:
+		handler = slog.NewTextHandler(w, options)
+	case JSONFormat:
+		handler = slog.NewJSONHandler(w, options)
+	default:
+		return nil, errors.New("unknown log format " + logConfig.Format + ", should be one of: " + TextFormat + ", " + JSONFormat)
+	}
+
+	return slog.New(&contextHandler{Handler: handler}), nil
+}
+
+// ParseLevel converts level name (debug, info, warn, error) into slog.Level, empty name is info
+func ParseLevel(name string) (slog.Level, error) {
+	if name == "" {
+		return slog.LevelInfo, nil
+	}
+
+	var level slog.Level
+	err := level.UnmarshalText([]byte(name))
+	if err != nil {
+		return 0, errors.New("unknown log level " + name + ", should be one of: debug, info, warn, error")
+	}
+
+	return level, nil
+}
+
+// NewRequestID generates random request ID
+func NewRequestID() string {
+	id := make([]byte, requestIDLength)
+	_, _ = rand.Read(id)
+
+	return hex.EncodeToString(id)
+}
+
+// WithRequestID returns copy of ctx carrying request ID
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDCtxKey{}, requestID)
+}
+
+// GetRequestID returns request ID carried by ctx, empty string if there is no request ID
+func GetRequestID(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDCtxKey{}).(string)
+
+	return requestID
+}
+
+// contextHandler adds request ID and trace ID from context to every record
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h *contextHandler) Handle(ctx context.Context, record slog.Record) error {
+	if requestID := GetRequestID(ctx); requestID != "" {
+		record.AddAttrs(slog.String(RequestIDKey, requestID))
+	}
+
+	if spanContext := trace.SpanContextFromContext(ctx); spanContext.IsValid() {
+		record.AddAttrs(slog.String(TraceIDKey, spanContext.TraceID().String()))
+	}
+
+	return h.Handler.Handle(ctx, record)
+}
+
+func (h *contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &contextHandler{Handler: h.Handler.WithAttrs(attrs)}
+}
+
+func (h *contextHandler) WithGroup(name string) slog.Handler {
+	return &contextHandler{Handler: h.Handler.WithGroup(name)}
+}
@@ -0,0 +1,46 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/bluntenpassant/ethereum_subscriber/config"
+	"github.com/stretchr/testify/assert"
+	"log/slog"
+	"testing"
+)
+
+func TestNew(t *testing.T) {
+	buf := &bytes.Buffer{}
+
+	level := &slog.LevelVar{}
+
+	log, err := New(config.Log{Level: "info", Format: JSONFormat}, level, buf)
+	assert.NoError(t, err)
+
+	ctx := WithRequestID(context.TODO(), "7f3b2c1d")
+
+	log.DebugContext(ctx, "filtered by level")
+	assert.Equal(t, 0, buf.Len())
+
+	log.With("address", "0x45849a974058661eb2128aceb60d2c6ed99e2a14").InfoContext(ctx, "subscribed")
+
+	record := map[string]interface{}{}
+	err = json.Unmarshal(buf.Bytes(), &record)
+	assert.NoError(t, err)
+	assert.Equal(t, "subscribed", record["msg"])
+	assert.Equal(t, "7f3b2c1d", record[RequestIDKey])
+	assert.Equal(t, "0x45849a974058661eb2128aceb60d2c6ed99e2a14", record["address"])
+
+	// Level is changed at runtime
+	buf.Reset()
+	level.Set(slog.LevelDebug)
+	log.DebugContext(ctx, "not filtered anymore")
+	assert.Contains(t, buf.String(), "not filtered anymore")
+
+	_, err = New(config.Log{Level: "verbose"}, level, buf)
+	assert.Error(t, err)
+
+	_, err = New(config.Log{Format: "xml"}, level, buf)
+	assert.Error(t, err)
+}
@@ -0,0 +1,126 @@
+// Package metrics contains Prometheus metrics of the service.
+// All metrics are registered in the default Prometheus registry and exposed by the HTTP server on /metrics
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Namespace is a prefix of all metrics names of the service
+const Namespace = "ethereum_subscriber"
+
+// Statuses of JSON-RPC requests
+const (
+	RPCStatusOK = "ok"
+	// RPCStatusTransportError means that request was not delivered or node responded with not OK HTTP status
+	RPCStatusTransportError = "transport_error"
+	// RPCStatusRPCError means that node responded with JSON-RPC error
+	RPCStatusRPCError = "rpc_error"
+	// RPCStatusDecodeError means that response of the node could not be decoded
+	RPCStatusDecodeError = "decode_error"
+)
+
+var (
+	rpcRequestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Name:      "rpc_requests_total",
+		Help:      "Number of JSON-RPC requests to Ethereum node by method and status.",
+	}, []string{"method", "status"})
+
+	rpcRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: Namespace,
+		Name:      "rpc_request_duration_seconds",
+		Help:      "Latency of JSON-RPC requests to Ethereum node by method.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method"})
+
+	chainHeadBlock = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: Namespace,
+		Name:      "chain_head_block",
+		Help:      "Last block number of the chain seen by the service by chain ID.",
+	}, []string{"chain_id"})
+
+	blocksProcessedTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Name:      "blocks_processed_total",
+		Help:      "Number of blocks fetched and scanned for subscribers transactions by parser.",
+	}, []string{"parser"})
+
+	transactionsMatchedTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Name:      "transactions_matched_total",
+		Help:      "Number of transactions matched to subscribers by parser.",
+	}, []string{"parser"})
+
+	repositoryOperationDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: Namespace,
+		Name:      "repository_operation_duration_seconds",
+		Help:      "Latency of storage operations by repository and operation.",
+		Buckets:   []float64{.0001, .0005, .001, .005, .01, .025, .05, .1, .25, .5, 1},
+	}, []string{"repository", "operation"})
+
+	httpRequestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Name:      "http_requests_total",
+		Help:      "Number of HTTP requests by route, method and status code.",
+	}, []string{"route", "method", "code"})
+
+	httpRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: Namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "Latency of HTTP requests by route and method.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"route", "method"})
+)
+
+// lastChainHeads keeps the last seen head block of every chain by chain ID for indexing lag calculation
+var lastChainHeads sync.Map
+
+// GetChainHead returns the last block number of the chain received from the node, 0 if it is not known yet
+func GetChainHead(chainID uint64) uint64 {
+	head, ok := lastChainHeads.Load(chainID)
+	if !ok {
+		return 0
+	}
+
+	return head.(uint64)
+}
+
+// ObserveRPCRequest records JSON-RPC request to Ethereum node
+func ObserveRPCRequest(method string, status string, duration time.Duration) {
+	rpcRequestsTotal.WithLabelValues(method, status).Inc()
+	rpcRequestDuration.WithLabelValues(method).Observe(duration.Seconds())
+}
+
+// SetChainHead records the last block number of the chain received from the node
+func SetChainHead(chainID uint64, blockNumber uint64) {
+	lastChainHeads.Store(chainID, blockNumber)
+	chainHeadBlock.WithLabelValues(strconv.FormatUint(chainID, 10)).Set(float64(blockNumber))
+}
+
+// AddBlocksProcessed records blocks scanned by parser
+func AddBlocksProcessed(parser string, count int) {
+	blocksProcessedTotal.WithLabelValues(parser).Add(float64(count))
+}
+
+// AddTransactionsMatched records transactions matched to subscribers by parser
+func AddTransactionsMatched(parser string, count int) {
+	transactionsMatchedTotal.WithLabelValues(parser).Add(float64(count))
+}
+
+// ObserveRepositoryOperation records latency of storage operation started at start,
+// it is designed to be deferred at the beginning of repository method:
+// defer metrics.ObserveRepositoryOperation(repositoryName, "GetCurrentBlock", time.Now())
+func ObserveRepositoryOperation(repository string, operation string, start time.Time) {
+	repositoryOperationDuration.WithLabelValues(repository, operation).Observe(time.Since(start).Seconds())
+}
+
+// ObserveHTTPRequest records HTTP request handled by route
+func ObserveHTTPRequest(route string, method string, code int, duration time.Duration) {
+	httpRequestsTotal.WithLabelValues(route, method, strconv.Itoa(code)).Inc()
+	httpRequestDuration.WithLabelValues(route, method).Observe(duration.Seconds())
+}
@@ -0,0 +1,99 @@
+package state_collector
+
+import (
+	"context"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/metrics"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/models"
+	"github.com/prometheus/client_golang/prometheus"
+	"log/slog"
+	"strconv"
+	"time"
+)
+
+// collectTimeout limits time of reading state from storage on every scrape
+const collectTimeout = 5 * time.Second
+
+// StateSource is a parser which state is exposed as metrics
+type StateSource interface {
+	GetCurrentBlock(ctx context.Context) (uint64, error)
+	GetSubscribers(ctx context.Context) ([]models.Subscriber, error)
+}
+
+// StateCollector reads state of the parser from storage on every scrape, so metrics are always
+// consistent with storage even if it is shared between several instances of the service
+type StateCollector struct {
+	chainID uint64
+	source  StateSource
+	logger  *slog.Logger
+
+	subscribersDesc  *prometheus.Desc
+	currentBlockDesc *prometheus.Desc
+	indexingLagDesc  *prometheus.Desc
+}
+
+// NewStateCollector returns collector of the parser of the chain with chainID,
+// metrics of every chain are labeled by chain_id, so collectors of several chains can be registered together
+func NewStateCollector(chainID uint64, source StateSource, logger *slog.Logger) *StateCollector {
+	labels := prometheus.Labels{"chain_id": strconv.FormatUint(chainID, 10)}
+
+	return &StateCollector{
+		chainID: chainID,
+		source:  source,
+		logger:  logger,
+		subscribersDesc: prometheus.NewDesc(
+			prometheus.BuildFQName(metrics.Namespace, "", "subscribers"),
+			"Number of subscribed addresses.",
+			nil, labels,
+		),
+		currentBlockDesc: prometheus.NewDesc(
+			prometheus.BuildFQName(metrics.Namespace, "", "current_block"),
+			"Last block number handled by parser.",
+			nil, labels,
+		),
+		indexingLagDesc: prometheus.NewDesc(
+			prometheus.BuildFQName(metrics.Namespace, "", "indexing_lag_blocks"),
+			"Difference between the last seen head block of the chain and the last block handled by parser.",
+			nil, labels,
+		),
+	}
+}
+
+func (c *StateCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.subscribersDesc
+	ch <- c.currentBlockDesc
+	ch <- c.indexingLagDesc
+}
+
+// Collect reports only metrics that could be read, errors are logged to keep other metrics available
+func (c *StateCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), collectTimeout)
+	defer cancel()
+
+	subscribers, err := c.source.GetSubscribers(ctx)
+	if err != nil {
+		c.logger.ErrorContext(ctx, "collecting subscribers metric failed", "error", err.Error())
+	} else {
+		ch <- prometheus.MustNewConstMetric(c.subscribersDesc, prometheus.GaugeValue, float64(len(subscribers)))
+	}
+
+	currentBlock, err := c.source.GetCurrentBlock(ctx)
+	if err != nil {
+		c.logger.ErrorContext(ctx, "collecting current block metric failed", "error", err.Error())
+		return
+	}
+
+	ch <- prometheus.MustNewConstMetric(c.currentBlockDesc, prometheus.GaugeValue, float64(currentBlock))
+
+	// Lag is unknown until both head and current block are known
+	head := metrics.GetChainHead(c.chainID)
+	if head == 0 || currentBlock == 0 {
+		return
+	}
+
+	var lag uint64
+	if head > currentBlock {
+		lag = head - currentBlock
+	}
+
+	ch <- prometheus.MustNewConstMetric(c.indexingLagDesc, prometheus.GaugeValue, float64(lag))
+}
@@ -0,0 +1,52 @@
+package state_collector
+
+import (
+	"context"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/metrics"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/models"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+type stateSourceMock struct {
+	currentBlock uint64
+	subscribers  []models.Subscriber
+}
+
+func (s *stateSourceMock) GetCurrentBlock(ctx context.Context) (uint64, error) {
+	return s.currentBlock, nil
+}
+
+func (s *stateSourceMock) GetSubscribers(ctx context.Context) ([]models.Subscriber, error) {
+	return s.subscribers, nil
+}
+
+func TestStateCollector_Collect(t *testing.T) {
+	metrics.SetChainHead(1, 16614490)
+
+	collector := NewStateCollector(1, &stateSourceMock{
+		currentBlock: 16614478,
+		subscribers: []models.Subscriber{
+			{Address: "0x45849a974058661eb2128aceb60d2c6ed99e2a14"},
+			{Address: "0x388c818ca8b9251b393131c08a736a67ccb19297"},
+		},
+	}, slog.Default())
+
+	expected := `
+# HELP ethereum_subscriber_current_block Last block number handled by parser.
+# TYPE ethereum_subscriber_current_block gauge
+ethereum_subscriber_current_block{chain_id="1"} 1.6614478e+07
+# HELP ethereum_subscriber_indexing_lag_blocks Difference between the last seen head block of the chain and the last block handled by parser.
+# TYPE ethereum_subscriber_indexing_lag_blocks gauge
+ethereum_subscriber_indexing_lag_blocks{chain_id="1"} 12
+# HELP ethereum_subscriber_subscribers Number of subscribed addresses.
+# TYPE ethereum_subscriber_subscribers gauge
+ethereum_subscriber_subscribers{chain_id="1"} 2
+`
+
+	err := testutil.CollectAndCompare(collector, strings.NewReader(expected))
+	assert.NoError(t, err)
+}
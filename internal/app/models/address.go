@@ -0,0 +1,85 @@
+package models
+
+import (
+	"encoding/hex"
+	"errors"
+	"golang.org/x/crypto/sha3"
+	"strconv"
+	"strings"
+)
+
+// addressLength is a length of Ethereum address represented as a hexadecimal string with 0x prefix
+const addressLength = 42
+
+// addressPrefix is a prefix of Ethereum address represented as a hexadecimal string
+const addressPrefix = "0x"
+
+// Address represents Ethereum address in canonical form: lowercase hexadecimal string with 0x prefix.
+// Address is stored and compared only in canonical form, checksum encoding (EIP-55) is used only for output
+type Address string
+
+// ParseAddress validates user input and converts it to canonical Address.
+// Input should be a 20-byte hexadecimal string with 0x prefix. If input is in mixed case
+// it is treated as EIP-55 checksum encoded and checksum is verified,
+// all lowercase or all uppercase input is accepted without checksum verification.
+func ParseAddress(s string) (Address, error) {
+	s = strings.TrimSpace(s)
+
+	if len(s) != addressLength {
+		return "", errors.New("address length should be " + strconv.Itoa(addressLength))
+	}
+
+	if s[:2] != addressPrefix && s[:2] != "0X" {
+		return "", errors.New("address should start with " + addressPrefix)
+	}
+
+	hexPart := s[2:]
+	if _, err := hex.DecodeString(hexPart); err != nil {
+		return "", errors.New("address should be a hexadecimal string")
+	}
+
+	address := Address(addressPrefix + strings.ToLower(hexPart))
+
+	if hexPart != strings.ToLower(hexPart) && hexPart != strings.ToUpper(hexPart) {
+		if address.Checksum() != addressPrefix+hexPart {
+			return "", errors.New("address checksum is invalid")
+		}
+	}
+
+	return address, nil
+}
+
+// NewAddress converts address received from a trusted source (like Ethereum node) into canonical Address
+// without validation
+func NewAddress(s string) Address {
+	return Address(strings.ToLower(s))
+}
+
+// String returns address in canonical lowercase form
+func (a Address) String() string {
+	return string(a)
+}
+
+// Checksum returns address in EIP-55 mixed-case checksum encoding (https://eips.ethereum.org/EIPS/eip-55).
+// Every hexadecimal letter of address is uppercased if corresponding nibble of Keccak-256 hash
+// of lowercase address (without prefix) is greater than 7
+func (a Address) Checksum() string {
+	if a == "" {
+		return ""
+	}
+
+	hexPart := strings.TrimPrefix(strings.ToLower(string(a)), addressPrefix)
+
+	hash := sha3.NewLegacyKeccak256()
+	hash.Write([]byte(hexPart))
+	hashHex := hex.EncodeToString(hash.Sum(nil))
+
+	checksum := []byte(hexPart)
+	for i, c := range checksum {
+		if c >= 'a' && c <= 'f' && i < len(hashHex) && hashHex[i] >= '8' {
+			checksum[i] = c - ('a' - 'A')
+		}
+	}
+
+	return addressPrefix + string(checksum)
+}
@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// AddressLabels are labels attached by a tenant to an address in its address book, like name of exchange,
+// ID of customer or name of contract. Addresses are the same in all EVM chains, so labels are shared by all chains
+type AddressLabels struct {
+	// TenantID is an ID of the tenant owning the address book, empty if authentication is disabled
+	TenantID string `json:"tenantId"`
+
+	// Address is the labelled address
+	Address Address `json:"address"`
+
+	// Labels are unique labels of the address in the order they were set
+	Labels []string `json:"labels"`
+
+	// UpdatedAt is a time of the last change of labels
+	UpdatedAt time.Time `json:"updatedAt"`
+}
@@ -0,0 +1,88 @@
+package models
+
+import (
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestParseAddress(t *testing.T) {
+	type TestCase struct {
+		Name            string
+		Input           string
+		ExpectedAddress Address
+		IsErr           bool
+	}
+
+	testCases := []TestCase{
+		{
+			Name:            "lowercase",
+			Input:           "0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed",
+			ExpectedAddress: "0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed",
+		},
+		{
+			Name:            "uppercase",
+			Input:           "0x5AAEB6053F3E94C9B9A09F33669435E7EF1BEAED",
+			ExpectedAddress: "0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed",
+		},
+		{
+			Name:            "valid checksum with spaces and new line",
+			Input:           "  0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed\n",
+			ExpectedAddress: "0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed",
+		},
+		{
+			Name:  "invalid checksum",
+			Input: "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAeD",
+			IsErr: true,
+		},
+		{
+			Name:  "short address",
+			Input: "0x5aaeb6053f3e94c9b9a09f33669435e7ef1bea",
+			IsErr: true,
+		},
+		{
+			Name:  "transaction hash instead of address",
+			Input: "0x0f41f88706566a6e14bfc1f85d9761eb216a3b141c6eec13c820b6da569bf8a5",
+			IsErr: true,
+		},
+		{
+			Name:  "without prefix",
+			Input: "005aaeb6053f3e94c9b9a09f33669435e7ef1beaed",
+			IsErr: true,
+		},
+		{
+			Name:  "not hexadecimal",
+			Input: "0x5aaeb6053f3e94c9b9a09f33669435e7ef1beazz",
+			IsErr: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			address, err := ParseAddress(testCase.Input)
+			if testCase.IsErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.ExpectedAddress, address)
+		})
+	}
+}
+
+func TestAddress_Checksum(t *testing.T) {
+	// test vectors from https://eips.ethereum.org/EIPS/eip-55
+	checksummedAddresses := []string{
+		"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed",
+		"0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359",
+		"0xdbF03B407c01E7cD3CBea99509d93f8DDDC8C6FB",
+		"0xD1220A0cf47c7B9Be7A2E6BA89F429762e7b9aDb",
+	}
+
+	for _, checksummedAddress := range checksummedAddresses {
+		address := NewAddress(checksummedAddress)
+		assert.Equal(t, strings.ToLower(checksummedAddress), address.String())
+		assert.Equal(t, checksummedAddress, address.Checksum())
+	}
+}
@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// APIKey is a key of API client, every key belongs to a tenant and gives access only to subscriptions of the tenant.
+// Raw key is shown only once on creation, only its hash is stored
+type APIKey struct {
+	// ID is a public identifier of the key used to list and revoke it
+	ID string `json:"id"`
+
+	// TenantID is an ID of the tenant owning the key
+	TenantID string `json:"tenantId"`
+
+	// Hash is a hex encoded SHA-256 hash of the raw key
+	Hash string `json:"hash"`
+
+	// CreatedAt is a time of key creation
+	CreatedAt time.Time `json:"createdAt"`
+}
@@ -0,0 +1,12 @@
+package models
+
+import "math/big"
+
+// Balance is ETH balance of address at the end of block
+type Balance struct {
+	// BlockNumber is the number of the block after which balance was recorded
+	BlockNumber uint64 `json:"blockNumber"`
+
+	// Balance is the balance in wei
+	Balance big.Int `json:"balance"`
+}
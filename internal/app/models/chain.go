@@ -0,0 +1,35 @@
+package models
+
+import "strconv"
+
+// Chain is an EVM chain (Ethereum, L2 or sidechain) watched by the service
+type Chain struct {
+	// ID is the chain ID (EIP-155), e.g. 1 for Ethereum Mainnet
+	ID uint64 `json:"chainId"`
+
+	// Name is a short name of the chain used to select it, e.g. ethereum or arbitrum
+	Name string `json:"name"`
+
+	// NativeCurrency is a symbol of native currency of the chain, e.g. ETH
+	NativeCurrency string `json:"nativeCurrency"`
+}
+
+// FindChain returns chain selected by name or chain ID, empty selector means the first (default) chain
+func FindChain(chains []Chain, selector string) (Chain, bool) {
+	if len(chains) == 0 {
+		return Chain{}, false
+	}
+
+	if selector == "" {
+		return chains[0], true
+	}
+
+	id, err := strconv.ParseUint(selector, 10, 64)
+	for _, chain := range chains {
+		if chain.Name == selector || (err == nil && chain.ID == id) {
+			return chain, true
+		}
+	}
+
+	return Chain{}, false
+}
@@ -0,0 +1,37 @@
+package models
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestFindChain(t *testing.T) {
+	type TestCase struct {
+		Name          string
+		Selector      string
+		ExpectedChain Chain
+		ExpectedOk    bool
+	}
+
+	chains := []Chain{
+		{ID: 1, Name: "ethereum", NativeCurrency: "ETH"},
+		{ID: 42161, Name: "arbitrum", NativeCurrency: "ETH"},
+	}
+
+	testCases := []TestCase{
+		{Name: "default chain", Selector: "", ExpectedChain: chains[0], ExpectedOk: true},
+		{Name: "by name", Selector: "arbitrum", ExpectedChain: chains[1], ExpectedOk: true},
+		{Name: "by id", Selector: "42161", ExpectedChain: chains[1], ExpectedOk: true},
+		{Name: "unknown name", Selector: "base", ExpectedOk: false},
+		{Name: "unknown id", Selector: "8453", ExpectedOk: false},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			chain, ok := FindChain(chains, testCase.Selector)
+
+			assert.Equal(t, testCase.ExpectedOk, ok)
+			assert.Equal(t, testCase.ExpectedChain, chain)
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Sources of contract ABIs
+const (
+	// ABISourceDirectory is an ABI loaded from ABI directory at start, it is shared by all tenants
+	ABISourceDirectory = "directory"
+	// ABISourceAPI is an ABI uploaded by a tenant through API, it overrides ABI of the same contract from directory
+	ABISourceAPI = "api"
+)
+
+// ContractABI is a JSON ABI of a contract deployed on the chain
+type ContractABI struct {
+	TenantID string          `json:"tenantId"`
+	ChainID  uint64          `json:"chainId"`
+	Address  Address         `json:"address"`
+	ABI      json.RawMessage `json:"abi"`
+	// Methods are canonical signatures of functions of the ABI, like transfer(address,uint256)
+	Methods   []string  `json:"methods"`
+	Source    string    `json:"source"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// DecodedCall is a contract method call decoded from input of transaction by ABI of the contract
+type DecodedCall struct {
+	// Method is a name of the called method
+	Method string `json:"method"`
+	// Signature is a canonical signature of the method, like transfer(address,uint256)
+	Signature string `json:"signature"`
+	// Selector is the first 4 bytes of Keccak-256 hash of the signature the input starts with
+	Selector  string            `json:"selector"`
+	Arguments []DecodedArgument `json:"arguments"`
+}
+
+// DecodedArgument is an argument of decoded call. Integers are rendered as decimal strings, addresses in EIP-55 checksum encoding,
+// bytes as hex strings, arrays as lists of values and tuples as lists of DecodedArgument
+type DecodedArgument struct {
+	Name  string      `json:"name"`
+	Type  string      `json:"type"`
+	Value interface{} `json:"value"`
+}
@@ -0,0 +1,35 @@
+package models
+
+import (
+	"errors"
+	"strings"
+)
+
+// IsENSName reports whether user input looks like an ENS name (like vitalik.eth) rather than an address.
+// Any dot separated string is treated as a name, further validation is done by ParseENSName
+func IsENSName(s string) bool {
+	return strings.Contains(strings.TrimSpace(s), ".")
+}
+
+// ParseENSName validates user input and converts ENS name to normalized lowercase form.
+// NOTE: only lowercasing is applied, full UTS-46 normalization (ENSIP-15) is not supported,
+// so names with non-ASCII characters should be passed already normalized
+func ParseENSName(s string) (string, error) {
+	name := strings.ToLower(strings.TrimSpace(s))
+
+	if name == "" {
+		return "", errors.New("ens name is empty")
+	}
+
+	if strings.ContainsAny(name, " \t\n") {
+		return "", errors.New("ens name should not contain whitespaces")
+	}
+
+	for _, label := range strings.Split(name, ".") {
+		if label == "" {
+			return "", errors.New("ens name should not contain empty labels")
+		}
+	}
+
+	return name, nil
+}
@@ -0,0 +1,64 @@
+package models
+
+import (
+	"errors"
+	ethereum_jsonrpc "github.com/bluntenpassant/ethereum_subscriber/internal/app/client/ethereum-jsonrpc"
+)
+
+// Domain errors returned by repositories and parsers, callers should check them by errors.Is instead of messages
+var (
+	// ErrNotSubscribed means that address is not subscribed (in the tenant of request)
+	ErrNotSubscribed = errors.New("address is not subscribed")
+
+	// ErrAlreadySubscribed means that address is already subscribed (in the tenant of request)
+	ErrAlreadySubscribed = errors.New("address is already subscribed")
+
+	// ErrQuotaExceeded means that tenant of request has reached maximum count of subscriptions
+	ErrQuotaExceeded = errors.New("subscriptions quota is exceeded")
+
+	// ErrAPIKeyNotFound means that API key with the ID or hash does not exist
+	ErrAPIKeyNotFound = errors.New("api key is not found")
+
+	// ErrInvalidAPIKey means that API key of request is malformed or revoked
+	ErrInvalidAPIKey = errors.New("invalid api key")
+
+	// ErrAPIKeyAlreadyExists means that API key with the ID already exists
+	ErrAPIKeyAlreadyExists = errors.New("api key already exists")
+
+	// ErrLabelsNotFound means that address has no labels in address book (of the tenant of request)
+	ErrLabelsNotFound = errors.New("address has no labels")
+
+	// ErrABINotFound means that ABI of the contract is neither uploaded (by the tenant of request) nor loaded from ABI directory
+	ErrABINotFound = errors.New("contract has no abi")
+
+	// ErrInvalidABI means that ABI can not be parsed, has no functions or has arguments of unsupported types
+	ErrInvalidABI = errors.New("invalid abi")
+
+	// ErrCurrentBlockNotParsed means that parser has not parsed any block yet
+	ErrCurrentBlockNotParsed = errors.New("current block is not parsed yet")
+
+	// ErrBalanceNotRecorded means that balance of subscribed address is not recorded yet
+	ErrBalanceNotRecorded = errors.New("balance of address is not recorded yet")
+
+	// ErrUpstreamRPC means that request to Ethereum node failed, it is matched by every error of ethereum_jsonrpc.Client
+	ErrUpstreamRPC = ethereum_jsonrpc.ErrRequestFailed
+)
+
+// causeError is an error with a message and its cause, it is formatted as the rest of errors of the service
+type causeError struct {
+	message string
+	cause   error
+}
+
+func (e *causeError) Error() string {
+	return e.message + " cause: " + e.cause.Error()
+}
+
+func (e *causeError) Unwrap() error {
+	return e.cause
+}
+
+// WrapError adds message to err keeping err in the chain, so domain errors are still matched by errors.Is
+func WrapError(message string, err error) error {
+	return &causeError{message: message, cause: err}
+}
@@ -0,0 +1,47 @@
+package models
+
+import (
+	"errors"
+	ethereum_jsonrpc "github.com/bluntenpassant/ethereum_subscriber/internal/app/client/ethereum-jsonrpc"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestWrapError(t *testing.T) {
+	type TestCase struct {
+		Name            string
+		Err             error
+		ExpectedMessage string
+		ExpectedTarget  error
+	}
+
+	testCases := []TestCase{
+		{
+			Name:            "domain error",
+			Err:             WrapError("error getting subscriber", ErrNotSubscribed),
+			ExpectedMessage: "error getting subscriber cause: address is not subscribed",
+			ExpectedTarget:  ErrNotSubscribed,
+		},
+		{
+			Name:            "nested wraps",
+			Err:             WrapError("error subscribing", WrapError("error adding subscriber", ErrAlreadySubscribed)),
+			ExpectedMessage: "error subscribing cause: error adding subscriber cause: address is already subscribed",
+			ExpectedTarget:  ErrAlreadySubscribed,
+		},
+		{
+			Name: "ethereum node error",
+			Err: WrapError("error getting current block number",
+				&ethereum_jsonrpc.RequestError{Method: "eth_blockNumber", Err: errors.New("connection refused")}),
+			ExpectedMessage: "error getting current block number cause: ethereum node request eth_blockNumber failed cause: connection refused",
+			ExpectedTarget:  ErrUpstreamRPC,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			assert.EqualError(t, testCase.Err, testCase.ExpectedMessage)
+			assert.ErrorIs(t, testCase.Err, testCase.ExpectedTarget)
+			assert.NotErrorIs(t, testCase.Err, ErrCurrentBlockNotParsed)
+		})
+	}
+}
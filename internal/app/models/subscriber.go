@@ -1,12 +1,51 @@
 package models
 
+import "strings"
+
 // Subscriber represent an address that subscribed for a listening and keeping data required by service
 // for getting all address transaction
 type Subscriber struct {
+	// ID of the chain where address is watched
+	ChainID uint64
 	// Subscriber address represented as a hexadecimal number in a string
-	Address string
+	Address Address
 	// Block number that had subscriber in a moment of subscription or last parsed block number (depending on mode)
 	SubscribeBlockNumber uint64
 	// Number of transactions that user had in a moment of subscription or last parsed block (depending on mode)
 	SubscribeTxCount uint64
+	// ENS name that was used for subscription and resolved into Address, empty if subscribed by address
+	ENSName string
+	// ID of the tenant owning subscription, empty if authentication is disabled
+	TenantID string
+}
+
+// Key returns key of the subscription, the same address is subscribed by every tenant independently
+func (s Subscriber) Key() SubscriberKey {
+	return SubscriberKey{TenantID: s.TenantID, Address: s.Address}
+}
+
+// SubscriberKey identifies subscription of address by tenant
+type SubscriberKey struct {
+	TenantID string
+	Address  Address
+}
+
+// String returns key as "tenant:address", or just address for subscription without tenant,
+// so storage keys of subscriptions created before tenants were introduced stay the same
+func (k SubscriberKey) String() string {
+	if k.TenantID == "" {
+		return k.Address.String()
+	}
+
+	return k.TenantID + ":" + k.Address.String()
+}
+
+// ParseSubscriberKey is the reverse of SubscriberKey.String
+func ParseSubscriberKey(s string) SubscriberKey {
+	i := strings.LastIndex(s, ":")
+	if i < 0 {
+		return SubscriberKey{Address: Address(s)}
+	}
+
+	return SubscriberKey{TenantID: s[:i], Address: Address(s[i+1:])}
 }
@@ -5,8 +5,10 @@ import (
 	"math/big"
 )
 
-// swagger:model Transaction
 type Transaction struct {
+	// ChainID is the ID of the chain where transaction was included
+	ChainID uint64 `json:"chainId"`
+
 	// BlockHash is the hash of the block that this transaction belongs to
 	BlockHash string `json:"blockHash"`
 
@@ -50,21 +52,23 @@ type Transaction struct {
 	S big.Int `json:"s"`
 }
 
-func ConvertJsonRPCTxToInternal(tx *ethereum_jsonrpc.Transaction) *Transaction {
+// ConvertJsonRPCTxToInternal converts transaction received from JSONRPC Api of the chain
+func ConvertJsonRPCTxToInternal(chainID uint64, tx *ethereum_jsonrpc.Transaction) *Transaction {
 	if tx == nil {
 		return nil
 	}
 
 	return &Transaction{
+		ChainID:          chainID,
 		BlockHash:        tx.BlockHash,
 		BlockNumber:      uint64(tx.BlockNumber),
-		From:             tx.From,
+		From:             NewAddress(tx.From).String(),
 		Gas:              big.Int(tx.Gas),
 		GasPrice:         big.Int(tx.GasPrice),
 		Hash:             tx.Hash,
 		Input:            tx.Input,
 		Nonce:            uint64(tx.Nonce),
-		To:               tx.To,
+		To:               NewAddress(tx.To).String(),
 		TransactionIndex: uint64(tx.TransactionIndex),
 		Value:            big.Int(tx.Value),
 		V:                big.Int(tx.V),
@@ -73,6 +77,38 @@ func ConvertJsonRPCTxToInternal(tx *ethereum_jsonrpc.Transaction) *Transaction {
 	}
 }
 
+// Directions of transaction relative to subscriber
+const (
+	DirectionIncoming = "incoming"
+	DirectionOutgoing = "outgoing"
+	DirectionSelf     = "self"
+)
+
+// Direction returns direction of transaction relative to address: outgoing if address sent it,
+// incoming if address received it and self if address sent it to itself
+func (t *Transaction) Direction(address Address) string {
+	from := NewAddress(t.From)
+	to := NewAddress(t.To)
+
+	switch {
+	case from == address && to == address:
+		return DirectionSelf
+	case from == address:
+		return DirectionOutgoing
+	default:
+		return DirectionIncoming
+	}
+}
+
+// Counterparty returns the other side of transaction relative to address, it is address itself for self transactions
+func (t *Transaction) Counterparty(address Address) Address {
+	if NewAddress(t.From) == address {
+		return NewAddress(t.To)
+	}
+
+	return NewAddress(t.From)
+}
+
 func ReverseTransactionsByLink(txs []*Transaction) {
 	left := 0
 	right := len(txs) - 1
@@ -114,3 +150,99 @@ func ReverseTransactionsCopy(txs []*Transaction) []*Transaction {
 
 	return newTxs
 }
+
+// ChecksumTransactionsCopy returns copies of transactions with From and To addresses in EIP-55 checksum encoding.
+// Transactions are stored with addresses in canonical lowercase form, so this function should be used only for output
+func ChecksumTransactionsCopy(txs []*Transaction) []*Transaction {
+	newTxs := make([]*Transaction, 0, len(txs))
+
+	for _, tx := range txs {
+		newTx := *tx
+		newTx.From = NewAddress(tx.From).Checksum()
+		newTx.To = NewAddress(tx.To).Checksum()
+
+		newTxs = append(newTxs, &newTx)
+	}
+
+	return newTxs
+}
+
+type DecimalTransaction struct {
+	// ChainID is the ID of the chain where transaction was included
+	ChainID uint64 `json:"chainId"`
+
+	// BlockHash is the hash of the block that this transaction belongs to
+	BlockHash string `json:"blockHash"`
+
+	// BlockNumber is the number of the block that this transaction belongs to
+	BlockNumber uint64 `json:"blockNumber"`
+
+	// From is the address of the account that initiated the transaction
+	From string `json:"from"`
+
+	// Gas is the amount of gas used by the transaction
+	Gas string `json:"gas"`
+
+	// GasPrice is the price of gas in wei
+	GasPrice string `json:"gasPrice"`
+
+	// GasPriceGwei is the price of gas in gwei
+	GasPriceGwei string `json:"gasPriceGwei"`
+
+	// Hash is the transaction hash
+	Hash string `json:"hash"`
+
+	// Input is the input data for the transaction
+	Input string `json:"input"`
+
+	// Nonce is the nonce of the account that initiated the transaction
+	Nonce uint64 `json:"nonce"`
+
+	// To is the address of the account that the transaction was sent to
+	To string `json:"to"`
+
+	// TransactionIndex is the index of the transaction in the block
+	TransactionIndex uint64 `json:"transactionIndex"`
+
+	// Value is the amount of Ether transferred in the transaction in wei
+	Value string `json:"value"`
+
+	// ValueETH is the amount of Ether transferred in the transaction
+	ValueETH string `json:"valueEth"`
+
+	// ValueUSD is the value of the transaction in USD at timestamp of its block, it is set only if price provider is configured
+	ValueUSD string `json:"valueUsd,omitempty"`
+
+	// V is the Ethereum network protocol version
+	V string `json:"v"`
+
+	// R is a component of the signature of the transaction
+	R string `json:"r"`
+
+	// S is a component of the signature of the transaction
+	S string `json:"s"`
+}
+
+// NewDecimalTransaction converts transaction for output to clients that can not handle big JSON numbers (like JavaScript):
+// amounts are rendered as decimal strings, value is also provided in ETH and gas price in gwei
+func NewDecimalTransaction(tx *Transaction) *DecimalTransaction {
+	return &DecimalTransaction{
+		ChainID:          tx.ChainID,
+		BlockHash:        tx.BlockHash,
+		BlockNumber:      tx.BlockNumber,
+		From:             tx.From,
+		Gas:              tx.Gas.String(),
+		GasPrice:         tx.GasPrice.String(),
+		GasPriceGwei:     FormatGwei(&tx.GasPrice),
+		Hash:             tx.Hash,
+		Input:            tx.Input,
+		Nonce:            tx.Nonce,
+		To:               tx.To,
+		TransactionIndex: tx.TransactionIndex,
+		Value:            tx.Value.String(),
+		ValueETH:         FormatEther(&tx.Value),
+		V:                tx.V.String(),
+		R:                tx.R.String(),
+		S:                tx.S.String(),
+	}
+}
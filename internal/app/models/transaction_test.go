@@ -0,0 +1,52 @@
+package models
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestTransaction_Direction(t *testing.T) {
+	type TestCase struct {
+		Name                 string
+		Transaction          Transaction
+		ExpectedDirection    string
+		ExpectedCounterparty Address
+	}
+
+	subscriber := Address("0x45849a974058661eb2128aceb60d2c6ed99e2a14")
+	other := Address("0x00000000219ab540356cbb839cbe05303d7705fa")
+
+	testCases := []TestCase{
+		{
+			Name:                 "incoming",
+			Transaction:          Transaction{From: other.String(), To: subscriber.String()},
+			ExpectedDirection:    DirectionIncoming,
+			ExpectedCounterparty: other,
+		},
+		{
+			Name:                 "outgoing with checksum addresses",
+			Transaction:          Transaction{From: subscriber.Checksum(), To: other.Checksum()},
+			ExpectedDirection:    DirectionOutgoing,
+			ExpectedCounterparty: other,
+		},
+		{
+			Name:                 "self",
+			Transaction:          Transaction{From: subscriber.String(), To: subscriber.String()},
+			ExpectedDirection:    DirectionSelf,
+			ExpectedCounterparty: subscriber,
+		},
+		{
+			Name:                 "contract creation",
+			Transaction:          Transaction{From: subscriber.String()},
+			ExpectedDirection:    DirectionOutgoing,
+			ExpectedCounterparty: "",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			assert.Equal(t, testCase.ExpectedDirection, testCase.Transaction.Direction(subscriber))
+			assert.Equal(t, testCase.ExpectedCounterparty, testCase.Transaction.Counterparty(subscriber))
+		})
+	}
+}
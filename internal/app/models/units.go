@@ -0,0 +1,49 @@
+package models
+
+import (
+	"math/big"
+	"strings"
+)
+
+// Decimals of Ether units relative to wei
+const (
+	// GweiDecimals is a number of decimals of gwei, gas prices are usually shown in gwei
+	GweiDecimals = 9
+	// EtherDecimals is a number of decimals of Ether
+	EtherDecimals = 18
+)
+
+// FormatUnits converts integer amount in the smallest units (like wei) into decimal string
+// with given number of decimals without precision loss. Trailing zeros of fractional part are trimmed,
+// e.g. 1500000000000000000 wei with EtherDecimals is "1.5"
+func FormatUnits(amount *big.Int, decimals int) string {
+	digits := new(big.Int).Abs(amount).String()
+
+	sign := ""
+	if amount.Sign() < 0 {
+		sign = "-"
+	}
+
+	if len(digits) <= decimals {
+		digits = strings.Repeat("0", decimals-len(digits)+1) + digits
+	}
+
+	integerPart := digits[:len(digits)-decimals]
+	fractionalPart := strings.TrimRight(digits[len(digits)-decimals:], "0")
+
+	if fractionalPart == "" {
+		return sign + integerPart
+	}
+
+	return sign + integerPart + "." + fractionalPart
+}
+
+// FormatEther converts amount in wei into Ether decimal string
+func FormatEther(wei *big.Int) string {
+	return FormatUnits(wei, EtherDecimals)
+}
+
+// FormatGwei converts amount in wei into gwei decimal string
+func FormatGwei(wei *big.Int) string {
+	return FormatUnits(wei, GweiDecimals)
+}
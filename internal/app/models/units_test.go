@@ -0,0 +1,36 @@
+package models
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math/big"
+	"testing"
+)
+
+func TestFormatUnits(t *testing.T) {
+	type TestCase struct {
+		Name     string
+		Amount   string
+		Decimals int
+		Expected string
+	}
+
+	testCases := []TestCase{
+		{Name: "zero", Amount: "0", Decimals: EtherDecimals, Expected: "0"},
+		{Name: "one ether", Amount: "1000000000000000000", Decimals: EtherDecimals, Expected: "1"},
+		{Name: "fractional ether", Amount: "1500000000000000000", Decimals: EtherDecimals, Expected: "1.5"},
+		{Name: "one wei", Amount: "1", Decimals: EtherDecimals, Expected: "0.000000000000000001"},
+		{Name: "gas price in gwei", Amount: "30123456789", Decimals: GweiDecimals, Expected: "30.123456789"},
+		{Name: "large amount", Amount: "123456789000000000000000000", Decimals: EtherDecimals, Expected: "123456789"},
+		{Name: "negative amount", Amount: "-250000000000000000", Decimals: EtherDecimals, Expected: "-0.25"},
+		{Name: "no decimals", Amount: "42", Decimals: 0, Expected: "42"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			amount, ok := new(big.Int).SetString(testCase.Amount, 10)
+			assert.True(t, ok)
+
+			assert.Equal(t, testCase.Expected, FormatUnits(amount, testCase.Decimals))
+		})
+	}
+}
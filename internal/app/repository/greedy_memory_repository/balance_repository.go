@@ -0,0 +1,73 @@
+package greedy_memory_repository
+
+import (
+	"context"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/metrics"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/models"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/tenant"
+	"sync"
+	"time"
+)
+
+// BalanceRepository is a struct that holds balance history of subscribers.
+// Histories are isolated by tenant like subscriptions, tenant is taken from ctx
+type BalanceRepository struct {
+	// balances contains balances of every subscription in chronological order
+	balances   map[models.SubscriberKey][]models.Balance
+	balancesMx sync.RWMutex
+}
+
+// NewBalanceRepository returns a new instance of the BalanceRepository struct
+func NewBalanceRepository() *BalanceRepository {
+	return &BalanceRepository{
+		balances:   make(map[models.SubscriberKey][]models.Balance),
+		balancesMx: sync.RWMutex{},
+	}
+}
+
+// AddBalance appends balance to history of address. Balance at the block that is not newer than the last recorded one
+// is ignored, so repeated indexing of the same block does not duplicate history
+func (r *BalanceRepository) AddBalance(ctx context.Context, address models.Address, balance models.Balance) error {
+	defer metrics.ObserveRepositoryOperation(repositoryName, "AddBalance", time.Now())
+
+	key := tenant.Key(ctx, address)
+
+	r.balancesMx.Lock()
+	defer r.balancesMx.Unlock()
+
+	history := r.balances[key]
+	if len(history) != 0 && history[len(history)-1].BlockNumber >= balance.BlockNumber {
+		return nil
+	}
+
+	r.balances[key] = append(history, balance)
+
+	return nil
+}
+
+// GetBalanceHistory returns copy of balance history of address, the first recorded balance goes first
+func (r *BalanceRepository) GetBalanceHistory(ctx context.Context, address models.Address) ([]models.Balance, error) {
+	defer metrics.ObserveRepositoryOperation(repositoryName, "GetBalanceHistory", time.Now())
+
+	key := tenant.Key(ctx, address)
+
+	r.balancesMx.RLock()
+	defer r.balancesMx.RUnlock()
+
+	history := make([]models.Balance, len(r.balances[key]))
+	copy(history, r.balances[key])
+
+	return history, nil
+}
+
+// DeleteBalanceHistory removes balance history of address, it is not an error if there is no history
+func (r *BalanceRepository) DeleteBalanceHistory(ctx context.Context, address models.Address) error {
+	defer metrics.ObserveRepositoryOperation(repositoryName, "DeleteBalanceHistory", time.Now())
+
+	r.balancesMx.Lock()
+	defer r.balancesMx.Unlock()
+
+	delete(r.balances, tenant.Key(ctx, address))
+
+	return nil
+}
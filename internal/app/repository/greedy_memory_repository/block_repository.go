@@ -2,7 +2,9 @@ package greedy_memory_repository
 
 import (
 	"context"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/metrics"
 	"sync"
+	"time"
 )
 
 // BlockRepository is a struct that contains information about the current block in a blockchain.
@@ -23,6 +25,8 @@ func NewBlockRepository() *BlockRepository {
 
 // SetMaxCurrentBlock updates the currentBlock in the BlockRepository if the newCurrentBlock is greater than the currentBlock.
 func (r *BlockRepository) SetMaxCurrentBlock(ctx context.Context, newCurrentBlock uint64) error {
+	defer metrics.ObserveRepositoryOperation(repositoryName, "SetMaxCurrentBlock", time.Now())
+
 	// Acquire a read lock to prevent concurrent writes to the currentBlock.
 	r.currentBlockMx.RLock()
 
@@ -51,6 +55,8 @@ func (r *BlockRepository) SetMaxCurrentBlock(ctx context.Context, newCurrentBloc
 
 // GetCurrentBlock returns the currentBlock in the BlockRepository.
 func (r *BlockRepository) GetCurrentBlock(ctx context.Context) (uint64, error) {
+	defer metrics.ObserveRepositoryOperation(repositoryName, "GetCurrentBlock", time.Now())
+
 	// Acquire a read lock to prevent concurrent writes to the currentBlock.
 	r.currentBlockMx.RLock()
 
@@ -2,15 +2,21 @@ package greedy_memory_repository
 
 import (
 	"context"
-	"errors"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/metrics"
 	"github.com/bluntenpassant/ethereum_subscriber/internal/app/models"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/tenant"
 	"sync"
+	"time"
 )
 
-// SubscriberRepository is a struct that holds the map of subscribers and their transactions
+// repositoryName is a label of repository in metrics
+const repositoryName = "greedy_memory"
+
+// SubscriberRepository is a struct that holds the map of subscribers and their transactions.
+// Subscribers are isolated by tenant, tenant of per-address operations is taken from ctx
 type SubscriberRepository struct {
-	subscribers   map[string]models.Subscriber
-	subscriberTxs map[string][]*models.Transaction
+	subscribers   map[models.SubscriberKey]models.Subscriber
+	subscriberTxs map[models.SubscriberKey][]*models.Transaction
 
 	// Mutexes to ensure concurrency safety while accessing subscribers and subscriber transactions maps
 	subscribersMx    sync.RWMutex
@@ -20,44 +26,82 @@ type SubscriberRepository struct {
 // NewSubscriberRepository returns a new instance of the SubscriberRepository struct
 func NewSubscriberRepository() *SubscriberRepository {
 	return &SubscriberRepository{
-		subscribers:      make(map[string]models.Subscriber),
-		subscriberTxs:    make(map[string][]*models.Transaction),
+		subscribers:      make(map[models.SubscriberKey]models.Subscriber),
+		subscriberTxs:    make(map[models.SubscriberKey][]*models.Transaction),
 		subscribersMx:    sync.RWMutex{},
 		subscribersTxsMx: sync.RWMutex{},
 	}
 }
 
 // GetTransactionsReversed returns the reversed transactions of a subscriber by address
-func (r *SubscriberRepository) GetTransactionsReversed(ctx context.Context, address string) ([]*models.Transaction, error) {
+func (r *SubscriberRepository) GetTransactionsReversed(ctx context.Context, address models.Address) ([]*models.Transaction, error) {
+	defer metrics.ObserveRepositoryOperation(repositoryName, "GetTransactionsReversed", time.Now())
+
+	key := tenant.Key(ctx, address)
+
 	// Lock the subscribers map for reading to ensure concurrency safety
 	r.subscribersMx.RLock()
-	if _, ok := r.subscribers[address]; !ok {
+	if _, ok := r.subscribers[key]; !ok {
 		r.subscribersMx.RUnlock()
-		return nil, errors.New("address is not registered")
+		return nil, models.ErrNotSubscribed
 	}
 	r.subscribersMx.RUnlock()
 
 	// Lock the subscribers transactions map for reading to ensure concurrency safety
 	r.subscribersTxsMx.RLock()
-	reversedSharedTransactions := models.ReverseTransactionsCopy(r.subscriberTxs[address])
+	reversedSharedTransactions := models.ReverseTransactionsCopy(r.subscriberTxs[key])
 	r.subscribersTxsMx.RUnlock()
 
 	return reversedSharedTransactions, nil
 }
 
+// ForEachTransaction calls fn with a copy of every transaction of a subscriber in chronological order
+// and stops on the first error of fn. Transactions are only appended, so the map is not locked while fn is called
+func (r *SubscriberRepository) ForEachTransaction(ctx context.Context, address models.Address, fn func(tx *models.Transaction) error) error {
+	defer metrics.ObserveRepositoryOperation(repositoryName, "ForEachTransaction", time.Now())
+
+	key := tenant.Key(ctx, address)
+
+	r.subscribersMx.RLock()
+	if _, ok := r.subscribers[key]; !ok {
+		r.subscribersMx.RUnlock()
+		return models.ErrNotSubscribed
+	}
+	r.subscribersMx.RUnlock()
+
+	r.subscribersTxsMx.RLock()
+	txs := r.subscriberTxs[key]
+	r.subscribersTxsMx.RUnlock()
+
+	for _, tx := range txs {
+		txCopy := *tx
+
+		err := fn(&txCopy)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // GetLastTransaction returns the last transaction of a subscriber by address
-func (r *SubscriberRepository) GetLastTransaction(ctx context.Context, address string) (*models.Transaction, error) {
+func (r *SubscriberRepository) GetLastTransaction(ctx context.Context, address models.Address) (*models.Transaction, error) {
+	defer metrics.ObserveRepositoryOperation(repositoryName, "GetLastTransaction", time.Now())
+
+	key := tenant.Key(ctx, address)
+
 	// Lock the subscribers map for reading to ensure concurrency safety
 	r.subscribersMx.RLock()
-	if _, ok := r.subscribers[address]; !ok {
+	if _, ok := r.subscribers[key]; !ok {
 		r.subscribersMx.RUnlock()
-		return nil, errors.New("address is not registered")
+		return nil, models.ErrNotSubscribed
 	}
 	r.subscribersMx.RUnlock()
 
 	// Lock the subscribers transactions map for reading to ensure concurrency safety
 	r.subscribersTxsMx.RLock()
-	txs := r.subscriberTxs[address]
+	txs := r.subscriberTxs[key]
 	if len(txs) == 0 {
 		r.subscribersTxsMx.RUnlock()
 		return nil, nil
@@ -71,22 +115,26 @@ func (r *SubscriberRepository) GetLastTransaction(ctx context.Context, address s
 
 // AddTransactions adds transactions to the subscriber with the given address.
 // If the address is not registered, it returns an error.
-func (r *SubscriberRepository) AddTransactions(ctx context.Context, address string, txs []*models.Transaction) error {
+func (r *SubscriberRepository) AddTransactions(ctx context.Context, address models.Address, txs []*models.Transaction) error {
+	defer metrics.ObserveRepositoryOperation(repositoryName, "AddTransactions", time.Now())
+
+	key := tenant.Key(ctx, address)
+
 	// Read lock on subscribers to safely access the map
 	r.subscribersMx.RLock()
-	subscriber, ok := r.subscribers[address]
+	subscriber, ok := r.subscribers[key]
 	if !ok {
 		// Unlock the map before returning
 		r.subscribersMx.RUnlock()
-		return errors.New("address is not registered")
+		return models.ErrNotSubscribed
 	}
 	r.subscribersMx.RUnlock()
 
 	// Write lock on subscriberTxs to safely access and modify the map
 	r.subscribersTxsMx.Lock()
-	r.subscriberTxs[address] = append(r.subscriberTxs[address], txs...)
+	r.subscriberTxs[key] = append(r.subscriberTxs[key], txs...)
 
-	internalTxs := r.subscriberTxs[address]
+	internalTxs := r.subscriberTxs[key]
 	r.subscribersTxsMx.Unlock()
 
 	var subscribeBlockNumber uint64
@@ -98,11 +146,9 @@ func (r *SubscriberRepository) AddTransactions(ctx context.Context, address stri
 
 	// Write lock on subscribers to safely access and modify the map
 	r.subscribersMx.Lock()
-	r.subscribers[address] = models.Subscriber{
-		Address:              address,
-		SubscribeBlockNumber: subscribeBlockNumber,
-		SubscribeTxCount:     subscriber.SubscribeTxCount + uint64(len(txs)),
-	}
+	subscriber.SubscribeBlockNumber = subscribeBlockNumber
+	subscriber.SubscribeTxCount += uint64(len(txs))
+	r.subscribers[key] = subscriber
 	r.subscribersMx.Unlock()
 
 	return nil
@@ -112,21 +158,23 @@ func (r *SubscriberRepository) AddTransactions(ctx context.Context, address stri
 // If the subscriber already exists, it returns an error.
 // The function uses a lock to ensure thread-safety while accessing the `subscribers` map.
 func (r *SubscriberRepository) AddNewSubscriber(ctx context.Context, subscriber models.Subscriber) error {
+	defer metrics.ObserveRepositoryOperation(repositoryName, "AddNewSubscriber", time.Now())
+
 	// Acquire the lock for the subscribers map
 	r.subscribersMx.Lock()
 
 	// Check if the subscriber already exists
-	if _, ok := r.subscribers[subscriber.Address]; ok {
+	if _, ok := r.subscribers[subscriber.Key()]; ok {
 		// Release the lock
 		r.subscribersMx.Unlock()
 		// Return an error if the subscriber already exists
-		return errors.New("subscriber already registered")
+		return models.ErrAlreadySubscribed
 	}
 
 	// Add the new subscriber to the subscribers map
-	r.subscribers[subscriber.Address] = subscriber
+	r.subscribers[subscriber.Key()] = subscriber
 	// Initialize the transactions slice for the subscriber
-	r.subscriberTxs[subscriber.Address] = []*models.Transaction{}
+	r.subscriberTxs[subscriber.Key()] = []*models.Transaction{}
 
 	// Release the lock
 	r.subscribersMx.Unlock()
@@ -138,17 +186,21 @@ func (r *SubscriberRepository) AddNewSubscriber(ctx context.Context, subscriber
 // GetSubscriberByAddress returns the subscriber with the specified address.
 // If the address is not subscribed, it returns an error.
 // The function uses a read lock to ensure thread-safety while accessing the `subscribers` map.
-func (r *SubscriberRepository) GetSubscriberByAddress(ctx context.Context, address string) (models.Subscriber, error) {
+func (r *SubscriberRepository) GetSubscriberByAddress(ctx context.Context, address models.Address) (models.Subscriber, error) {
+	defer metrics.ObserveRepositoryOperation(repositoryName, "GetSubscriberByAddress", time.Now())
+
+	key := tenant.Key(ctx, address)
+
 	// Acquire the read lock for the subscribers map
 	r.subscribersMx.RLock()
 
 	// Get the subscriber with the specified address
-	subscriber, ok := r.subscribers[address]
+	subscriber, ok := r.subscribers[key]
 	if !ok {
 		// Release the read lock
 		r.subscribersMx.RUnlock()
 		// Return an error if the address is not subscribed
-		return models.Subscriber{}, errors.New("address is not subscribed")
+		return models.Subscriber{}, models.ErrNotSubscribed
 	}
 
 	// Release the read lock
@@ -157,3 +209,94 @@ func (r *SubscriberRepository) GetSubscriberByAddress(ctx context.Context, addre
 	// Return the subscriber and nil to indicate success
 	return subscriber, nil
 }
+
+// GetSubscribers returns all registered subscribers of all tenants.
+// The function uses a read lock to ensure thread-safety while accessing the `subscribers` map.
+func (r *SubscriberRepository) GetSubscribers(ctx context.Context) ([]models.Subscriber, error) {
+	defer metrics.ObserveRepositoryOperation(repositoryName, "GetSubscribers", time.Now())
+
+	// Acquire the read lock for the subscribers map
+	r.subscribersMx.RLock()
+	defer r.subscribersMx.RUnlock()
+
+	subscribers := make([]models.Subscriber, 0, len(r.subscribers))
+	for _, subscriber := range r.subscribers {
+		subscribers = append(subscribers, subscriber)
+	}
+
+	return subscribers, nil
+}
+
+// CountSubscribers returns count of subscriptions of the tenant from ctx
+func (r *SubscriberRepository) CountSubscribers(ctx context.Context) (int, error) {
+	defer metrics.ObserveRepositoryOperation(repositoryName, "CountSubscribers", time.Now())
+
+	tenantID := tenant.FromContext(ctx)
+
+	r.subscribersMx.RLock()
+	defer r.subscribersMx.RUnlock()
+
+	count := 0
+	for key := range r.subscribers {
+		if key.TenantID == tenantID {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+// AddBlockTransactions stores transactions found in a single indexed block and moves cursors of subscribers
+// to this block. Every subscriber that was matched against the block must be present in txsBySubscriber,
+// even if no transactions were found for it. Subscribers whose cursor is already at or beyond blockNumber
+// or that are not registered are skipped. Both maps are locked for the whole operation,
+// so readers never see transactions without an updated cursor or vice versa.
+func (r *SubscriberRepository) AddBlockTransactions(ctx context.Context, blockNumber uint64, txsBySubscriber map[models.SubscriberKey][]*models.Transaction) error {
+	defer metrics.ObserveRepositoryOperation(repositoryName, "AddBlockTransactions", time.Now())
+
+	// Lock both maps for writing to keep subscribers cursors and transactions consistent
+	r.subscribersMx.Lock()
+	defer r.subscribersMx.Unlock()
+	r.subscribersTxsMx.Lock()
+	defer r.subscribersTxsMx.Unlock()
+
+	for key, txs := range txsBySubscriber {
+		subscriber, ok := r.subscribers[key]
+		// Skip unknown subscribers and subscribers that already indexed this block
+		if !ok || subscriber.SubscribeBlockNumber >= blockNumber {
+			continue
+		}
+
+		r.subscriberTxs[key] = append(r.subscriberTxs[key], txs...)
+
+		subscriber.SubscribeBlockNumber = blockNumber
+		subscriber.SubscribeTxCount += uint64(len(txs))
+		r.subscribers[key] = subscriber
+	}
+
+	return nil
+}
+
+// DeleteSubscriber removes the subscriber with the specified address together with its transactions.
+// If the address is not subscribed, it returns an error.
+func (r *SubscriberRepository) DeleteSubscriber(ctx context.Context, address models.Address) error {
+	defer metrics.ObserveRepositoryOperation(repositoryName, "DeleteSubscriber", time.Now())
+
+	key := tenant.Key(ctx, address)
+
+	// Both maps are locked in the same order as in AddBlockTransactions, so indexing never sees a half removed subscriber
+	r.subscribersMx.Lock()
+	defer r.subscribersMx.Unlock()
+
+	if _, ok := r.subscribers[key]; !ok {
+		return models.ErrNotSubscribed
+	}
+
+	r.subscribersTxsMx.Lock()
+	delete(r.subscriberTxs, key)
+	r.subscribersTxsMx.Unlock()
+
+	delete(r.subscribers, key)
+
+	return nil
+}
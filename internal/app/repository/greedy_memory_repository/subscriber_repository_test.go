@@ -2,7 +2,9 @@ package greedy_memory_repository
 
 import (
 	"context"
+	"errors"
 	"github.com/bluntenpassant/ethereum_subscriber/internal/app/models"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/tenant"
 	"github.com/stretchr/testify/assert"
 	"math/big"
 	"testing"
@@ -199,6 +201,80 @@ func TestSubscriberRepository_GetTransactionsReversed(t *testing.T) {
 	}
 }
 
+func TestSubscriberRepository_ForEachTransaction(t *testing.T) {
+	ctx := context.TODO()
+
+	subscriberRepository := NewSubscriberRepository()
+
+	transactions := []*models.Transaction{
+		{BlockNumber: 16614479, Hash: "0x0f41f88706566a6e14bfc1f85d9761eb216a3b141c6eec13c820b6da569bf8a5", Value: *big.NewInt(1)},
+		{BlockNumber: 16614480, Hash: "0x1f41f88706566a6e14bfc1f85d9761eb216a3b141c6eec13c820b6da569bf8a5", Value: *big.NewInt(2)},
+		{BlockNumber: 16614481, Hash: "0x2f41f88706566a6e14bfc1f85d9761eb216a3b141c6eec13c820b6da569bf8a5", Value: *big.NewInt(3)},
+	}
+
+	fnErr := errors.New("client is gone")
+
+	type TestCase struct {
+		Name          string
+		Subscriber    *models.Subscriber
+		Transactions  []*models.Transaction
+		Address       models.Address
+		FnErr         error
+		ExpectedErr   error
+		ExpectedCount int
+	}
+
+	testCases := []TestCase{
+		{
+			Name:        "not subscribed",
+			Address:     "0x1111111111111111111111111111111111111111",
+			ExpectedErr: models.ErrNotSubscribed,
+		},
+		{
+			Name:       "no transactions",
+			Subscriber: &models.Subscriber{Address: "0x2222222222222222222222222222222222222222"},
+			Address:    "0x2222222222222222222222222222222222222222",
+		},
+		{
+			Name:          "transactions in chronological order",
+			Subscriber:    &models.Subscriber{Address: "0x3333333333333333333333333333333333333333"},
+			Transactions:  transactions,
+			Address:       "0x3333333333333333333333333333333333333333",
+			ExpectedCount: len(transactions),
+		},
+		{
+			Name:          "fn failed",
+			Subscriber:    &models.Subscriber{Address: "0x4444444444444444444444444444444444444444"},
+			Transactions:  transactions,
+			Address:       "0x4444444444444444444444444444444444444444",
+			FnErr:         fnErr,
+			ExpectedErr:   fnErr,
+			ExpectedCount: 1,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			if testCase.Subscriber != nil {
+				assert.NoError(t, subscriberRepository.AddNewSubscriber(ctx, *testCase.Subscriber))
+				assert.NoError(t, subscriberRepository.AddTransactions(ctx, testCase.Address, testCase.Transactions))
+			}
+
+			var txs []*models.Transaction
+			err := subscriberRepository.ForEachTransaction(ctx, testCase.Address, func(tx *models.Transaction) error {
+				txs = append(txs, tx)
+				return testCase.FnErr
+			})
+			assert.ErrorIs(t, err, testCase.ExpectedErr)
+			assert.Len(t, txs, testCase.ExpectedCount)
+
+			if testCase.ExpectedErr == nil {
+				assert.EqualValues(t, testCase.Transactions, txs)
+			}
+		})
+	}
+}
+
 func TestSubscriberRepository_AddTransactions(t *testing.T) {
 	ctx := context.TODO()
 
@@ -279,7 +355,7 @@ func TestSubscriberRepository_AddTransactions(t *testing.T) {
 		// because we must get error in a last testcase due to duplicating registered address
 		err := subscriberRepository.AddNewSubscriber(ctx, testCase.Subscriber)
 		assert.NoError(t, err)
-		err = subscriberRepository.AddTransactions(ctx, testCase.Name, testCase.Transactions)
+		err = subscriberRepository.AddTransactions(ctx, models.Address(testCase.Name), testCase.Transactions)
 		if testCase.IsErr {
 			assert.Error(t, err)
 		}
@@ -384,3 +460,223 @@ func TestSubscriberRepository_GetSubscriberByAddress(t *testing.T) {
 		})
 	}
 }
+
+func TestSubscriberRepository_GetSubscribers(t *testing.T) {
+	ctx := context.TODO()
+
+	subscriberRepository := NewSubscriberRepository()
+
+	subscribers, err := subscriberRepository.GetSubscribers(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(subscribers))
+
+	expectedSubscribers := []models.Subscriber{
+		{
+			Address:              "0x45849a974058661eb2128aceb60d2c6ed99e2a14",
+			SubscribeBlockNumber: 15,
+			SubscribeTxCount:     14,
+		},
+		{
+			Address:              "0x388c818ca8b9251b393131c08a736a67ccb19297",
+			SubscribeBlockNumber: 19,
+			SubscribeTxCount:     7,
+		},
+	}
+
+	for _, subscriber := range expectedSubscribers {
+		err = subscriberRepository.AddNewSubscriber(ctx, subscriber)
+		assert.NoError(t, err)
+	}
+
+	subscribers, err = subscriberRepository.GetSubscribers(ctx)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, expectedSubscribers, subscribers)
+}
+
+func TestSubscriberRepository_CountSubscribers(t *testing.T) {
+	ctx := context.TODO()
+	tenantACtx := tenant.WithID(ctx, "tenant_a")
+	tenantBCtx := tenant.WithID(ctx, "tenant_b")
+
+	subscriberRepository := NewSubscriberRepository()
+
+	assert.NoError(t, subscriberRepository.AddNewSubscriber(ctx, models.Subscriber{Address: "0xd8da6bf26964af9d7eed9e03e53415d37aa96045"}))
+	assert.NoError(t, subscriberRepository.AddNewSubscriber(tenantACtx, models.Subscriber{Address: "0xd8da6bf26964af9d7eed9e03e53415d37aa96045", TenantID: "tenant_a"}))
+	assert.NoError(t, subscriberRepository.AddNewSubscriber(tenantACtx, models.Subscriber{Address: "0xdef1c0ded9bec7f1a1670819833240f027b25eff", TenantID: "tenant_a"}))
+
+	type TestCase struct {
+		name          string
+		ctx           context.Context
+		expectedCount int
+	}
+
+	testCases := []TestCase{
+		{name: "without tenant", ctx: ctx, expectedCount: 1},
+		{name: "tenant with subscriptions", ctx: tenantACtx, expectedCount: 2},
+		{name: "tenant without subscriptions", ctx: tenantBCtx, expectedCount: 0},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			count, err := subscriberRepository.CountSubscribers(testCase.ctx)
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expectedCount, count)
+		})
+	}
+}
+
+func TestSubscriberRepository_AddBlockTransactions(t *testing.T) {
+	ctx := context.TODO()
+
+	subscriberRepository := NewSubscriberRepository()
+
+	sender := models.Subscriber{
+		Address:              "0x45849a974058661eb2128aceb60d2c6ed99e2a14",
+		SubscribeBlockNumber: 16614478,
+	}
+	receiver := models.Subscriber{
+		Address:              "0x388c818ca8b9251b393131c08a736a67ccb19297",
+		SubscribeBlockNumber: 16614478,
+	}
+	// already indexed block in the past, so block should not be applied to it
+	ahead := models.Subscriber{
+		Address:              "0x6cc8dcbca746a6e4fdefb98e1d0df903b107fd21",
+		SubscribeBlockNumber: 16614480,
+	}
+
+	for _, subscriber := range []models.Subscriber{sender, receiver, ahead} {
+		err := subscriberRepository.AddNewSubscriber(ctx, subscriber)
+		assert.NoError(t, err)
+	}
+
+	tx := &models.Transaction{
+		BlockHash:   "0xdfcbf46fb8ae7c1769e28a864b7e49d66e931214010a3d0c3c82248d2b0bd50f",
+		BlockNumber: 16614479,
+		From:        sender.Address.String(),
+		Gas:         *big.NewInt(16338636656),
+		GasPrice:    *big.NewInt(16338636656),
+		Hash:        "0x0f41f88706566a6e14bfc1f85d9761eb216a3b141c6eec13c820b6da569bf8a5",
+		To:          receiver.Address.String(),
+	}
+
+	err := subscriberRepository.AddBlockTransactions(ctx, 16614479, map[models.SubscriberKey][]*models.Transaction{
+		sender.Key():   {tx},
+		receiver.Key(): {},
+		ahead.Key():    {tx},
+		// not registered address must be ignored
+		{Address: "0x0000000000000000000000000000000000000000"}: {tx},
+		// subscription of another tenant must be ignored
+		{TenantID: "tenant_a", Address: receiver.Address}: {tx},
+	})
+	assert.NoError(t, err)
+
+	gotSender, err := subscriberRepository.GetSubscriberByAddress(ctx, sender.Address)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(16614479), gotSender.SubscribeBlockNumber)
+	assert.Equal(t, uint64(1), gotSender.SubscribeTxCount)
+
+	gotReceiver, err := subscriberRepository.GetSubscriberByAddress(ctx, receiver.Address)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(16614479), gotReceiver.SubscribeBlockNumber)
+	assert.Equal(t, uint64(0), gotReceiver.SubscribeTxCount)
+
+	gotAhead, err := subscriberRepository.GetSubscriberByAddress(ctx, ahead.Address)
+	assert.NoError(t, err)
+	assert.EqualValues(t, ahead, gotAhead)
+
+	senderTxs, err := subscriberRepository.GetTransactionsReversed(ctx, sender.Address)
+	assert.NoError(t, err)
+	assert.EqualValues(t, []*models.Transaction{tx}, senderTxs)
+
+	aheadTxs, err := subscriberRepository.GetTransactionsReversed(ctx, ahead.Address)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(aheadTxs))
+
+	// applying the same block again must not duplicate transactions
+	err = subscriberRepository.AddBlockTransactions(ctx, 16614479, map[models.SubscriberKey][]*models.Transaction{
+		sender.Key(): {tx},
+	})
+	assert.NoError(t, err)
+
+	senderTxs, err = subscriberRepository.GetTransactionsReversed(ctx, sender.Address)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(senderTxs))
+}
+
+func TestSubscriberRepository_TenantIsolation(t *testing.T) {
+	ctx := context.TODO()
+	tenantACtx := tenant.WithID(ctx, "tenant_a")
+	tenantBCtx := tenant.WithID(ctx, "tenant_b")
+
+	subscriberRepository := NewSubscriberRepository()
+
+	subscriberA := models.Subscriber{
+		ChainID:              1,
+		Address:              "0x45849a974058661eb2128aceb60d2c6ed99e2a14",
+		SubscribeBlockNumber: 16614478,
+		TenantID:             "tenant_a",
+	}
+	subscriberB := subscriberA
+	subscriberB.TenantID = "tenant_b"
+
+	assert.NoError(t, subscriberRepository.AddNewSubscriber(tenantACtx, subscriberA))
+	assert.NoError(t, subscriberRepository.AddNewSubscriber(tenantBCtx, subscriberB))
+
+	tx := &models.Transaction{
+		BlockNumber: 16614479,
+		From:        subscriberA.Address.String(),
+		Hash:        "0x0f41f88706566a6e14bfc1f85d9761eb216a3b141c6eec13c820b6da569bf8a5",
+	}
+
+	err := subscriberRepository.AddTransactions(tenantACtx, subscriberA.Address, []*models.Transaction{tx})
+	assert.NoError(t, err)
+
+	txsA, err := subscriberRepository.GetTransactionsReversed(tenantACtx, subscriberA.Address)
+	assert.NoError(t, err)
+	assert.Equal(t, []*models.Transaction{tx}, txsA)
+
+	txsB, err := subscriberRepository.GetTransactionsReversed(tenantBCtx, subscriberB.Address)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(txsB))
+
+	_, err = subscriberRepository.GetTransactionsReversed(ctx, subscriberA.Address)
+	assert.ErrorIs(t, err, models.ErrNotSubscribed)
+
+	// Cursor update keeps chain and tenant of subscriber
+	gotSubscriberA, err := subscriberRepository.GetSubscriberByAddress(tenantACtx, subscriberA.Address)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), gotSubscriberA.ChainID)
+	assert.Equal(t, "tenant_a", gotSubscriberA.TenantID)
+	assert.Equal(t, uint64(1), gotSubscriberA.SubscribeTxCount)
+}
+
+func TestSubscriberRepository_DeleteSubscriber(t *testing.T) {
+	ctx := context.TODO()
+	tenantACtx := tenant.WithID(ctx, "tenant_a")
+
+	subscriberRepository := NewSubscriberRepository()
+
+	subscriber := models.Subscriber{Address: "0xd8da6bf26964af9d7eed9e03e53415d37aa96045", SubscribeBlockNumber: 5, TenantID: "tenant_a"}
+	assert.NoError(t, subscriberRepository.AddNewSubscriber(tenantACtx, subscriber))
+	assert.NoError(t, subscriberRepository.AddTransactions(tenantACtx, subscriber.Address, []*models.Transaction{{BlockNumber: 6}}))
+
+	// Subscription of another tenant is not affected
+	err := subscriberRepository.DeleteSubscriber(ctx, subscriber.Address)
+	assert.ErrorIs(t, err, models.ErrNotSubscribed)
+
+	err = subscriberRepository.DeleteSubscriber(tenantACtx, subscriber.Address)
+	assert.NoError(t, err)
+
+	_, err = subscriberRepository.GetSubscriberByAddress(tenantACtx, subscriber.Address)
+	assert.ErrorIs(t, err, models.ErrNotSubscribed)
+
+	err = subscriberRepository.DeleteSubscriber(tenantACtx, subscriber.Address)
+	assert.ErrorIs(t, err, models.ErrNotSubscribed)
+
+	// Transactions of removed subscription are not returned after subscribing again
+	assert.NoError(t, subscriberRepository.AddNewSubscriber(tenantACtx, subscriber))
+
+	txs, err := subscriberRepository.GetTransactionsReversed(tenantACtx, subscriber.Address)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(txs))
+}
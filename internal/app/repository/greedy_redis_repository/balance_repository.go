@@ -0,0 +1,110 @@
+package greedy_redis_repository
+
+import (
+	"context"
+	"errors"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/metrics"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/models"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/tenant"
+	redis_driver "github.com/redis/go-redis/v9"
+	"sync/atomic"
+	"time"
+)
+
+// BalanceRepository is a struct that represents a repository for balance history of subscribers.
+// History of every address is kept in Redis list in chronological order.
+type BalanceRepository struct {
+	// keySpace selects keys of the approach and the chain, so their data does not collide
+	keySpace KeySpace
+	redis    *redis_driver.Client
+	// expirationTime is kept as nanoseconds to be changed at runtime by SetExpirationTime
+	expirationTime atomic.Int64
+}
+
+// NewBalanceRepository returns a new instance of the BalanceRepository with the specified Redis client and expiration time.
+func NewBalanceRepository(keySpace KeySpace, redis *redis_driver.Client, expirationTime time.Duration) *BalanceRepository {
+	repository := &BalanceRepository{
+		keySpace: keySpace,
+		redis:    redis,
+	}
+	repository.SetExpirationTime(expirationTime)
+
+	return repository
+}
+
+// SetExpirationTime changes expiration time of keys written after the call, it is safe for concurrent use
+func (r *BalanceRepository) SetExpirationTime(expirationTime time.Duration) {
+	r.expirationTime.Store(int64(expirationTime))
+}
+
+func (r *BalanceRepository) getExpirationTime() time.Duration {
+	return time.Duration(r.expirationTime.Load())
+}
+
+// AddBalance appends balance to history of address. Balance at the block that is not newer than the last recorded one
+// is ignored, so repeated indexing of the same block does not duplicate history.
+// Balances of the address are expected to be added by a single writer (indexing pass)
+func (r *BalanceRepository) AddBalance(ctx context.Context, address models.Address, balance models.Balance) error {
+	defer metrics.ObserveRepositoryOperation(repositoryName, "AddBalance", time.Now())
+
+	key := getSubscribersBalancesKey(r.keySpace, tenant.Key(ctx, address))
+
+	rawLastBalance, err := r.redis.LIndex(ctx, key, -1).Bytes()
+	if err != nil && !errors.Is(err, redis_driver.Nil) {
+		return err
+	}
+
+	if err == nil {
+		lastBalance, err := deserializeBalanceValue(rawLastBalance)
+		if err != nil {
+			return err
+		}
+
+		if lastBalance.BlockNumber >= balance.BlockNumber {
+			return nil
+		}
+	}
+
+	rawBalance, err := serializeBalanceValue(balance)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.redis.TxPipelined(ctx, func(pipe redis_driver.Pipeliner) error {
+		pipe.RPush(ctx, key, rawBalance)
+		pipe.Expire(ctx, key, r.getExpirationTime())
+
+		return nil
+	})
+
+	return err
+}
+
+// GetBalanceHistory returns balance history of address, the first recorded balance goes first
+func (r *BalanceRepository) GetBalanceHistory(ctx context.Context, address models.Address) ([]models.Balance, error) {
+	defer metrics.ObserveRepositoryOperation(repositoryName, "GetBalanceHistory", time.Now())
+
+	rawBalances, err := r.redis.LRange(ctx, getSubscribersBalancesKey(r.keySpace, tenant.Key(ctx, address)), 0, -1).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	history := make([]models.Balance, 0, len(rawBalances))
+	for _, rawBalance := range rawBalances {
+		balance, err := deserializeBalanceValue([]byte(rawBalance))
+		if err != nil {
+			return nil, err
+		}
+
+		history = append(history, balance)
+	}
+
+	return history, nil
+}
+
+// DeleteBalanceHistory removes balance history of address, it is not an error if there is no history
+func (r *BalanceRepository) DeleteBalanceHistory(ctx context.Context, address models.Address) error {
+	defer metrics.ObserveRepositoryOperation(repositoryName, "DeleteBalanceHistory", time.Now())
+
+	return r.redis.Del(ctx, getSubscribersBalancesKey(r.keySpace, tenant.Key(ctx, address))).Err()
+}
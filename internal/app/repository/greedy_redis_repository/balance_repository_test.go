@@ -0,0 +1,67 @@
+package greedy_redis_repository
+
+import (
+	"context"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/models"
+	"github.com/stretchr/testify/assert"
+	"math/big"
+	"testing"
+	"time"
+)
+
+func TestBalanceRepository_AddBalance(t *testing.T) {
+	ctx := context.TODO()
+	address := models.Address("0x45849a974058661eb2128aceb60d2c6ed99e2a14")
+
+	redisClient := newTestRedisClient(t)
+	redisClient.Del(ctx, getSubscribersBalancesKey(testKeySpace, models.SubscriberKey{Address: address}))
+	defer redisClient.Del(ctx, getSubscribersBalancesKey(testKeySpace, models.SubscriberKey{Address: address}))
+
+	balanceRepository := NewBalanceRepository(testKeySpace, redisClient, 10*time.Second)
+
+	history, err := balanceRepository.GetBalanceHistory(ctx, address)
+	assert.NoError(t, err)
+	assert.Empty(t, history)
+
+	err = balanceRepository.AddBalance(ctx, address, models.Balance{BlockNumber: 10, Balance: *big.NewInt(100)})
+	assert.NoError(t, err)
+
+	err = balanceRepository.AddBalance(ctx, address, models.Balance{BlockNumber: 12, Balance: *big.NewInt(50)})
+	assert.NoError(t, err)
+
+	// balance of already recorded block is ignored
+	err = balanceRepository.AddBalance(ctx, address, models.Balance{BlockNumber: 12, Balance: *big.NewInt(70)})
+	assert.NoError(t, err)
+
+	history, err = balanceRepository.GetBalanceHistory(ctx, address)
+	assert.NoError(t, err)
+	assert.Equal(t, []models.Balance{
+		{BlockNumber: 10, Balance: *big.NewInt(100)},
+		{BlockNumber: 12, Balance: *big.NewInt(50)},
+	}, history)
+}
+
+func TestBalanceRepository_DeleteBalanceHistory(t *testing.T) {
+	ctx := context.TODO()
+	address := models.Address("0x45849a974058661eb2128aceb60d2c6ed99e2a14")
+
+	redisClient := newTestRedisClient(t)
+	redisClient.Del(ctx, getSubscribersBalancesKey(testKeySpace, models.SubscriberKey{Address: address}))
+	defer redisClient.Del(ctx, getSubscribersBalancesKey(testKeySpace, models.SubscriberKey{Address: address}))
+
+	balanceRepository := NewBalanceRepository(testKeySpace, redisClient, 10*time.Second)
+
+	err := balanceRepository.AddBalance(ctx, address, models.Balance{BlockNumber: 10, Balance: *big.NewInt(100)})
+	assert.NoError(t, err)
+
+	err = balanceRepository.DeleteBalanceHistory(ctx, address)
+	assert.NoError(t, err)
+
+	history, err := balanceRepository.GetBalanceHistory(ctx, address)
+	assert.NoError(t, err)
+	assert.Empty(t, history)
+
+	// Deleting missing history is not an error
+	err = balanceRepository.DeleteBalanceHistory(ctx, address)
+	assert.NoError(t, err)
+}
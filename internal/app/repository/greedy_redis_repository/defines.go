@@ -3,28 +3,95 @@ package greedy_redis_repository
 import (
 	"encoding/json"
 	"github.com/bluntenpassant/ethereum_subscriber/internal/app/models"
+	"strconv"
+	"strings"
 )
 
-// redisNilErrMsg is a constant that holds the value "redis: nil" and is used to check if the error returned by Redis client is "nil".
-const redisNilErrMsg = "redis: nil"
+// repositoryName is a label of repository in metrics
+const repositoryName = "greedy_redis"
 
-// currentBlockKey is a constant that holds the key for the current block in the Redis database.
-const currentBlockKey = "sync_greedy_key_currentBlock"
+// GreedyKeyPrefix is a prefix of keys of greedy approach in the Redis database.
+const GreedyKeyPrefix = "sync_greedy_key_"
 
-// subscribersKey is a constant that holds the prefix for the subscribers' keys in the Redis database.
-const subscribersKey = "sync_greedy_key_Subscriber-"
+// IndexedKeyPrefix is a prefix of keys of indexed approach in the Redis database. Indexed approach keeps a cursor
+// of every subscriber instead of storing transactions behind the current block, so it does not share keys with greedy approach.
+const IndexedKeyPrefix = "indexed_key_"
 
-// subscribersTxsKey is a constant that holds the prefix for the subscribers' transactions keys in the Redis database.
-const subscribersTxsKey = "sync_greedy_key_SubscriberTxs-"
+// currentBlockKey is a constant that holds the key for the current block in the Redis database, it follows the prefix of approach.
+const currentBlockKey = "currentBlock"
 
-// getCurrentBlockKey returns the key for the current block in the Redis database.
-func getCurrentBlockKey() string {
-	return currentBlockKey
+// subscribersKey is a constant that holds the prefix for the subscribers' keys in the Redis database, it follows the prefix of approach.
+const subscribersKey = "Subscriber-"
+
+// subscribersTxsKey is a constant that holds the prefix for the subscribers' transactions keys in the Redis database, it follows the prefix of approach.
+const subscribersTxsKey = "SubscriberTxs-"
+
+// subscribersSetKey is a constant that holds the key for the set of all registered subscribers' keys (tenant and address) in the Redis database,
+// it follows the prefix of approach.
+const subscribersSetKey = "Subscribers"
+
+// subscribersBalancesKey is a constant that holds the prefix for the subscribers' balance history keys in the Redis database,
+// it follows the prefix of approach.
+const subscribersBalancesKey = "SubscriberBalances-"
+
+// maxTxRetries is a constant that holds the number of attempts for optimistic transactions before giving up.
+const maxTxRetries = 10
+
+// txsChunkSize is a constant that holds the size of chunks in bytes by which transactions of a subscriber are read in ForEachTransaction.
+const txsChunkSize = 64 << 10
+
+// KeySpace selects keys of repositories in the Redis database, so approaches and chains sharing one database do not collide.
+type KeySpace struct {
+	// Prefix is GreedyKeyPrefix or IndexedKeyPrefix
+	Prefix  string
+	ChainID uint64
+	// Default is set for the default chain, its keys do not contain chain ID, the same as before chains were introduced,
+	// so data stored by the single chain version is kept after upgrade
+	Default bool
+}
+
+// key returns the key with name of the approach and the chain
+func (k KeySpace) key(name string) string {
+	if k.Default {
+		return k.Prefix + name
+	}
+
+	return k.Prefix + name + "-" + strconv.FormatUint(k.ChainID, 10)
+}
+
+// subscriberKey returns the key with name prefix of the subscription of the approach and the chain.
+// Keys of tenants always contain chain ID, even on the default chain, so tenant of the default chain cannot pass for a tenant of another chain
+func (k KeySpace) subscriberKey(name string, key models.SubscriberKey) string {
+	if k.Default && key.TenantID == "" {
+		return k.Prefix + name + key.String()
+	}
+
+	return k.Prefix + name + strconv.FormatUint(k.ChainID, 10) + "-" + key.String()
+}
+
+// getCurrentBlockKey returns the key for the current block of the approach and the chain in the Redis database.
+func getCurrentBlockKey(keySpace KeySpace) string {
+	return keySpace.key(currentBlockKey)
 }
 
-// getSubscribersTxsKey returns the key for the transactions of a subscriber in the Redis database.
-func getSubscribersTxsKey(address string) string {
-	return subscribersTxsKey + address
+// getSubscribersSetKey returns the key for the set of all registered subscribers' addresses of the approach and the chain in the Redis database.
+func getSubscribersSetKey(keySpace KeySpace) string {
+	return keySpace.key(subscribersSetKey)
+}
+
+// getSubscribersSetPattern returns MATCH pattern of members of the set of subscribers' keys that belong to the tenant.
+// Members of subscriptions without tenant are bare addresses, tenant IDs contain no glob characters
+func getSubscribersSetPattern(tenantID string) string {
+	if tenantID == "" {
+		return "0x" + strings.Repeat("?", 40)
+	}
+
+	return tenantID + ":*"
+}
+
+// getSubscribersTxsKey returns the key for the transactions of a subscriber of the approach and the chain in the Redis database.
+func getSubscribersTxsKey(keySpace KeySpace, key models.SubscriberKey) string {
+	return keySpace.subscriberKey(subscribersTxsKey, key)
 }
 
 // serializeSubscribersTxsValue serializes an array of transactions into a JSON byte array.
@@ -43,9 +110,10 @@ func deserializeSubscribersTxsValue(rawTxs []byte) ([]*models.Transaction, error
 	return txs, nil
 }
 
-// getSubscribersKey returns the key for a subscriber in the Redis database.
-func getSubscribersKey(address string) string {
-	return subscribersKey + address
+// getSubscribersKey returns the key for a subscriber of the approach and the chain in the Redis database.
+// Key of subscription without tenant on the default chain contains only address, the same as before tenants were introduced.
+func getSubscribersKey(keySpace KeySpace, key models.SubscriberKey) string {
+	return keySpace.subscriberKey(subscribersKey, key)
 }
 
 // serializeCurrentBlockValue serializes a uint64 into a uint64 value.
@@ -73,3 +141,25 @@ func deserealizeSubscribersValue(rawSubscriber []byte) (models.Subscriber, error
 
 	return subscriber, nil
 }
+
+// getSubscribersBalancesKey returns the key for the balance history of a subscriber of the approach and the chain in the Redis database.
+func getSubscribersBalancesKey(keySpace KeySpace, key models.SubscriberKey) string {
+	return keySpace.subscriberKey(subscribersBalancesKey, key)
+}
+
+// serializeBalanceValue serializes a Balance struct into a JSON byte array.
+// Balance is marshaled by pointer, because big.Int implements json.Marshaler only on pointer receiver
+func serializeBalanceValue(balance models.Balance) ([]byte, error) {
+	return json.Marshal(&balance)
+}
+
+// deserializeBalanceValue deserializes a JSON byte array into a Balance struct.
+func deserializeBalanceValue(rawBalance []byte) (models.Balance, error) {
+	var balance models.Balance
+	err := json.Unmarshal(rawBalance, &balance)
+	if err != nil {
+		return models.Balance{}, err
+	}
+
+	return balance, nil
+}
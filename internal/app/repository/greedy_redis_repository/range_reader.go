@@ -0,0 +1,36 @@
+package greedy_redis_repository
+
+import (
+	"context"
+	redis_driver "github.com/redis/go-redis/v9"
+	"io"
+)
+
+// rangeReader reads string value of the key by GETRANGE, so a big value is read by chunks instead of one GET.
+// Missing key reads as an empty value
+type rangeReader struct {
+	ctx    context.Context
+	redis  *redis_driver.Client
+	key    string
+	offset int64
+}
+
+func (r *rangeReader) Read(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+
+	chunk, err := r.redis.GetRange(r.ctx, r.key, r.offset, r.offset+int64(len(p))-1).Bytes()
+	if err != nil {
+		return 0, err
+	}
+
+	if len(chunk) == 0 {
+		return 0, io.EOF
+	}
+
+	n := copy(p, chunk)
+	r.offset += int64(n)
+
+	return n, nil
+}
@@ -0,0 +1,19 @@
+package greedy_redis_repository
+
+import (
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"testing"
+)
+
+// newTestRedisClient returns client of in-memory Redis started for the test, so tests do not share state
+func newTestRedisClient(t *testing.T) *redis.Client {
+	server := miniredis.RunT(t)
+
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() {
+		client.Close()
+	})
+
+	return client
+}
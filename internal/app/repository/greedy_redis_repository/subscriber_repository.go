@@ -1,43 +1,76 @@
 package greedy_redis_repository
 
 import (
+	"bufio"
 	"context"
+	"encoding/json"
 	"errors"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/metrics"
 	"github.com/bluntenpassant/ethereum_subscriber/internal/app/models"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/tenant"
 	redis_driver "github.com/redis/go-redis/v9"
+	"io"
+	"log/slog"
+	"sync/atomic"
 	"time"
 )
 
 // SubscriberRepository is a struct that represents a repository for subscriber data. It holds a Redis client instance and an expiration time for data stored in the Redis cache.
 type SubscriberRepository struct {
-	redis          *redis_driver.Client
-	expirationTime time.Duration
+	// keySpace selects keys of the approach and the chain, so their data does not collide
+	keySpace KeySpace
+	redis    *redis_driver.Client
+	// expirationTime is kept as nanoseconds to be changed at runtime by SetExpirationTime
+	expirationTime atomic.Int64
+	logger         *slog.Logger
 }
 
 // NewSubscriberRepository is a constructor for SubscriberRepository that takes in a Redis client instance and an expiration time for data stored in the Redis cache, and returns a pointer to a SubscriberRepository instance.
-func NewSubscriberRepository(redis *redis_driver.Client, expirationTime time.Duration) *SubscriberRepository {
-	return &SubscriberRepository{
-		redis:          redis,
-		expirationTime: expirationTime,
+// Redis commands are logged by the hook of the client, logger reports decisions of the repository like forgotten subscribers
+func NewSubscriberRepository(keySpace KeySpace, redis *redis_driver.Client, expirationTime time.Duration, logger *slog.Logger) *SubscriberRepository {
+	repository := &SubscriberRepository{
+		keySpace: keySpace,
+		redis:    redis,
+		logger:   logger.With("repository", repositoryName, "key_prefix", keySpace.Prefix, "chain_id", keySpace.ChainID),
 	}
+	repository.SetExpirationTime(expirationTime)
+
+	return repository
+}
+
+// SetExpirationTime changes expiration time of keys written after the call, it is safe for concurrent use
+func (r *SubscriberRepository) SetExpirationTime(expirationTime time.Duration) {
+	r.expirationTime.Store(int64(expirationTime))
+}
+
+func (r *SubscriberRepository) getExpirationTime() time.Duration {
+	return time.Duration(r.expirationTime.Load())
 }
 
 // GetTransactionsReversed retrieves the transactions associated with a subscriber, reversed, from the Redis cache. It takes in a context and the subscriber's address, and returns a slice of Transaction instances and an error if one occurred.
-func (r *SubscriberRepository) GetTransactionsReversed(ctx context.Context, address string) ([]*models.Transaction, error) {
+func (r *SubscriberRepository) GetTransactionsReversed(ctx context.Context, address models.Address) ([]*models.Transaction, error) {
+	defer metrics.ObserveRepositoryOperation(repositoryName, "GetTransactionsReversed", time.Now())
+
+	key := tenant.Key(ctx, address)
+
 	// Check if the subscriber exists in the cache
-	err := r.redis.Get(ctx, getSubscribersKey(address)).Err()
+	err := r.redis.Get(ctx, getSubscribersKey(r.keySpace, key)).Err()
 	if err != nil {
 		// If the subscriber does not exist, return an error indicating that the address is not registered
-		if err.Error() == redisNilErrMsg {
-			return nil, errors.New("address is not registered")
+		if errors.Is(err, redis_driver.Nil) {
+			return nil, models.ErrNotSubscribed
 		}
 		// If there was a different error, return it
 		return nil, err
 	}
 
 	// Get the raw byte representation of the subscriber's transactions from the cache
-	rawTxs, err := r.redis.Get(ctx, getSubscribersTxsKey(address)).Bytes()
+	rawTxs, err := r.redis.Get(ctx, getSubscribersTxsKey(r.keySpace, key)).Bytes()
 	if err != nil {
+		// If no transactions were stored for the subscriber yet, return an empty list
+		if errors.Is(err, redis_driver.Nil) {
+			return []*models.Transaction{}, nil
+		}
 		// If there was an error retrieving the data, return it
 		return nil, err
 	}
@@ -54,30 +87,91 @@ func (r *SubscriberRepository) GetTransactionsReversed(ctx context.Context, addr
 	return reversedSharedTransactions, nil
 }
 
+// ForEachTransaction calls fn for every transaction of a subscriber in chronological order and stops on the first error of fn.
+// Transactions are stored as one JSON array, so it is read by chunks and decoded one transaction at a time instead of
+// loading the whole history. The array is only appended to, so transactions added during the iteration may be included.
+func (r *SubscriberRepository) ForEachTransaction(ctx context.Context, address models.Address, fn func(tx *models.Transaction) error) error {
+	defer metrics.ObserveRepositoryOperation(repositoryName, "ForEachTransaction", time.Now())
+
+	key := tenant.Key(ctx, address)
+
+	// Check if the subscriber exists in the cache
+	err := r.redis.Get(ctx, getSubscribersKey(r.keySpace, key)).Err()
+	if err != nil {
+		if errors.Is(err, redis_driver.Nil) {
+			return models.ErrNotSubscribed
+		}
+		return err
+	}
+
+	reader := &rangeReader{ctx: ctx, redis: r.redis, key: getSubscribersTxsKey(r.keySpace, key)}
+	decoder := json.NewDecoder(bufio.NewReaderSize(reader, txsChunkSize))
+
+	token, err := decoder.Token()
+	if err != nil {
+		// If no transactions were stored for the subscriber yet, there is nothing to iterate
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		return err
+	}
+
+	// Empty list of transactions is serialized as null
+	if token == nil {
+		return nil
+	}
+
+	if delim, ok := token.(json.Delim); !ok || delim != '[' {
+		return errors.New("transactions of subscriber are not a JSON array")
+	}
+
+	for decoder.More() {
+		tx := &models.Transaction{}
+		err = decoder.Decode(tx)
+		if err != nil {
+			return err
+		}
+
+		err = fn(tx)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Read the closing bracket, so a value truncated in the middle of the iteration is reported
+	_, err = decoder.Token()
+
+	return err
+}
+
 // GetLastTransaction returns the last transaction for a given address from the Redis cache.
 // If the address is not registered or if there are no transactions associated with the address, it returns nil and an error.
-func (r *SubscriberRepository) GetLastTransaction(ctx context.Context, address string) (*models.Transaction, error) {
+func (r *SubscriberRepository) GetLastTransaction(ctx context.Context, address models.Address) (*models.Transaction, error) {
+	defer metrics.ObserveRepositoryOperation(repositoryName, "GetLastTransaction", time.Now())
+
+	key := tenant.Key(ctx, address)
+
 	// Check if the address is registered in the Redis cache
-	err := r.redis.Get(ctx, getSubscribersKey(address)).Err()
+	err := r.redis.Get(ctx, getSubscribersKey(r.keySpace, key)).Err()
 	if err != nil {
-		// If the error message is "redis: nil", then the address is not registered
-		if err.Error() == redisNilErrMsg {
-			return nil, errors.New("address is not registered")
+		// If the key does not exist, then the address is not registered
+		if errors.Is(err, redis_driver.Nil) {
+			return nil, models.ErrNotSubscribed
 		}
 
-		// Return the error if it is not "redis: nil"
+		// Return the error if the key exists
 		return nil, err
 	}
 
 	// Get the transactions associated with the address from the Redis cache
-	rawTxs, err := r.redis.Get(ctx, getSubscribersTxsKey(address)).Bytes()
+	rawTxs, err := r.redis.Get(ctx, getSubscribersTxsKey(r.keySpace, key)).Bytes()
 	if err != nil {
-		// If the error message is "redis: nil", then there are no transactions associated with the address
-		if err.Error() == redisNilErrMsg {
+		// If the key does not exist, then there are no transactions associated with the address
+		if errors.Is(err, redis_driver.Nil) {
 			return nil, nil
 		}
 
-		// Return the error if it is not "redis: nil"
+		// Return the error if the key exists
 		return nil, err
 	}
 
@@ -98,13 +192,17 @@ func (r *SubscriberRepository) GetLastTransaction(ctx context.Context, address s
 
 // AddTransactions adds a list of transactions for a given address to the Redis cache.
 // If the address is not registered, it returns an error.
-func (r *SubscriberRepository) AddTransactions(ctx context.Context, address string, txs []*models.Transaction) error {
+func (r *SubscriberRepository) AddTransactions(ctx context.Context, address models.Address, txs []*models.Transaction) error {
+	defer metrics.ObserveRepositoryOperation(repositoryName, "AddTransactions", time.Now())
+
+	key := tenant.Key(ctx, address)
+
 	// Get the raw byte data of the subscriber from Redis using the given address
-	rawSubscriber, err := r.redis.Get(ctx, getSubscribersKey(address)).Bytes()
+	rawSubscriber, err := r.redis.Get(ctx, getSubscribersKey(r.keySpace, key)).Bytes()
 	if err != nil {
-		// If the error is a "nil" error, then the address is not registered
-		if err.Error() == redisNilErrMsg {
-			return errors.New("address is not registered")
+		// If the key does not exist, then the address is not registered
+		if errors.Is(err, redis_driver.Nil) {
+			return models.ErrNotSubscribed
 		}
 
 		// Return any other error encountered
@@ -118,17 +216,17 @@ func (r *SubscriberRepository) AddTransactions(ctx context.Context, address stri
 	}
 
 	// Get the raw byte data of the transactions for the given address
-	rawTxs, err := r.redis.Get(ctx, getSubscribersTxsKey(address)).Bytes()
+	rawTxs, err := r.redis.Get(ctx, getSubscribersTxsKey(r.keySpace, key)).Bytes()
 	if err != nil {
-		// If the error is a "nil" error, then there are no stored transactions for this address
-		if err.Error() == redisNilErrMsg {
+		// If the key does not exist, then there are no stored transactions for this address
+		if errors.Is(err, redis_driver.Nil) {
 			// Serialize the current transactions
 			currentRawTxs, err2 := serializeSubscribersTxsValue(txs)
 			if err2 != nil {
 				return err2
 			}
 			// Store the serialized transactions in Redis
-			err3 := r.redis.Set(ctx, getSubscribersTxsKey(address), currentRawTxs, r.expirationTime).Err()
+			err3 := r.redis.Set(ctx, getSubscribersTxsKey(r.keySpace, key), currentRawTxs, r.getExpirationTime()).Err()
 			if err3 != nil {
 				return err3
 			}
@@ -164,23 +262,20 @@ func (r *SubscriberRepository) AddTransactions(ctx context.Context, address stri
 	}
 
 	// Store the serialized updated stored transactions in Redis
-	err = r.redis.Set(ctx, getSubscribersTxsKey(address), storedTxsRaw, r.expirationTime).Err()
+	err = r.redis.Set(ctx, getSubscribersTxsKey(r.keySpace, key), storedTxsRaw, r.getExpirationTime()).Err()
 	if err != nil {
 		return err
 	}
 
-	newSubscriber := models.Subscriber{
-		Address:              address,
-		SubscribeBlockNumber: subscribeBlockNumber,
-		SubscribeTxCount:     subscriber.SubscribeTxCount + uint64(len(txs)),
-	}
+	subscriber.SubscribeBlockNumber = subscribeBlockNumber
+	subscriber.SubscribeTxCount += uint64(len(txs))
 
-	rawNewSubscriber, err := serializeSubscribersValue(newSubscriber)
+	rawNewSubscriber, err := serializeSubscribersValue(subscriber)
 	if err != nil {
 		return err
 	}
 
-	err = r.redis.Set(ctx, getSubscribersKey(address), rawNewSubscriber, r.expirationTime).Err()
+	err = r.redis.Set(ctx, getSubscribersKey(r.keySpace, key), rawNewSubscriber, r.getExpirationTime()).Err()
 	if err != nil {
 		return err
 	}
@@ -189,16 +284,18 @@ func (r *SubscriberRepository) AddTransactions(ctx context.Context, address stri
 }
 
 // AddNewSubscriber adds a new subscriber to the repository.
-// If the subscriber with the same address already exists in the repository, an error "subscriber already registered" will be returned.
+// If the subscriber with the same address already exists in the repository, models.ErrAlreadySubscribed will be returned.
 func (r *SubscriberRepository) AddNewSubscriber(ctx context.Context, subscriber models.Subscriber) error {
+	defer metrics.ObserveRepositoryOperation(repositoryName, "AddNewSubscriber", time.Now())
+
 	// Check if subscriber with the same address already exists
-	_, err := r.redis.Get(ctx, getSubscribersKey(subscriber.Address)).Result()
+	_, err := r.redis.Get(ctx, getSubscribersKey(r.keySpace, subscriber.Key())).Result()
 	if err == nil {
-		return errors.New("subscriber already registered")
+		return models.ErrAlreadySubscribed
 	}
 
-	// Return an error if it's not a nil error message
-	if err.Error() != redisNilErrMsg {
+	// Return an error if the key exists
+	if !errors.Is(err, redis_driver.Nil) {
 		return err
 	}
 
@@ -209,23 +306,33 @@ func (r *SubscriberRepository) AddNewSubscriber(ctx context.Context, subscriber
 	}
 
 	// Store the serialized subscriber data in the repository with the specified expiration time
-	setSubCmd := r.redis.Set(ctx, getSubscribersKey(subscriber.Address), serializedSubscriber, r.expirationTime)
+	setSubCmd := r.redis.Set(ctx, getSubscribersKey(r.keySpace, subscriber.Key()), serializedSubscriber, r.getExpirationTime())
 	if setSubCmd.Err() != nil {
 		return setSubCmd.Err()
 	}
 
-	return nil
+	// Register the subscriber key in the set of all subscribers, so it could be listed without scanning keys
+	err = r.redis.SAdd(ctx, getSubscribersSetKey(r.keySpace), subscriber.Key().String()).Err()
+	if err != nil {
+		return err
+	}
+
+	return r.redis.Expire(ctx, getSubscribersSetKey(r.keySpace), r.getExpirationTime()).Err()
 }
 
 // GetSubscriberByAddress returns a subscriber with a given address from the repository.
-// If the subscriber with the given address doesn't exist in the repository, an error "subscriber is not subscribed" will be returned.
-func (r *SubscriberRepository) GetSubscriberByAddress(ctx context.Context, address string) (models.Subscriber, error) {
+// If the subscriber with the given address doesn't exist in the repository, models.ErrNotSubscribed will be returned.
+func (r *SubscriberRepository) GetSubscriberByAddress(ctx context.Context, address models.Address) (models.Subscriber, error) {
+	defer metrics.ObserveRepositoryOperation(repositoryName, "GetSubscriberByAddress", time.Now())
+
+	key := tenant.Key(ctx, address)
+
 	// Get the raw subscriber data from the repository
-	subscriberRawData, err := r.redis.Get(ctx, getSubscribersKey(address)).Bytes()
+	subscriberRawData, err := r.redis.Get(ctx, getSubscribersKey(r.keySpace, key)).Bytes()
 	if err != nil {
-		// If the subscriber with the given address doesn't exist in the repository, return an error "subscriber is not subscribed"
-		if err.Error() == redisNilErrMsg {
-			return models.Subscriber{}, errors.New("subscriber is not subscribed")
+		// If the subscriber with the given address doesn't exist in the repository, return models.ErrNotSubscribed
+		if errors.Is(err, redis_driver.Nil) {
+			return models.Subscriber{}, models.ErrNotSubscribed
 		}
 
 		return models.Subscriber{}, err
@@ -239,3 +346,208 @@ func (r *SubscriberRepository) GetSubscriberByAddress(ctx context.Context, addre
 
 	return subscriber, nil
 }
+
+// GetSubscribers returns all registered subscribers of all tenants from the repository.
+// Subscribers whose data has already expired are removed from the set of subscribers and skipped.
+func (r *SubscriberRepository) GetSubscribers(ctx context.Context) ([]models.Subscriber, error) {
+	defer metrics.ObserveRepositoryOperation(repositoryName, "GetSubscribers", time.Now())
+
+	// Get keys of all registered subscribers
+	members, err := r.redis.SMembers(ctx, getSubscribersSetKey(r.keySpace)).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(members) == 0 {
+		return []models.Subscriber{}, nil
+	}
+
+	keys := make([]string, 0, len(members))
+	for _, member := range members {
+		keys = append(keys, getSubscribersKey(r.keySpace, models.ParseSubscriberKey(member)))
+	}
+
+	// Get the raw subscribers data in one round trip
+	rawSubscribers, err := r.redis.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	subscribers := make([]models.Subscriber, 0, len(rawSubscribers))
+	for i, rawSubscriber := range rawSubscribers {
+		rawSubscriberStr, ok := rawSubscriber.(string)
+		if !ok {
+			// Subscriber data is expired, so we forget about the subscriber
+			err = r.redis.SRem(ctx, getSubscribersSetKey(r.keySpace), members[i]).Err()
+			if err != nil {
+				return nil, err
+			}
+
+			r.logger.InfoContext(ctx, "subscriber data is expired, subscriber is forgotten", "subscriber", members[i])
+			continue
+		}
+
+		subscriber, err := deserealizeSubscribersValue([]byte(rawSubscriberStr))
+		if err != nil {
+			return nil, err
+		}
+
+		subscribers = append(subscribers, subscriber)
+	}
+
+	return subscribers, nil
+}
+
+// CountSubscribers returns count of subscriptions of the tenant from ctx. Only keys of subscriptions are scanned,
+// so subscriptions with expired data are counted until GetSubscribers forgets them
+func (r *SubscriberRepository) CountSubscribers(ctx context.Context) (int, error) {
+	defer metrics.ObserveRepositoryOperation(repositoryName, "CountSubscribers", time.Now())
+
+	// SSCAN may return a member more than once, so members are deduplicated
+	members := make(map[string]struct{})
+	iter := r.redis.SScan(ctx, getSubscribersSetKey(r.keySpace), 0, getSubscribersSetPattern(tenant.FromContext(ctx)), 0).Iterator()
+	for iter.Next(ctx) {
+		members[iter.Val()] = struct{}{}
+	}
+
+	err := iter.Err()
+	if err != nil {
+		return 0, err
+	}
+
+	return len(members), nil
+}
+
+// AddBlockTransactions stores transactions found in a single indexed block and moves cursors of subscribers
+// to this block. Every subscriber that was matched against the block must be present in txsBySubscriber,
+// even if no transactions were found for it. Subscribers whose cursor is already at or beyond blockNumber
+// or that are not registered are skipped.
+// All changes are applied in one MULTI/EXEC transaction guarded by WATCH on every touched key, so concurrent writers
+// can not interleave with the update. The transaction is retried if any of watched keys was changed in the meantime.
+func (r *SubscriberRepository) AddBlockTransactions(ctx context.Context, blockNumber uint64, txsBySubscriber map[models.SubscriberKey][]*models.Transaction) error {
+	defer metrics.ObserveRepositoryOperation(repositoryName, "AddBlockTransactions", time.Now())
+
+	if len(txsBySubscriber) == 0 {
+		return nil
+	}
+
+	subscriberKeys := make([]models.SubscriberKey, 0, len(txsBySubscriber))
+	keys := make([]string, 0, 2*len(txsBySubscriber))
+	for subscriberKey := range txsBySubscriber {
+		subscriberKeys = append(subscriberKeys, subscriberKey)
+		keys = append(keys, getSubscribersKey(r.keySpace, subscriberKey), getSubscribersTxsKey(r.keySpace, subscriberKey))
+	}
+
+	txFunc := func(tx *redis_driver.Tx) error {
+		// Read current state of all subscribers and their transactions under WATCH
+		rawValues, err := tx.MGet(ctx, keys...).Result()
+		if err != nil {
+			return err
+		}
+
+		type update struct {
+			subscriber models.Subscriber
+			txs        []*models.Transaction
+		}
+
+		updates := make([]update, 0, len(subscriberKeys))
+		for i, subscriberKey := range subscriberKeys {
+			rawSubscriber, ok := rawValues[2*i].(string)
+			if !ok {
+				// Subscriber is not registered or its data is expired
+				continue
+			}
+
+			subscriber, err := deserealizeSubscribersValue([]byte(rawSubscriber))
+			if err != nil {
+				return err
+			}
+
+			// Skip subscribers that already indexed this block
+			if subscriber.SubscribeBlockNumber >= blockNumber {
+				continue
+			}
+
+			storedTxs := []*models.Transaction{}
+			if rawTxs, ok := rawValues[2*i+1].(string); ok {
+				storedTxs, err = deserializeSubscribersTxsValue([]byte(rawTxs))
+				if err != nil {
+					return err
+				}
+			}
+
+			blockTxs := txsBySubscriber[subscriberKey]
+			storedTxs = append(storedTxs, blockTxs...)
+
+			subscriber.SubscribeBlockNumber = blockNumber
+			subscriber.SubscribeTxCount += uint64(len(blockTxs))
+
+			updates = append(updates, update{
+				subscriber: subscriber,
+				txs:        storedTxs,
+			})
+		}
+
+		// Write all subscribers and transactions atomically
+		_, err = tx.TxPipelined(ctx, func(pipe redis_driver.Pipeliner) error {
+			for _, u := range updates {
+				rawSubscriber, err := serializeSubscribersValue(u.subscriber)
+				if err != nil {
+					return err
+				}
+
+				rawTxs, err := serializeSubscribersTxsValue(u.txs)
+				if err != nil {
+					return err
+				}
+
+				pipe.Set(ctx, getSubscribersKey(r.keySpace, u.subscriber.Key()), rawSubscriber, r.getExpirationTime())
+				pipe.Set(ctx, getSubscribersTxsKey(r.keySpace, u.subscriber.Key()), rawTxs, r.getExpirationTime())
+			}
+
+			pipe.Expire(ctx, getSubscribersSetKey(r.keySpace), r.getExpirationTime())
+
+			return nil
+		})
+
+		return err
+	}
+
+	for i := 0; i < maxTxRetries; i++ {
+		err := r.redis.Watch(ctx, txFunc, keys...)
+		if err != redis_driver.TxFailedErr {
+			return err
+		}
+
+		r.logger.DebugContext(ctx, "subscribers are changed concurrently, retrying transaction", "block_number", blockNumber, "attempt", i+1)
+	}
+
+	return errors.New("unable to add block transactions: too many concurrent updates")
+}
+
+// DeleteSubscriber removes the subscriber with the given address together with its transactions.
+// All keys are removed in one MULTI/EXEC transaction, so AddBlockTransactions watching them retries and skips the subscriber.
+// If the subscriber with the given address doesn't exist in the repository, models.ErrNotSubscribed will be returned.
+func (r *SubscriberRepository) DeleteSubscriber(ctx context.Context, address models.Address) error {
+	defer metrics.ObserveRepositoryOperation(repositoryName, "DeleteSubscriber", time.Now())
+
+	key := tenant.Key(ctx, address)
+
+	var deleted *redis_driver.IntCmd
+	_, err := r.redis.TxPipelined(ctx, func(pipe redis_driver.Pipeliner) error {
+		deleted = pipe.Del(ctx, getSubscribersKey(r.keySpace, key))
+		pipe.Del(ctx, getSubscribersTxsKey(r.keySpace, key))
+		pipe.SRem(ctx, getSubscribersSetKey(r.keySpace), key.String())
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if deleted.Val() == 0 {
+		return models.ErrNotSubscribed
+	}
+
+	return nil
+}
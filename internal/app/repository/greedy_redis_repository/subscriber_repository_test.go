@@ -2,27 +2,26 @@ package greedy_redis_repository
 
 import (
 	"context"
+	"errors"
 	"github.com/bluntenpassant/ethereum_subscriber/internal/app/models"
-	"github.com/redis/go-redis/v9"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/tenant"
 	"github.com/stretchr/testify/assert"
+	"io"
+	"log/slog"
 	"math/big"
 	"testing"
 	"time"
 )
 
-const redisHostSubscriberRepository = "0.0.0.0"
-const redisPortSubscriberRepository = "6379"
-const redisPasswordSubscriberRepository = ""
+var testKeySpace = KeySpace{Prefix: GreedyKeyPrefix, ChainID: 1}
+
+var testLogger = slog.New(slog.NewTextHandler(io.Discard, nil))
 
 func TestSubscriberRepository_GetLastTransaction(t *testing.T) {
 	ctx := context.TODO()
 
-	redisClient := redis.NewClient(&redis.Options{
-		Addr:     redisHostSubscriberRepository + ":" + redisPortSubscriberRepository,
-		Password: redisPasswordSubscriberRepository,
-		DB:       0,
-	})
-	subscriberRepository := NewSubscriberRepository(redisClient, 10*time.Second)
+	redisClient := newTestRedisClient(t)
+	subscriberRepository := NewSubscriberRepository(testKeySpace, redisClient, 10*time.Second, testLogger)
 
 	type TestCase struct {
 		Name         string
@@ -116,12 +115,8 @@ func TestSubscriberRepository_GetLastTransaction(t *testing.T) {
 func TestSubscriberRepository_GetTransactionsReversed(t *testing.T) {
 	ctx := context.TODO()
 
-	redisClient := redis.NewClient(&redis.Options{
-		Addr:     redisHostSubscriberRepository + ":" + redisPortSubscriberRepository,
-		Password: redisPasswordSubscriberRepository,
-		DB:       0,
-	})
-	subscriberRepository := NewSubscriberRepository(redisClient, 10*time.Second)
+	redisClient := newTestRedisClient(t)
+	subscriberRepository := NewSubscriberRepository(testKeySpace, redisClient, 10*time.Second, testLogger)
 
 	type TestCase struct {
 		Name         string
@@ -212,15 +207,93 @@ func TestSubscriberRepository_GetTransactionsReversed(t *testing.T) {
 	}
 }
 
+func TestSubscriberRepository_ForEachTransaction(t *testing.T) {
+	ctx := context.TODO()
+
+	redisClient := newTestRedisClient(t)
+	subscriberRepository := NewSubscriberRepository(testKeySpace, redisClient, 10*time.Second, testLogger)
+
+	// History is bigger than txsChunkSize, so it is read by several chunks
+	manyTxs := make([]*models.Transaction, 0, 1000)
+	for i := 0; i < cap(manyTxs); i++ {
+		manyTxs = append(manyTxs, &models.Transaction{
+			BlockNumber: uint64(16614479 + i),
+			From:        "0x45849a974058661eb2128aceb60d2c6ed99e2a14",
+			To:          "0x388c818ca8b9251b393131c08a736a67ccb19297",
+			Value:       *big.NewInt(int64(i)),
+			Hash:        "0x0f41f88706566a6e14bfc1f85d9761eb216a3b141c6eec13c820b6da569bf8a5",
+			Input:       "0xa9059cbb0000000000000000000000006cc8dcbca746a6e4fdefb98e1d0df903b107fd21",
+		})
+	}
+
+	fnErr := errors.New("client is gone")
+
+	type TestCase struct {
+		Name          string
+		Subscriber    *models.Subscriber
+		Transactions  []*models.Transaction
+		Address       models.Address
+		FnErr         error
+		ExpectedErr   error
+		ExpectedCount int
+	}
+
+	testCases := []TestCase{
+		{
+			Name:        "not subscribed",
+			Address:     "0x1111111111111111111111111111111111111111",
+			ExpectedErr: models.ErrNotSubscribed,
+		},
+		{
+			Name:       "no transactions",
+			Subscriber: &models.Subscriber{Address: "0x2222222222222222222222222222222222222222"},
+			Address:    "0x2222222222222222222222222222222222222222",
+		},
+		{
+			Name:          "many transactions",
+			Subscriber:    &models.Subscriber{Address: "0x3333333333333333333333333333333333333333"},
+			Transactions:  manyTxs,
+			Address:       "0x3333333333333333333333333333333333333333",
+			ExpectedCount: len(manyTxs),
+		},
+		{
+			Name:          "fn failed",
+			Subscriber:    &models.Subscriber{Address: "0x4444444444444444444444444444444444444444"},
+			Transactions:  manyTxs,
+			Address:       "0x4444444444444444444444444444444444444444",
+			FnErr:         fnErr,
+			ExpectedErr:   fnErr,
+			ExpectedCount: 1,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			if testCase.Subscriber != nil {
+				assert.NoError(t, subscriberRepository.AddNewSubscriber(ctx, *testCase.Subscriber))
+				assert.NoError(t, subscriberRepository.AddTransactions(ctx, testCase.Address, testCase.Transactions))
+			}
+
+			var txs []*models.Transaction
+			err := subscriberRepository.ForEachTransaction(ctx, testCase.Address, func(tx *models.Transaction) error {
+				txs = append(txs, tx)
+				return testCase.FnErr
+			})
+			assert.ErrorIs(t, err, testCase.ExpectedErr)
+			assert.Len(t, txs, testCase.ExpectedCount)
+
+			if testCase.ExpectedErr == nil {
+				assert.EqualValues(t, testCase.Transactions[:testCase.ExpectedCount], txs)
+			}
+		})
+	}
+}
+
 func TestSubscriberRepository_AddTransactions(t *testing.T) {
 	ctx := context.TODO()
 
-	redisClient := redis.NewClient(&redis.Options{
-		Addr:     redisHostSubscriberRepository + ":" + redisPortSubscriberRepository,
-		Password: redisPasswordSubscriberRepository,
-		DB:       0,
-	})
-	subscriberRepository := NewSubscriberRepository(redisClient, 10*time.Second)
+	redisClient := newTestRedisClient(t)
+	subscriberRepository := NewSubscriberRepository(testKeySpace, redisClient, 10*time.Second, testLogger)
 
 	type TestCase struct {
 		Name         string
@@ -297,7 +370,7 @@ func TestSubscriberRepository_AddTransactions(t *testing.T) {
 		// because we must get error in a last testcase due to duplicating registered address
 		err := subscriberRepository.AddNewSubscriber(ctx, testCase.Subscriber)
 		assert.NoError(t, err)
-		err = subscriberRepository.AddTransactions(ctx, testCase.Name, testCase.Transactions)
+		err = subscriberRepository.AddTransactions(ctx, models.Address(testCase.Name), testCase.Transactions)
 		if testCase.IsErr {
 			assert.Error(t, err)
 		}
@@ -307,11 +380,7 @@ func TestSubscriberRepository_AddTransactions(t *testing.T) {
 func TestSubscriberRepository_AddNewSubscriber(t *testing.T) {
 	ctx := context.TODO()
 
-	redisClient := redis.NewClient(&redis.Options{
-		Addr:     redisHostSubscriberRepository + ":" + redisPortSubscriberRepository,
-		Password: redisPasswordSubscriberRepository,
-		DB:       0,
-	})
+	redisClient := newTestRedisClient(t)
 
 	type TestCase struct {
 		Name                  string
@@ -347,7 +416,7 @@ func TestSubscriberRepository_AddNewSubscriber(t *testing.T) {
 
 	for _, testCase := range testCases {
 		t.Run(testCase.Name, func(t *testing.T) {
-			subscriberRepository := NewSubscriberRepository(redisClient, 10*time.Second)
+			subscriberRepository := NewSubscriberRepository(testKeySpace, redisClient, 10*time.Second, testLogger)
 			err := subscriberRepository.AddNewSubscriber(ctx, models.Subscriber{
 				Address:              testCase.SubscriberForAddition.Address,
 				SubscribeBlockNumber: testCase.SubscriberForAddition.SubscribeBlockNumber,
@@ -361,13 +430,9 @@ func TestSubscriberRepository_AddNewSubscriber(t *testing.T) {
 func TestSubscriberRepository_GetSubscriberByAddress(t *testing.T) {
 	ctx := context.TODO()
 
-	redisClient := redis.NewClient(&redis.Options{
-		Addr:     redisHostSubscriberRepository + ":" + redisPortSubscriberRepository,
-		Password: redisPasswordSubscriberRepository,
-		DB:       0,
-	})
+	redisClient := newTestRedisClient(t)
 
-	subscriberRepository := NewSubscriberRepository(redisClient, 10*time.Second)
+	subscriberRepository := NewSubscriberRepository(testKeySpace, redisClient, 10*time.Second, testLogger)
 
 	type TestCase struct {
 		Name                  string
@@ -414,3 +479,370 @@ func TestSubscriberRepository_GetSubscriberByAddress(t *testing.T) {
 		})
 	}
 }
+
+func TestSubscriberRepository_GetSubscribers(t *testing.T) {
+	ctx := context.TODO()
+
+	redisClient := newTestRedisClient(t)
+	subscriberRepository := NewSubscriberRepository(testKeySpace, redisClient, 10*time.Second, testLogger)
+
+	expectedSubscribers := []models.Subscriber{
+		{
+			Address:              "0xb5d85cbf7cb3ee0d56b3bb207d5fc4b82f43f511",
+			SubscribeBlockNumber: 15,
+			SubscribeTxCount:     14,
+		},
+		{
+			Address:              "0xe592427a0aece92de3edee1f18e0157c05861564",
+			SubscribeBlockNumber: 19,
+			SubscribeTxCount:     7,
+		},
+	}
+
+	for _, subscriber := range expectedSubscribers {
+		err := subscriberRepository.AddNewSubscriber(ctx, subscriber)
+		assert.NoError(t, err)
+	}
+
+	// other tests share the same database, so we check only presence of our subscribers
+	subscribers, err := subscriberRepository.GetSubscribers(ctx)
+	assert.NoError(t, err)
+	for _, subscriber := range expectedSubscribers {
+		assert.Contains(t, subscribers, subscriber)
+	}
+}
+
+func TestSubscriberRepository_CountSubscribers(t *testing.T) {
+	ctx := context.TODO()
+	tenantACtx := tenant.WithID(ctx, "tenant_a")
+	tenantBCtx := tenant.WithID(ctx, "tenant_b")
+
+	redisClient := newTestRedisClient(t)
+	// Other tests share the same database, so subscriptions are counted on a chain of their own
+	keySpace := KeySpace{Prefix: GreedyKeyPrefix, ChainID: 10}
+	subscriberRepository := NewSubscriberRepository(keySpace, redisClient, 10*time.Second, testLogger)
+
+	subscribers := []models.Subscriber{
+		{Address: "0xd8da6bf26964af9d7eed9e03e53415d37aa96045"},
+		{Address: "0xd8da6bf26964af9d7eed9e03e53415d37aa96045", TenantID: "tenant_a"},
+		{Address: "0xdef1c0ded9bec7f1a1670819833240f027b25eff", TenantID: "tenant_a"},
+	}
+
+	redisClient.Del(ctx, getSubscribersSetKey(keySpace))
+	defer redisClient.Del(ctx, getSubscribersSetKey(keySpace))
+	for _, subscriber := range subscribers {
+		defer redisClient.Del(ctx, getSubscribersKey(keySpace, subscriber.Key()))
+		assert.NoError(t, subscriberRepository.AddNewSubscriber(tenant.WithID(ctx, subscriber.TenantID), subscriber))
+	}
+
+	type TestCase struct {
+		name          string
+		ctx           context.Context
+		expectedCount int
+	}
+
+	testCases := []TestCase{
+		{name: "without tenant", ctx: ctx, expectedCount: 1},
+		{name: "tenant with subscriptions", ctx: tenantACtx, expectedCount: 2},
+		{name: "tenant without subscriptions", ctx: tenantBCtx, expectedCount: 0},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			count, err := subscriberRepository.CountSubscribers(testCase.ctx)
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expectedCount, count)
+		})
+	}
+}
+
+func TestSubscriberRepository_AddBlockTransactions(t *testing.T) {
+	ctx := context.TODO()
+
+	redisClient := newTestRedisClient(t)
+	subscriberRepository := NewSubscriberRepository(testKeySpace, redisClient, 10*time.Second, testLogger)
+
+	sender := models.Subscriber{
+		Address:              "0x7a250d5630b4cf539739df2c5dacb4c659f2488d",
+		SubscribeBlockNumber: 16614478,
+	}
+	receiver := models.Subscriber{
+		Address:              "0x68b3465833fb72a70ecdf485e0e4c7bd8665fc45",
+		SubscribeBlockNumber: 16614478,
+	}
+	// already indexed block in the past, so block should not be applied to it
+	ahead := models.Subscriber{
+		Address:              "0x1111111254eeb25477b68fb85ed929f73a960582",
+		SubscribeBlockNumber: 16614480,
+	}
+
+	for _, subscriber := range []models.Subscriber{sender, receiver, ahead} {
+		err := subscriberRepository.AddNewSubscriber(ctx, subscriber)
+		assert.NoError(t, err)
+	}
+
+	tx := &models.Transaction{
+		BlockHash:   "0xdfcbf46fb8ae7c1769e28a864b7e49d66e931214010a3d0c3c82248d2b0bd50f",
+		BlockNumber: 16614479,
+		From:        sender.Address.String(),
+		Gas:         *big.NewInt(16338636656),
+		GasPrice:    *big.NewInt(16338636656),
+		Hash:        "0x0f41f88706566a6e14bfc1f85d9761eb216a3b141c6eec13c820b6da569bf8a5",
+		To:          receiver.Address.String(),
+	}
+
+	err := subscriberRepository.AddBlockTransactions(ctx, 16614479, map[models.SubscriberKey][]*models.Transaction{
+		sender.Key():   {tx},
+		receiver.Key(): {},
+		ahead.Key():    {tx},
+		// not registered address must be ignored
+		{Address: "0x0000000000000000000000000000000000000000"}: {tx},
+	})
+	assert.NoError(t, err)
+
+	gotSender, err := subscriberRepository.GetSubscriberByAddress(ctx, sender.Address)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(16614479), gotSender.SubscribeBlockNumber)
+	assert.Equal(t, uint64(1), gotSender.SubscribeTxCount)
+
+	gotReceiver, err := subscriberRepository.GetSubscriberByAddress(ctx, receiver.Address)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(16614479), gotReceiver.SubscribeBlockNumber)
+	assert.Equal(t, uint64(0), gotReceiver.SubscribeTxCount)
+
+	gotAhead, err := subscriberRepository.GetSubscriberByAddress(ctx, ahead.Address)
+	assert.NoError(t, err)
+	assert.EqualValues(t, ahead, gotAhead)
+
+	senderTxs, err := subscriberRepository.GetTransactionsReversed(ctx, sender.Address)
+	assert.NoError(t, err)
+	assert.EqualValues(t, []*models.Transaction{tx}, senderTxs)
+
+	aheadTxs, err := subscriberRepository.GetTransactionsReversed(ctx, ahead.Address)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(aheadTxs))
+
+	_, err = subscriberRepository.GetSubscriberByAddress(ctx, "0x0000000000000000000000000000000000000000")
+	assert.ErrorIs(t, err, models.ErrNotSubscribed)
+
+	// applying the same block again must not duplicate transactions
+	err = subscriberRepository.AddBlockTransactions(ctx, 16614479, map[models.SubscriberKey][]*models.Transaction{
+		sender.Key(): {tx},
+	})
+	assert.NoError(t, err)
+
+	senderTxs, err = subscriberRepository.GetTransactionsReversed(ctx, sender.Address)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(senderTxs))
+}
+
+func TestSubscriberRepository_TenantIsolation(t *testing.T) {
+	ctx := context.TODO()
+	tenantACtx := tenant.WithID(ctx, "tenant_a")
+	tenantBCtx := tenant.WithID(ctx, "tenant_b")
+
+	redisClient := newTestRedisClient(t)
+	subscriberRepository := NewSubscriberRepository(testKeySpace, redisClient, 10*time.Second, testLogger)
+
+	subscriberA := models.Subscriber{
+		ChainID:              1,
+		Address:              "0xdef1c0ded9bec7f1a1670819833240f027b25eff",
+		SubscribeBlockNumber: 16614478,
+		TenantID:             "tenant_a",
+	}
+	subscriberB := subscriberA
+	subscriberB.TenantID = "tenant_b"
+
+	for _, subscriber := range []models.Subscriber{subscriberA, subscriberB} {
+		redisClient.Del(ctx, getSubscribersKey(testKeySpace, subscriber.Key()), getSubscribersTxsKey(testKeySpace, subscriber.Key()))
+		defer redisClient.Del(ctx, getSubscribersKey(testKeySpace, subscriber.Key()), getSubscribersTxsKey(testKeySpace, subscriber.Key()))
+	}
+
+	assert.NoError(t, subscriberRepository.AddNewSubscriber(tenantACtx, subscriberA))
+	assert.NoError(t, subscriberRepository.AddNewSubscriber(tenantBCtx, subscriberB))
+
+	tx := &models.Transaction{
+		BlockNumber: 16614479,
+		From:        subscriberA.Address.String(),
+		Hash:        "0x0f41f88706566a6e14bfc1f85d9761eb216a3b141c6eec13c820b6da569bf8a5",
+	}
+
+	err := subscriberRepository.AddBlockTransactions(ctx, 16614479, map[models.SubscriberKey][]*models.Transaction{
+		subscriberA.Key(): {tx},
+		subscriberB.Key(): {},
+	})
+	assert.NoError(t, err)
+
+	txsA, err := subscriberRepository.GetTransactionsReversed(tenantACtx, subscriberA.Address)
+	assert.NoError(t, err)
+	assert.Equal(t, []*models.Transaction{tx}, txsA)
+
+	txsB, err := subscriberRepository.GetTransactionsReversed(tenantBCtx, subscriberB.Address)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(txsB))
+
+	_, err = subscriberRepository.GetSubscriberByAddress(ctx, subscriberA.Address)
+	assert.ErrorIs(t, err, models.ErrNotSubscribed)
+
+	// Cursor update keeps chain and tenant of subscriber
+	gotSubscriberA, err := subscriberRepository.GetSubscriberByAddress(tenantACtx, subscriberA.Address)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), gotSubscriberA.ChainID)
+	assert.Equal(t, "tenant_a", gotSubscriberA.TenantID)
+	assert.Equal(t, uint64(16614479), gotSubscriberA.SubscribeBlockNumber)
+
+	subscribers, err := subscriberRepository.GetSubscribers(ctx)
+	assert.NoError(t, err)
+	assert.Contains(t, subscribers, gotSubscriberA)
+}
+
+func TestSubscriberRepository_TenantChainIsolation(t *testing.T) {
+	ctx := context.TODO()
+	// Without chain ID in keys of the default chain, tenant "137-x" of the default chain would share keys with tenant "x" of chain 137
+	defaultTenantCtx := tenant.WithID(ctx, "137-x")
+	otherTenantCtx := tenant.WithID(ctx, "x")
+
+	redisClient := newTestRedisClient(t)
+	defaultRepository := NewSubscriberRepository(KeySpace{Prefix: GreedyKeyPrefix, ChainID: 1, Default: true}, redisClient, 10*time.Second, testLogger)
+	otherRepository := NewSubscriberRepository(KeySpace{Prefix: GreedyKeyPrefix, ChainID: 137}, redisClient, 10*time.Second, testLogger)
+
+	defaultSubscriber := models.Subscriber{
+		ChainID:              1,
+		Address:              "0xdef1c0ded9bec7f1a1670819833240f027b25eff",
+		SubscribeBlockNumber: 16614478,
+		TenantID:             "137-x",
+	}
+
+	assert.NoError(t, defaultRepository.AddNewSubscriber(defaultTenantCtx, defaultSubscriber))
+
+	tx := &models.Transaction{
+		BlockNumber: 16614479,
+		From:        defaultSubscriber.Address.String(),
+		Hash:        "0x0f41f88706566a6e14bfc1f85d9761eb216a3b141c6eec13c820b6da569bf8a5",
+	}
+
+	err := defaultRepository.AddBlockTransactions(ctx, 16614479, map[models.SubscriberKey][]*models.Transaction{
+		defaultSubscriber.Key(): {tx},
+	})
+	assert.NoError(t, err)
+
+	_, err = otherRepository.GetSubscriberByAddress(otherTenantCtx, defaultSubscriber.Address)
+	assert.ErrorIs(t, err, models.ErrNotSubscribed)
+
+	_, err = otherRepository.GetTransactionsReversed(otherTenantCtx, defaultSubscriber.Address)
+	assert.ErrorIs(t, err, models.ErrNotSubscribed)
+
+	otherSubscriber := defaultSubscriber
+	otherSubscriber.ChainID = 137
+	otherSubscriber.TenantID = "x"
+
+	assert.NoError(t, otherRepository.AddNewSubscriber(otherTenantCtx, otherSubscriber))
+
+	txs, err := otherRepository.GetTransactionsReversed(otherTenantCtx, otherSubscriber.Address)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(txs))
+
+	txs, err = defaultRepository.GetTransactionsReversed(defaultTenantCtx, defaultSubscriber.Address)
+	assert.NoError(t, err)
+	assert.Equal(t, []*models.Transaction{tx}, txs)
+}
+
+func TestSubscriberRepository_DeleteSubscriber(t *testing.T) {
+	ctx := context.TODO()
+	tenantACtx := tenant.WithID(ctx, "tenant_a")
+
+	redisClient := newTestRedisClient(t)
+	subscriberRepository := NewSubscriberRepository(testKeySpace, redisClient, 10*time.Second, testLogger)
+
+	subscriber := models.Subscriber{
+		ChainID:              1,
+		Address:              "0xdef1c0ded9bec7f1a1670819833240f027b25eff",
+		SubscribeBlockNumber: 16614478,
+		TenantID:             "tenant_a",
+	}
+
+	redisClient.Del(ctx, getSubscribersKey(testKeySpace, subscriber.Key()), getSubscribersTxsKey(testKeySpace, subscriber.Key()))
+	defer redisClient.Del(ctx, getSubscribersKey(testKeySpace, subscriber.Key()), getSubscribersTxsKey(testKeySpace, subscriber.Key()))
+
+	assert.NoError(t, subscriberRepository.AddNewSubscriber(tenantACtx, subscriber))
+
+	err := subscriberRepository.AddBlockTransactions(ctx, 16614479, map[models.SubscriberKey][]*models.Transaction{
+		subscriber.Key(): {{BlockNumber: 16614479, From: subscriber.Address.String()}},
+	})
+	assert.NoError(t, err)
+
+	// Subscription of another tenant is not affected
+	err = subscriberRepository.DeleteSubscriber(ctx, subscriber.Address)
+	assert.ErrorIs(t, err, models.ErrNotSubscribed)
+
+	err = subscriberRepository.DeleteSubscriber(tenantACtx, subscriber.Address)
+	assert.NoError(t, err)
+
+	_, err = subscriberRepository.GetSubscriberByAddress(tenantACtx, subscriber.Address)
+	assert.ErrorIs(t, err, models.ErrNotSubscribed)
+
+	exists, err := redisClient.Exists(ctx, getSubscribersTxsKey(testKeySpace, subscriber.Key())).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), exists)
+
+	isMember, err := redisClient.SIsMember(ctx, getSubscribersSetKey(testKeySpace), subscriber.Key().String()).Result()
+	assert.NoError(t, err)
+	assert.False(t, isMember)
+
+	err = subscriberRepository.DeleteSubscriber(tenantACtx, subscriber.Address)
+	assert.ErrorIs(t, err, models.ErrNotSubscribed)
+}
+
+func TestSubscriberRepository_KeySpace(t *testing.T) {
+	ctx := context.TODO()
+
+	redisClient := newTestRedisClient(t)
+
+	indexedKeySpace := KeySpace{Prefix: IndexedKeyPrefix, ChainID: 1}
+	greedyRepository := NewSubscriberRepository(testKeySpace, redisClient, 10*time.Second, testLogger)
+	indexedRepository := NewSubscriberRepository(indexedKeySpace, redisClient, 10*time.Second, testLogger)
+
+	subscriber := models.Subscriber{ChainID: 1, Address: "0x7a250d5630b4cf539739df2c5dacb4c659f2488d", SubscribeBlockNumber: 16614478}
+
+	redisClient.Del(ctx, getSubscribersKey(testKeySpace, subscriber.Key()), getSubscribersKey(indexedKeySpace, subscriber.Key()))
+	defer redisClient.Del(ctx, getSubscribersKey(testKeySpace, subscriber.Key()), getSubscribersKey(indexedKeySpace, subscriber.Key()))
+	defer redisClient.SRem(ctx, getSubscribersSetKey(indexedKeySpace), subscriber.Key().String())
+
+	assert.Equal(t, "indexed_key_Subscriber-1-0x7a250d5630b4cf539739df2c5dacb4c659f2488d", getSubscribersKey(indexedKeySpace, subscriber.Key()))
+
+	assert.NoError(t, indexedRepository.AddNewSubscriber(ctx, subscriber))
+
+	// Subscription of indexed approach is not visible to greedy approach sharing the database
+	_, err := greedyRepository.GetSubscriberByAddress(ctx, subscriber.Address)
+	assert.ErrorIs(t, err, models.ErrNotSubscribed)
+
+	gotSubscriber, err := indexedRepository.GetSubscriberByAddress(ctx, subscriber.Address)
+	assert.NoError(t, err)
+	assert.Equal(t, subscriber.Address, gotSubscriber.Address)
+}
+
+func TestSubscriberRepository_DefaultChainKeys(t *testing.T) {
+	ctx := context.TODO()
+
+	redisClient := newTestRedisClient(t)
+
+	// Subscription and transactions stored by the single chain version, before chain ID was added to keys
+	address := models.Address("0x7a250d5630b4cf539739df2c5dacb4c659f2488d")
+	keys := []string{"sync_greedy_key_Subscriber-" + address.String(), "sync_greedy_key_SubscriberTxs-" + address.String()}
+	redisClient.Del(ctx, keys...)
+	defer redisClient.Del(ctx, keys...)
+
+	assert.NoError(t, redisClient.Set(ctx, keys[0], `{"Address":"`+address.String()+`","SubscribeBlockNumber":16614478}`, 10*time.Second).Err())
+	assert.NoError(t, redisClient.Set(ctx, keys[1], `[{"blockNumber":16614479,"hash":"0x01"}]`, 10*time.Second).Err())
+
+	defaultRepository := NewSubscriberRepository(KeySpace{Prefix: GreedyKeyPrefix, ChainID: 1, Default: true}, redisClient, 10*time.Second, testLogger)
+	otherRepository := NewSubscriberRepository(KeySpace{Prefix: GreedyKeyPrefix, ChainID: 42161}, redisClient, 10*time.Second, testLogger)
+
+	txs, err := defaultRepository.GetTransactionsReversed(ctx, address)
+	assert.NoError(t, err)
+	assert.Len(t, txs, 1)
+	assert.Equal(t, "0x01", txs[0].Hash)
+
+	_, err = otherRepository.GetTransactionsReversed(ctx, address)
+	assert.ErrorIs(t, err, models.ErrNotSubscribed)
+}
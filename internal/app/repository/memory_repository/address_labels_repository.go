@@ -0,0 +1,107 @@
+package memory_repository
+
+import (
+	"context"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/metrics"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/models"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/tenant"
+	"sync"
+	"time"
+)
+
+// AddressLabelsRepository keeps address books of tenants in memory, labels are lost on restart.
+// Tenant of address book is taken from context
+type AddressLabelsRepository struct {
+	// labels are labels of addresses by tenant and address
+	labels   map[string]map[models.Address]models.AddressLabels
+	labelsMx sync.RWMutex
+}
+
+func NewAddressLabelsRepository() *AddressLabelsRepository {
+	return &AddressLabelsRepository{
+		labels:   make(map[string]map[models.Address]models.AddressLabels),
+		labelsMx: sync.RWMutex{},
+	}
+}
+
+// SetAddressLabels replaces labels of the address
+func (r *AddressLabelsRepository) SetAddressLabels(ctx context.Context, labels models.AddressLabels) error {
+	defer metrics.ObserveRepositoryOperation(repositoryName, "SetAddressLabels", time.Now())
+
+	r.labelsMx.Lock()
+	defer r.labelsMx.Unlock()
+
+	tenantID := tenant.FromContext(ctx)
+	if _, ok := r.labels[tenantID]; !ok {
+		r.labels[tenantID] = make(map[models.Address]models.AddressLabels)
+	}
+
+	r.labels[tenantID][labels.Address] = labels
+
+	return nil
+}
+
+func (r *AddressLabelsRepository) GetAddressLabels(ctx context.Context, address models.Address) (models.AddressLabels, error) {
+	defer metrics.ObserveRepositoryOperation(repositoryName, "GetAddressLabels", time.Now())
+
+	r.labelsMx.RLock()
+	defer r.labelsMx.RUnlock()
+
+	labels, ok := r.labels[tenant.FromContext(ctx)][address]
+	if !ok {
+		return models.AddressLabels{}, models.ErrLabelsNotFound
+	}
+
+	return labels, nil
+}
+
+// GetAddressesLabels returns labels of the addresses, addresses without labels are skipped
+func (r *AddressLabelsRepository) GetAddressesLabels(ctx context.Context, addresses []models.Address) ([]models.AddressLabels, error) {
+	defer metrics.ObserveRepositoryOperation(repositoryName, "GetAddressesLabels", time.Now())
+
+	r.labelsMx.RLock()
+	defer r.labelsMx.RUnlock()
+
+	tenantLabels := r.labels[tenant.FromContext(ctx)]
+
+	labels := make([]models.AddressLabels, 0, len(addresses))
+	for _, address := range addresses {
+		if addressLabels, ok := tenantLabels[address]; ok {
+			labels = append(labels, addressLabels)
+		}
+	}
+
+	return labels, nil
+}
+
+func (r *AddressLabelsRepository) GetAllAddressLabels(ctx context.Context) ([]models.AddressLabels, error) {
+	defer metrics.ObserveRepositoryOperation(repositoryName, "GetAllAddressLabels", time.Now())
+
+	r.labelsMx.RLock()
+	defer r.labelsMx.RUnlock()
+
+	tenantLabels := r.labels[tenant.FromContext(ctx)]
+
+	labels := make([]models.AddressLabels, 0, len(tenantLabels))
+	for _, addressLabels := range tenantLabels {
+		labels = append(labels, addressLabels)
+	}
+
+	return labels, nil
+}
+
+func (r *AddressLabelsRepository) DeleteAddressLabels(ctx context.Context, address models.Address) error {
+	defer metrics.ObserveRepositoryOperation(repositoryName, "DeleteAddressLabels", time.Now())
+
+	r.labelsMx.Lock()
+	defer r.labelsMx.Unlock()
+
+	tenantID := tenant.FromContext(ctx)
+	if _, ok := r.labels[tenantID][address]; !ok {
+		return models.ErrLabelsNotFound
+	}
+
+	delete(r.labels[tenantID], address)
+
+	return nil
+}
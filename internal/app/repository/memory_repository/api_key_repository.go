@@ -0,0 +1,86 @@
+package memory_repository
+
+import (
+	"context"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/metrics"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/models"
+	"sync"
+	"time"
+)
+
+// APIKeyRepository keeps API keys of tenants in memory, keys are lost on restart
+type APIKeyRepository struct {
+	keys map[string]models.APIKey
+	// idsByHash indexes keys by hash of raw key for authentication
+	idsByHash map[string]string
+	keysMx    sync.RWMutex
+}
+
+func NewAPIKeyRepository() *APIKeyRepository {
+	return &APIKeyRepository{
+		keys:      make(map[string]models.APIKey),
+		idsByHash: make(map[string]string),
+		keysMx:    sync.RWMutex{},
+	}
+}
+
+func (r *APIKeyRepository) AddAPIKey(ctx context.Context, key models.APIKey) error {
+	defer metrics.ObserveRepositoryOperation(repositoryName, "AddAPIKey", time.Now())
+
+	r.keysMx.Lock()
+	defer r.keysMx.Unlock()
+
+	if _, ok := r.keys[key.ID]; ok {
+		return models.ErrAPIKeyAlreadyExists
+	}
+
+	r.keys[key.ID] = key
+	r.idsByHash[key.Hash] = key.ID
+
+	return nil
+}
+
+func (r *APIKeyRepository) GetAPIKeyByHash(ctx context.Context, hash string) (models.APIKey, error) {
+	defer metrics.ObserveRepositoryOperation(repositoryName, "GetAPIKeyByHash", time.Now())
+
+	r.keysMx.RLock()
+	defer r.keysMx.RUnlock()
+
+	key, ok := r.keys[r.idsByHash[hash]]
+	if !ok {
+		return models.APIKey{}, models.ErrAPIKeyNotFound
+	}
+
+	return key, nil
+}
+
+func (r *APIKeyRepository) GetAPIKeys(ctx context.Context) ([]models.APIKey, error) {
+	defer metrics.ObserveRepositoryOperation(repositoryName, "GetAPIKeys", time.Now())
+
+	r.keysMx.RLock()
+	defer r.keysMx.RUnlock()
+
+	keys := make([]models.APIKey, 0, len(r.keys))
+	for _, key := range r.keys {
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
+
+func (r *APIKeyRepository) DeleteAPIKey(ctx context.Context, id string) error {
+	defer metrics.ObserveRepositoryOperation(repositoryName, "DeleteAPIKey", time.Now())
+
+	r.keysMx.Lock()
+	defer r.keysMx.Unlock()
+
+	key, ok := r.keys[id]
+	if !ok {
+		return models.ErrAPIKeyNotFound
+	}
+
+	delete(r.keys, id)
+	delete(r.idsByHash, key.Hash)
+
+	return nil
+}
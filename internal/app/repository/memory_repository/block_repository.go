@@ -2,7 +2,9 @@ package memory_repository
 
 import (
 	"context"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/metrics"
 	"sync"
+	"time"
 )
 
 type BlockRepository struct {
@@ -18,6 +20,8 @@ func NewBlockRepository() *BlockRepository {
 }
 
 func (r *BlockRepository) SetMaxCurrentBlock(ctx context.Context, newCurrentBlock uint64) error {
+	defer metrics.ObserveRepositoryOperation(repositoryName, "SetMaxCurrentBlock", time.Now())
+
 	r.currentBlockMx.RLock()
 
 	if newCurrentBlock > r.currentBlock {
@@ -36,6 +40,8 @@ func (r *BlockRepository) SetMaxCurrentBlock(ctx context.Context, newCurrentBloc
 }
 
 func (r *BlockRepository) GetCurrentBlock(ctx context.Context) (uint64, error) {
+	defer metrics.ObserveRepositoryOperation(repositoryName, "GetCurrentBlock", time.Now())
+
 	r.currentBlockMx.RLock()
 	currentBlock := r.currentBlock
 	r.currentBlockMx.RUnlock()
@@ -0,0 +1,113 @@
+package memory_repository
+
+import (
+	"context"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/metrics"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/models"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/tenant"
+	"sync"
+	"time"
+)
+
+// contractABIsKey is a key of ABIs uploaded by the tenant for contracts of the chain
+type contractABIsKey struct {
+	tenantID string
+	chainID  uint64
+}
+
+// ContractABIRepository keeps ABIs of contracts uploaded by tenants in memory, ABIs are lost on restart.
+// Tenant of ABI is taken from context
+type ContractABIRepository struct {
+	// abis are ABIs by tenant and chain and contract address
+	abis   map[contractABIsKey]map[models.Address]models.ContractABI
+	abisMx sync.RWMutex
+}
+
+func NewContractABIRepository() *ContractABIRepository {
+	return &ContractABIRepository{
+		abis:   make(map[contractABIsKey]map[models.Address]models.ContractABI),
+		abisMx: sync.RWMutex{},
+	}
+}
+
+// SetContractABI replaces ABI of the contract
+func (r *ContractABIRepository) SetContractABI(ctx context.Context, contractABI models.ContractABI) error {
+	defer metrics.ObserveRepositoryOperation(repositoryName, "SetContractABI", time.Now())
+
+	r.abisMx.Lock()
+	defer r.abisMx.Unlock()
+
+	key := contractABIsKey{tenantID: tenant.FromContext(ctx), chainID: contractABI.ChainID}
+	if _, ok := r.abis[key]; !ok {
+		r.abis[key] = make(map[models.Address]models.ContractABI)
+	}
+
+	r.abis[key][contractABI.Address] = contractABI
+
+	return nil
+}
+
+func (r *ContractABIRepository) GetContractABI(ctx context.Context, chainID uint64, address models.Address) (models.ContractABI, error) {
+	defer metrics.ObserveRepositoryOperation(repositoryName, "GetContractABI", time.Now())
+
+	r.abisMx.RLock()
+	defer r.abisMx.RUnlock()
+
+	contractABI, ok := r.abis[contractABIsKey{tenantID: tenant.FromContext(ctx), chainID: chainID}][address]
+	if !ok {
+		return models.ContractABI{}, models.ErrABINotFound
+	}
+
+	return contractABI, nil
+}
+
+// GetContractsABIs returns ABIs of the contracts, contracts without ABI are skipped
+func (r *ContractABIRepository) GetContractsABIs(ctx context.Context, chainID uint64, addresses []models.Address) ([]models.ContractABI, error) {
+	defer metrics.ObserveRepositoryOperation(repositoryName, "GetContractsABIs", time.Now())
+
+	r.abisMx.RLock()
+	defer r.abisMx.RUnlock()
+
+	tenantABIs := r.abis[contractABIsKey{tenantID: tenant.FromContext(ctx), chainID: chainID}]
+
+	contractABIs := make([]models.ContractABI, 0, len(addresses))
+	for _, address := range addresses {
+		if contractABI, ok := tenantABIs[address]; ok {
+			contractABIs = append(contractABIs, contractABI)
+		}
+	}
+
+	return contractABIs, nil
+}
+
+func (r *ContractABIRepository) GetAllContractABIs(ctx context.Context, chainID uint64) ([]models.ContractABI, error) {
+	defer metrics.ObserveRepositoryOperation(repositoryName, "GetAllContractABIs", time.Now())
+
+	r.abisMx.RLock()
+	defer r.abisMx.RUnlock()
+
+	tenantABIs := r.abis[contractABIsKey{tenantID: tenant.FromContext(ctx), chainID: chainID}]
+
+	contractABIs := make([]models.ContractABI, 0, len(tenantABIs))
+	for _, contractABI := range tenantABIs {
+		contractABIs = append(contractABIs, contractABI)
+	}
+
+	return contractABIs, nil
+}
+
+func (r *ContractABIRepository) DeleteContractABI(ctx context.Context, chainID uint64, address models.Address) error {
+	defer metrics.ObserveRepositoryOperation(repositoryName, "DeleteContractABI", time.Now())
+
+	r.abisMx.Lock()
+	defer r.abisMx.Unlock()
+
+	key := contractABIsKey{tenantID: tenant.FromContext(ctx), chainID: chainID}
+	if _, ok := r.abis[key][address]; !ok {
+		return models.ErrABINotFound
+	}
+
+	delete(r.abis[key], address)
+
+	return nil
+}
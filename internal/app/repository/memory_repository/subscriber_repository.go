@@ -2,44 +2,105 @@ package memory_repository
 
 import (
 	"context"
-	"errors"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/metrics"
 	"github.com/bluntenpassant/ethereum_subscriber/internal/app/models"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/tenant"
 	"sync"
+	"time"
 )
 
+// repositoryName is a label of repository in metrics
+const repositoryName = "memory"
+
 type SubscriberRepository struct {
-	subscribers   map[string]models.Subscriber
+	// subscribers are isolated by tenant, tenant of per-address operations is taken from ctx
+	subscribers   map[models.SubscriberKey]models.Subscriber
 	subscribersMx sync.RWMutex
 }
 
 func NewSubscriberRepository() *SubscriberRepository {
 	return &SubscriberRepository{
-		subscribers:   make(map[string]models.Subscriber),
+		subscribers:   make(map[models.SubscriberKey]models.Subscriber),
 		subscribersMx: sync.RWMutex{},
 	}
 }
 
 func (r *SubscriberRepository) AddNewSubscriber(ctx context.Context, subscriber models.Subscriber) error {
+	defer metrics.ObserveRepositoryOperation(repositoryName, "AddNewSubscriber", time.Now())
+
 	r.subscribersMx.Lock()
 	defer r.subscribersMx.Unlock()
 
-	if _, ok := r.subscribers[subscriber.Address]; ok {
-		return errors.New("subscriber already registered")
+	if _, ok := r.subscribers[subscriber.Key()]; ok {
+		return models.ErrAlreadySubscribed
 	}
 
-	r.subscribers[subscriber.Address] = subscriber
+	r.subscribers[subscriber.Key()] = subscriber
 
 	return nil
 }
 
-func (r *SubscriberRepository) GetSubscriberByAddress(ctx context.Context, address string) (models.Subscriber, error) {
+func (r *SubscriberRepository) GetSubscriberByAddress(ctx context.Context, address models.Address) (models.Subscriber, error) {
+	defer metrics.ObserveRepositoryOperation(repositoryName, "GetSubscriberByAddress", time.Now())
+
 	r.subscribersMx.RLock()
 	defer r.subscribersMx.RUnlock()
 
-	subscriber, ok := r.subscribers[address]
+	subscriber, ok := r.subscribers[tenant.Key(ctx, address)]
 	if !ok {
-		return models.Subscriber{}, errors.New("address is not subscribed")
+		return models.Subscriber{}, models.ErrNotSubscribed
 	}
 
 	return subscriber, nil
 }
+
+func (r *SubscriberRepository) GetSubscribers(ctx context.Context) ([]models.Subscriber, error) {
+	defer metrics.ObserveRepositoryOperation(repositoryName, "GetSubscribers", time.Now())
+
+	r.subscribersMx.RLock()
+	defer r.subscribersMx.RUnlock()
+
+	subscribers := make([]models.Subscriber, 0, len(r.subscribers))
+	for _, subscriber := range r.subscribers {
+		subscribers = append(subscribers, subscriber)
+	}
+
+	return subscribers, nil
+}
+
+// CountSubscribers returns count of subscriptions of the tenant from ctx
+func (r *SubscriberRepository) CountSubscribers(ctx context.Context) (int, error) {
+	defer metrics.ObserveRepositoryOperation(repositoryName, "CountSubscribers", time.Now())
+
+	tenantID := tenant.FromContext(ctx)
+
+	r.subscribersMx.RLock()
+	defer r.subscribersMx.RUnlock()
+
+	count := 0
+	for key := range r.subscribers {
+		if key.TenantID == tenantID {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+// DeleteSubscriber removes subscription of address, error is returned if address is not subscribed
+func (r *SubscriberRepository) DeleteSubscriber(ctx context.Context, address models.Address) error {
+	defer metrics.ObserveRepositoryOperation(repositoryName, "DeleteSubscriber", time.Now())
+
+	key := tenant.Key(ctx, address)
+
+	r.subscribersMx.Lock()
+	defer r.subscribersMx.Unlock()
+
+	if _, ok := r.subscribers[key]; !ok {
+		return models.ErrNotSubscribed
+	}
+
+	delete(r.subscribers, key)
+
+	return nil
+}
@@ -3,6 +3,7 @@ package memory_repository
 import (
 	"context"
 	"github.com/bluntenpassant/ethereum_subscriber/internal/app/models"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/tenant"
 	"github.com/stretchr/testify/assert"
 	"testing"
 )
@@ -38,3 +39,125 @@ func TestSubscriberRepository_GetSubscriberByAddress(t *testing.T) {
 
 	assert.EqualValues(t, gotSubscriber, subscriber)
 }
+
+func TestSubscriberRepository_TenantIsolation(t *testing.T) {
+	ctx := context.TODO()
+	tenantACtx := tenant.WithID(ctx, "tenant_a")
+	tenantBCtx := tenant.WithID(ctx, "tenant_b")
+
+	subscriberRepository := NewSubscriberRepository()
+
+	subscriber := models.Subscriber{
+		Address:              "0xd8da6bf26964af9d7eed9e03e53415d37aa96045",
+		SubscribeBlockNumber: 5,
+		TenantID:             "tenant_a",
+	}
+
+	err := subscriberRepository.AddNewSubscriber(tenantACtx, subscriber)
+	assert.NoError(t, err)
+
+	gotSubscriber, err := subscriberRepository.GetSubscriberByAddress(tenantACtx, subscriber.Address)
+	assert.NoError(t, err)
+	assert.Equal(t, subscriber, gotSubscriber)
+
+	_, err = subscriberRepository.GetSubscriberByAddress(tenantBCtx, subscriber.Address)
+	assert.ErrorIs(t, err, models.ErrNotSubscribed)
+
+	_, err = subscriberRepository.GetSubscriberByAddress(ctx, subscriber.Address)
+	assert.ErrorIs(t, err, models.ErrNotSubscribed)
+
+	// The same address is subscribed by another tenant independently
+	err = subscriberRepository.AddNewSubscriber(tenantBCtx, models.Subscriber{Address: subscriber.Address, TenantID: "tenant_b"})
+	assert.NoError(t, err)
+}
+
+func TestSubscriberRepository_GetSubscribers(t *testing.T) {
+	ctx := context.TODO()
+
+	subscriberRepository := NewSubscriberRepository()
+
+	subscribers, err := subscriberRepository.GetSubscribers(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(subscribers))
+
+	expectedSubscribers := []models.Subscriber{
+		{
+			Address:              "0xd8da6bf26964af9d7eed9e03e53415d37aa96045",
+			SubscribeBlockNumber: 15,
+			SubscribeTxCount:     14,
+			ENSName:              "vitalik.eth",
+		},
+		{
+			Address:              "0x388c818ca8b9251b393131c08a736a67ccb19297",
+			SubscribeBlockNumber: 19,
+			SubscribeTxCount:     7,
+		},
+	}
+
+	for _, subscriber := range expectedSubscribers {
+		err = subscriberRepository.AddNewSubscriber(ctx, subscriber)
+		assert.NoError(t, err)
+	}
+
+	subscribers, err = subscriberRepository.GetSubscribers(ctx)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, expectedSubscribers, subscribers)
+}
+
+func TestSubscriberRepository_CountSubscribers(t *testing.T) {
+	ctx := context.TODO()
+	tenantACtx := tenant.WithID(ctx, "tenant_a")
+	tenantBCtx := tenant.WithID(ctx, "tenant_b")
+
+	subscriberRepository := NewSubscriberRepository()
+
+	assert.NoError(t, subscriberRepository.AddNewSubscriber(ctx, models.Subscriber{Address: "0xd8da6bf26964af9d7eed9e03e53415d37aa96045"}))
+	assert.NoError(t, subscriberRepository.AddNewSubscriber(tenantACtx, models.Subscriber{Address: "0xd8da6bf26964af9d7eed9e03e53415d37aa96045", TenantID: "tenant_a"}))
+	assert.NoError(t, subscriberRepository.AddNewSubscriber(tenantACtx, models.Subscriber{Address: "0xdef1c0ded9bec7f1a1670819833240f027b25eff", TenantID: "tenant_a"}))
+
+	type TestCase struct {
+		name          string
+		ctx           context.Context
+		expectedCount int
+	}
+
+	testCases := []TestCase{
+		{name: "without tenant", ctx: ctx, expectedCount: 1},
+		{name: "tenant with subscriptions", ctx: tenantACtx, expectedCount: 2},
+		{name: "tenant without subscriptions", ctx: tenantBCtx, expectedCount: 0},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			count, err := subscriberRepository.CountSubscribers(testCase.ctx)
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expectedCount, count)
+		})
+	}
+}
+
+func TestSubscriberRepository_DeleteSubscriber(t *testing.T) {
+	ctx := context.TODO()
+	tenantACtx := tenant.WithID(ctx, "tenant_a")
+
+	subscriberRepository := NewSubscriberRepository()
+
+	subscriber := models.Subscriber{Address: "0xd8da6bf26964af9d7eed9e03e53415d37aa96045", TenantID: "tenant_a"}
+	assert.NoError(t, subscriberRepository.AddNewSubscriber(tenantACtx, subscriber))
+
+	// Subscription of another tenant is not affected
+	err := subscriberRepository.DeleteSubscriber(ctx, subscriber.Address)
+	assert.ErrorIs(t, err, models.ErrNotSubscribed)
+
+	err = subscriberRepository.DeleteSubscriber(tenantACtx, subscriber.Address)
+	assert.NoError(t, err)
+
+	_, err = subscriberRepository.GetSubscriberByAddress(tenantACtx, subscriber.Address)
+	assert.ErrorIs(t, err, models.ErrNotSubscribed)
+
+	err = subscriberRepository.DeleteSubscriber(tenantACtx, subscriber.Address)
+	assert.ErrorIs(t, err, models.ErrNotSubscribed)
+
+	// Address can be subscribed again
+	assert.NoError(t, subscriberRepository.AddNewSubscriber(tenantACtx, subscriber))
+}
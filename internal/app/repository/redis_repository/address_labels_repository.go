@@ -0,0 +1,147 @@
+package redis_repository
+
+import (
+	"context"
+	"errors"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/metrics"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/models"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/tenant"
+	"github.com/redis/go-redis/v9"
+	"time"
+)
+
+// AddressLabelsRepository is a structure to store address books of tenants in a Redis database.
+// Labels do not expire, they are kept until deleted. Tenant of address book is taken from context
+type AddressLabelsRepository struct {
+	redis *redis.Client
+}
+
+// NewAddressLabelsRepository creates a new instance of AddressLabelsRepository
+func NewAddressLabelsRepository(redis *redis.Client) *AddressLabelsRepository {
+	return &AddressLabelsRepository{
+		redis: redis,
+	}
+}
+
+// SetAddressLabels replaces labels of the address and adds the address to the set of labelled addresses of the tenant
+func (r *AddressLabelsRepository) SetAddressLabels(ctx context.Context, labels models.AddressLabels) error {
+	defer metrics.ObserveRepositoryOperation(repositoryName, "SetAddressLabels", time.Now())
+
+	rawLabels, err := serializeAddressLabelsValue(labels)
+	if err != nil {
+		return err
+	}
+
+	key := tenant.Key(ctx, labels.Address)
+
+	_, err = r.redis.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, getAddressLabelsKey(key), rawLabels, 0)
+		pipe.SAdd(ctx, getAddressLabelsSetKey(key.TenantID), key.Address.String())
+
+		return nil
+	})
+
+	return err
+}
+
+// GetAddressLabels returns labels of the address
+func (r *AddressLabelsRepository) GetAddressLabels(ctx context.Context, address models.Address) (models.AddressLabels, error) {
+	defer metrics.ObserveRepositoryOperation(repositoryName, "GetAddressLabels", time.Now())
+
+	rawLabels, err := r.redis.Get(ctx, getAddressLabelsKey(tenant.Key(ctx, address))).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return models.AddressLabels{}, models.ErrLabelsNotFound
+		}
+
+		return models.AddressLabels{}, err
+	}
+
+	return deserializeAddressLabelsValue(rawLabels)
+}
+
+// GetAddressesLabels returns labels of the addresses in one request, addresses without labels are skipped
+func (r *AddressLabelsRepository) GetAddressesLabels(ctx context.Context, addresses []models.Address) ([]models.AddressLabels, error) {
+	defer metrics.ObserveRepositoryOperation(repositoryName, "GetAddressesLabels", time.Now())
+
+	if len(addresses) == 0 {
+		return []models.AddressLabels{}, nil
+	}
+
+	keys := make([]string, 0, len(addresses))
+	for _, address := range addresses {
+		keys = append(keys, getAddressLabelsKey(tenant.Key(ctx, address)))
+	}
+
+	return r.getLabels(ctx, keys)
+}
+
+// GetAllAddressLabels returns labels of all labelled addresses of the tenant
+func (r *AddressLabelsRepository) GetAllAddressLabels(ctx context.Context) ([]models.AddressLabels, error) {
+	defer metrics.ObserveRepositoryOperation(repositoryName, "GetAllAddressLabels", time.Now())
+
+	addresses, err := r.redis.SMembers(ctx, getAddressLabelsSetKey(tenant.FromContext(ctx))).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(addresses) == 0 {
+		return []models.AddressLabels{}, nil
+	}
+
+	keys := make([]string, 0, len(addresses))
+	for _, address := range addresses {
+		keys = append(keys, getAddressLabelsKey(tenant.Key(ctx, models.Address(address))))
+	}
+
+	return r.getLabels(ctx, keys)
+}
+
+// DeleteAddressLabels removes labels of the address
+func (r *AddressLabelsRepository) DeleteAddressLabels(ctx context.Context, address models.Address) error {
+	defer metrics.ObserveRepositoryOperation(repositoryName, "DeleteAddressLabels", time.Now())
+
+	key := tenant.Key(ctx, address)
+
+	var del *redis.IntCmd
+	_, err := r.redis.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		del = pipe.Del(ctx, getAddressLabelsKey(key))
+		pipe.SRem(ctx, getAddressLabelsSetKey(key.TenantID), key.Address.String())
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if del.Val() == 0 {
+		return models.ErrLabelsNotFound
+	}
+
+	return nil
+}
+
+// getLabels returns labels stored by keys, missing keys are skipped
+func (r *AddressLabelsRepository) getLabels(ctx context.Context, keys []string) ([]models.AddressLabels, error) {
+	rawLabels, err := r.redis.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	labels := make([]models.AddressLabels, 0, len(rawLabels))
+	for _, rawAddressLabels := range rawLabels {
+		rawAddressLabelsStr, ok := rawAddressLabels.(string)
+		if !ok {
+			continue
+		}
+
+		addressLabels, err := deserializeAddressLabelsValue([]byte(rawAddressLabelsStr))
+		if err != nil {
+			return nil, err
+		}
+
+		labels = append(labels, addressLabels)
+	}
+
+	return labels, nil
+}
@@ -0,0 +1,70 @@
+package redis_repository
+
+import (
+	"context"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/models"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/tenant"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestAddressLabelsRepository(t *testing.T) {
+	ctx := tenant.WithID(context.TODO(), "tenant_a")
+	otherCtx := tenant.WithID(context.TODO(), "tenant_b")
+
+	redisClient := newTestRedisClient(t)
+	addressLabelsRepository := NewAddressLabelsRepository(redisClient)
+
+	labels := models.AddressLabels{
+		TenantID:  "tenant_a",
+		Address:   models.Address("0x28c6c06298d514db089934071355e5743bf21d60"),
+		Labels:    []string{"exchange", "binance"},
+		UpdatedAt: time.Date(2023, 2, 13, 14, 0, 0, 0, time.UTC),
+	}
+	otherAddress := models.Address("0x00000000219ab540356cbb839cbe05303d7705fa")
+
+	labelsKey := getAddressLabelsKey(tenant.Key(ctx, labels.Address))
+	redisClient.Del(ctx, labelsKey, getAddressLabelsSetKey("tenant_a"), getAddressLabelsSetKey("tenant_b"))
+	defer redisClient.Del(ctx, labelsKey, getAddressLabelsSetKey("tenant_a"), getAddressLabelsSetKey("tenant_b"))
+
+	err := addressLabelsRepository.SetAddressLabels(ctx, labels)
+	assert.NoError(t, err)
+
+	gotLabels, err := addressLabelsRepository.GetAddressLabels(ctx, labels.Address)
+	assert.NoError(t, err)
+	assert.Equal(t, labels, gotLabels)
+
+	// Address books of tenants are isolated
+	_, err = addressLabelsRepository.GetAddressLabels(otherCtx, labels.Address)
+	assert.ErrorIs(t, err, models.ErrLabelsNotFound)
+
+	allLabels, err := addressLabelsRepository.GetAllAddressLabels(otherCtx)
+	assert.NoError(t, err)
+	assert.Empty(t, allLabels)
+
+	labels.Labels = []string{"customer:42"}
+	err = addressLabelsRepository.SetAddressLabels(ctx, labels)
+	assert.NoError(t, err)
+
+	addressesLabels, err := addressLabelsRepository.GetAddressesLabels(ctx, []models.Address{otherAddress, labels.Address})
+	assert.NoError(t, err)
+	assert.Equal(t, []models.AddressLabels{labels}, addressesLabels)
+
+	allLabels, err = addressLabelsRepository.GetAllAddressLabels(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []models.AddressLabels{labels}, allLabels)
+
+	err = addressLabelsRepository.DeleteAddressLabels(ctx, labels.Address)
+	assert.NoError(t, err)
+
+	_, err = addressLabelsRepository.GetAddressLabels(ctx, labels.Address)
+	assert.ErrorIs(t, err, models.ErrLabelsNotFound)
+
+	allLabels, err = addressLabelsRepository.GetAllAddressLabels(ctx)
+	assert.NoError(t, err)
+	assert.Empty(t, allLabels)
+
+	err = addressLabelsRepository.DeleteAddressLabels(ctx, labels.Address)
+	assert.ErrorIs(t, err, models.ErrLabelsNotFound)
+}
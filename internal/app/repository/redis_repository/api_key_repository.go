@@ -0,0 +1,147 @@
+package redis_repository
+
+import (
+	"context"
+	"errors"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/metrics"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/models"
+	"github.com/redis/go-redis/v9"
+	"time"
+)
+
+// APIKeyRepository is a structure to store API keys of tenants in a Redis database.
+// Keys do not expire, they are kept until revoked
+type APIKeyRepository struct {
+	redis *redis.Client
+}
+
+// NewAPIKeyRepository creates a new instance of APIKeyRepository
+func NewAPIKeyRepository(redis *redis.Client) *APIKeyRepository {
+	return &APIKeyRepository{
+		redis: redis,
+	}
+}
+
+// AddAPIKey stores API key, index by hash of raw key and ID of the key in the set of all keys
+func (r *APIKeyRepository) AddAPIKey(ctx context.Context, key models.APIKey) error {
+	defer metrics.ObserveRepositoryOperation(repositoryName, "AddAPIKey", time.Now())
+
+	rawKey, err := serializeAPIKeyValue(key)
+	if err != nil {
+		return err
+	}
+
+	// SetNX guards against overwriting key with the same ID
+	ok, err := r.redis.SetNX(ctx, getAPIKeysKey(key.ID), rawKey, 0).Result()
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		return models.ErrAPIKeyAlreadyExists
+	}
+
+	_, err = r.redis.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, getAPIKeysHashKey(key.Hash), key.ID, 0)
+		pipe.SAdd(ctx, apiKeysSetKey, key.ID)
+
+		return nil
+	})
+
+	return err
+}
+
+// GetAPIKeyByHash returns API key by hash of raw key
+func (r *APIKeyRepository) GetAPIKeyByHash(ctx context.Context, hash string) (models.APIKey, error) {
+	defer metrics.ObserveRepositoryOperation(repositoryName, "GetAPIKeyByHash", time.Now())
+
+	id, err := r.redis.Get(ctx, getAPIKeysHashKey(hash)).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return models.APIKey{}, models.ErrAPIKeyNotFound
+		}
+
+		return models.APIKey{}, err
+	}
+
+	rawKey, err := r.redis.Get(ctx, getAPIKeysKey(id)).Bytes()
+	if err != nil {
+		// Key is revoked between reads
+		if errors.Is(err, redis.Nil) {
+			return models.APIKey{}, models.ErrAPIKeyNotFound
+		}
+
+		return models.APIKey{}, err
+	}
+
+	return deserializeAPIKeyValue(rawKey)
+}
+
+// GetAPIKeys returns all API keys of all tenants
+func (r *APIKeyRepository) GetAPIKeys(ctx context.Context) ([]models.APIKey, error) {
+	defer metrics.ObserveRepositoryOperation(repositoryName, "GetAPIKeys", time.Now())
+
+	ids, err := r.redis.SMembers(ctx, apiKeysSetKey).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ids) == 0 {
+		return []models.APIKey{}, nil
+	}
+
+	keys := make([]string, 0, len(ids))
+	for _, id := range ids {
+		keys = append(keys, getAPIKeysKey(id))
+	}
+
+	rawKeys, err := r.redis.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	apiKeys := make([]models.APIKey, 0, len(rawKeys))
+	for _, rawKey := range rawKeys {
+		rawKeyStr, ok := rawKey.(string)
+		if !ok {
+			continue
+		}
+
+		apiKey, err := deserializeAPIKeyValue([]byte(rawKeyStr))
+		if err != nil {
+			return nil, err
+		}
+
+		apiKeys = append(apiKeys, apiKey)
+	}
+
+	return apiKeys, nil
+}
+
+// DeleteAPIKey revokes API key with the given ID
+func (r *APIKeyRepository) DeleteAPIKey(ctx context.Context, id string) error {
+	defer metrics.ObserveRepositoryOperation(repositoryName, "DeleteAPIKey", time.Now())
+
+	rawKey, err := r.redis.Get(ctx, getAPIKeysKey(id)).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return models.ErrAPIKeyNotFound
+		}
+
+		return err
+	}
+
+	key, err := deserializeAPIKeyValue(rawKey)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.redis.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, getAPIKeysKey(id), getAPIKeysHashKey(key.Hash))
+		pipe.SRem(ctx, apiKeysSetKey, id)
+
+		return nil
+	})
+
+	return err
+}
@@ -0,0 +1,50 @@
+package redis_repository
+
+import (
+	"context"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/models"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestAPIKeyRepository(t *testing.T) {
+	ctx := context.TODO()
+
+	redisClient := newTestRedisClient(t)
+	apiKeyRepository := NewAPIKeyRepository(redisClient)
+
+	key := models.APIKey{
+		ID:        "0f41f887",
+		TenantID:  "tenant_a",
+		Hash:      "7885d4adcbb79d7ae83bd60b4d990206b5a357c5aa24bb5098d83788d0f1e6d2",
+		CreatedAt: time.Date(2023, 2, 13, 14, 0, 0, 0, time.UTC),
+	}
+
+	redisClient.Del(ctx, getAPIKeysKey(key.ID), getAPIKeysHashKey(key.Hash))
+	defer redisClient.Del(ctx, getAPIKeysKey(key.ID), getAPIKeysHashKey(key.Hash))
+
+	err := apiKeyRepository.AddAPIKey(ctx, key)
+	assert.NoError(t, err)
+
+	err = apiKeyRepository.AddAPIKey(ctx, key)
+	assert.ErrorIs(t, err, models.ErrAPIKeyAlreadyExists)
+
+	gotKey, err := apiKeyRepository.GetAPIKeyByHash(ctx, key.Hash)
+	assert.NoError(t, err)
+	assert.Equal(t, key, gotKey)
+
+	// other tests share the same database, so we check only presence of our key
+	keys, err := apiKeyRepository.GetAPIKeys(ctx)
+	assert.NoError(t, err)
+	assert.Contains(t, keys, key)
+
+	err = apiKeyRepository.DeleteAPIKey(ctx, key.ID)
+	assert.NoError(t, err)
+
+	_, err = apiKeyRepository.GetAPIKeyByHash(ctx, key.Hash)
+	assert.ErrorIs(t, err, models.ErrAPIKeyNotFound)
+
+	err = apiKeyRepository.DeleteAPIKey(ctx, key.ID)
+	assert.ErrorIs(t, err, models.ErrAPIKeyNotFound)
+}
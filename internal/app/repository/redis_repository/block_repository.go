@@ -2,23 +2,41 @@ package redis_repository
 
 import (
 	"context"
+	"errors"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/metrics"
 	"github.com/redis/go-redis/v9"
+	"sync/atomic"
 	"time"
 )
 
 // BlockRepository is a structure that holds information about the current block.
 // It uses a Redis client to store the current block and has an expiration time for the stored value.
 type BlockRepository struct {
-	redis          *redis.Client
-	expirationTime time.Duration
+	// keySpace selects keys of the chain, so data of different chains does not collide
+	keySpace KeySpace
+	redis    *redis.Client
+	// expirationTime is kept as nanoseconds to be changed at runtime by SetExpirationTime
+	expirationTime atomic.Int64
 }
 
 // NewBlockRepository returns a new instance of the BlockRepository with the specified Redis client and expiration time.
-func NewBlockRepository(redis *redis.Client, expirationTime time.Duration) *BlockRepository {
-	return &BlockRepository{
-		redis:          redis,
-		expirationTime: expirationTime,
+func NewBlockRepository(keySpace KeySpace, redis *redis.Client, expirationTime time.Duration) *BlockRepository {
+	repository := &BlockRepository{
+		keySpace: keySpace,
+		redis:    redis,
 	}
+	repository.SetExpirationTime(expirationTime)
+
+	return repository
+}
+
+// SetExpirationTime changes expiration time of keys written after the call, it is safe for concurrent use
+func (r *BlockRepository) SetExpirationTime(expirationTime time.Duration) {
+	r.expirationTime.Store(int64(expirationTime))
+}
+
+func (r *BlockRepository) getExpirationTime() time.Duration {
+	return time.Duration(r.expirationTime.Load())
 }
 
 // SetMaxCurrentBlock updates the current block in the repository.
@@ -26,12 +44,14 @@ func NewBlockRepository(redis *redis.Client, expirationTime time.Duration) *Bloc
 // If the value does not exist, it sets the new value and returns nil.
 // If there is an error, it returns the error.
 func (r *BlockRepository) SetMaxCurrentBlock(ctx context.Context, newCurrentBlock uint64) error {
+	defer metrics.ObserveRepositoryOperation(repositoryName, "SetMaxCurrentBlock", time.Now())
+
 	// Get the current block from the repository
-	currentBlock, err := r.redis.Get(ctx, getCurrentBlockKey()).Uint64()
+	currentBlock, err := r.redis.Get(ctx, getCurrentBlockKey(r.keySpace)).Uint64()
 	if err != nil {
 		// If the current block does not exist, set the new current block
-		if err.Error() == redisNilErrMsg {
-			err = r.redis.Set(ctx, getCurrentBlockKey(), serializeCurrentBlockValue(newCurrentBlock), r.expirationTime).Err()
+		if errors.Is(err, redis.Nil) {
+			err = r.redis.Set(ctx, getCurrentBlockKey(r.keySpace), serializeCurrentBlockValue(newCurrentBlock), r.getExpirationTime()).Err()
 			if err != nil {
 				return err
 			}
@@ -44,7 +64,7 @@ func (r *BlockRepository) SetMaxCurrentBlock(ctx context.Context, newCurrentBloc
 
 	// If the new current block is higher than the existing current block, set the new value
 	if newCurrentBlock > currentBlock {
-		err := r.redis.Set(ctx, getCurrentBlockKey(), serializeCurrentBlockValue(newCurrentBlock), r.expirationTime).Err()
+		err := r.redis.Set(ctx, getCurrentBlockKey(r.keySpace), serializeCurrentBlockValue(newCurrentBlock), r.getExpirationTime()).Err()
 		if err != nil {
 			return err
 		}
@@ -57,8 +77,10 @@ func (r *BlockRepository) SetMaxCurrentBlock(ctx context.Context, newCurrentBloc
 // GetCurrentBlock returns the current block from the repository.
 // If there is an error, it returns 0 and the error.
 func (r *BlockRepository) GetCurrentBlock(ctx context.Context) (uint64, error) {
+	defer metrics.ObserveRepositoryOperation(repositoryName, "GetCurrentBlock", time.Now())
+
 	// Get the current block from the repository
-	currentBlock, err := r.redis.Get(ctx, getCurrentBlockKey()).Uint64()
+	currentBlock, err := r.redis.Get(ctx, getCurrentBlockKey(r.keySpace)).Uint64()
 	if err != nil {
 		// Return 0 and the error if there was a problem retrieving the current block
 		return 0, err
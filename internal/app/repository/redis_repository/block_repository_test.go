@@ -2,27 +2,18 @@ package redis_repository
 
 import (
 	"context"
-	"github.com/redis/go-redis/v9"
 	"github.com/stretchr/testify/assert"
 	"testing"
 	"time"
 )
 
-const redisHostBlockRepository = "0.0.0.0"
-const redisPortBlockRepository = "6379"
-const redisPasswordBlockRepository = ""
-
 func TestBlockRepository_SetMaxCurrentBlock(t *testing.T) {
 	ctx := context.TODO()
 
-	redisClient := redis.NewClient(&redis.Options{
-		Addr:     redisHostBlockRepository + ":" + redisPortBlockRepository,
-		Password: redisPasswordBlockRepository,
-		DB:       0,
-	})
+	redisClient := newTestRedisClient(t)
 
 	// first testcase
-	blockRepository := NewBlockRepository(redisClient, 10*time.Second)
+	blockRepository := NewBlockRepository(testKeySpace, redisClient, 10*time.Second)
 	err := blockRepository.SetMaxCurrentBlock(ctx, 1)
 	assert.NoError(t, err)
 
@@ -35,7 +26,7 @@ func TestBlockRepository_SetMaxCurrentBlock(t *testing.T) {
 
 	// second testcase
 
-	blockRepository = NewBlockRepository(redisClient, 10*time.Second)
+	blockRepository = NewBlockRepository(testKeySpace, redisClient, 10*time.Second)
 	err = blockRepository.SetMaxCurrentBlock(ctx, 5)
 	assert.NoError(t, err)
 
@@ -56,14 +47,10 @@ func TestBlockRepository_SetMaxCurrentBlock(t *testing.T) {
 func TestBlockRepository_GetCurrentBlock(t *testing.T) {
 	ctx := context.TODO()
 
-	redisClient := redis.NewClient(&redis.Options{
-		Addr:     redisHostBlockRepository + ":" + redisPortBlockRepository,
-		Password: redisPasswordBlockRepository,
-		DB:       0,
-	})
+	redisClient := newTestRedisClient(t)
 
 	// first testcase
-	blockRepository := NewBlockRepository(redisClient, 10*time.Second)
+	blockRepository := NewBlockRepository(testKeySpace, redisClient, 10*time.Second)
 	err := blockRepository.SetMaxCurrentBlock(ctx, 7)
 	assert.NoError(t, err)
 
@@ -0,0 +1,147 @@
+package redis_repository
+
+import (
+	"context"
+	"errors"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/metrics"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/models"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/tenant"
+	"github.com/redis/go-redis/v9"
+	"time"
+)
+
+// ContractABIRepository is a structure to store ABIs of contracts uploaded by tenants in a Redis database.
+// ABIs do not expire, they are kept until deleted. Tenant of ABI is taken from context
+type ContractABIRepository struct {
+	redis *redis.Client
+}
+
+// NewContractABIRepository creates a new instance of ContractABIRepository
+func NewContractABIRepository(redis *redis.Client) *ContractABIRepository {
+	return &ContractABIRepository{
+		redis: redis,
+	}
+}
+
+// SetContractABI replaces ABI of the contract and adds the contract to the set of contracts of the chain with ABI of the tenant
+func (r *ContractABIRepository) SetContractABI(ctx context.Context, contractABI models.ContractABI) error {
+	defer metrics.ObserveRepositoryOperation(repositoryName, "SetContractABI", time.Now())
+
+	rawContractABI, err := serializeContractABIValue(contractABI)
+	if err != nil {
+		return err
+	}
+
+	key := tenant.Key(ctx, contractABI.Address)
+
+	_, err = r.redis.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, getContractABIKey(contractABI.ChainID, key), rawContractABI, 0)
+		pipe.SAdd(ctx, getContractABISetKey(contractABI.ChainID, key.TenantID), key.Address.String())
+
+		return nil
+	})
+
+	return err
+}
+
+// GetContractABI returns ABI of the contract
+func (r *ContractABIRepository) GetContractABI(ctx context.Context, chainID uint64, address models.Address) (models.ContractABI, error) {
+	defer metrics.ObserveRepositoryOperation(repositoryName, "GetContractABI", time.Now())
+
+	rawContractABI, err := r.redis.Get(ctx, getContractABIKey(chainID, tenant.Key(ctx, address))).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return models.ContractABI{}, models.ErrABINotFound
+		}
+
+		return models.ContractABI{}, err
+	}
+
+	return deserializeContractABIValue(rawContractABI)
+}
+
+// GetContractsABIs returns ABIs of the contracts in one request, contracts without ABI are skipped
+func (r *ContractABIRepository) GetContractsABIs(ctx context.Context, chainID uint64, addresses []models.Address) ([]models.ContractABI, error) {
+	defer metrics.ObserveRepositoryOperation(repositoryName, "GetContractsABIs", time.Now())
+
+	if len(addresses) == 0 {
+		return []models.ContractABI{}, nil
+	}
+
+	keys := make([]string, 0, len(addresses))
+	for _, address := range addresses {
+		keys = append(keys, getContractABIKey(chainID, tenant.Key(ctx, address)))
+	}
+
+	return r.getContractABIs(ctx, keys)
+}
+
+// GetAllContractABIs returns ABIs of all contracts of the chain uploaded by the tenant
+func (r *ContractABIRepository) GetAllContractABIs(ctx context.Context, chainID uint64) ([]models.ContractABI, error) {
+	defer metrics.ObserveRepositoryOperation(repositoryName, "GetAllContractABIs", time.Now())
+
+	addresses, err := r.redis.SMembers(ctx, getContractABISetKey(chainID, tenant.FromContext(ctx))).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(addresses) == 0 {
+		return []models.ContractABI{}, nil
+	}
+
+	keys := make([]string, 0, len(addresses))
+	for _, address := range addresses {
+		keys = append(keys, getContractABIKey(chainID, tenant.Key(ctx, models.Address(address))))
+	}
+
+	return r.getContractABIs(ctx, keys)
+}
+
+// DeleteContractABI removes ABI of the contract
+func (r *ContractABIRepository) DeleteContractABI(ctx context.Context, chainID uint64, address models.Address) error {
+	defer metrics.ObserveRepositoryOperation(repositoryName, "DeleteContractABI", time.Now())
+
+	key := tenant.Key(ctx, address)
+
+	var del *redis.IntCmd
+	_, err := r.redis.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		del = pipe.Del(ctx, getContractABIKey(chainID, key))
+		pipe.SRem(ctx, getContractABISetKey(chainID, key.TenantID), key.Address.String())
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if del.Val() == 0 {
+		return models.ErrABINotFound
+	}
+
+	return nil
+}
+
+// getContractABIs returns ABIs stored by keys, missing keys are skipped
+func (r *ContractABIRepository) getContractABIs(ctx context.Context, keys []string) ([]models.ContractABI, error) {
+	rawContractABIs, err := r.redis.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	contractABIs := make([]models.ContractABI, 0, len(rawContractABIs))
+	for _, rawContractABI := range rawContractABIs {
+		rawContractABIStr, ok := rawContractABI.(string)
+		if !ok {
+			continue
+		}
+
+		contractABI, err := deserializeContractABIValue([]byte(rawContractABIStr))
+		if err != nil {
+			return nil, err
+		}
+
+		contractABIs = append(contractABIs, contractABI)
+	}
+
+	return contractABIs, nil
+}
@@ -0,0 +1,73 @@
+package redis_repository
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/models"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/tenant"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestContractABIRepository(t *testing.T) {
+	ctx := tenant.WithID(context.TODO(), "tenant_a")
+	otherCtx := tenant.WithID(context.TODO(), "tenant_b")
+
+	redisClient := newTestRedisClient(t)
+	contractABIRepository := NewContractABIRepository(redisClient)
+
+	contractABI := models.ContractABI{
+		TenantID:  "tenant_a",
+		ChainID:   1,
+		Address:   models.Address("0xdac17f958d2ee523a2206206994597c13d831ec7"),
+		ABI:       json.RawMessage(`[{"type":"function","name":"transfer","inputs":[{"name":"to","type":"address"},{"name":"value","type":"uint256"}]}]`),
+		Methods:   []string{"transfer(address,uint256)"},
+		Source:    models.ABISourceAPI,
+		UpdatedAt: time.Date(2023, 2, 13, 14, 0, 0, 0, time.UTC),
+	}
+	otherAddress := models.Address("0x00000000219ab540356cbb839cbe05303d7705fa")
+
+	abiKey := getContractABIKey(1, tenant.Key(ctx, contractABI.Address))
+	redisClient.Del(ctx, abiKey, getContractABISetKey(1, "tenant_a"), getContractABISetKey(1, "tenant_b"))
+	defer redisClient.Del(ctx, abiKey, getContractABISetKey(1, "tenant_a"), getContractABISetKey(1, "tenant_b"))
+
+	err := contractABIRepository.SetContractABI(ctx, contractABI)
+	assert.NoError(t, err)
+
+	gotABI, err := contractABIRepository.GetContractABI(ctx, 1, contractABI.Address)
+	assert.NoError(t, err)
+	assert.Equal(t, contractABI, gotABI)
+
+	// ABIs of tenants and chains are isolated
+	_, err = contractABIRepository.GetContractABI(otherCtx, 1, contractABI.Address)
+	assert.ErrorIs(t, err, models.ErrABINotFound)
+
+	_, err = contractABIRepository.GetContractABI(ctx, 10, contractABI.Address)
+	assert.ErrorIs(t, err, models.ErrABINotFound)
+
+	allABIs, err := contractABIRepository.GetAllContractABIs(otherCtx, 1)
+	assert.NoError(t, err)
+	assert.Empty(t, allABIs)
+
+	contractABIs, err := contractABIRepository.GetContractsABIs(ctx, 1, []models.Address{otherAddress, contractABI.Address})
+	assert.NoError(t, err)
+	assert.Equal(t, []models.ContractABI{contractABI}, contractABIs)
+
+	allABIs, err = contractABIRepository.GetAllContractABIs(ctx, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []models.ContractABI{contractABI}, allABIs)
+
+	err = contractABIRepository.DeleteContractABI(ctx, 1, contractABI.Address)
+	assert.NoError(t, err)
+
+	_, err = contractABIRepository.GetContractABI(ctx, 1, contractABI.Address)
+	assert.ErrorIs(t, err, models.ErrABINotFound)
+
+	allABIs, err = contractABIRepository.GetAllContractABIs(ctx, 1)
+	assert.NoError(t, err)
+	assert.Empty(t, allABIs)
+
+	err = contractABIRepository.DeleteContractABI(ctx, 1, contractABI.Address)
+	assert.ErrorIs(t, err, models.ErrABINotFound)
+}
@@ -3,10 +3,12 @@ package redis_repository
 import (
 	"encoding/json"
 	"github.com/bluntenpassant/ethereum_subscriber/internal/app/models"
+	"strconv"
+	"strings"
 )
 
-// redisNilErrMsg is a constant string representing the error message for a nil value in redis
-const redisNilErrMsg = "redis: nil"
+// repositoryName is a label of repository in metrics
+const repositoryName = "redis"
 
 // currentBlockKey is a constant string representing the key for the current block in redis
 const currentBlockKey = "sync_key_currentBlock"
@@ -14,14 +16,75 @@ const currentBlockKey = "sync_key_currentBlock"
 // subscribersKey is a constant string representing the prefix for subscribers' keys in redis
 const subscribersKey = "sync_key_Subscriber-"
 
-// getCurrentBlockKey returns the key for the current block
-func getCurrentBlockKey() string {
-	return currentBlockKey
+// subscribersSetKey is a constant string representing the key for the set of all subscribers' keys (tenant and address) in redis
+const subscribersSetKey = "sync_key_Subscribers"
+
+// apiKeysKey is a constant string representing the prefix for API keys' keys in redis
+const apiKeysKey = "api_key_ApiKey-"
+
+// apiKeysHashKey is a constant string representing the prefix for keys mapping hash of raw API key to ID of the key in redis
+const apiKeysHashKey = "api_key_ApiKeyHash-"
+
+// apiKeysSetKey is a constant string representing the key for the set of IDs of all API keys in redis
+const apiKeysSetKey = "api_key_ApiKeys"
+
+// addressLabelsKey is a constant string representing the prefix for keys of labels of addresses in redis
+const addressLabelsKey = "address_book_Labels-"
+
+// addressLabelsSetKey is a constant string representing the prefix for keys of sets of labelled addresses of tenants in redis
+const addressLabelsSetKey = "address_book_LabelledAddresses"
+
+// contractABIKey is a constant string representing the prefix for keys of ABIs of contracts uploaded by tenants in redis
+const contractABIKey = "abi_registry_ABI-"
+
+// contractABISetKey is a constant string representing the prefix for keys of sets of contracts with ABI uploaded by tenants in redis
+const contractABISetKey = "abi_registry_Contracts-"
+
+// KeySpace selects keys of subscriptions and current block of a chain in redis
+type KeySpace struct {
+	ChainID uint64
+	// Default is set for the default chain, its keys do not contain chain ID, the same as before chains were introduced,
+	// so data stored by the single chain version is kept after upgrade
+	Default bool
+}
+
+// getCurrentBlockKey returns the key for the current block of the chain
+func getCurrentBlockKey(keySpace KeySpace) string {
+	if keySpace.Default {
+		return currentBlockKey
+	}
+
+	return currentBlockKey + "-" + strconv.FormatUint(keySpace.ChainID, 10)
+}
+
+// getSubscribersSetKey returns the key for the set of all subscribers' keys of the chain
+func getSubscribersSetKey(keySpace KeySpace) string {
+	if keySpace.Default {
+		return subscribersSetKey
+	}
+
+	return subscribersSetKey + "-" + strconv.FormatUint(keySpace.ChainID, 10)
+}
+
+// getSubscribersSetPattern returns MATCH pattern of members of the set of subscribers' keys that belong to the tenant.
+// Members of subscriptions without tenant are bare addresses, tenant IDs contain no glob characters
+func getSubscribersSetPattern(tenantID string) string {
+	if tenantID == "" {
+		return "0x" + strings.Repeat("?", 40)
+	}
+
+	return tenantID + ":*"
 }
 
-// getSubscribersKey returns the key for a subscriber with the given address
-func getSubscribersKey(address string) string {
-	return subscribersKey + address
+// getSubscribersKey returns the key for a subscription of the chain.
+// Key of subscription without tenant on the default chain contains only address, the same as before tenants were introduced,
+// keys of tenants always contain chain ID, so tenant of the default chain cannot pass for a tenant of another chain
+func getSubscribersKey(keySpace KeySpace, key models.SubscriberKey) string {
+	if keySpace.Default && key.TenantID == "" {
+		return subscribersKey + key.String()
+	}
+
+	return subscribersKey + strconv.FormatUint(keySpace.ChainID, 10) + "-" + key.String()
 }
 
 // serializeCurrentBlockValue serializes the current block value as a uint64
@@ -49,3 +112,91 @@ func deserealizeSubscribersValue(rawSubscriber []byte) (models.Subscriber, error
 
 	return subscriber, nil
 }
+
+// getAPIKeysKey returns the key for API key with the given ID, API keys are shared by all chains
+func getAPIKeysKey(id string) string {
+	return apiKeysKey + id
+}
+
+// getAPIKeysHashKey returns the key for ID of API key with the given hash of raw key
+func getAPIKeysHashKey(hash string) string {
+	return apiKeysHashKey + hash
+}
+
+// serializeAPIKeyValue serializes an API key as a byte slice
+func serializeAPIKeyValue(key models.APIKey) ([]byte, error) {
+	return json.Marshal(key)
+}
+
+// deserializeAPIKeyValue deserializes an API key from a byte slice
+func deserializeAPIKeyValue(rawKey []byte) (models.APIKey, error) {
+	var key models.APIKey
+	err := json.Unmarshal(rawKey, &key)
+	if err != nil {
+		return models.APIKey{}, err
+	}
+
+	return key, nil
+}
+
+// getAddressLabelsKey returns the key for labels of address by tenant, address books are shared by all chains
+func getAddressLabelsKey(key models.SubscriberKey) string {
+	return addressLabelsKey + key.String()
+}
+
+// getAddressLabelsSetKey returns the key for the set of labelled addresses of the tenant,
+// it has no suffix for address book without tenant
+func getAddressLabelsSetKey(tenantID string) string {
+	if tenantID == "" {
+		return addressLabelsSetKey
+	}
+
+	return addressLabelsSetKey + "-" + tenantID
+}
+
+// serializeAddressLabelsValue serializes labels of address as a byte slice
+func serializeAddressLabelsValue(labels models.AddressLabels) ([]byte, error) {
+	return json.Marshal(labels)
+}
+
+// deserializeAddressLabelsValue deserializes labels of address from a byte slice
+func deserializeAddressLabelsValue(rawLabels []byte) (models.AddressLabels, error) {
+	var labels models.AddressLabels
+	err := json.Unmarshal(rawLabels, &labels)
+	if err != nil {
+		return models.AddressLabels{}, err
+	}
+
+	return labels, nil
+}
+
+// getContractABIKey returns the key for ABI of contract of the chain uploaded by tenant
+func getContractABIKey(chainID uint64, key models.SubscriberKey) string {
+	return contractABIKey + strconv.FormatUint(chainID, 10) + "-" + key.String()
+}
+
+// getContractABISetKey returns the key for the set of contracts of the chain with ABI uploaded by the tenant,
+// it has no tenant suffix for ABIs without tenant
+func getContractABISetKey(chainID uint64, tenantID string) string {
+	if tenantID == "" {
+		return contractABISetKey + strconv.FormatUint(chainID, 10)
+	}
+
+	return contractABISetKey + strconv.FormatUint(chainID, 10) + "-" + tenantID
+}
+
+// serializeContractABIValue serializes ABI of contract as a byte slice
+func serializeContractABIValue(contractABI models.ContractABI) ([]byte, error) {
+	return json.Marshal(contractABI)
+}
+
+// deserializeContractABIValue deserializes ABI of contract from a byte slice
+func deserializeContractABIValue(rawContractABI []byte) (models.ContractABI, error) {
+	var contractABI models.ContractABI
+	err := json.Unmarshal(rawContractABI, &contractABI)
+	if err != nil {
+		return models.ContractABI{}, err
+	}
+
+	return contractABI, nil
+}
@@ -3,25 +3,48 @@ package redis_repository
 import (
 	"context"
 	"errors"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/metrics"
 	"github.com/bluntenpassant/ethereum_subscriber/internal/app/models"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/tenant"
 	"github.com/redis/go-redis/v9"
+	"log/slog"
+	"sync/atomic"
 	"time"
 )
 
-// SubscriberRepository is a structure to store subscribers in a Redis database
+// SubscriberRepository is a structure to store subscribers in a Redis database.
+// Subscribers are isolated by tenant, tenant of per-address operations is taken from ctx
 type SubscriberRepository struct {
-	redis          *redis.Client
-	expirationTime time.Duration
+	// keySpace selects keys of the chain, so data of different chains does not collide
+	keySpace KeySpace
+	redis    *redis.Client
+	// expirationTime is kept as nanoseconds to be changed at runtime by SetExpirationTime
+	expirationTime atomic.Int64
+	logger         *slog.Logger
 }
 
 // NewSubscriberRepository creates a new instance of SubscriberRepository
 // redis - an instance of redis.Client for communication with the Redis database
 // expirationTime - the expiration time for subscribers stored in the Redis database
-func NewSubscriberRepository(redis *redis.Client, expirationTime time.Duration) *SubscriberRepository {
-	return &SubscriberRepository{
-		redis:          redis,
-		expirationTime: expirationTime,
+// logger - a logger of decisions of the repository like forgotten subscribers, Redis commands are logged by the hook of the client
+func NewSubscriberRepository(keySpace KeySpace, redis *redis.Client, expirationTime time.Duration, logger *slog.Logger) *SubscriberRepository {
+	repository := &SubscriberRepository{
+		keySpace: keySpace,
+		redis:    redis,
+		logger:   logger.With("repository", repositoryName, "chain_id", keySpace.ChainID),
 	}
+	repository.SetExpirationTime(expirationTime)
+
+	return repository
+}
+
+// SetExpirationTime changes expiration time of keys written after the call, it is safe for concurrent use
+func (r *SubscriberRepository) SetExpirationTime(expirationTime time.Duration) {
+	r.expirationTime.Store(int64(expirationTime))
+}
+
+func (r *SubscriberRepository) getExpirationTime() time.Duration {
+	return time.Duration(r.expirationTime.Load())
 }
 
 // AddNewSubscriber adds a new subscriber to the Redis database
@@ -29,15 +52,17 @@ func NewSubscriberRepository(redis *redis.Client, expirationTime time.Duration)
 // subscriber - the subscriber to be added to the Redis database
 // returns an error if adding the subscriber to the Redis database failed
 func (r *SubscriberRepository) AddNewSubscriber(ctx context.Context, subscriber models.Subscriber) error {
+	defer metrics.ObserveRepositoryOperation(repositoryName, "AddNewSubscriber", time.Now())
+
 	// Check if the subscriber already exists in the Redis database
-	_, err := r.redis.Get(ctx, getSubscribersKey(subscriber.Address)).Result()
+	_, err := r.redis.Get(ctx, getSubscribersKey(r.keySpace, subscriber.Key())).Result()
 	if err == nil {
 		// If the subscriber already exists, return an error
-		return errors.New("subscriber already registered")
+		return models.ErrAlreadySubscribed
 	}
 
 	// If the error returned from Redis is not nil, return the error
-	if err.Error() != redisNilErrMsg {
+	if !errors.Is(err, redis.Nil) {
 		return err
 	}
 
@@ -48,25 +73,34 @@ func (r *SubscriberRepository) AddNewSubscriber(ctx context.Context, subscriber
 	}
 
 	// Set the subscriber in the Redis database
-	setSubCmd := r.redis.Set(ctx, getSubscribersKey(subscriber.Address), serializedSubscriber, r.expirationTime)
+	setSubCmd := r.redis.Set(ctx, getSubscribersKey(r.keySpace, subscriber.Key()), serializedSubscriber, r.getExpirationTime())
 	if setSubCmd.Err() != nil {
 		return setSubCmd.Err()
 	}
 
-	return nil
+	// Add the subscriber key to the set of all subscribers, so subscribers could be listed without scanning keys
+	err = r.redis.SAdd(ctx, getSubscribersSetKey(r.keySpace), subscriber.Key().String()).Err()
+	if err != nil {
+		return err
+	}
+
+	// Keep the set alive as long as the latest added subscriber
+	return r.redis.Expire(ctx, getSubscribersSetKey(r.keySpace), r.getExpirationTime()).Err()
 }
 
 // GetSubscriberByAddress retrieves a subscriber from the Redis database based on their address
 // ctx - a context for the Redis request
 // address - the address of the subscriber to retrieve
 // returns the subscriber and an error if retrieving the subscriber from the Redis database failed
-func (r *SubscriberRepository) GetSubscriberByAddress(ctx context.Context, address string) (models.Subscriber, error) {
-	// Get the subscriber data from redis using the provided address and the result of the getSubscribersKey function.
-	subscriberRawData, err := r.redis.Get(ctx, getSubscribersKey(address)).Bytes()
+func (r *SubscriberRepository) GetSubscriberByAddress(ctx context.Context, address models.Address) (models.Subscriber, error) {
+	defer metrics.ObserveRepositoryOperation(repositoryName, "GetSubscriberByAddress", time.Now())
+
+	// Get the subscriber data from redis using the provided address, tenant from ctx and the result of the getSubscribersKey function.
+	subscriberRawData, err := r.redis.Get(ctx, getSubscribersKey(r.keySpace, tenant.Key(ctx, address))).Bytes()
 	// If there was an error, check if it was due to the subscriber not being subscribed.
 	if err != nil {
-		if err.Error() == redisNilErrMsg {
-			return models.Subscriber{}, errors.New("subscriber is not subscribed")
+		if errors.Is(err, redis.Nil) {
+			return models.Subscriber{}, models.ErrNotSubscribed
 		}
 
 		return models.Subscriber{}, err
@@ -81,3 +115,105 @@ func (r *SubscriberRepository) GetSubscriberByAddress(ctx context.Context, addre
 	// Return the subscriber and nil for the error.
 	return subscriber, nil
 }
+
+// GetSubscribers retrieves all subscribers of all tenants from the Redis database
+// ctx - a context for the Redis request
+// returns all subscribers and an error if retrieving subscribers from the Redis database failed.
+// Subscribers whose data has already expired are removed from the set of subscribers and skipped
+func (r *SubscriberRepository) GetSubscribers(ctx context.Context) ([]models.Subscriber, error) {
+	defer metrics.ObserveRepositoryOperation(repositoryName, "GetSubscribers", time.Now())
+
+	// Get keys of all subscribers
+	members, err := r.redis.SMembers(ctx, getSubscribersSetKey(r.keySpace)).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(members) == 0 {
+		return []models.Subscriber{}, nil
+	}
+
+	keys := make([]string, 0, len(members))
+	for _, member := range members {
+		keys = append(keys, getSubscribersKey(r.keySpace, models.ParseSubscriberKey(member)))
+	}
+
+	// Get the raw subscribers data in one round trip
+	rawSubscribers, err := r.redis.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	subscribers := make([]models.Subscriber, 0, len(rawSubscribers))
+	for i, rawSubscriber := range rawSubscribers {
+		rawSubscriberStr, ok := rawSubscriber.(string)
+		if !ok {
+			// Subscriber data is expired, so we forget about the subscriber
+			err = r.redis.SRem(ctx, getSubscribersSetKey(r.keySpace), members[i]).Err()
+			if err != nil {
+				return nil, err
+			}
+
+			r.logger.InfoContext(ctx, "subscriber data is expired, subscriber is forgotten", "subscriber", members[i])
+			continue
+		}
+
+		// Deserialize the raw data into a Subscriber struct.
+		subscriber, err := deserealizeSubscribersValue([]byte(rawSubscriberStr))
+		if err != nil {
+			return nil, err
+		}
+
+		subscribers = append(subscribers, subscriber)
+	}
+
+	return subscribers, nil
+}
+
+// CountSubscribers returns count of subscriptions of the tenant from ctx. Only keys of subscriptions are scanned,
+// so subscriptions with expired data are counted until GetSubscribers forgets them
+func (r *SubscriberRepository) CountSubscribers(ctx context.Context) (int, error) {
+	defer metrics.ObserveRepositoryOperation(repositoryName, "CountSubscribers", time.Now())
+
+	// SSCAN may return a member more than once, so members are deduplicated
+	members := make(map[string]struct{})
+	iter := r.redis.SScan(ctx, getSubscribersSetKey(r.keySpace), 0, getSubscribersSetPattern(tenant.FromContext(ctx)), 0).Iterator()
+	for iter.Next(ctx) {
+		members[iter.Val()] = struct{}{}
+	}
+
+	err := iter.Err()
+	if err != nil {
+		return 0, err
+	}
+
+	return len(members), nil
+}
+
+// DeleteSubscriber removes a subscriber from the Redis database based on their address
+// ctx - a context for the Redis request
+// address - the address of the subscriber to remove
+// returns an error if the subscriber is not subscribed or removing it from the Redis database failed
+func (r *SubscriberRepository) DeleteSubscriber(ctx context.Context, address models.Address) error {
+	defer metrics.ObserveRepositoryOperation(repositoryName, "DeleteSubscriber", time.Now())
+
+	key := tenant.Key(ctx, address)
+
+	// Remove the subscriber and its key in the set of all subscribers together
+	var deleted *redis.IntCmd
+	_, err := r.redis.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		deleted = pipe.Del(ctx, getSubscribersKey(r.keySpace, key))
+		pipe.SRem(ctx, getSubscribersSetKey(r.keySpace), key.String())
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if deleted.Val() == 0 {
+		return models.ErrNotSubscribed
+	}
+
+	return nil
+}
@@ -3,24 +3,22 @@ package redis_repository
 import (
 	"context"
 	"github.com/bluntenpassant/ethereum_subscriber/internal/app/models"
-	"github.com/redis/go-redis/v9"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/tenant"
 	"github.com/stretchr/testify/assert"
+	"io"
+	"log/slog"
 	"testing"
 	"time"
 )
 
-const redisHostSubscriberRepository = "0.0.0.0"
-const redisPortSubscriberRepository = "6379"
-const redisPasswordSubscriberRepository = ""
+var testKeySpace = KeySpace{ChainID: 1}
+
+var testLogger = slog.New(slog.NewTextHandler(io.Discard, nil))
 
 func TestSubscriberRepository_AddNewSubscriber(t *testing.T) {
 	ctx := context.TODO()
 
-	redisClient := redis.NewClient(&redis.Options{
-		Addr:     redisHostSubscriberRepository + ":" + redisPortSubscriberRepository,
-		Password: redisPasswordSubscriberRepository,
-		DB:       0,
-	})
+	redisClient := newTestRedisClient(t)
 
 	type TestCase struct {
 		Name                  string
@@ -56,7 +54,7 @@ func TestSubscriberRepository_AddNewSubscriber(t *testing.T) {
 
 	for _, testCase := range testCases {
 		t.Run(testCase.Name, func(t *testing.T) {
-			subscriberRepository := NewSubscriberRepository(redisClient, 10*time.Second)
+			subscriberRepository := NewSubscriberRepository(testKeySpace, redisClient, 10*time.Second, testLogger)
 			err := subscriberRepository.AddNewSubscriber(ctx, models.Subscriber{
 				Address:              testCase.SubscriberForAddition.Address,
 				SubscribeBlockNumber: testCase.SubscriberForAddition.SubscribeBlockNumber,
@@ -70,13 +68,9 @@ func TestSubscriberRepository_AddNewSubscriber(t *testing.T) {
 func TestSubscriberRepository_GetSubscriberByAddress(t *testing.T) {
 	ctx := context.TODO()
 
-	redisClient := redis.NewClient(&redis.Options{
-		Addr:     redisHostSubscriberRepository + ":" + redisPortSubscriberRepository,
-		Password: redisPasswordSubscriberRepository,
-		DB:       0,
-	})
+	redisClient := newTestRedisClient(t)
 
-	subscriberRepository := NewSubscriberRepository(redisClient, 10*time.Second)
+	subscriberRepository := NewSubscriberRepository(testKeySpace, redisClient, 10*time.Second, testLogger)
 
 	type TestCase struct {
 		Name                  string
@@ -128,7 +122,7 @@ func TestSubscriberRepository_GetSubscriberByAddress(t *testing.T) {
 		// because we must get error in a last testcase due to duplicating registered address
 		err := subscriberRepository.AddNewSubscriber(ctx, testCase.SubscriberForAddition)
 		if testCase.IsErr {
-			assert.Error(t, err)
+			assert.ErrorIs(t, err, models.ErrAlreadySubscribed)
 		}
 
 		gotSubscriber, err := subscriberRepository.GetSubscriberByAddress(ctx, testCase.SubscriberForAddition.Address)
@@ -137,3 +131,213 @@ func TestSubscriberRepository_GetSubscriberByAddress(t *testing.T) {
 		assert.EqualValues(t, gotSubscriber, testCase.SubscriberForAddition)
 	}
 }
+
+func TestSubscriberRepository_GetSubscribers(t *testing.T) {
+	ctx := context.TODO()
+
+	redisClient := newTestRedisClient(t)
+	subscriberRepository := NewSubscriberRepository(testKeySpace, redisClient, 10*time.Second, testLogger)
+
+	expectedSubscribers := []models.Subscriber{
+		{
+			Address:              "0xd8da6bf26964af9d7eed9e03e53415d37aa96045",
+			SubscribeBlockNumber: 15,
+			SubscribeTxCount:     14,
+			ENSName:              "vitalik.eth",
+		},
+		{
+			Address:              "0xe592427a0aece92de3edee1f18e0157c05861564",
+			SubscribeBlockNumber: 19,
+			SubscribeTxCount:     7,
+		},
+	}
+
+	for _, subscriber := range expectedSubscribers {
+		err := subscriberRepository.AddNewSubscriber(ctx, subscriber)
+		assert.NoError(t, err)
+	}
+
+	// other tests share the same database, so we check only presence of our subscribers
+	subscribers, err := subscriberRepository.GetSubscribers(ctx)
+	assert.NoError(t, err)
+	for _, subscriber := range expectedSubscribers {
+		assert.Contains(t, subscribers, subscriber)
+	}
+}
+
+func TestSubscriberRepository_CountSubscribers(t *testing.T) {
+	ctx := context.TODO()
+	tenantACtx := tenant.WithID(ctx, "tenant_a")
+	tenantBCtx := tenant.WithID(ctx, "tenant_b")
+
+	redisClient := newTestRedisClient(t)
+	// Other tests share the same database, so subscriptions are counted on a chain of their own
+	keySpace := KeySpace{ChainID: 10}
+	subscriberRepository := NewSubscriberRepository(keySpace, redisClient, 10*time.Second, testLogger)
+
+	subscribers := []models.Subscriber{
+		{Address: "0xd8da6bf26964af9d7eed9e03e53415d37aa96045"},
+		{Address: "0xd8da6bf26964af9d7eed9e03e53415d37aa96045", TenantID: "tenant_a"},
+		{Address: "0xdef1c0ded9bec7f1a1670819833240f027b25eff", TenantID: "tenant_a"},
+	}
+
+	redisClient.Del(ctx, getSubscribersSetKey(keySpace))
+	defer redisClient.Del(ctx, getSubscribersSetKey(keySpace))
+	for _, subscriber := range subscribers {
+		defer redisClient.Del(ctx, getSubscribersKey(keySpace, subscriber.Key()))
+		assert.NoError(t, subscriberRepository.AddNewSubscriber(tenant.WithID(ctx, subscriber.TenantID), subscriber))
+	}
+
+	type TestCase struct {
+		name          string
+		ctx           context.Context
+		expectedCount int
+	}
+
+	testCases := []TestCase{
+		{name: "without tenant", ctx: ctx, expectedCount: 1},
+		{name: "tenant with subscriptions", ctx: tenantACtx, expectedCount: 2},
+		{name: "tenant without subscriptions", ctx: tenantBCtx, expectedCount: 0},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			count, err := subscriberRepository.CountSubscribers(testCase.ctx)
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expectedCount, count)
+		})
+	}
+}
+
+func TestSubscriberRepository_TenantIsolation(t *testing.T) {
+	ctx := context.TODO()
+	tenantACtx := tenant.WithID(ctx, "tenant_a")
+	tenantBCtx := tenant.WithID(ctx, "tenant_b")
+
+	redisClient := newTestRedisClient(t)
+	subscriberRepository := NewSubscriberRepository(testKeySpace, redisClient, 10*time.Second, testLogger)
+
+	subscriber := models.Subscriber{
+		Address:              "0xdef1c0ded9bec7f1a1670819833240f027b25eff",
+		SubscribeBlockNumber: 15,
+		TenantID:             "tenant_a",
+	}
+
+	redisClient.Del(ctx, getSubscribersKey(testKeySpace, subscriber.Key()))
+	defer redisClient.Del(ctx, getSubscribersKey(testKeySpace, subscriber.Key()))
+
+	err := subscriberRepository.AddNewSubscriber(tenantACtx, subscriber)
+	assert.NoError(t, err)
+
+	gotSubscriber, err := subscriberRepository.GetSubscriberByAddress(tenantACtx, subscriber.Address)
+	assert.NoError(t, err)
+	assert.Equal(t, subscriber, gotSubscriber)
+
+	_, err = subscriberRepository.GetSubscriberByAddress(tenantBCtx, subscriber.Address)
+	assert.ErrorIs(t, err, models.ErrNotSubscribed)
+
+	subscribers, err := subscriberRepository.GetSubscribers(ctx)
+	assert.NoError(t, err)
+	assert.Contains(t, subscribers, subscriber)
+}
+
+func TestSubscriberRepository_TenantChainIsolation(t *testing.T) {
+	ctx := context.TODO()
+	// Without chain ID in keys of the default chain, tenant "137-x" of the default chain would share keys with tenant "x" of chain 137
+	defaultTenantCtx := tenant.WithID(ctx, "137-x")
+	otherTenantCtx := tenant.WithID(ctx, "x")
+
+	redisClient := newTestRedisClient(t)
+	defaultRepository := NewSubscriberRepository(KeySpace{ChainID: 1, Default: true}, redisClient, 10*time.Second, testLogger)
+	otherRepository := NewSubscriberRepository(KeySpace{ChainID: 137}, redisClient, 10*time.Second, testLogger)
+
+	defaultSubscriber := models.Subscriber{
+		Address:              "0xdef1c0ded9bec7f1a1670819833240f027b25eff",
+		SubscribeBlockNumber: 15,
+		TenantID:             "137-x",
+	}
+
+	assert.NoError(t, defaultRepository.AddNewSubscriber(defaultTenantCtx, defaultSubscriber))
+
+	_, err := otherRepository.GetSubscriberByAddress(otherTenantCtx, defaultSubscriber.Address)
+	assert.ErrorIs(t, err, models.ErrNotSubscribed)
+
+	otherSubscriber := defaultSubscriber
+	otherSubscriber.SubscribeBlockNumber = 20
+	otherSubscriber.TenantID = "x"
+
+	assert.NoError(t, otherRepository.AddNewSubscriber(otherTenantCtx, otherSubscriber))
+
+	gotSubscriber, err := defaultRepository.GetSubscriberByAddress(defaultTenantCtx, defaultSubscriber.Address)
+	assert.NoError(t, err)
+	assert.Equal(t, defaultSubscriber, gotSubscriber)
+
+	gotSubscriber, err = otherRepository.GetSubscriberByAddress(otherTenantCtx, otherSubscriber.Address)
+	assert.NoError(t, err)
+	assert.Equal(t, otherSubscriber, gotSubscriber)
+}
+
+func TestSubscriberRepository_DeleteSubscriber(t *testing.T) {
+	ctx := context.TODO()
+	tenantACtx := tenant.WithID(ctx, "tenant_a")
+
+	redisClient := newTestRedisClient(t)
+	subscriberRepository := NewSubscriberRepository(testKeySpace, redisClient, 10*time.Second, testLogger)
+
+	subscriber := models.Subscriber{
+		Address:              "0xdef1c0ded9bec7f1a1670819833240f027b25eff",
+		SubscribeBlockNumber: 15,
+		TenantID:             "tenant_a",
+	}
+
+	redisClient.Del(ctx, getSubscribersKey(testKeySpace, subscriber.Key()))
+	defer redisClient.Del(ctx, getSubscribersKey(testKeySpace, subscriber.Key()))
+
+	assert.NoError(t, subscriberRepository.AddNewSubscriber(tenantACtx, subscriber))
+
+	// Subscription of another tenant is not affected
+	err := subscriberRepository.DeleteSubscriber(ctx, subscriber.Address)
+	assert.ErrorIs(t, err, models.ErrNotSubscribed)
+
+	err = subscriberRepository.DeleteSubscriber(tenantACtx, subscriber.Address)
+	assert.NoError(t, err)
+
+	_, err = subscriberRepository.GetSubscriberByAddress(tenantACtx, subscriber.Address)
+	assert.ErrorIs(t, err, models.ErrNotSubscribed)
+
+	subscribers, err := subscriberRepository.GetSubscribers(ctx)
+	assert.NoError(t, err)
+	assert.NotContains(t, subscribers, subscriber)
+
+	err = subscriberRepository.DeleteSubscriber(tenantACtx, subscriber.Address)
+	assert.ErrorIs(t, err, models.ErrNotSubscribed)
+}
+
+func TestSubscriberRepository_DefaultChainKeys(t *testing.T) {
+	ctx := context.TODO()
+
+	redisClient := newTestRedisClient(t)
+
+	// Subscription stored by the single chain version, before chain ID was added to keys
+	address := models.Address("0x7a250d5630b4cf539739df2c5dacb4c659f2488d")
+	redisClient.Del(ctx, "sync_key_Subscriber-"+address.String())
+	defer redisClient.Del(ctx, "sync_key_Subscriber-"+address.String())
+	defer redisClient.SRem(ctx, "sync_key_Subscribers", address.String())
+
+	assert.NoError(t, redisClient.Set(ctx, "sync_key_Subscriber-"+address.String(), `{"Address":"`+address.String()+`","SubscribeBlockNumber":15}`, 10*time.Second).Err())
+	assert.NoError(t, redisClient.SAdd(ctx, "sync_key_Subscribers", address.String()).Err())
+
+	defaultRepository := NewSubscriberRepository(KeySpace{ChainID: 1, Default: true}, redisClient, 10*time.Second, testLogger)
+	otherRepository := NewSubscriberRepository(KeySpace{ChainID: 42161}, redisClient, 10*time.Second, testLogger)
+
+	subscriber, err := defaultRepository.GetSubscriberByAddress(ctx, address)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(15), subscriber.SubscribeBlockNumber)
+
+	subscribers, err := defaultRepository.GetSubscribers(ctx)
+	assert.NoError(t, err)
+	assert.Contains(t, subscribers, subscriber)
+
+	_, err = otherRepository.GetSubscriberByAddress(ctx, address)
+	assert.ErrorIs(t, err, models.ErrNotSubscribed)
+}
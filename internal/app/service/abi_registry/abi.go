@@ -0,0 +1,324 @@
+package abi_registry
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/models"
+	"golang.org/x/crypto/sha3"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Kinds of ABI types (https://docs.soliditylang.org/en/latest/abi-spec.html#types), fixed point numbers are not supported
+const (
+	uintKind = iota
+	intKind
+	addressKind
+	boolKind
+	fixedBytesKind
+	bytesKind
+	stringKind
+	sliceKind
+	arrayKind
+	tupleKind
+)
+
+// maxStaticSize limits size of static values in bytes, so fixed size arrays from uploaded ABI can not exhaust memory
+const maxStaticSize = 1 << 20
+
+// ABIError is an error of parsing ABI, it matches models.ErrInvalidABI
+type ABIError struct {
+	// Function is a name of function with invalid arguments, it is empty if ABI is invalid as a whole
+	Function string
+
+	Err error
+}
+
+func (e *ABIError) Error() string {
+	if e.Function != "" {
+		return "invalid abi function " + e.Function + " cause: " + e.Err.Error()
+	}
+
+	return "invalid abi cause: " + e.Err.Error()
+}
+
+func (e *ABIError) Unwrap() error {
+	return e.Err
+}
+
+// Is makes every ABIError match models.ErrInvalidABI
+func (e *ABIError) Is(target error) bool {
+	return target == models.ErrInvalidABI
+}
+
+// Argument is an input of a function in JSON ABI, components describe fields of tuple
+type Argument struct {
+	Name       string     `json:"name"`
+	Type       string     `json:"type"`
+	Components []Argument `json:"components"`
+}
+
+// abiEntry is an entry of JSON ABI, only functions are used since only calls are decoded
+type abiEntry struct {
+	Type   string     `json:"type"`
+	Name   string     `json:"name"`
+	Inputs []Argument `json:"inputs"`
+}
+
+// artifact is a compiler artifact (Hardhat, Truffle or Foundry) that keeps JSON ABI in abi field
+type artifact struct {
+	ABI json.RawMessage `json:"abi"`
+}
+
+// abiType is a parsed ABI type of an argument
+type abiType struct {
+	kind int
+	// canonical is a name of the type used in signatures, like uint256 for uint or (address,uint256) for tuple
+	canonical string
+	// size is a size of integer in bits or size of fixed bytes in bytes
+	size int
+	// length is a length of fixed size array
+	length int
+	// elem is a type of elements of array
+	elem *abiType
+	// components are types of fields of tuple, names are their names
+	components []abiType
+	names      []string
+}
+
+// method is a function of contract ABI
+type method struct {
+	name      string
+	signature string
+	selector  [4]byte
+	inputs    []abiType
+	names     []string
+}
+
+// ABI is a parsed JSON ABI of a contract with functions by selector
+type ABI struct {
+	methods map[[4]byte]method
+}
+
+// ExtractABI returns JSON ABI from raw ABI, raw ABI is either JSON ABI itself or compiler artifact with abi field
+func ExtractABI(raw []byte) (json.RawMessage, error) {
+	raw = bytes.TrimSpace(raw)
+
+	if bytes.HasPrefix(raw, []byte("{")) {
+		contractArtifact := artifact{}
+		err := json.Unmarshal(raw, &contractArtifact)
+		if err != nil {
+			return nil, &ABIError{Err: err}
+		}
+
+		if len(contractArtifact.ABI) == 0 {
+			return nil, &ABIError{Err: errors.New("artifact has no abi field")}
+		}
+
+		raw = contractArtifact.ABI
+	}
+
+	compacted := bytes.Buffer{}
+	err := json.Compact(&compacted, raw)
+	if err != nil {
+		return nil, &ABIError{Err: err}
+	}
+
+	return compacted.Bytes(), nil
+}
+
+// ParseABI parses JSON ABI or compiler artifact with it. Error is returned if ABI has no functions
+// or any function has an argument of unsupported type
+func ParseABI(raw []byte) (*ABI, error) {
+	raw, err := ExtractABI(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []abiEntry
+	err = json.Unmarshal(raw, &entries)
+	if err != nil {
+		return nil, &ABIError{Err: err}
+	}
+
+	contractABI := &ABI{methods: make(map[[4]byte]method)}
+
+	for _, entry := range entries {
+		// Type of function entries may be omitted
+		if entry.Type != "function" && entry.Type != "" {
+			continue
+		}
+
+		m, err := newMethod(entry)
+		if err != nil {
+			return nil, &ABIError{Function: entry.Name, Err: err}
+		}
+
+		contractABI.methods[m.selector] = m
+	}
+
+	if len(contractABI.methods) == 0 {
+		return nil, &ABIError{Err: errors.New("abi has no functions")}
+	}
+
+	return contractABI, nil
+}
+
+// Methods returns sorted canonical signatures of functions of the ABI
+func (a *ABI) Methods() []string {
+	signatures := make([]string, 0, len(a.methods))
+	for _, m := range a.methods {
+		signatures = append(signatures, m.signature)
+	}
+
+	sort.Strings(signatures)
+
+	return signatures
+}
+
+func newMethod(entry abiEntry) (method, error) {
+	if entry.Name == "" {
+		return method{}, errors.New("function has no name")
+	}
+
+	inputs, names, err := parseArguments(entry.Inputs)
+	if err != nil {
+		return method{}, err
+	}
+
+	signature := entry.Name + tupleCanonical(inputs)
+
+	m := method{
+		name:      entry.Name,
+		signature: signature,
+		inputs:    inputs,
+		names:     names,
+	}
+	copy(m.selector[:], keccak256([]byte(signature)))
+
+	return m, nil
+}
+
+func parseArguments(arguments []Argument) ([]abiType, []string, error) {
+	types := make([]abiType, 0, len(arguments))
+	names := make([]string, 0, len(arguments))
+
+	for _, argument := range arguments {
+		t, err := parseType(argument.Type, argument.Components)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		types = append(types, t)
+		names = append(names, argument.Name)
+	}
+
+	return types, names, nil
+}
+
+// parseType parses ABI type like uint256, bytes32, address[] or tuple[2] with components of tuple
+func parseType(typ string, components []Argument) (abiType, error) {
+	if strings.HasSuffix(typ, "]") {
+		i := strings.LastIndex(typ, "[")
+		if i <= 0 {
+			return abiType{}, errors.New("invalid type " + typ)
+		}
+
+		elem, err := parseType(typ[:i], components)
+		if err != nil {
+			return abiType{}, err
+		}
+
+		dimension := typ[i+1 : len(typ)-1]
+		if dimension == "" {
+			return abiType{kind: sliceKind, canonical: elem.canonical + "[]", elem: &elem}, nil
+		}
+
+		length, err := strconv.Atoi(dimension)
+		if err != nil || length <= 0 || elem.headSize() > maxStaticSize/length {
+			return abiType{}, errors.New("invalid length of array type " + typ)
+		}
+
+		return abiType{kind: arrayKind, canonical: elem.canonical + "[" + dimension + "]", length: length, elem: &elem}, nil
+	}
+
+	switch {
+	case typ == "tuple":
+		types, names, err := parseArguments(components)
+		if err != nil {
+			return abiType{}, err
+		}
+
+		return abiType{kind: tupleKind, canonical: tupleCanonical(types), components: types, names: names}, nil
+	case typ == "address":
+		return abiType{kind: addressKind, canonical: typ}, nil
+	case typ == "bool":
+		return abiType{kind: boolKind, canonical: typ}, nil
+	case typ == "string":
+		return abiType{kind: stringKind, canonical: typ}, nil
+	case typ == "bytes":
+		return abiType{kind: bytesKind, canonical: typ}, nil
+	case typ == "function":
+		// Function is an address followed by a selector encoded like bytes24
+		return abiType{kind: fixedBytesKind, canonical: typ, size: 24}, nil
+	case strings.HasPrefix(typ, "bytes"):
+		size, err := strconv.Atoi(strings.TrimPrefix(typ, "bytes"))
+		if err != nil || size < 1 || size > 32 {
+			return abiType{}, errors.New("invalid type " + typ)
+		}
+
+		return abiType{kind: fixedBytesKind, canonical: typ, size: size}, nil
+	case strings.HasPrefix(typ, "uint"):
+		size, err := parseIntSize(strings.TrimPrefix(typ, "uint"))
+		if err != nil {
+			return abiType{}, errors.New("invalid type " + typ)
+		}
+
+		return abiType{kind: uintKind, canonical: "uint" + strconv.Itoa(size), size: size}, nil
+	case strings.HasPrefix(typ, "int"):
+		size, err := parseIntSize(strings.TrimPrefix(typ, "int"))
+		if err != nil {
+			return abiType{}, errors.New("invalid type " + typ)
+		}
+
+		return abiType{kind: intKind, canonical: "int" + strconv.Itoa(size), size: size}, nil
+	}
+
+	return abiType{}, errors.New("unsupported type " + typ)
+}
+
+// parseIntSize parses size of integer type in bits, integer without size is 256 bits
+func parseIntSize(size string) (int, error) {
+	if size == "" {
+		return 256, nil
+	}
+
+	bits, err := strconv.Atoi(size)
+	if err != nil {
+		return 0, err
+	}
+
+	if bits < 8 || bits > 256 || bits%8 != 0 {
+		return 0, errors.New("invalid size of integer")
+	}
+
+	return bits, nil
+}
+
+func tupleCanonical(types []abiType) string {
+	canonical := make([]string, 0, len(types))
+	for _, t := range types {
+		canonical = append(canonical, t.canonical)
+	}
+
+	return "(" + strings.Join(canonical, ",") + ")"
+}
+
+func keccak256(data []byte) []byte {
+	hash := sha3.NewLegacyKeccak256()
+	hash.Write(data)
+
+	return hash.Sum(nil)
+}
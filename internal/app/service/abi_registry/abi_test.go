@@ -0,0 +1,146 @@
+package abi_registry
+
+import (
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/models"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+const erc20ABI = `[
+	{"type":"function","name":"transfer","inputs":[{"name":"to","type":"address"},{"name":"value","type":"uint256"}],"outputs":[{"name":"","type":"bool"}]},
+	{"type":"event","name":"Transfer","inputs":[{"name":"from","type":"address","indexed":true},{"name":"to","type":"address","indexed":true},{"name":"value","type":"uint256"}]}
+]`
+
+const registryABI = `{"contractName":"Registry","abi":[
+	{"name":"register","inputs":[{"name":"name","type":"string"},{"name":"ids","type":"uint256[]"},
+		{"name":"info","type":"tuple","components":[{"name":"owner","type":"address"},{"name":"data","type":"bytes"}]}]},
+	{"type":"function","name":"configure","inputs":[{"name":"offset","type":"int24"},{"name":"enabled","type":"bool"},
+		{"name":"interfaceId","type":"bytes4"},{"name":"admins","type":"address[2]"}]},
+	{"type":"constructor","inputs":[]}
+]}`
+
+// words returns hex encoding of ABI words, every word is left padded with zeros unless it is 64 characters long
+func words(values ...string) string {
+	encoded := ""
+	for _, value := range values {
+		encoded += strings.Repeat("0", 64-len(value)) + value
+	}
+
+	return encoded
+}
+
+func TestParseABI(t *testing.T) {
+	type TestCase struct {
+		Name            string
+		ABI             string
+		ExpectedMethods []string
+		ExpectedError   string
+	}
+
+	testCases := []TestCase{
+		{Name: "abi", ABI: erc20ABI, ExpectedMethods: []string{"transfer(address,uint256)"}},
+		{Name: "artifact", ABI: registryABI, ExpectedMethods: []string{"configure(int24,bool,bytes4,address[2])",
+			"register(string,uint256[],(address,bytes))"}},
+		{Name: "no functions", ABI: `[{"type":"event","name":"Transfer","inputs":[]}]`, ExpectedError: "invalid abi cause: abi has no functions"},
+		{Name: "artifact without abi", ABI: `{"contractName":"Registry"}`, ExpectedError: "invalid abi cause: artifact has no abi field"},
+		{Name: "unsupported type", ABI: `[{"type":"function","name":"price","inputs":[{"name":"value","type":"fixed128x18"}]}]`,
+			ExpectedError: "invalid abi function price cause: unsupported type fixed128x18"},
+		{Name: "invalid size", ABI: `[{"type":"function","name":"set","inputs":[{"name":"value","type":"uint7"}]}]`,
+			ExpectedError: "invalid abi function set cause: invalid type uint7"},
+		{Name: "huge array", ABI: `[{"type":"function","name":"set","inputs":[{"name":"values","type":"uint256[1000000000]"}]}]`,
+			ExpectedError: "invalid abi function set cause: invalid length of array type uint256[1000000000]"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			contractABI, err := ParseABI([]byte(testCase.ABI))
+			if testCase.ExpectedError != "" {
+				assert.EqualError(t, err, testCase.ExpectedError)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.ExpectedMethods, contractABI.Methods())
+		})
+	}
+}
+
+func TestABI_DecodeCall(t *testing.T) {
+	type TestCase struct {
+		Name          string
+		ABI           string
+		Input         string
+		ExpectedCall  *models.DecodedCall
+		ExpectedError string
+	}
+
+	testCases := []TestCase{
+		{
+			Name:  "static arguments",
+			ABI:   erc20ABI,
+			Input: "0xa9059cbb" + words("28c6c06298d514db089934071355e5743bf21d60", "f4240"),
+			ExpectedCall: &models.DecodedCall{Method: "transfer", Signature: "transfer(address,uint256)", Selector: "0xa9059cbb",
+				Arguments: []models.DecodedArgument{
+					{Name: "to", Type: "address", Value: "0x28C6c06298d514Db089934071355E5743bf21d60"},
+					{Name: "value", Type: "uint256", Value: "1000000"},
+				}},
+		},
+		{
+			Name: "dynamic arguments",
+			ABI:  registryABI,
+			Input: "0xb2892e7b" + words("60", "a0", "100",
+				"5", "616c696365"+strings.Repeat("0", 54),
+				"2", "1", "2",
+				"28c6c06298d514db089934071355e5743bf21d60", "40", "2", "beef"+strings.Repeat("0", 60)),
+			ExpectedCall: &models.DecodedCall{Method: "register", Signature: "register(string,uint256[],(address,bytes))", Selector: "0xb2892e7b",
+				Arguments: []models.DecodedArgument{
+					{Name: "name", Type: "string", Value: "alice"},
+					{Name: "ids", Type: "uint256[]", Value: []interface{}{"1", "2"}},
+					{Name: "info", Type: "(address,bytes)", Value: []models.DecodedArgument{
+						{Name: "owner", Type: "address", Value: "0x28C6c06298d514Db089934071355E5743bf21d60"},
+						{Name: "data", Type: "bytes", Value: "0xbeef"},
+					}},
+				}},
+		},
+		{
+			Name: "signed integer and fixed array",
+			ABI:  registryABI,
+			Input: "0x7ae3b71b" + words(strings.Repeat("f", 63)+"b", "1", "01ffc9a7"+strings.Repeat("0", 56),
+				"28c6c06298d514db089934071355e5743bf21d60", "00000000219ab540356cbb839cbe05303d7705fa"),
+			ExpectedCall: &models.DecodedCall{Method: "configure", Signature: "configure(int24,bool,bytes4,address[2])", Selector: "0x7ae3b71b",
+				Arguments: []models.DecodedArgument{
+					{Name: "offset", Type: "int24", Value: "-5"},
+					{Name: "enabled", Type: "bool", Value: true},
+					{Name: "interfaceId", Type: "bytes4", Value: "0x01ffc9a7"},
+					{Name: "admins", Type: "address[2]", Value: []interface{}{"0x28C6c06298d514Db089934071355E5743bf21d60",
+						"0x00000000219ab540356cBB839Cbe05303d7705Fa"}},
+				}},
+		},
+		{Name: "transfer of ether", ABI: erc20ABI, Input: "0x"},
+		{Name: "unknown function", ABI: erc20ABI, Input: "0x095ea7b3" + words("1", "2")},
+		{Name: "short input", ABI: erc20ABI, Input: "0xa9059cbb" + words("1"),
+			ExpectedError: "error decoding arguments of transfer(address,uint256) cause: data is too short"},
+		{Name: "offset out of input", ABI: registryABI, Input: "0xb2892e7b" + words("ffff", "a0", "100"),
+			ExpectedError: "error decoding arguments of register(string,uint256[],(address,bytes)) cause: length or offset is out of data"},
+		{Name: "huge length", ABI: registryABI, Input: "0xb2892e7b" + words("60", "60", "60", "ffffffff"),
+			ExpectedError: "error decoding arguments of register(string,uint256[],(address,bytes)) cause: length or offset is out of data"},
+		{Name: "invalid hex", ABI: erc20ABI, Input: "0xzz", ExpectedError: "invalid input cause: encoding/hex: invalid byte: U+007A 'z'"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			contractABI, err := ParseABI([]byte(testCase.ABI))
+			assert.NoError(t, err)
+
+			call, err := contractABI.DecodeCall(testCase.Input)
+			if testCase.ExpectedError != "" {
+				assert.EqualError(t, err, testCase.ExpectedError)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.ExpectedCall, call)
+		})
+	}
+}
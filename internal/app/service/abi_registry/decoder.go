@@ -0,0 +1,243 @@
+package abi_registry
+
+import (
+	"encoding/hex"
+	"errors"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/models"
+	"math/big"
+	"strings"
+)
+
+// wordSize is a size of ABI encoded word in bytes, every value is padded to words
+const wordSize = 32
+
+// DecodeCall decodes method and arguments from hex encoded input of transaction.
+// Nil is returned without error if input is not a call of a function of the ABI, like plain transfer of ether
+func (a *ABI) DecodeCall(input string) (*models.DecodedCall, error) {
+	data, err := hex.DecodeString(strings.TrimPrefix(input, "0x"))
+	if err != nil {
+		return nil, errors.New("invalid input cause: " + err.Error())
+	}
+
+	if len(data) < 4 {
+		return nil, nil
+	}
+
+	var selector [4]byte
+	copy(selector[:], data[:4])
+
+	m, ok := a.methods[selector]
+	if !ok {
+		return nil, nil
+	}
+
+	values, err := decodeTuple(m.inputs, data[4:])
+	if err != nil {
+		return nil, errors.New("error decoding arguments of " + m.signature + " cause: " + err.Error())
+	}
+
+	return &models.DecodedCall{
+		Method:    m.name,
+		Signature: m.signature,
+		Selector:  "0x" + hex.EncodeToString(selector[:]),
+		Arguments: decodedArguments(m.inputs, m.names, values),
+	}, nil
+}
+
+// decodeTuple decodes values of types encoded one after another (https://docs.soliditylang.org/en/latest/abi-spec.html#formal-specification-of-the-encoding):
+// static values are kept in head, dynamic values are kept in tail and head keeps their offsets from the start of data
+func decodeTuple(types []abiType, data []byte) ([]interface{}, error) {
+	values := make([]interface{}, 0, len(types))
+	position := 0
+
+	for _, t := range types {
+		if !t.isDynamic() {
+			if position+t.headSize() > len(data) {
+				return nil, errors.New("data is too short")
+			}
+
+			value, err := decodeValue(t, data[position:])
+			if err != nil {
+				return nil, err
+			}
+
+			values = append(values, value)
+			position += t.headSize()
+			continue
+		}
+
+		offset, err := readLength(data, position)
+		if err != nil {
+			return nil, err
+		}
+
+		if offset > len(data) {
+			return nil, errors.New("offset is out of data")
+		}
+
+		value, err := decodeValue(t, data[offset:])
+		if err != nil {
+			return nil, err
+		}
+
+		values = append(values, value)
+		position += wordSize
+	}
+
+	return values, nil
+}
+
+// decodeValue decodes value of type t from data starting with its encoding
+func decodeValue(t abiType, data []byte) (interface{}, error) {
+	switch t.kind {
+	case uintKind, intKind, addressKind, boolKind, fixedBytesKind:
+		if len(data) < wordSize {
+			return nil, errors.New("data is too short")
+		}
+
+		return decodeWord(t, data[:wordSize])
+	case bytesKind, stringKind:
+		length, err := readLength(data, 0)
+		if err != nil {
+			return nil, err
+		}
+
+		if length > len(data)-wordSize {
+			return nil, errors.New("length of " + t.canonical + " is out of data")
+		}
+
+		content := data[wordSize : wordSize+length]
+		if t.kind == stringKind {
+			return string(content), nil
+		}
+
+		return "0x" + hex.EncodeToString(content), nil
+	case sliceKind:
+		length, err := readLength(data, 0)
+		if err != nil {
+			return nil, err
+		}
+
+		// Every element takes at least a word, so length is checked before allocation
+		if length > (len(data)-wordSize)/wordSize {
+			return nil, errors.New("length of " + t.canonical + " is out of data")
+		}
+
+		return decodeTuple(repeatType(*t.elem, length), data[wordSize:])
+	case arrayKind:
+		if t.length > len(data)/wordSize {
+			return nil, errors.New("length of " + t.canonical + " is out of data")
+		}
+
+		return decodeTuple(repeatType(*t.elem, t.length), data)
+	case tupleKind:
+		values, err := decodeTuple(t.components, data)
+		if err != nil {
+			return nil, err
+		}
+
+		return decodedArguments(t.components, t.names, values), nil
+	}
+
+	return nil, errors.New("unsupported type " + t.canonical)
+}
+
+// decodeWord decodes value of static type that takes a single word
+func decodeWord(t abiType, word []byte) (interface{}, error) {
+	switch t.kind {
+	case uintKind:
+		return new(big.Int).SetBytes(word).String(), nil
+	case intKind:
+		// Signed integers are two's complement numbers sign extended to 256 bits
+		value := new(big.Int).SetBytes(word)
+		if word[0]&0x80 != 0 {
+			value.Sub(value, new(big.Int).Lsh(big.NewInt(1), wordSize*8))
+		}
+
+		return value.String(), nil
+	case addressKind:
+		return models.NewAddress("0x" + hex.EncodeToString(word[12:])).Checksum(), nil
+	case boolKind:
+		value := new(big.Int).SetBytes(word)
+		if value.Cmp(big.NewInt(1)) > 0 {
+			return nil, errors.New("invalid bool value")
+		}
+
+		return value.Sign() == 1, nil
+	case fixedBytesKind:
+		return "0x" + hex.EncodeToString(word[:t.size]), nil
+	}
+
+	return nil, errors.New("unsupported type " + t.canonical)
+}
+
+// readLength reads length or offset kept in the word at position of data
+func readLength(data []byte, position int) (int, error) {
+	if position+wordSize > len(data) {
+		return 0, errors.New("data is too short")
+	}
+
+	value := new(big.Int).SetBytes(data[position : position+wordSize])
+	if !value.IsInt64() || value.Int64() > int64(len(data)) {
+		return 0, errors.New("length or offset is out of data")
+	}
+
+	return int(value.Int64()), nil
+}
+
+// isDynamic reports whether value of the type is kept in tail of encoding
+func (t abiType) isDynamic() bool {
+	switch t.kind {
+	case bytesKind, stringKind, sliceKind:
+		return true
+	case arrayKind:
+		return t.elem.isDynamic()
+	case tupleKind:
+		for _, component := range t.components {
+			if component.isDynamic() {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// headSize returns size of value of the type in head of encoding, dynamic values keep only offset in head
+func (t abiType) headSize() int {
+	if t.isDynamic() {
+		return wordSize
+	}
+
+	switch t.kind {
+	case arrayKind:
+		return t.length * t.elem.headSize()
+	case tupleKind:
+		size := 0
+		for _, component := range t.components {
+			size += component.headSize()
+		}
+
+		return size
+	}
+
+	return wordSize
+}
+
+func repeatType(t abiType, count int) []abiType {
+	types := make([]abiType, count)
+	for i := range types {
+		types[i] = t
+	}
+
+	return types
+}
+
+func decodedArguments(types []abiType, names []string, values []interface{}) []models.DecodedArgument {
+	arguments := make([]models.DecodedArgument, 0, len(values))
+	for i, value := range values {
+		arguments = append(arguments, models.DecodedArgument{Name: names[i], Type: types[i].canonical, Value: value})
+	}
+
+	return arguments
+}
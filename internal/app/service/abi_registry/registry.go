@@ -0,0 +1,300 @@
+package abi_registry
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/models"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/tenant"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+type ContractABIRepository interface {
+	SetContractABI(ctx context.Context, contractABI models.ContractABI) error
+	GetContractABI(ctx context.Context, chainID uint64, address models.Address) (models.ContractABI, error)
+	GetContractsABIs(ctx context.Context, chainID uint64, addresses []models.Address) ([]models.ContractABI, error)
+	GetAllContractABIs(ctx context.Context, chainID uint64) ([]models.ContractABI, error)
+	DeleteContractABI(ctx context.Context, chainID uint64, address models.Address) error
+}
+
+// contract is an ABI loaded from ABI directory
+type contract struct {
+	info models.ContractABI
+	abi  *ABI
+}
+
+// Registry keeps ABIs of contracts and decodes calls of them from input of transactions.
+// ABIs loaded from directory are shared by all tenants, tenants can upload own ABIs through API,
+// uploaded ABI overrides ABI of the same contract from directory for the tenant
+type Registry struct {
+	contractABIRepository ContractABIRepository
+
+	// directoryABIs are ABIs loaded from directory by chain ID and address, they are not changed after start
+	directoryABIs map[uint64]map[models.Address]contract
+
+	now func() time.Time
+}
+
+func NewRegistry(contractABIRepository ContractABIRepository) *Registry {
+	return &Registry{
+		contractABIRepository: contractABIRepository,
+		directoryABIs:         make(map[uint64]map[models.Address]contract),
+		now:                   time.Now,
+	}
+}
+
+// LoadDir loads ABIs from directory with a subdirectory per chain named by chain name or chain ID,
+// subdirectory keeps JSON ABIs or compiler artifacts in files named by contract address, like ethereum/0xdac1...1ec7.json.
+// It should be called before registry is used
+func (r *Registry) LoadDir(dir string, chains []models.Chain) error {
+	chainDirs, err := os.ReadDir(dir)
+	if err != nil {
+		return errors.New("error reading abi directory cause: " + err.Error())
+	}
+
+	for _, chainDir := range chainDirs {
+		if !chainDir.IsDir() {
+			continue
+		}
+
+		chain, ok := models.FindChain(chains, chainDir.Name())
+		if !ok {
+			return errors.New("abi directory " + chainDir.Name() + " does not match any configured chain")
+		}
+
+		err = r.loadChainDir(filepath.Join(dir, chainDir.Name()), chain.ID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *Registry) loadChainDir(dir string, chainID uint64) error {
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return errors.New("error reading abi directory cause: " + err.Error())
+	}
+
+	for _, file := range files {
+		if file.IsDir() || filepath.Ext(file.Name()) != ".json" {
+			continue
+		}
+
+		path := filepath.Join(dir, file.Name())
+
+		address, err := models.ParseAddress(strings.TrimSuffix(file.Name(), ".json"))
+		if err != nil {
+			return errors.New("abi file " + path + " is not named by contract address cause: " + err.Error())
+		}
+
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			return errors.New("error reading abi file " + path + " cause: " + err.Error())
+		}
+
+		info, err := file.Info()
+		if err != nil {
+			return errors.New("error reading abi file " + path + " cause: " + err.Error())
+		}
+
+		contractABI, err := newContractABI(chainID, address, raw)
+		if err != nil {
+			return errors.New("error loading abi file " + path + " cause: " + err.Error())
+		}
+
+		contractABI.info.Source = models.ABISourceDirectory
+		contractABI.info.UpdatedAt = info.ModTime().UTC()
+
+		if _, ok := r.directoryABIs[chainID]; !ok {
+			r.directoryABIs[chainID] = make(map[models.Address]contract)
+		}
+
+		r.directoryABIs[chainID][address] = contractABI
+	}
+
+	return nil
+}
+
+// SetABI uploads ABI of the contract for the tenant, raw ABI is JSON ABI or compiler artifact with abi field.
+// Error is returned if ABI is invalid or has no functions
+func (r *Registry) SetABI(ctx context.Context, chainID uint64, address models.Address, raw json.RawMessage) (models.ContractABI, error) {
+	contractABI, err := newContractABI(chainID, address, raw)
+	if err != nil {
+		return models.ContractABI{}, err
+	}
+
+	contractABI.info.TenantID = tenant.FromContext(ctx)
+	contractABI.info.Source = models.ABISourceAPI
+	contractABI.info.UpdatedAt = r.now().UTC()
+
+	err = r.contractABIRepository.SetContractABI(ctx, contractABI.info)
+	if err != nil {
+		return models.ContractABI{}, models.WrapError("error setting abi", err)
+	}
+
+	return contractABI.info, nil
+}
+
+// GetABI returns ABI of the contract uploaded by the tenant or loaded from directory,
+// ErrABINotFound is returned if contract has no ABI
+func (r *Registry) GetABI(ctx context.Context, chainID uint64, address models.Address) (models.ContractABI, error) {
+	contractABI, err := r.contractABIRepository.GetContractABI(ctx, chainID, address)
+	if err == nil {
+		return contractABI, nil
+	}
+
+	if !errors.Is(err, models.ErrABINotFound) {
+		return models.ContractABI{}, models.WrapError("error getting abi", err)
+	}
+
+	directoryABI, ok := r.directoryABIs[chainID][address]
+	if !ok {
+		return models.ContractABI{}, models.WrapError("error getting abi", models.ErrABINotFound)
+	}
+
+	return directoryABI.info, nil
+}
+
+// ListABIs returns ABIs of all contracts of the chain known to the tenant sorted by address
+func (r *Registry) ListABIs(ctx context.Context, chainID uint64) ([]models.ContractABI, error) {
+	contractABIs, err := r.contractABIRepository.GetAllContractABIs(ctx, chainID)
+	if err != nil {
+		return nil, models.WrapError("error getting abis", err)
+	}
+
+	uploaded := make(map[models.Address]bool, len(contractABIs))
+	for _, contractABI := range contractABIs {
+		uploaded[contractABI.Address] = true
+	}
+
+	for address, directoryABI := range r.directoryABIs[chainID] {
+		if !uploaded[address] {
+			contractABIs = append(contractABIs, directoryABI.info)
+		}
+	}
+
+	sort.Slice(contractABIs, func(i, j int) bool {
+		return contractABIs[i].Address < contractABIs[j].Address
+	})
+
+	return contractABIs, nil
+}
+
+// DeleteABI removes ABI of the contract uploaded by the tenant, ABI from directory becomes used again if it exists.
+// ErrABINotFound is returned if the tenant has not uploaded ABI of the contract
+func (r *Registry) DeleteABI(ctx context.Context, chainID uint64, address models.Address) error {
+	err := r.contractABIRepository.DeleteContractABI(ctx, chainID, address)
+	if err != nil {
+		return models.WrapError("error deleting abi", err)
+	}
+
+	return nil
+}
+
+// DecodeCalls decodes calls of transactions to contracts with known ABI, ABIs uploaded by the tenant are requested
+// from storage once per chain. Result has decoded call for every transaction, it is nil if transaction is not
+// a call of known function or its input can not be decoded
+func (r *Registry) DecodeCalls(ctx context.Context, transactions []*models.Transaction) ([]*models.DecodedCall, error) {
+	contractsByChain := make(map[uint64][]models.Address)
+	seen := make(map[uint64]map[models.Address]bool)
+
+	for _, tx := range transactions {
+		to := models.NewAddress(tx.To)
+		// Input of call starts with 4 bytes of selector
+		if to == "" || len(tx.Input) < 10 {
+			continue
+		}
+
+		if _, ok := seen[tx.ChainID]; !ok {
+			seen[tx.ChainID] = make(map[models.Address]bool)
+		}
+
+		if !seen[tx.ChainID][to] {
+			seen[tx.ChainID][to] = true
+			contractsByChain[tx.ChainID] = append(contractsByChain[tx.ChainID], to)
+		}
+	}
+
+	abis := make(map[uint64]map[models.Address]*ABI, len(contractsByChain))
+	for chainID, addresses := range contractsByChain {
+		chainABIs, err := r.getABIs(ctx, chainID, addresses)
+		if err != nil {
+			return nil, err
+		}
+
+		abis[chainID] = chainABIs
+	}
+
+	calls := make([]*models.DecodedCall, len(transactions))
+	for i, tx := range transactions {
+		contractABI, ok := abis[tx.ChainID][models.NewAddress(tx.To)]
+		if !ok {
+			continue
+		}
+
+		// Input that does not match ABI is left raw, e.g. contract is a proxy which implementation has changed
+		call, err := contractABI.DecodeCall(tx.Input)
+		if err != nil {
+			continue
+		}
+
+		calls[i] = call
+	}
+
+	return calls, nil
+}
+
+// getABIs returns parsed ABIs of the contracts of the chain, uploaded ABIs override ABIs from directory
+func (r *Registry) getABIs(ctx context.Context, chainID uint64, addresses []models.Address) (map[models.Address]*ABI, error) {
+	abis := make(map[models.Address]*ABI, len(addresses))
+	for _, address := range addresses {
+		if directoryABI, ok := r.directoryABIs[chainID][address]; ok {
+			abis[address] = directoryABI.abi
+		}
+	}
+
+	contractABIs, err := r.contractABIRepository.GetContractsABIs(ctx, chainID, addresses)
+	if err != nil {
+		return nil, models.WrapError("error getting abis", err)
+	}
+
+	for _, contractABI := range contractABIs {
+		parsedABI, err := ParseABI(contractABI.ABI)
+		if err != nil {
+			continue
+		}
+
+		abis[contractABI.Address] = parsedABI
+	}
+
+	return abis, nil
+}
+
+// newContractABI parses raw ABI of the contract, JSON ABI is extracted from compiler artifact
+func newContractABI(chainID uint64, address models.Address, raw []byte) (contract, error) {
+	parsedABI, err := ParseABI(raw)
+	if err != nil {
+		return contract{}, err
+	}
+
+	rawABI, err := ExtractABI(raw)
+	if err != nil {
+		return contract{}, err
+	}
+
+	return contract{
+		info: models.ContractABI{
+			ChainID: chainID,
+			Address: address,
+			ABI:     rawABI,
+			Methods: parsedABI.Methods(),
+		},
+		abi: parsedABI,
+	}, nil
+}
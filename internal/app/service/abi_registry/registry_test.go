@@ -0,0 +1,169 @@
+package abi_registry
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/models"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/repository/memory_repository"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/tenant"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+const (
+	tokenAddress    = models.Address("0xdac17f958d2ee523a2206206994597c13d831ec7")
+	registryAddress = models.Address("0x00000000219ab540356cbb839cbe05303d7705fa")
+)
+
+var chains = []models.Chain{{ID: 1, Name: "ethereum", NativeCurrency: "ETH"}, {ID: 10, Name: "optimism", NativeCurrency: "ETH"}}
+
+func TestRegistry_LoadDir(t *testing.T) {
+	type TestCase struct {
+		Name          string
+		Files         map[string]string
+		ExpectedError string
+	}
+
+	testCases := []TestCase{
+		{Name: "chain name and id", Files: map[string]string{"ethereum/" + tokenAddress.String() + ".json": erc20ABI,
+			"10/" + registryAddress.String() + ".json": registryABI, "ethereum/README.md": "ABIs of Ethereum Mainnet"}},
+		{Name: "unknown chain", Files: map[string]string{"base/" + tokenAddress.String() + ".json": erc20ABI},
+			ExpectedError: "abi directory base does not match any configured chain"},
+		{Name: "file not named by address", Files: map[string]string{"ethereum/usdt.json": erc20ABI},
+			ExpectedError: "abi file {dir}/ethereum/usdt.json is not named by contract address cause: address length should be 42"},
+		{Name: "invalid abi", Files: map[string]string{"ethereum/" + tokenAddress.String() + ".json": `[{"type":"event"}]`},
+			ExpectedError: "error loading abi file {dir}/ethereum/" + tokenAddress.String() + ".json cause: invalid abi cause: abi has no functions"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			dir := t.TempDir()
+			for name, content := range testCase.Files {
+				assert.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0o755))
+				assert.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644))
+			}
+
+			registry := NewRegistry(memory_repository.NewContractABIRepository())
+
+			err := registry.LoadDir(dir, chains)
+			if testCase.ExpectedError != "" {
+				assert.EqualError(t, err, replaceDir(testCase.ExpectedError, dir))
+				return
+			}
+
+			assert.NoError(t, err)
+
+			tokenABI, err := registry.GetABI(context.TODO(), 1, tokenAddress)
+			assert.NoError(t, err)
+			assert.Equal(t, models.ABISourceDirectory, tokenABI.Source)
+			assert.Equal(t, []string{"transfer(address,uint256)"}, tokenABI.Methods)
+
+			// Only abi field of artifact is kept
+			contractABI, err := registry.GetABI(context.TODO(), 10, registryAddress)
+			assert.NoError(t, err)
+			assert.JSONEq(t, `[{"name":"register","inputs":[{"name":"name","type":"string"},{"name":"ids","type":"uint256[]"},
+				{"name":"info","type":"tuple","components":[{"name":"owner","type":"address"},{"name":"data","type":"bytes"}]}]},
+				{"type":"function","name":"configure","inputs":[{"name":"offset","type":"int24"},{"name":"enabled","type":"bool"},
+				{"name":"interfaceId","type":"bytes4"},{"name":"admins","type":"address[2]"}]},{"type":"constructor","inputs":[]}]`,
+				string(contractABI.ABI))
+
+			_, err = registry.GetABI(context.TODO(), 10, tokenAddress)
+			assert.ErrorIs(t, err, models.ErrABINotFound)
+		})
+	}
+}
+
+func TestRegistry_ABIs(t *testing.T) {
+	ctx := tenant.WithID(context.TODO(), "tenant_a")
+	otherCtx := tenant.WithID(context.TODO(), "tenant_b")
+
+	dir := t.TempDir()
+	assert.NoError(t, os.Mkdir(filepath.Join(dir, "ethereum"), 0o755))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "ethereum", tokenAddress.String()+".json"), []byte(erc20ABI), 0o644))
+
+	registry := NewRegistry(memory_repository.NewContractABIRepository())
+	registry.now = func() time.Time {
+		return time.Date(2023, 2, 13, 14, 0, 0, 0, time.FixedZone("CET", 3600))
+	}
+	assert.NoError(t, registry.LoadDir(dir, chains))
+
+	transfer := &models.Transaction{ChainID: 1, Hash: "0x01", To: tokenAddress.String(),
+		Input: "0xa9059cbb" + words("28c6c06298d514db089934071355e5743bf21d60", "f4240")}
+	register := &models.Transaction{ChainID: 1, Hash: "0x02", To: registryAddress.String(),
+		Input: "0xb2892e7b" + words("60", "80", "a0", "0", "0", "28c6c06298d514db089934071355e5743bf21d60", "40", "0")}
+	malformed := &models.Transaction{ChainID: 1, Hash: "0x03", To: tokenAddress.String(), Input: "0xa9059cbb" + words("1")}
+	ether := &models.Transaction{ChainID: 1, Hash: "0x04", To: tokenAddress.String(), Input: "0x"}
+	creation := &models.Transaction{ChainID: 1, Hash: "0x05", Input: "0x60806040"}
+	transactions := []*models.Transaction{transfer, register, malformed, ether, creation}
+
+	// ABI from directory is shared by all tenants
+	calls, err := registry.DecodeCalls(ctx, transactions)
+	assert.NoError(t, err)
+	assert.Len(t, calls, len(transactions))
+	assert.Equal(t, "transfer", calls[0].Method)
+	assert.Equal(t, []*models.DecodedCall{nil, nil, nil, nil}, calls[1:])
+
+	_, err = registry.SetABI(ctx, 1, registryAddress, json.RawMessage(`[{"type":"event","name":"Registered","inputs":[]}]`))
+	assert.EqualError(t, err, "invalid abi cause: abi has no functions")
+	assert.ErrorIs(t, err, models.ErrInvalidABI)
+
+	contractABI, err := registry.SetABI(ctx, 1, registryAddress, json.RawMessage(registryABI))
+	assert.NoError(t, err)
+	assert.Equal(t, models.ContractABI{
+		TenantID:  "tenant_a",
+		ChainID:   1,
+		Address:   registryAddress,
+		ABI:       contractABI.ABI,
+		Methods:   []string{"configure(int24,bool,bytes4,address[2])", "register(string,uint256[],(address,bytes))"},
+		Source:    models.ABISourceAPI,
+		UpdatedAt: time.Date(2023, 2, 13, 13, 0, 0, 0, time.UTC),
+	}, contractABI)
+
+	calls, err = registry.DecodeCalls(ctx, transactions)
+	assert.NoError(t, err)
+	assert.Equal(t, "register", calls[1].Method)
+	assert.Equal(t, []interface{}{}, calls[1].Arguments[1].Value)
+
+	// Uploaded ABI is visible only to its tenant
+	calls, err = registry.DecodeCalls(otherCtx, transactions)
+	assert.NoError(t, err)
+	assert.Nil(t, calls[1])
+
+	// Uploaded ABI overrides ABI from directory until it is deleted
+	_, err = registry.SetABI(ctx, 1, tokenAddress, json.RawMessage(registryABI))
+	assert.NoError(t, err)
+
+	contractABIs, err := registry.ListABIs(ctx, 1)
+	assert.NoError(t, err)
+	assert.Len(t, contractABIs, 2)
+	assert.Equal(t, registryAddress, contractABIs[0].Address)
+	assert.Equal(t, tokenAddress, contractABIs[1].Address)
+	assert.Equal(t, models.ABISourceAPI, contractABIs[1].Source)
+
+	calls, err = registry.DecodeCalls(ctx, transactions)
+	assert.NoError(t, err)
+	assert.Nil(t, calls[0])
+
+	err = registry.DeleteABI(ctx, 1, tokenAddress)
+	assert.NoError(t, err)
+
+	err = registry.DeleteABI(ctx, 1, tokenAddress)
+	assert.ErrorIs(t, err, models.ErrABINotFound)
+
+	tokenABI, err := registry.GetABI(ctx, 1, tokenAddress)
+	assert.NoError(t, err)
+	assert.Equal(t, models.ABISourceDirectory, tokenABI.Source)
+
+	contractABIs, err = registry.ListABIs(otherCtx, 1)
+	assert.NoError(t, err)
+	assert.Len(t, contractABIs, 1)
+	assert.Equal(t, tokenAddress, contractABIs[0].Address)
+}
+
+func replaceDir(s string, dir string) string {
+	return filepath.FromSlash(strings.ReplaceAll(s, "{dir}", dir))
+}
@@ -0,0 +1,162 @@
+package address_book
+
+import (
+	"context"
+	"errors"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/models"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/tenant"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxLabels is a maximum count of labels of an address
+const maxLabels = 16
+
+// maxLabelLength is a maximum length of a label in bytes
+const maxLabelLength = 64
+
+type AddressLabelsRepository interface {
+	SetAddressLabels(ctx context.Context, labels models.AddressLabels) error
+	GetAddressLabels(ctx context.Context, address models.Address) (models.AddressLabels, error)
+	GetAddressesLabels(ctx context.Context, addresses []models.Address) ([]models.AddressLabels, error)
+	GetAllAddressLabels(ctx context.Context) ([]models.AddressLabels, error)
+	DeleteAddressLabels(ctx context.Context, address models.Address) error
+}
+
+// AddressBook manages labels that tenants attach to addresses, like name of exchange, ID of customer or name of contract.
+// Every tenant has its own address book, tenant is taken from context like for subscriptions
+type AddressBook struct {
+	addressLabelsRepository AddressLabelsRepository
+	now                     func() time.Time
+}
+
+func NewAddressBook(addressLabelsRepository AddressLabelsRepository) *AddressBook {
+	return &AddressBook{
+		addressLabelsRepository: addressLabelsRepository,
+		now:                     time.Now,
+	}
+}
+
+// SetLabels replaces labels of the address. Labels are trimmed and deduplicated, at least one label is required
+func (b *AddressBook) SetLabels(ctx context.Context, address models.Address, labels []string) (models.AddressLabels, error) {
+	labels, err := normalizeLabels(labels)
+	if err != nil {
+		return models.AddressLabels{}, err
+	}
+
+	addressLabels := models.AddressLabels{
+		TenantID:  tenant.FromContext(ctx),
+		Address:   address,
+		Labels:    labels,
+		UpdatedAt: b.now().UTC(),
+	}
+
+	err = b.addressLabelsRepository.SetAddressLabels(ctx, addressLabels)
+	if err != nil {
+		return models.AddressLabels{}, models.WrapError("error setting labels", err)
+	}
+
+	return addressLabels, nil
+}
+
+// GetLabels returns labels of the address, ErrLabelsNotFound is returned if address has no labels
+func (b *AddressBook) GetLabels(ctx context.Context, address models.Address) (models.AddressLabels, error) {
+	addressLabels, err := b.addressLabelsRepository.GetAddressLabels(ctx, address)
+	if err != nil {
+		return models.AddressLabels{}, models.WrapError("error getting labels", err)
+	}
+
+	return addressLabels, nil
+}
+
+// ListLabels returns labels of all labelled addresses sorted by address
+func (b *AddressBook) ListLabels(ctx context.Context) ([]models.AddressLabels, error) {
+	labels, err := b.addressLabelsRepository.GetAllAddressLabels(ctx)
+	if err != nil {
+		return nil, models.WrapError("error getting labels", err)
+	}
+
+	sort.Slice(labels, func(i, j int) bool {
+		return labels[i].Address < labels[j].Address
+	})
+
+	return labels, nil
+}
+
+// DeleteLabels removes all labels of the address, ErrLabelsNotFound is returned if address has no labels
+func (b *AddressBook) DeleteLabels(ctx context.Context, address models.Address) error {
+	err := b.addressLabelsRepository.DeleteAddressLabels(ctx, address)
+	if err != nil {
+		return models.WrapError("error deleting labels", err)
+	}
+
+	return nil
+}
+
+// LookupLabels returns labels of the addresses in one request to storage, addresses without labels are missing in the result
+func (b *AddressBook) LookupLabels(ctx context.Context, addresses []models.Address) (map[models.Address][]string, error) {
+	labels, err := b.addressLabelsRepository.GetAddressesLabels(ctx, uniqueAddresses(addresses))
+	if err != nil {
+		return nil, models.WrapError("error getting labels", err)
+	}
+
+	labelsByAddress := make(map[models.Address][]string, len(labels))
+	for _, addressLabels := range labels {
+		labelsByAddress[addressLabels.Address] = addressLabels.Labels
+	}
+
+	return labelsByAddress, nil
+}
+
+// normalizeLabels trims labels and removes duplicates keeping order of the first occurrence
+func normalizeLabels(labels []string) ([]string, error) {
+	normalized := make([]string, 0, len(labels))
+	seen := make(map[string]bool, len(labels))
+
+	for _, label := range labels {
+		label = strings.TrimSpace(label)
+		if label == "" {
+			return nil, errors.New("label should not be empty")
+		}
+
+		if len(label) > maxLabelLength {
+			return nil, errors.New("label should not be longer than " + strconv.Itoa(maxLabelLength) + " bytes")
+		}
+
+		if seen[label] {
+			continue
+		}
+
+		seen[label] = true
+		normalized = append(normalized, label)
+	}
+
+	if len(normalized) == 0 {
+		return nil, errors.New("labels are not provided")
+	}
+
+	if len(normalized) > maxLabels {
+		return nil, errors.New("address should not have more than " + strconv.Itoa(maxLabels) + " labels")
+	}
+
+	return normalized, nil
+}
+
+// uniqueAddresses returns addresses without duplicates and empty addresses, e.g. recipient of contract creation
+func uniqueAddresses(addresses []models.Address) []models.Address {
+	unique := make([]models.Address, 0, len(addresses))
+	seen := make(map[models.Address]bool, len(addresses))
+
+	for _, address := range addresses {
+		if address == "" || seen[address] {
+			continue
+		}
+
+		seen[address] = true
+		unique = append(unique, address)
+	}
+
+	return unique
+}
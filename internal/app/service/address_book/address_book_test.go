@@ -0,0 +1,103 @@
+package address_book
+
+import (
+	"context"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/models"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/repository/memory_repository"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/tenant"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"time"
+)
+
+const (
+	exchangeAddress = models.Address("0x28c6c06298d514db089934071355e5743bf21d60")
+	depositAddress  = models.Address("0x00000000219ab540356cbb839cbe05303d7705fa")
+)
+
+func TestAddressBook_SetLabels(t *testing.T) {
+	type TestCase struct {
+		Name           string
+		Labels         []string
+		ExpectedLabels []string
+		ExpectedError  string
+	}
+
+	testCases := []TestCase{
+		{Name: "labels", Labels: []string{"exchange", "binance"}, ExpectedLabels: []string{"exchange", "binance"}},
+		{Name: "trimmed duplicates", Labels: []string{" customer:42 ", "customer:42", "vip"}, ExpectedLabels: []string{"customer:42", "vip"}},
+		{Name: "no labels", Labels: nil, ExpectedError: "labels are not provided"},
+		{Name: "empty label", Labels: []string{"exchange", "  "}, ExpectedError: "label should not be empty"},
+		{Name: "long label", Labels: []string{strings.Repeat("a", 65)}, ExpectedError: "label should not be longer than 64 bytes"},
+		{Name: "too many labels", Labels: strings.Split("a,b,c,d,e,f,g,h,i,j,k,l,m,n,o,p,q", ","), ExpectedError: "address should not have more than 16 labels"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			ctx := tenant.WithID(context.TODO(), "tenant_a")
+
+			addressBook := NewAddressBook(memory_repository.NewAddressLabelsRepository())
+			addressBook.now = func() time.Time {
+				return time.Date(2023, 2, 13, 14, 0, 0, 0, time.FixedZone("CET", 3600))
+			}
+
+			labels, err := addressBook.SetLabels(ctx, exchangeAddress, testCase.Labels)
+			if testCase.ExpectedError != "" {
+				assert.EqualError(t, err, testCase.ExpectedError)
+
+				_, err = addressBook.GetLabels(ctx, exchangeAddress)
+				assert.ErrorIs(t, err, models.ErrLabelsNotFound)
+				return
+			}
+
+			expected := models.AddressLabels{
+				TenantID:  "tenant_a",
+				Address:   exchangeAddress,
+				Labels:    testCase.ExpectedLabels,
+				UpdatedAt: time.Date(2023, 2, 13, 13, 0, 0, 0, time.UTC),
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, expected, labels)
+
+			labels, err = addressBook.GetLabels(ctx, exchangeAddress)
+			assert.NoError(t, err)
+			assert.Equal(t, expected, labels)
+		})
+	}
+}
+
+func TestAddressBook_Labels(t *testing.T) {
+	ctx := tenant.WithID(context.TODO(), "tenant_a")
+
+	addressBook := NewAddressBook(memory_repository.NewAddressLabelsRepository())
+
+	_, err := addressBook.SetLabels(ctx, exchangeAddress, []string{"exchange"})
+	assert.NoError(t, err)
+
+	_, err = addressBook.SetLabels(ctx, depositAddress, []string{"deposit contract"})
+	assert.NoError(t, err)
+
+	labels, err := addressBook.ListLabels(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, labels, 2)
+	assert.Equal(t, depositAddress, labels[0].Address)
+	assert.Equal(t, exchangeAddress, labels[1].Address)
+
+	labelsByAddress, err := addressBook.LookupLabels(ctx, []models.Address{exchangeAddress, "", exchangeAddress,
+		models.Address("0x45849a974058661eb2128aceb60d2c6ed99e2a14")})
+	assert.NoError(t, err)
+	assert.Equal(t, map[models.Address][]string{exchangeAddress: {"exchange"}}, labelsByAddress)
+
+	err = addressBook.DeleteLabels(ctx, exchangeAddress)
+	assert.NoError(t, err)
+
+	err = addressBook.DeleteLabels(ctx, exchangeAddress)
+	assert.ErrorIs(t, err, models.ErrLabelsNotFound)
+
+	// Other tenants do not see labels of the tenant
+	labels, err = addressBook.ListLabels(tenant.WithID(context.TODO(), "tenant_b"))
+	assert.NoError(t, err)
+	assert.Empty(t, labels)
+}
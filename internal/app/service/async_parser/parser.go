@@ -5,20 +5,31 @@ import (
 	"errors"
 	ethereum_jsonrpc "github.com/bluntenpassant/ethereum_subscriber/internal/app/client/ethereum-jsonrpc"
 	ethereum_jsonrpc_models "github.com/bluntenpassant/ethereum_subscriber/internal/app/client/ethereum-jsonrpc/models"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/metrics"
 	"github.com/bluntenpassant/ethereum_subscriber/internal/app/models"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/tenant"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"log/slog"
 	"sync"
 	"sync/atomic"
 )
 
+// parserName is a label of parser in metrics
+const parserName = "async"
+
 type EthereumJsonRPCClient interface {
-	GetTxCount(req *ethereum_jsonrpc.GetTxCountReq) (*ethereum_jsonrpc.GetTxCountResp, error)
-	GetBlockByNumber(req *ethereum_jsonrpc.GetBlockByNumberReq) (*ethereum_jsonrpc.GetBlockByNumberResp, error)
-	GetBlockNumber() (*ethereum_jsonrpc.GetBlockNumberResp, error)
+	GetTxCount(ctx context.Context, req *ethereum_jsonrpc.GetTxCountReq) (*ethereum_jsonrpc.GetTxCountResp, error)
+	GetBlockByNumber(ctx context.Context, req *ethereum_jsonrpc.GetBlockByNumberReq) (*ethereum_jsonrpc.GetBlockByNumberResp, error)
+	GetBlockNumber(ctx context.Context) (*ethereum_jsonrpc.GetBlockNumberResp, error)
 }
 
 type SubscriberRepository interface {
 	AddNewSubscriber(ctx context.Context, subscriber models.Subscriber) error
-	GetSubscriberByAddress(ctx context.Context, address string) (models.Subscriber, error)
+	GetSubscriberByAddress(ctx context.Context, address models.Address) (models.Subscriber, error)
+	GetSubscribers(ctx context.Context) ([]models.Subscriber, error)
+	CountSubscribers(ctx context.Context) (int, error)
+	DeleteSubscriber(ctx context.Context, address models.Address) error
 }
 
 type BlockRepository interface {
@@ -27,16 +38,21 @@ type BlockRepository interface {
 }
 
 type Parser struct {
+	// chainID is stamped on subscribers and transactions handled by the parser
+	chainID               uint64
 	ethereumJsonRPCClient EthereumJsonRPCClient
 	subscriberRepository  SubscriberRepository
 	blockRepository       BlockRepository
+	logger                *slog.Logger
 }
 
-func NewParser(ethereumJsonRPCClient EthereumJsonRPCClient, subscriberRepository SubscriberRepository, blockRepository BlockRepository) *Parser {
+func NewParser(chainID uint64, ethereumJsonRPCClient EthereumJsonRPCClient, subscriberRepository SubscriberRepository, blockRepository BlockRepository, logger *slog.Logger) *Parser {
 	return &Parser{
+		chainID:               chainID,
 		ethereumJsonRPCClient: ethereumJsonRPCClient,
 		subscriberRepository:  subscriberRepository,
 		blockRepository:       blockRepository,
+		logger:                logger.With("parser", parserName, "chain_id", chainID),
 	}
 }
 
@@ -46,14 +62,44 @@ func (p *Parser) GetCurrentBlock(ctx context.Context) (uint64, error) {
 	return currentBlock, err
 }
 
-func (p *Parser) Subscribe(ctx context.Context, address string) error {
-	blockNumberResp, err := p.ethereumJsonRPCClient.GetBlockNumber()
+func (p *Parser) GetSubscribers(ctx context.Context) ([]models.Subscriber, error) {
+	subscribers, err := p.subscriberRepository.GetSubscribers(ctx)
+
+	return subscribers, err
+}
+
+// CountSubscribers returns count of subscriptions of the tenant from ctx
+func (p *Parser) CountSubscribers(ctx context.Context) (int, error) {
+	return p.subscriberRepository.CountSubscribers(ctx)
+}
+
+// GetSubscriber returns subscription of address by tenant from ctx
+func (p *Parser) GetSubscriber(ctx context.Context, address models.Address) (models.Subscriber, error) {
+	subscriber, err := p.subscriberRepository.GetSubscriberByAddress(ctx, address)
+
+	return subscriber, err
+}
+
+// Unsubscribe removes subscription of address by tenant from ctx
+func (p *Parser) Unsubscribe(ctx context.Context, address models.Address) error {
+	err := p.subscriberRepository.DeleteSubscriber(ctx, address)
 	if err != nil {
-		return errors.New("error getting current block number cause: " + err.Error())
+		return err
+	}
+
+	p.logger.InfoContext(ctx, "unsubscribed", "address", address.String())
+
+	return nil
+}
+
+func (p *Parser) Subscribe(ctx context.Context, address models.Address, ensName string) error {
+	blockNumberResp, err := p.ethereumJsonRPCClient.GetBlockNumber(ctx)
+	if err != nil {
+		return models.WrapError("error getting current block number", err)
 	}
 
-	txCountResp, err := p.ethereumJsonRPCClient.GetTxCount(&ethereum_jsonrpc.GetTxCountReq{
-		Address:  address,
+	txCountResp, err := p.ethereumJsonRPCClient.GetTxCount(ctx, &ethereum_jsonrpc.GetTxCountReq{
+		Address:  address.String(),
 		EndBlock: blockNumberResp.BlockNumber,
 	})
 	if err != nil {
@@ -61,12 +107,21 @@ func (p *Parser) Subscribe(ctx context.Context, address string) error {
 	}
 
 	err = p.subscriberRepository.AddNewSubscriber(ctx, models.Subscriber{
+		ChainID:              p.chainID,
 		Address:              address,
 		SubscribeBlockNumber: uint64(blockNumberResp.BlockNumber),
 		SubscribeTxCount:     uint64(txCountResp.Nonce),
+		ENSName:              ensName,
+		TenantID:             tenant.FromContext(ctx),
 	})
+	if err != nil {
+		return err
+	}
 
-	return err
+	p.logger.InfoContext(ctx, "subscribed", "address", address.String(), "ens_name", ensName,
+		"block", uint64(blockNumberResp.BlockNumber), "tx_count", uint64(txCountResp.Nonce))
+
+	return nil
 }
 
 // GetTransactions uses for a getting a full list of inbound or outbounds transactions by user since subscription.
@@ -91,27 +146,66 @@ func (p *Parser) Subscribe(ctx context.Context, address string) error {
 // but it might significantly increase performance due to keeping already parsed transactions.
 // This approach aimed at long-term program execution with long lifetime.
 // NOTE 3* this approach by default does not guarantees order of transactions and it might require extra sorting for transactions
-func (p *Parser) GetTransactions(ctx context.Context, address string) ([]*models.Transaction, error) {
+func (p *Parser) GetTransactions(ctx context.Context, address models.Address) ([]*models.Transaction, error) {
+	ctx, span := tracing.Start(ctx, "async_parser.GetTransactions", attribute.String("address", address.String()))
+	defer span.End()
+
 	subscriber, err := p.subscriberRepository.GetSubscriberByAddress(ctx, address)
 	if err != nil {
-		return nil, err
+		return nil, tracing.Error(span, err)
+	}
+
+	currentBlockNumber, txCount, err := p.countNewTransactions(ctx, subscriber)
+	if err != nil {
+		return nil, tracing.Error(span, err)
 	}
 
-	currentBlockNumberResp, err := p.ethereumJsonRPCClient.GetBlockNumber()
+	transactions, err := p.scanBlocks(ctx, subscriber, currentBlockNumber, txCount)
+	if err != nil {
+		return nil, tracing.Error(span, err)
+	}
+
+	return transactions, nil
+}
+
+// countNewTransactions returns the current block number in Ethereum Network and count of subscriber transactions
+// since the last parsed block (steps 1-3 of GetTransactions algorithm)
+func (p *Parser) countNewTransactions(ctx context.Context, subscriber models.Subscriber) (uint64, uint64, error) {
+	ctx, span := tracing.Start(ctx, "count")
+	defer span.End()
+
+	currentBlockNumberResp, err := p.ethereumJsonRPCClient.GetBlockNumber(ctx)
 	if err != nil {
-		return nil, err
+		return 0, 0, tracing.Error(span, err)
 	}
 
-	currentTxCountResp, err := p.ethereumJsonRPCClient.GetTxCount(&ethereum_jsonrpc.GetTxCountReq{
-		Address:  address,
+	currentTxCountResp, err := p.ethereumJsonRPCClient.GetTxCount(ctx, &ethereum_jsonrpc.GetTxCountReq{
+		Address:  subscriber.Address.String(),
 		EndBlock: currentBlockNumberResp.BlockNumber,
 	})
 	if err != nil {
-		return nil, err
+		return 0, 0, tracing.Error(span, err)
 	}
 
 	txCount := uint64(currentTxCountResp.Nonce) - subscriber.SubscribeTxCount
 
+	span.SetAttributes(attribute.Int64("block_number", int64(currentBlockNumberResp.BlockNumber)), attribute.Int64("tx_count", int64(txCount)))
+
+	return uint64(currentBlockNumberResp.BlockNumber), txCount, nil
+}
+
+// scanBlocks requests all blocks from currentBlockNumber back to the last parsed block concurrently
+// and collects subscriber transactions (step 4 of GetTransactions algorithm)
+func (p *Parser) scanBlocks(ctx context.Context, subscriber models.Subscriber, currentBlockNumber uint64, txCount uint64) ([]*models.Transaction, error) {
+	ctx, span := tracing.Start(ctx, "scan", attribute.Int64("from_block", int64(currentBlockNumber)),
+		attribute.Int64("to_block", int64(subscriber.SubscribeBlockNumber)))
+	defer span.End()
+
+	address := subscriber.Address
+
+	p.logger.DebugContext(ctx, "scanning blocks for transactions", "address", address.String(),
+		"from_block", currentBlockNumber, "to_block", subscriber.SubscribeBlockNumber, "tx_count", txCount)
+
 	var addressTxCountAtomic uint64
 
 	atomic.StoreUint64(&addressTxCountAtomic, txCount)
@@ -129,12 +223,12 @@ func (p *Parser) GetTransactions(ctx context.Context, address string) ([]*models
 
 	errChan := make(chan error, txCount+1)
 
-	for i := uint64(currentBlockNumberResp.BlockNumber); i >= subscriber.SubscribeBlockNumber; i-- {
+	for i := currentBlockNumber; i >= subscriber.SubscribeBlockNumber; i-- {
 		wg.Add(1)
 		go func(blockNumber uint64) {
 			defer wg.Done()
 
-			blockResp, err := p.ethereumJsonRPCClient.GetBlockByNumber(&ethereum_jsonrpc.GetBlockByNumberReq{
+			blockResp, err := p.ethereumJsonRPCClient.GetBlockByNumber(ctx, &ethereum_jsonrpc.GetBlockByNumberReq{
 				BlockNumber: ethereum_jsonrpc_models.HexUint64(blockNumber),
 				IsGetFullTx: true,
 			})
@@ -143,7 +237,9 @@ func (p *Parser) GetTransactions(ctx context.Context, address string) ([]*models
 				return
 			}
 
-			if blockNumber == uint64(currentBlockNumberResp.BlockNumber) {
+			metrics.AddBlocksProcessed(parserName, 1)
+
+			if blockNumber == currentBlockNumber {
 				err = p.blockRepository.SetMaxCurrentBlock(ctx, blockNumber)
 				if err != nil {
 					errChan <- err
@@ -153,12 +249,13 @@ func (p *Parser) GetTransactions(ctx context.Context, address string) ([]*models
 
 			for j := len(blockResp.Block.Transactions) - 1; j >= 0 && atomic.LoadUint64(&addressTxCountAtomic) > 0; j-- {
 				tx := blockResp.Block.Transactions[j]
-				if tx.From == address || tx.To == address {
+				if models.NewAddress(tx.From) == address || models.NewAddress(tx.To) == address {
 					transactionMx.Lock()
 					transaction := txPool.Get().(*models.Transaction)
-					*transaction = *models.ConvertJsonRPCTxToInternal(tx)
+					*transaction = *models.ConvertJsonRPCTxToInternal(p.chainID, tx)
 					transactions = append(transactions, transaction)
 					transactionMx.Unlock()
+					metrics.AddTransactionsMatched(parserName, 1)
 
 					atomic.AddUint64(&addressTxCountAtomic, ^uint64(0))
 				}
@@ -168,14 +265,15 @@ func (p *Parser) GetTransactions(ctx context.Context, address string) ([]*models
 
 	wg.Wait()
 
-	var errorMsg string
+	// Errors of all blocks are joined, so they are still matched by errors.Is (e.g. failures of Ethereum node)
+	var errs []error
 
 	var done bool
 	for !done {
 		select {
 		case err := <-errChan:
 			if err != nil {
-				errorMsg += "Error: " + err.Error() + "\n"
+				errs = append(errs, err)
 			}
 		default:
 			done = true
@@ -183,9 +281,11 @@ func (p *Parser) GetTransactions(ctx context.Context, address string) ([]*models
 		}
 	}
 
-	if errorMsg != "" {
-		return nil, errors.New(errorMsg)
+	if len(errs) != 0 {
+		return nil, tracing.Error(span, errors.Join(errs...))
 	}
 
+	span.SetAttributes(attribute.Int("transactions", len(transactions)))
+
 	return transactions, nil
 }
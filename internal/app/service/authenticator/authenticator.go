@@ -0,0 +1,160 @@
+package authenticator
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"github.com/bluntenpassant/ethereum_subscriber/config"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/models"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// keyPrefix makes API keys of the service recognizable, e.g. in leaked secrets scanners
+const keyPrefix = "ethsub_"
+
+// keySize is a count of random bytes of raw API key
+const keySize = 32
+
+// idSize is a count of random bytes of API key ID
+const idSize = 8
+
+// tenantIDPattern restricts tenant IDs, so separators of storage keys (':' and '-') cannot appear in them
+// and keys of different tenants and chains never collide
+var tenantIDPattern = regexp.MustCompile(`^[a-z0-9_]+$`)
+
+type APIKeyRepository interface {
+	AddAPIKey(ctx context.Context, key models.APIKey) error
+	GetAPIKeyByHash(ctx context.Context, hash string) (models.APIKey, error)
+	GetAPIKeys(ctx context.Context) ([]models.APIKey, error)
+	DeleteAPIKey(ctx context.Context, id string) error
+}
+
+// Authenticator checks keys of API requests and manages API keys of tenants
+type Authenticator struct {
+	enabled  bool
+	adminKey string
+
+	apiKeyRepository APIKeyRepository
+	now              func() time.Time
+}
+
+func NewAuthenticator(authConfig config.Auth, apiKeyRepository APIKeyRepository) *Authenticator {
+	return &Authenticator{
+		enabled:          authConfig.Enabled,
+		adminKey:         authConfig.AdminKey,
+		apiKeyRepository: apiKeyRepository,
+		now:              time.Now,
+	}
+}
+
+// Enabled reports whether requests should be authenticated, otherwise all clients share one namespace without tenant
+func (a *Authenticator) Enabled() bool {
+	return a.enabled
+}
+
+// IsAdmin reports whether rawKey is the admin key, keys are compared in constant time
+func (a *Authenticator) IsAdmin(rawKey string) bool {
+	return a.adminKey != "" && subtle.ConstantTimeCompare([]byte(rawKey), []byte(a.adminKey)) == 1
+}
+
+// Authenticate returns API key matching rawKey, key carries ID of the tenant owning it.
+// ErrInvalidAPIKey is returned if key is malformed or not found
+func (a *Authenticator) Authenticate(ctx context.Context, rawKey string) (models.APIKey, error) {
+	if !strings.HasPrefix(rawKey, keyPrefix) {
+		return models.APIKey{}, models.ErrInvalidAPIKey
+	}
+
+	key, err := a.apiKeyRepository.GetAPIKeyByHash(ctx, hashKey(rawKey))
+	if err != nil {
+		if errors.Is(err, models.ErrAPIKeyNotFound) {
+			return models.APIKey{}, models.ErrInvalidAPIKey
+		}
+
+		return models.APIKey{}, models.WrapError("error getting api key", err)
+	}
+
+	return key, nil
+}
+
+// CreateKey generates a new API key of the tenant. Raw key is returned only here, only its hash is stored
+func (a *Authenticator) CreateKey(ctx context.Context, tenantID string) (models.APIKey, string, error) {
+	if tenantID == "" {
+		return models.APIKey{}, "", errors.New("tenant is not provided")
+	}
+
+	if !tenantIDPattern.MatchString(tenantID) {
+		return models.APIKey{}, "", errors.New("tenant should contain only lowercase letters, digits and '_'")
+	}
+
+	id, err := randomHex(idSize)
+	if err != nil {
+		return models.APIKey{}, "", err
+	}
+
+	secret, err := randomHex(keySize)
+	if err != nil {
+		return models.APIKey{}, "", err
+	}
+
+	rawKey := keyPrefix + secret
+	key := models.APIKey{
+		ID:        id,
+		TenantID:  tenantID,
+		Hash:      hashKey(rawKey),
+		CreatedAt: a.now().UTC(),
+	}
+
+	err = a.apiKeyRepository.AddAPIKey(ctx, key)
+	if err != nil {
+		return models.APIKey{}, "", err
+	}
+
+	return key, rawKey, nil
+}
+
+// ListKeys returns API keys of all tenants, the oldest key goes first
+func (a *Authenticator) ListKeys(ctx context.Context) ([]models.APIKey, error) {
+	keys, err := a.apiKeyRepository.GetAPIKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].CreatedAt.Equal(keys[j].CreatedAt) {
+			return keys[i].ID < keys[j].ID
+		}
+
+		return keys[i].CreatedAt.Before(keys[j].CreatedAt)
+	})
+
+	return keys, nil
+}
+
+// RevokeKey deletes API key, requests with it are rejected right after
+func (a *Authenticator) RevokeKey(ctx context.Context, id string) error {
+	return a.apiKeyRepository.DeleteAPIKey(ctx, id)
+}
+
+// hashKey returns hex encoded SHA-256 hash of raw key. Keys are random, so plain hash without salt is enough
+func hashKey(rawKey string) string {
+	hash := sha256.Sum256([]byte(rawKey))
+
+	return hex.EncodeToString(hash[:])
+}
+
+func randomHex(size int) (string, error) {
+	raw := make([]byte, size)
+
+	_, err := rand.Read(raw)
+	if err != nil {
+		return "", errors.New("generating random key failed cause: " + err.Error())
+	}
+
+	return hex.EncodeToString(raw), nil
+}
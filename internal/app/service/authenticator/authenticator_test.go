@@ -0,0 +1,91 @@
+package authenticator
+
+import (
+	"context"
+	"github.com/bluntenpassant/ethereum_subscriber/config"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/models"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/repository/memory_repository"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestAuthenticator_IsAdmin(t *testing.T) {
+	type TestCase struct {
+		Name     string
+		AdminKey string
+		Key      string
+		Expected bool
+	}
+
+	testCases := []TestCase{
+		{Name: "admin key", AdminKey: "secret", Key: "secret", Expected: true},
+		{Name: "wrong key", AdminKey: "secret", Key: "secreT", Expected: false},
+		{Name: "empty key", AdminKey: "secret", Key: "", Expected: false},
+		{Name: "admin key is not configured", AdminKey: "", Key: "", Expected: false},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			authenticator := NewAuthenticator(config.Auth{Enabled: true, AdminKey: testCase.AdminKey}, memory_repository.NewAPIKeyRepository())
+			assert.Equal(t, testCase.Expected, authenticator.IsAdmin(testCase.Key))
+		})
+	}
+}
+
+func TestAuthenticator_Keys(t *testing.T) {
+	ctx := context.TODO()
+
+	authenticator := NewAuthenticator(config.Auth{Enabled: true, AdminKey: "secret"}, memory_repository.NewAPIKeyRepository())
+
+	_, _, err := authenticator.CreateKey(ctx, "")
+	assert.EqualError(t, err, "tenant is not provided")
+
+	for _, tenantID := range []string{"tenant:a", "137-tenant", "Tenant", "tenant a"} {
+		_, _, err = authenticator.CreateKey(ctx, tenantID)
+		assert.EqualError(t, err, "tenant should contain only lowercase letters, digits and '_'", tenantID)
+	}
+
+	keyA, rawKeyA, err := authenticator.CreateKey(ctx, "tenant_a")
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(rawKeyA, keyPrefix))
+	assert.Equal(t, "tenant_a", keyA.TenantID)
+	// Raw key is not stored
+	assert.NotContains(t, keyA.Hash, strings.TrimPrefix(rawKeyA, keyPrefix))
+
+	keyB, rawKeyB, err := authenticator.CreateKey(ctx, "tenant_b")
+	assert.NoError(t, err)
+	assert.NotEqual(t, rawKeyA, rawKeyB)
+
+	key, err := authenticator.Authenticate(ctx, rawKeyA)
+	assert.NoError(t, err)
+	assert.Equal(t, "tenant_a", key.TenantID)
+	assert.Equal(t, keyA.ID, key.ID)
+
+	key, err = authenticator.Authenticate(ctx, rawKeyB)
+	assert.NoError(t, err)
+	assert.Equal(t, "tenant_b", key.TenantID)
+	assert.Equal(t, keyB.ID, key.ID)
+
+	_, err = authenticator.Authenticate(ctx, keyPrefix+"unknown")
+	assert.ErrorIs(t, err, models.ErrInvalidAPIKey)
+
+	_, err = authenticator.Authenticate(ctx, "secret")
+	assert.ErrorIs(t, err, models.ErrInvalidAPIKey)
+
+	keys, err := authenticator.ListKeys(ctx)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{keyA.ID, keyB.ID}, []string{keys[0].ID, keys[1].ID})
+
+	err = authenticator.RevokeKey(ctx, keyA.ID)
+	assert.NoError(t, err)
+
+	err = authenticator.RevokeKey(ctx, keyA.ID)
+	assert.ErrorIs(t, err, models.ErrAPIKeyNotFound)
+
+	_, err = authenticator.Authenticate(ctx, rawKeyA)
+	assert.ErrorIs(t, err, models.ErrInvalidAPIKey)
+
+	_, err = authenticator.Authenticate(ctx, rawKeyB)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,256 @@
+package config_reloader
+
+import (
+	"context"
+	"errors"
+	"github.com/bluntenpassant/ethereum_subscriber/config"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/logger"
+	"log/slog"
+	"os"
+	"os/signal"
+	"reflect"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// Parameters that are applied at runtime, changes of all other parameters require restart
+const (
+	ethereumJsonRPCHostParam   = "ethereum_jsonrpc.host"
+	logLevelParam              = "log.level"
+	dataKeepAliveDurationParam = "storage.redis.data_keep_alive_duration"
+)
+
+// chainsParam is a list of additional chains, only changes of their hosts are applied at runtime
+const chainsParam = "chains"
+
+// cliParamsPrefix is a prefix of parameters used only by CLI, their changes are ignored
+const cliParamsPrefix = "cli."
+
+// rateLimitParamsPrefix is a prefix of rate limits, all of them are applied at runtime together
+const rateLimitParamsPrefix = "rate_limit."
+
+type EthereumJsonRPCClient interface {
+	SetHost(host string)
+}
+
+type Storage interface {
+	SetDataKeepAliveDuration(dataKeepAliveDuration time.Duration)
+}
+
+type RateLimiter interface {
+	SetConfig(rateLimitConfig config.RateLimit)
+}
+
+// Status describes effective config and result of the last reload
+type Status struct {
+	Config     config.Config
+	ReloadedAt time.Time
+	// ReloadError is an error of the last reload, empty if it was successful
+	ReloadError string
+}
+
+// Reloader reads config file again on SIGHUP or when the file is changed and applies safe changes at runtime.
+// Config with changes that require restart is rejected as a whole, so effective config always
+// matches a config file that was accepted.
+type Reloader struct {
+	path string
+	// ethereumJsonRPCClients are clients of all chains by chain ID
+	ethereumJsonRPCClients map[uint64]EthereumJsonRPCClient
+	storage                Storage
+	rateLimiter            RateLimiter
+	logLevel               *slog.LevelVar
+	logger                 *slog.Logger
+
+	// lastModTime is modification time of config file when it was read last time
+	lastModTime time.Time
+
+	mx     sync.RWMutex
+	status Status
+}
+
+// NewReloader creates reloader of config file from path, current is config the service was started with.
+// ethereumJsonRPCClients are clients of all configured chains by chain ID, their hosts are changed at runtime
+func NewReloader(path string, current config.Config, ethereumJsonRPCClients map[uint64]EthereumJsonRPCClient, storage Storage, rateLimiter RateLimiter,
+	logLevel *slog.LevelVar, logger *slog.Logger) *Reloader {
+	reloader := &Reloader{
+		path:                   path,
+		ethereumJsonRPCClients: ethereumJsonRPCClients,
+		storage:                storage,
+		rateLimiter:            rateLimiter,
+		logLevel:               logLevel,
+		logger:                 logger.With("component", "config_reloader"),
+		status: Status{
+			Config:     current,
+			ReloadedAt: time.Now(),
+		},
+	}
+	reloader.lastModTime = reloader.modTime()
+
+	return reloader
+}
+
+// Status returns effective config and result of the last reload
+func (r *Reloader) Status() Status {
+	r.mx.RLock()
+	defer r.mx.RUnlock()
+
+	return r.status
+}
+
+// Run reloads config on SIGHUP and, if pollInterval is positive, when modification time of config file changes.
+// Failed reloads are logged and keep current config.
+func (r *Reloader) Run(ctx context.Context, pollInterval time.Duration) error {
+	reloadSignals := make(chan os.Signal, 1)
+	signal.Notify(reloadSignals, syscall.SIGHUP)
+	defer signal.Stop(reloadSignals)
+
+	// Without config file there is nothing to watch, config consists of defaults and environment only
+	var poll <-chan time.Time
+	if pollInterval > 0 && r.path != "" {
+		ticker := time.NewTicker(pollInterval)
+		defer ticker.Stop()
+		poll = ticker.C
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-reloadSignals:
+			r.logger.InfoContext(ctx, "reloading config on SIGHUP", "path", r.path)
+		case <-poll:
+			modTime := r.modTime()
+			if modTime.Equal(r.lastModTime) {
+				continue
+			}
+
+			r.lastModTime = modTime
+			r.logger.InfoContext(ctx, "config file changed, reloading", "path", r.path)
+		}
+
+		err := r.Reload(ctx)
+		if err != nil {
+			r.logger.ErrorContext(ctx, "reloading config failed, current config is kept", "error", err.Error())
+		}
+	}
+}
+
+// Reload reads config and applies changed parameters. If config is invalid or contains changes
+// that require restart, nothing is applied and error is returned
+func (r *Reloader) Reload(ctx context.Context) error {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+
+	err := r.reload(ctx)
+	r.status.ReloadedAt = time.Now()
+	r.status.ReloadError = ""
+	if err != nil {
+		r.status.ReloadError = err.Error()
+	}
+
+	return err
+}
+
+func (r *Reloader) reload(ctx context.Context) error {
+	next, err := config.Load(r.path)
+	if err != nil {
+		return err
+	}
+
+	current := r.status.Config
+
+	changed := config.Diff(current, next)
+	if len(changed) == 0 {
+		r.logger.InfoContext(ctx, "config is not changed")
+		return nil
+	}
+
+	var restartRequired []string
+	for _, param := range changed {
+		switch {
+		case param == ethereumJsonRPCHostParam, param == logLevelParam, param == dataKeepAliveDurationParam,
+			strings.HasPrefix(param, rateLimitParamsPrefix):
+		case param == chainsParam && onlyHostsChanged(current.Chains, next.Chains):
+		// Parameters of CLI are not used by API server
+		case strings.HasPrefix(param, cliParamsPrefix):
+		default:
+			restartRequired = append(restartRequired, param)
+		}
+	}
+
+	if len(restartRequired) > 0 {
+		return errors.New("changes of " + strings.Join(restartRequired, ", ") + " require restart")
+	}
+
+	// Level is parsed before applying anything, so invalid level does not leave config partially applied
+	level, err := logger.ParseLevel(next.Log.Level)
+	if err != nil {
+		return err
+	}
+
+	// Chains are the same except hosts, so they are matched by position, the default chain goes first
+	currentChains := current.GetChains()
+	for i, chain := range next.GetChains() {
+		if chain.Host == currentChains[i].Host {
+			continue
+		}
+
+		client, ok := r.ethereumJsonRPCClients[chain.ChainID]
+		if ok {
+			client.SetHost(chain.Host)
+		}
+	}
+
+	if next.Log.Level != current.Log.Level {
+		r.logLevel.Set(level)
+	}
+
+	if next.Storage.Redis.DataKeepAliveDuration != current.Storage.Redis.DataKeepAliveDuration {
+		r.storage.SetDataKeepAliveDuration(next.Storage.Redis.DataKeepAliveDuration)
+	}
+
+	// Rate limits contain map of routes, so they are compared deeply
+	if !reflect.DeepEqual(next.RateLimit, current.RateLimit) {
+		r.rateLimiter.SetConfig(next.RateLimit)
+	}
+
+	r.status.Config = next
+
+	r.logger.InfoContext(ctx, "config reloaded", "changed", strings.Join(changed, ", "))
+
+	return nil
+}
+
+// onlyHostsChanged reports whether chains differ only by hosts, adding, removing or reordering chains requires restart
+func onlyHostsChanged(current []config.Chain, next []config.Chain) bool {
+	if len(current) != len(next) {
+		return false
+	}
+
+	for i := range current {
+		currentChain, nextChain := current[i], next[i]
+		currentChain.Host, nextChain.Host = "", ""
+
+		if currentChain != nextChain {
+			return false
+		}
+	}
+
+	return true
+}
+
+// modTime returns modification time of config file, zero time if it can not be read
+func (r *Reloader) modTime() time.Time {
+	if r.path == "" {
+		return time.Time{}
+	}
+
+	info, err := os.Stat(r.path)
+	if err != nil {
+		return time.Time{}
+	}
+
+	return info.ModTime()
+}
@@ -0,0 +1,196 @@
+package config_reloader
+
+import (
+	"context"
+	"github.com/bluntenpassant/ethereum_subscriber/config"
+	"github.com/stretchr/testify/assert"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+type mockEthereumJsonRPCClient struct {
+	host string
+}
+
+func (m *mockEthereumJsonRPCClient) SetHost(host string) {
+	m.host = host
+}
+
+type mockStorage struct {
+	dataKeepAliveDuration time.Duration
+}
+
+func (m *mockStorage) SetDataKeepAliveDuration(dataKeepAliveDuration time.Duration) {
+	m.dataKeepAliveDuration = dataKeepAliveDuration
+}
+
+type mockRateLimiter struct {
+	config *config.RateLimit
+}
+
+func (m *mockRateLimiter) SetConfig(rateLimitConfig config.RateLimit) {
+	m.config = &rateLimitConfig
+}
+
+func TestReloader_Reload(t *testing.T) {
+	const initialFile = "ethereum_jsonrpc:\n  host: http://node-1:8545\n"
+	const initialChainsFile = initialFile + "chains:\n  - name: arbitrum\n    chain_id: 42161\n    host: http://arbitrum-1:8545\n"
+
+	type TestCase struct {
+		Name string
+		// InitialFile is config the service was started with, initialFile is used if it is empty
+		InitialFile                   string
+		File                          string
+		ExpectedErr                   string
+		ExpectedHost                  string
+		ExpectedChainHost             string
+		ExpectedLevel                 slog.Level
+		ExpectedDataKeepAliveDuration time.Duration
+		ExpectedRateLimit             *config.RateLimit
+	}
+
+	testCases := []TestCase{
+		{
+			Name:                          "not changed",
+			File:                          "ethereum_jsonrpc:\n  host: http://node-1:8545\n",
+			ExpectedLevel:                 slog.LevelInfo,
+			ExpectedDataKeepAliveDuration: 0,
+		},
+		{
+			Name:                          "safe changes are applied",
+			File:                          "ethereum_jsonrpc:\n  host: http://node-2:8545\nlog:\n  level: debug\nstorage:\n  redis:\n    data_keep_alive_duration: 30m\n",
+			ExpectedHost:                  "http://node-2:8545",
+			ExpectedLevel:                 slog.LevelDebug,
+			ExpectedDataKeepAliveDuration: 30 * time.Minute,
+		},
+		{
+			Name: "rate limits are applied",
+			File: "ethereum_jsonrpc:\n  host: http://node-1:8545\nrate_limit:\n  enabled: true\n  burst: 5\n  max_subscriptions_per_tenant: 10\n" +
+				"  routes:\n    /get_transactions/{address}:\n      requests_per_second: 1\n      burst: 1\n",
+			ExpectedLevel: slog.LevelInfo,
+			ExpectedRateLimit: &config.RateLimit{
+				Enabled:           true,
+				RequestsPerSecond: 10,
+				Burst:             5,
+				Routes: map[string]config.Limit{
+					"/get_transactions/{address}":              {RequestsPerSecond: 1, Burst: 1},
+					"/v2/subscriptions/{address}/transactions": {RequestsPerSecond: 0.5, Burst: 5},
+					"/export/{address}":                        {RequestsPerSecond: 0.2, Burst: 2},
+				},
+				MaxSubscriptionsPerTenant: 10,
+			},
+		},
+		{
+			Name:          "changes requiring restart are rejected",
+			File:          "ethereum_jsonrpc:\n  host: http://node-2:8545\ngeneral:\n  approach: indexed\nhttp:\n  port: 9090\n",
+			ExpectedErr:   "changes of general.approach, http.port require restart",
+			ExpectedLevel: slog.LevelInfo,
+		},
+		{
+			Name:              "hosts of chains are applied",
+			InitialFile:       initialChainsFile,
+			File:              "ethereum_jsonrpc:\n  host: http://node-2:8545\nchains:\n  - name: arbitrum\n    chain_id: 42161\n    host: http://arbitrum-2:8545\n",
+			ExpectedHost:      "http://node-2:8545",
+			ExpectedChainHost: "http://arbitrum-2:8545",
+			ExpectedLevel:     slog.LevelInfo,
+		},
+		{
+			Name:          "changes of chains besides hosts are rejected",
+			InitialFile:   initialChainsFile,
+			File:          initialFile + "chains:\n  - name: arbitrum\n    chain_id: 42170\n    host: http://arbitrum-2:8545\n",
+			ExpectedErr:   "changes of chains require restart",
+			ExpectedLevel: slog.LevelInfo,
+		},
+		{
+			Name:          "added chains are rejected",
+			File:          initialChainsFile,
+			ExpectedErr:   "changes of chains require restart",
+			ExpectedLevel: slog.LevelInfo,
+		},
+		{
+			Name:          "invalid log level is rejected",
+			File:          "ethereum_jsonrpc:\n  host: http://node-2:8545\nlog:\n  level: verbose\n",
+			ExpectedErr:   "unknown log level verbose",
+			ExpectedLevel: slog.LevelInfo,
+		},
+		{
+			Name:          "invalid config is rejected",
+			File:          "general:\n  storage: postgres\n",
+			ExpectedErr:   "general.storage: unknown value",
+			ExpectedLevel: slog.LevelInfo,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			if testCase.InitialFile == "" {
+				testCase.InitialFile = initialFile
+			}
+
+			path := filepath.Join(t.TempDir(), "config.yaml")
+			assert.NoError(t, os.WriteFile(path, []byte(testCase.InitialFile), 0o600))
+
+			current, err := config.Load(path)
+			assert.NoError(t, err)
+
+			client := &mockEthereumJsonRPCClient{}
+			chainClient := &mockEthereumJsonRPCClient{}
+			storage := &mockStorage{}
+			rateLimiter := &mockRateLimiter{}
+			logLevel := &slog.LevelVar{}
+
+			clients := map[uint64]EthereumJsonRPCClient{current.EthereumJsonRPC.ChainID: client, 42161: chainClient}
+			reloader := NewReloader(path, current, clients, storage, rateLimiter, logLevel, slog.Default())
+
+			assert.NoError(t, os.WriteFile(path, []byte(testCase.File), 0o600))
+
+			err = reloader.Reload(context.TODO())
+			status := reloader.Status()
+
+			if testCase.ExpectedErr != "" {
+				assert.ErrorContains(t, err, testCase.ExpectedErr)
+				assert.Contains(t, status.ReloadError, testCase.ExpectedErr)
+				// Effective config is not changed
+				assert.Equal(t, current, status.Config)
+			} else {
+				assert.NoError(t, err)
+				assert.Empty(t, status.ReloadError)
+			}
+
+			assert.Equal(t, testCase.ExpectedHost, client.host)
+			assert.Equal(t, testCase.ExpectedChainHost, chainClient.host)
+			assert.Equal(t, testCase.ExpectedLevel, logLevel.Level())
+			assert.Equal(t, testCase.ExpectedDataKeepAliveDuration, storage.dataKeepAliveDuration)
+			assert.Equal(t, testCase.ExpectedRateLimit, rateLimiter.config)
+		})
+	}
+}
+
+func TestReloader_Run(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	assert.NoError(t, os.WriteFile(path, []byte("log:\n  level: info\n"), 0o600))
+
+	current, err := config.Load(path)
+	assert.NoError(t, err)
+
+	logLevel := &slog.LevelVar{}
+	reloader := NewReloader(path, current, map[uint64]EthereumJsonRPCClient{}, &mockStorage{}, &mockRateLimiter{}, logLevel, slog.Default())
+
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+
+	go reloader.Run(ctx, 10*time.Millisecond)
+
+	// Modification time is changed explicitly, because file system may have coarse timestamps
+	assert.NoError(t, os.WriteFile(path, []byte("log:\n  level: warn\n"), 0o600))
+	assert.NoError(t, os.Chtimes(path, time.Now(), time.Now().Add(time.Minute)))
+
+	assert.Eventually(t, func() bool {
+		return logLevel.Level() == slog.LevelWarn
+	}, time.Second, 10*time.Millisecond)
+
+	assert.Equal(t, "warn", reloader.Status().Config.Log.Level)
+}
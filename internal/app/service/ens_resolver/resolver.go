@@ -0,0 +1,125 @@
+package ens_resolver
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	ethereum_jsonrpc "github.com/bluntenpassant/ethereum_subscriber/internal/app/client/ethereum-jsonrpc"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/models"
+	"golang.org/x/crypto/sha3"
+	"strings"
+)
+
+// resolverMethodSelector is a selector of ENS registry method resolver(bytes32) returning resolver contract of the name
+var resolverMethodSelector = []byte{0x01, 0x78, 0xb8, 0xbf}
+
+// addrMethodSelector is a selector of ENS resolver method addr(bytes32) returning address the name points to
+var addrMethodSelector = []byte{0x3b, 0x3b, 0x57, 0xde}
+
+// DefaultRegistryAddress is an address of ENS registry contract in Ethereum Mainnet
+const DefaultRegistryAddress models.Address = "0x00000000000c2e074ec69a0dfb2997ba6c7d2e1e"
+
+// zeroAddress is returned by ENS contracts when name or its address record is not set
+const zeroAddress models.Address = "0x0000000000000000000000000000000000000000"
+
+type EthereumJsonRPCClient interface {
+	Call(ctx context.Context, req *ethereum_jsonrpc.CallReq) (*ethereum_jsonrpc.CallResp, error)
+}
+
+// Resolver resolves ENS names (https://docs.ens.domains/) into addresses
+// through eth_call requests to ENS registry and resolver contracts
+type Resolver struct {
+	ethereumJsonRPCClient EthereumJsonRPCClient
+	registryAddress       models.Address
+}
+
+func NewResolver(ethereumJsonRPCClient EthereumJsonRPCClient, registryAddress models.Address) *Resolver {
+	return &Resolver{
+		ethereumJsonRPCClient: ethereumJsonRPCClient,
+		registryAddress:       registryAddress,
+	}
+}
+
+// Resolve returns address the ENS name points to.
+// Algorithm:
+// 1. Calculate namehash of the name (node)
+// 2. Ask ENS registry for resolver contract of the node
+// 3. Ask resolver contract for address record of the node
+// Error is returned if the name is not registered or has no address record
+func (r *Resolver) Resolve(ctx context.Context, name string) (models.Address, error) {
+	name, err := models.ParseENSName(name)
+	if err != nil {
+		return "", err
+	}
+
+	node := NameHash(name)
+
+	resolverAddress, err := r.callAddressMethod(ctx, r.registryAddress, resolverMethodSelector, node)
+	if err != nil {
+		return "", models.WrapError("error getting ens resolver", err)
+	}
+
+	if resolverAddress == zeroAddress {
+		return "", errors.New("ens name " + name + " is not registered")
+	}
+
+	address, err := r.callAddressMethod(ctx, resolverAddress, addrMethodSelector, node)
+	if err != nil {
+		return "", models.WrapError("error resolving ens name", err)
+	}
+
+	if address == zeroAddress {
+		return "", errors.New("ens name " + name + " does not point to any address")
+	}
+
+	return address, nil
+}
+
+// callAddressMethod calls contract method with a single bytes32 argument that returns an address
+func (r *Resolver) callAddressMethod(ctx context.Context, contract models.Address, selector []byte, node [32]byte) (models.Address, error) {
+	data := make([]byte, 0, len(selector)+len(node))
+	data = append(data, selector...)
+	data = append(data, node[:]...)
+
+	callResp, err := r.ethereumJsonRPCClient.Call(ctx, &ethereum_jsonrpc.CallReq{
+		To:   contract.String(),
+		Data: data,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	// Address is ABI encoded as a 32-byte word with address in the last 20 bytes
+	if len(callResp.Result) < 32 {
+		return "", errors.New("unexpected contract response length")
+	}
+
+	return models.NewAddress("0x" + hex.EncodeToString(callResp.Result[12:32])), nil
+}
+
+// NameHash calculates ENS namehash of the name (https://docs.ens.domains/resolution/names#namehash):
+// namehash("") = 0x00..00, namehash(label.rest) = keccak256(namehash(rest) + keccak256(label))
+func NameHash(name string) [32]byte {
+	var node [32]byte
+
+	if name == "" {
+		return node
+	}
+
+	labels := strings.Split(name, ".")
+	for i := len(labels) - 1; i >= 0; i-- {
+		labelHash := keccak256([]byte(labels[i]))
+		copy(node[:], keccak256(node[:], labelHash))
+	}
+
+	return node
+}
+
+func keccak256(data ...[]byte) []byte {
+	hash := sha3.NewLegacyKeccak256()
+	for _, d := range data {
+		hash.Write(d)
+	}
+
+	return hash.Sum(nil)
+}
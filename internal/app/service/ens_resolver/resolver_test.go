@@ -0,0 +1,72 @@
+package ens_resolver
+
+import (
+	"context"
+	"encoding/hex"
+	ethereum_jsonrpc "github.com/bluntenpassant/ethereum_subscriber/internal/app/client/ethereum-jsonrpc"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/models"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestNameHash(t *testing.T) {
+	// test vectors from https://eips.ethereum.org/EIPS/eip-137
+	testCases := map[string]string{
+		"":            "0000000000000000000000000000000000000000000000000000000000000000",
+		"eth":         "93cdeb708b7545dc668eb9280176169d1c33cfd8ed6f04690a0bcc88a93fc4ae",
+		"foo.eth":     "de9b09fd7c5f901e23a3f19fecc54828e9c848539801e86591bd9801b019f84f",
+		"vitalik.eth": "ee6c4522aab0003e8d14cd40a6af439055fd2577951148c14b6cea9a53475835",
+	}
+
+	for name, expectedNode := range testCases {
+		node := NameHash(name)
+		assert.Equal(t, expectedNode, hex.EncodeToString(node[:]), name)
+	}
+}
+
+func TestMethodSelectors(t *testing.T) {
+	assert.Equal(t, keccak256([]byte("resolver(bytes32)"))[:4], resolverMethodSelector)
+	assert.Equal(t, keccak256([]byte("addr(bytes32)"))[:4], addrMethodSelector)
+}
+
+// ethereumJsonRPCClientMock returns predefined addresses for calls to contracts
+type ethereumJsonRPCClientMock struct {
+	addressByContract map[string]string
+}
+
+func (c *ethereumJsonRPCClientMock) Call(ctx context.Context, req *ethereum_jsonrpc.CallReq) (*ethereum_jsonrpc.CallResp, error) {
+	address, _ := hex.DecodeString(c.addressByContract[req.To][2:])
+
+	result := make([]byte, 32)
+	copy(result[12:], address)
+
+	return &ethereum_jsonrpc.CallResp{Result: result}, nil
+}
+
+func TestResolver_Resolve(t *testing.T) {
+	ctx := context.TODO()
+
+	registry := "0x00000000000c2e074ec69a0dfb2997ba6c7d2e1e"
+	resolver := "0x231b0ee14048e9dccd1d247744d114a4eb5e8e63"
+	address := "0xd8da6bf26964af9d7eed9e03e53415d37aa96045"
+
+	ensResolver := NewResolver(&ethereumJsonRPCClientMock{addressByContract: map[string]string{
+		registry: resolver,
+		resolver: address,
+	}}, models.Address(registry))
+
+	resolvedAddress, err := ensResolver.Resolve(ctx, " Vitalik.eth\n")
+	assert.NoError(t, err)
+	assert.Equal(t, models.Address(address), resolvedAddress)
+
+	// registry returns zero address for not registered names
+	ensResolver = NewResolver(&ethereumJsonRPCClientMock{addressByContract: map[string]string{
+		registry: string(zeroAddress),
+	}}, models.Address(registry))
+
+	_, err = ensResolver.Resolve(ctx, "not-registered.eth")
+	assert.Error(t, err)
+
+	_, err = ensResolver.Resolve(ctx, "vitalik..eth")
+	assert.Error(t, err)
+}
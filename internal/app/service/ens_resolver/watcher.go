@@ -0,0 +1,114 @@
+package ens_resolver
+
+import (
+	"context"
+	"errors"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/models"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/tenant"
+	"log/slog"
+	"time"
+)
+
+type ENSResolver interface {
+	Resolve(ctx context.Context, name string) (models.Address, error)
+}
+
+type Parser interface {
+	GetSubscribers(ctx context.Context) ([]models.Subscriber, error)
+}
+
+// SubscriptionQuota subscribes addresses within maximum count of subscriptions of a tenant on all chains
+type SubscriptionQuota interface {
+	Subscribe(ctx context.Context, chainID uint64, address models.Address, ensName string) error
+}
+
+// Watcher periodically re-resolves ENS names of subscribers of the chain, because owner of the name
+// can point it to another address at any moment
+type Watcher struct {
+	resolver          ENSResolver
+	parser            Parser
+	subscriptionQuota SubscriptionQuota
+	chainID           uint64
+	logger            *slog.Logger
+}
+
+func NewWatcher(resolver ENSResolver, parser Parser, subscriptionQuota SubscriptionQuota, chainID uint64, logger *slog.Logger) *Watcher {
+	return &Watcher{
+		resolver:          resolver,
+		parser:            parser,
+		subscriptionQuota: subscriptionQuota,
+		chainID:           chainID,
+		logger:            logger,
+	}
+}
+
+// Run re-resolves ENS names every interval until ctx is done.
+// Errors are logged and do not stop the loop
+func (w *Watcher) Run(ctx context.Context, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+
+		err := w.CheckNames(ctx)
+		if err != nil {
+			w.logger.ErrorContext(ctx, "re-resolving ens names failed", "error", err.Error())
+		}
+	}
+}
+
+// CheckNames resolves ENS names of all subscribers again and if a name points to a new address,
+// the new address is subscribed with the same name by the same tenant. Subscription of the old address is kept,
+// so already collected transactions stay available. New address is not subscribed if tenant has reached its quota
+func (w *Watcher) CheckNames(ctx context.Context) error {
+	subscribers, err := w.parser.GetSubscribers(ctx)
+	if err != nil {
+		return err
+	}
+
+	subscribed := make(map[models.SubscriberKey]struct{}, len(subscribers))
+	for _, subscriber := range subscribers {
+		subscribed[subscriber.Key()] = struct{}{}
+	}
+
+	for _, subscriber := range subscribers {
+		if subscriber.ENSName == "" {
+			continue
+		}
+
+		address, err := w.resolver.Resolve(ctx, subscriber.ENSName)
+		if err != nil {
+			w.logger.WarnContext(ctx, "resolving ens name failed", "ens_name", subscriber.ENSName, "error", err.Error())
+			continue
+		}
+
+		key := models.SubscriberKey{TenantID: subscriber.TenantID, Address: address}
+		if _, ok := subscribed[key]; ok {
+			continue
+		}
+
+		err = w.subscriptionQuota.Subscribe(tenant.WithID(ctx, subscriber.TenantID), w.chainID, address, subscriber.ENSName)
+		if errors.Is(err, models.ErrQuotaExceeded) {
+			w.logger.WarnContext(ctx, "ens name points to new address, but tenant has reached quota", "ens_name", subscriber.ENSName,
+				"address", address.String(), "tenant", subscriber.TenantID)
+			continue
+		}
+
+		if err != nil {
+			w.logger.ErrorContext(ctx, "subscribing new address of ens name failed", "ens_name", subscriber.ENSName,
+				"address", address.String(), "error", err.Error())
+			continue
+		}
+
+		subscribed[key] = struct{}{}
+		w.logger.InfoContext(ctx, "ens name points to new address, new address is subscribed", "ens_name", subscriber.ENSName,
+			"address", address.String())
+	}
+
+	return nil
+}
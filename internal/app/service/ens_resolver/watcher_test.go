@@ -0,0 +1,74 @@
+package ens_resolver
+
+import (
+	"context"
+	"github.com/bluntenpassant/ethereum_subscriber/config"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/models"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/repository/memory_repository"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/service/rate_limiter"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/service/subscription_quota"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/tenant"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"log/slog"
+	"testing"
+)
+
+// repositoryParser subscribes addresses straight into memory repository
+type repositoryParser struct {
+	*memory_repository.SubscriberRepository
+}
+
+func (p repositoryParser) Subscribe(ctx context.Context, address models.Address, ensName string) error {
+	return p.AddNewSubscriber(ctx, models.Subscriber{Address: address, ENSName: ensName, TenantID: tenant.FromContext(ctx)})
+}
+
+func (p repositoryParser) GetSubscriber(ctx context.Context, address models.Address) (models.Subscriber, error) {
+	return p.GetSubscriberByAddress(ctx, address)
+}
+
+// resolverMock resolves every name into the same address
+type resolverMock struct {
+	address models.Address
+}
+
+func (r resolverMock) Resolve(ctx context.Context, name string) (models.Address, error) {
+	return r.address, nil
+}
+
+func TestWatcher_CheckNames(t *testing.T) {
+	const (
+		oldAddress = models.Address("0xd8da6bf26964af9d7eed9e03e53415d37aa96045")
+		newAddress = models.Address("0xdef1c0ded9bec7f1a1670819833240f027b25eff")
+	)
+
+	type TestCase struct {
+		name                string
+		maxSubscriptions    int
+		expectedSubscribers int
+	}
+
+	testCases := []TestCase{
+		{name: "new address is subscribed", maxSubscriptions: 0, expectedSubscribers: 2},
+		{name: "quota is reached", maxSubscriptions: 1, expectedSubscribers: 1},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctx := tenant.WithID(context.Background(), "tenant_a")
+
+			parser := repositoryParser{memory_repository.NewSubscriberRepository()}
+			assert.NoError(t, parser.Subscribe(ctx, oldAddress, "vitalik.eth"))
+
+			quota := subscription_quota.NewQuota(map[uint64]subscription_quota.Parser{1: parser},
+				rate_limiter.NewLimiter(config.RateLimit{MaxSubscriptionsPerTenant: testCase.maxSubscriptions}))
+			watcher := NewWatcher(resolverMock{address: newAddress}, parser, quota, 1, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+			assert.NoError(t, watcher.CheckNames(context.Background()))
+
+			count, err := parser.CountSubscribers(ctx)
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expectedSubscribers, count)
+		})
+	}
+}
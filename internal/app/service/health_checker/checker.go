@@ -0,0 +1,172 @@
+package health_checker
+
+import (
+	"context"
+	"errors"
+	ethereum_jsonrpc "github.com/bluntenpassant/ethereum_subscriber/internal/app/client/ethereum-jsonrpc"
+	redis2 "github.com/redis/go-redis/v9"
+	"strconv"
+	"time"
+)
+
+// Statuses of components and overall readiness
+const (
+	StatusOK   = "ok"
+	StatusFail = "fail"
+)
+
+// Names of checked components in readiness report
+const (
+	redisComponent           = "redis"
+	ethereumJsonRPCComponent = "ethereum_jsonrpc"
+	indexingLagComponent     = "indexing_lag"
+)
+
+// defaultCheckTimeout is used when timeout of checks is not configured
+const defaultCheckTimeout = 3 * time.Second
+
+type EthereumJsonRPCClient interface {
+	GetBlockNumber(ctx context.Context) (*ethereum_jsonrpc.GetBlockNumberResp, error)
+}
+
+type Parser interface {
+	GetCurrentBlock(ctx context.Context) (uint64, error)
+}
+
+// ComponentStatus represents result of a single dependency check
+type ComponentStatus struct {
+	Status  string `json:"status"`
+	Details string `json:"details,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+// Report represents readiness of the service with status of every checked component.
+// Service is ready only if all components are ok
+type Report struct {
+	Status     string                     `json:"status"`
+	Components map[string]ComponentStatus `json:"components"`
+}
+
+// Checker checks dependencies of the service that are required for handling requests
+type Checker struct {
+	redis                 *redis2.Client
+	ethereumJsonRPCClient EthereumJsonRPCClient
+	parser                Parser
+
+	maxIndexingLag uint64
+	checkTimeout   time.Duration
+}
+
+// NewChecker returns Checker, redis could be nil if redis storage is not used.
+// maxIndexingLag = 0 disables indexing lag check
+func NewChecker(redis *redis2.Client, ethereumJsonRPCClient EthereumJsonRPCClient, parser Parser, maxIndexingLag uint64, checkTimeout time.Duration) *Checker {
+	if checkTimeout <= 0 {
+		checkTimeout = defaultCheckTimeout
+	}
+
+	return &Checker{
+		redis:                 redis,
+		ethereumJsonRPCClient: ethereumJsonRPCClient,
+		parser:                parser,
+		maxIndexingLag:        maxIndexingLag,
+		checkTimeout:          checkTimeout,
+	}
+}
+
+// Check runs all checks, every check is limited by configured timeout
+func (c *Checker) Check(ctx context.Context) Report {
+	report := Report{
+		Status:     StatusOK,
+		Components: make(map[string]ComponentStatus),
+	}
+
+	if c.redis != nil {
+		report.Components[redisComponent] = c.checkRedis(ctx)
+	}
+
+	headBlock, rpcStatus := c.checkEthereumJsonRPC(ctx)
+	report.Components[ethereumJsonRPCComponent] = rpcStatus
+
+	if c.maxIndexingLag > 0 {
+		report.Components[indexingLagComponent] = c.checkIndexingLag(ctx, headBlock, rpcStatus.Status == StatusOK)
+	}
+
+	for _, component := range report.Components {
+		if component.Status != StatusOK {
+			report.Status = StatusFail
+		}
+	}
+
+	return report
+}
+
+func (c *Checker) checkRedis(ctx context.Context) ComponentStatus {
+	ctx, cancel := context.WithTimeout(ctx, c.checkTimeout)
+	defer cancel()
+
+	err := c.redis.Ping(ctx).Err()
+	if err != nil {
+		return failStatus(err)
+	}
+
+	return ComponentStatus{Status: StatusOK}
+}
+
+// checkEthereumJsonRPC requests the head block of Ethereum Network, it is used later for indexing lag check
+func (c *Checker) checkEthereumJsonRPC(ctx context.Context) (uint64, ComponentStatus) {
+	ctx, cancel := context.WithTimeout(ctx, c.checkTimeout)
+	defer cancel()
+
+	resp, err := c.ethereumJsonRPCClient.GetBlockNumber(ctx)
+	if err != nil {
+		if ctx.Err() != nil {
+			return 0, failStatus(errors.New("ethereum node did not respond in " + c.checkTimeout.String()))
+		}
+
+		return 0, failStatus(err)
+	}
+
+	headBlock := uint64(resp.BlockNumber)
+
+	return headBlock, ComponentStatus{
+		Status:  StatusOK,
+		Details: "head block " + strconv.FormatUint(headBlock, 10),
+	}
+}
+
+// checkIndexingLag compares the head block with the last block handled by parser.
+// Lag could not be calculated without the head block, and it is not checked until parser handled any block
+func (c *Checker) checkIndexingLag(ctx context.Context, headBlock uint64, isHeadKnown bool) ComponentStatus {
+	if !isHeadKnown {
+		return failStatus(errors.New("head block is unknown"))
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.checkTimeout)
+	defer cancel()
+
+	currentBlock, err := c.parser.GetCurrentBlock(ctx)
+	if err != nil {
+		return failStatus(err)
+	}
+
+	if currentBlock == 0 {
+		return ComponentStatus{Status: StatusOK, Details: "no blocks handled yet"}
+	}
+
+	var lag uint64
+	if headBlock > currentBlock {
+		lag = headBlock - currentBlock
+	}
+
+	details := "lag " + strconv.FormatUint(lag, 10) + " blocks, threshold " + strconv.FormatUint(c.maxIndexingLag, 10)
+
+	if lag > c.maxIndexingLag {
+		return ComponentStatus{Status: StatusFail, Details: details, Error: "indexing lag exceeds threshold"}
+	}
+
+	return ComponentStatus{Status: StatusOK, Details: details}
+}
+
+func failStatus(err error) ComponentStatus {
+	return ComponentStatus{Status: StatusFail, Error: err.Error()}
+}
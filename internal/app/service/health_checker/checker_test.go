@@ -0,0 +1,116 @@
+package health_checker
+
+import (
+	"context"
+	"errors"
+	ethereum_jsonrpc "github.com/bluntenpassant/ethereum_subscriber/internal/app/client/ethereum-jsonrpc"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/client/ethereum-jsonrpc/models"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+type ethereumJsonRPCClientMock struct {
+	headBlock uint64
+	err       error
+	delay     time.Duration
+}
+
+func (c *ethereumJsonRPCClientMock) GetBlockNumber(ctx context.Context) (*ethereum_jsonrpc.GetBlockNumberResp, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-time.After(c.delay):
+	}
+
+	if c.err != nil {
+		return nil, c.err
+	}
+
+	return &ethereum_jsonrpc.GetBlockNumberResp{BlockNumber: models.HexUint64(c.headBlock)}, nil
+}
+
+type parserMock struct {
+	currentBlock uint64
+}
+
+func (p *parserMock) GetCurrentBlock(ctx context.Context) (uint64, error) {
+	return p.currentBlock, nil
+}
+
+func TestChecker_Check(t *testing.T) {
+	ctx := context.TODO()
+
+	type TestCase struct {
+		Name                  string
+		EthereumJsonRPCClient *ethereumJsonRPCClientMock
+		CurrentBlock          uint64
+		MaxIndexingLag        uint64
+		ExpectedStatus        string
+		ExpectedComponents    map[string]string
+	}
+
+	testCases := []TestCase{
+		{
+			Name:                  "everything is ok",
+			EthereumJsonRPCClient: &ethereumJsonRPCClientMock{headBlock: 110},
+			CurrentBlock:          100,
+			MaxIndexingLag:        10,
+			ExpectedStatus:        StatusOK,
+			ExpectedComponents:    map[string]string{ethereumJsonRPCComponent: StatusOK, indexingLagComponent: StatusOK},
+		},
+		{
+			Name:                  "lag exceeds threshold",
+			EthereumJsonRPCClient: &ethereumJsonRPCClientMock{headBlock: 111},
+			CurrentBlock:          100,
+			MaxIndexingLag:        10,
+			ExpectedStatus:        StatusFail,
+			ExpectedComponents:    map[string]string{ethereumJsonRPCComponent: StatusOK, indexingLagComponent: StatusFail},
+		},
+		{
+			Name:                  "lag is not checked before first handled block",
+			EthereumJsonRPCClient: &ethereumJsonRPCClientMock{headBlock: 111},
+			CurrentBlock:          0,
+			MaxIndexingLag:        10,
+			ExpectedStatus:        StatusOK,
+			ExpectedComponents:    map[string]string{ethereumJsonRPCComponent: StatusOK, indexingLagComponent: StatusOK},
+		},
+		{
+			Name:                  "lag check is disabled",
+			EthereumJsonRPCClient: &ethereumJsonRPCClientMock{headBlock: 1000},
+			CurrentBlock:          100,
+			MaxIndexingLag:        0,
+			ExpectedStatus:        StatusOK,
+			ExpectedComponents:    map[string]string{ethereumJsonRPCComponent: StatusOK},
+		},
+		{
+			Name:                  "ethereum node is unavailable",
+			EthereumJsonRPCClient: &ethereumJsonRPCClientMock{err: errors.New("connection refused")},
+			CurrentBlock:          100,
+			MaxIndexingLag:        10,
+			ExpectedStatus:        StatusFail,
+			ExpectedComponents:    map[string]string{ethereumJsonRPCComponent: StatusFail, indexingLagComponent: StatusFail},
+		},
+		{
+			Name:                  "ethereum node does not respond in time",
+			EthereumJsonRPCClient: &ethereumJsonRPCClientMock{headBlock: 110, delay: 100 * time.Millisecond},
+			CurrentBlock:          100,
+			MaxIndexingLag:        10,
+			ExpectedStatus:        StatusFail,
+			ExpectedComponents:    map[string]string{ethereumJsonRPCComponent: StatusFail, indexingLagComponent: StatusFail},
+		},
+	}
+
+	for _, testCase := range testCases {
+		checker := NewChecker(nil, testCase.EthereumJsonRPCClient, &parserMock{currentBlock: testCase.CurrentBlock}, testCase.MaxIndexingLag, 10*time.Millisecond)
+
+		report := checker.Check(ctx)
+		assert.Equal(t, testCase.ExpectedStatus, report.Status, testCase.Name)
+
+		components := make(map[string]string, len(report.Components))
+		for name, component := range report.Components {
+			components[name] = component.Status
+		}
+		assert.Equal(t, testCase.ExpectedComponents, components, testCase.Name)
+	}
+}
@@ -0,0 +1,452 @@
+package indexed_parser
+
+import (
+	"context"
+	ethereum_jsonrpc "github.com/bluntenpassant/ethereum_subscriber/internal/app/client/ethereum-jsonrpc"
+	ethereum_jsonrpc_models "github.com/bluntenpassant/ethereum_subscriber/internal/app/client/ethereum-jsonrpc/models"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/metrics"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/models"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/tenant"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"log/slog"
+	"math/big"
+	"sync"
+	"time"
+)
+
+// parserName is a label of parser in metrics
+const parserName = "indexed"
+
+// defaultPollInterval is used by Run when interval is not configured.
+// It is close to the average block time in Ethereum Network
+const defaultPollInterval = 12 * time.Second
+
+type EthereumJsonRPCClient interface {
+	GetBlockByNumber(ctx context.Context, req *ethereum_jsonrpc.GetBlockByNumberReq) (*ethereum_jsonrpc.GetBlockByNumberResp, error)
+	GetBlockNumber(ctx context.Context) (*ethereum_jsonrpc.GetBlockNumberResp, error)
+	GetBalance(ctx context.Context, req *ethereum_jsonrpc.GetBalanceReq) (*ethereum_jsonrpc.GetBalanceResp, error)
+}
+
+type SubscriberRepository interface {
+	GetTransactionsReversed(ctx context.Context, address models.Address) ([]*models.Transaction, error)
+	ForEachTransaction(ctx context.Context, address models.Address, fn func(tx *models.Transaction) error) error
+	AddNewSubscriber(ctx context.Context, subscriber models.Subscriber) error
+	GetSubscriberByAddress(ctx context.Context, address models.Address) (models.Subscriber, error)
+	GetSubscribers(ctx context.Context) ([]models.Subscriber, error)
+	CountSubscribers(ctx context.Context) (int, error)
+	DeleteSubscriber(ctx context.Context, address models.Address) error
+	AddBlockTransactions(ctx context.Context, blockNumber uint64, txsBySubscriber map[models.SubscriberKey][]*models.Transaction) error
+}
+
+type BlockRepository interface {
+	SetMaxCurrentBlock(ctx context.Context, newCurrentBlock uint64) error
+	GetCurrentBlock(ctx context.Context) (uint64, error)
+}
+
+type BalanceRepository interface {
+	AddBalance(ctx context.Context, address models.Address, balance models.Balance) error
+	GetBalanceHistory(ctx context.Context, address models.Address) ([]models.Balance, error)
+	DeleteBalanceHistory(ctx context.Context, address models.Address) error
+}
+
+type Parser struct {
+	// chainID is stamped on subscribers and transactions handled by the parser
+	chainID               uint64
+	ethereumJsonRPCClient EthereumJsonRPCClient
+	subscriberRepository  SubscriberRepository
+	blockRepository       BlockRepository
+	balanceRepository     BalanceRepository
+	logger                *slog.Logger
+
+	// indexMx guarantees that only one indexing pass is running at a time,
+	// so background indexing and user requests never handle the same block twice
+	indexMx sync.Mutex
+}
+
+func NewParser(chainID uint64, ethereumJsonRPCClient EthereumJsonRPCClient, subscriberRepository SubscriberRepository, blockRepository BlockRepository,
+	balanceRepository BalanceRepository, logger *slog.Logger) *Parser {
+	return &Parser{
+		chainID:               chainID,
+		ethereumJsonRPCClient: ethereumJsonRPCClient,
+		subscriberRepository:  subscriberRepository,
+		blockRepository:       blockRepository,
+		balanceRepository:     balanceRepository,
+		logger:                logger.With("parser", parserName, "chain_id", chainID),
+	}
+}
+
+func (p *Parser) GetCurrentBlock(ctx context.Context) (uint64, error) {
+	currentBlock, err := p.blockRepository.GetCurrentBlock(ctx)
+
+	return currentBlock, err
+}
+
+func (p *Parser) GetSubscribers(ctx context.Context) ([]models.Subscriber, error) {
+	subscribers, err := p.subscriberRepository.GetSubscribers(ctx)
+
+	return subscribers, err
+}
+
+// CountSubscribers returns count of subscriptions of the tenant from ctx
+func (p *Parser) CountSubscribers(ctx context.Context) (int, error) {
+	return p.subscriberRepository.CountSubscribers(ctx)
+}
+
+// GetSubscriber returns subscription of address by tenant from ctx
+func (p *Parser) GetSubscriber(ctx context.Context, address models.Address) (models.Subscriber, error) {
+	subscriber, err := p.subscriberRepository.GetSubscriberByAddress(ctx, address)
+
+	return subscriber, err
+}
+
+// Unsubscribe removes subscription of address by tenant from ctx with its transactions and balance history
+func (p *Parser) Unsubscribe(ctx context.Context, address models.Address) error {
+	err := p.removeSubscriber(ctx, address)
+	if err != nil {
+		return err
+	}
+
+	p.logger.InfoContext(ctx, "unsubscribed", "address", address.String())
+
+	return nil
+}
+
+// removeSubscriber deletes subscriber of address by tenant from ctx with its transactions and balance history
+func (p *Parser) removeSubscriber(ctx context.Context, address models.Address) error {
+	// Indexing pass is not running during removal, otherwise it could record balance of removed subscriber
+	p.indexMx.Lock()
+	defer p.indexMx.Unlock()
+
+	err := p.subscriberRepository.DeleteSubscriber(ctx, address)
+	if err != nil {
+		return err
+	}
+
+	return p.balanceRepository.DeleteBalanceHistory(ctx, address)
+}
+
+// Subscribe registers address with a cursor on the current block in Ethereum Network,
+// so only transactions from the next blocks will be indexed for the subscriber.
+// Balance of the address at the current block becomes the first entry of its balance history
+func (p *Parser) Subscribe(ctx context.Context, address models.Address, ensName string) error {
+	blockNumberResp, err := p.ethereumJsonRPCClient.GetBlockNumber(ctx)
+	if err != nil {
+		return models.WrapError("error getting current block number", err)
+	}
+
+	balance, err := p.getBalance(ctx, address, uint64(blockNumberResp.BlockNumber))
+	if err != nil {
+		return err
+	}
+
+	err = p.subscriberRepository.AddNewSubscriber(ctx, models.Subscriber{
+		ChainID:              p.chainID,
+		Address:              address,
+		SubscribeBlockNumber: uint64(blockNumberResp.BlockNumber),
+		SubscribeTxCount:     0,
+		ENSName:              ensName,
+		TenantID:             tenant.FromContext(ctx),
+	})
+	if err != nil {
+		return err
+	}
+
+	err = p.balanceRepository.AddBalance(ctx, address, balance)
+	if err != nil {
+		// Subscriber without the first balance is removed, so subscription can be retried instead of failing
+		// with models.ErrAlreadySubscribed. Removal is not cancelled with request, it may be the reason of the failure
+		rollbackErr := p.removeSubscriber(context.WithoutCancel(ctx), address)
+		if rollbackErr != nil {
+			p.logger.ErrorContext(ctx, "rolling back subscription failed", "address", address.String(), "error", rollbackErr.Error())
+		}
+
+		return err
+	}
+
+	p.logger.InfoContext(ctx, "subscribed", "address", address.String(), "ens_name", ensName,
+		"block", uint64(blockNumberResp.BlockNumber))
+
+	return nil
+}
+
+// GetTransactions returns all transactions of subscriber since subscription, last transaction goes first.
+// Before reading transactions from storage, method indexes all blocks that appeared since the last indexing pass,
+// so result is always actual even if background indexing is not running.
+func (p *Parser) GetTransactions(ctx context.Context, address models.Address) ([]*models.Transaction, error) {
+	ctx, span := tracing.Start(ctx, "indexed_parser.GetTransactions", attribute.String("address", address.String()))
+	defer span.End()
+
+	_, err := p.subscriberRepository.GetSubscriberByAddress(ctx, address)
+	if err != nil {
+		return nil, tracing.Error(span, err)
+	}
+
+	err = p.IndexNewBlocks(ctx)
+	if err != nil {
+		return nil, tracing.Error(span, err)
+	}
+
+	readCtx, readSpan := tracing.Start(ctx, "read")
+	defer readSpan.End()
+
+	transactions, err := p.subscriberRepository.GetTransactionsReversed(readCtx, address)
+	if err != nil {
+		tracing.Error(readSpan, err)
+		return nil, tracing.Error(span, err)
+	}
+
+	return transactions, nil
+}
+
+// ForEachTransaction indexes new blocks like GetTransactions and calls fn for every stored transaction of subscriber
+// in chronological order, so the whole history is not loaded in memory. Iteration stops on the first error of fn
+func (p *Parser) ForEachTransaction(ctx context.Context, address models.Address, fn func(tx *models.Transaction) error) error {
+	ctx, span := tracing.Start(ctx, "indexed_parser.ForEachTransaction", attribute.String("address", address.String()))
+	defer span.End()
+
+	_, err := p.subscriberRepository.GetSubscriberByAddress(ctx, address)
+	if err != nil {
+		return tracing.Error(span, err)
+	}
+
+	err = p.IndexNewBlocks(ctx)
+	if err != nil {
+		return tracing.Error(span, err)
+	}
+
+	err = p.subscriberRepository.ForEachTransaction(ctx, address, fn)
+	if err != nil {
+		return tracing.Error(span, err)
+	}
+
+	return nil
+}
+
+// GetBalance returns the last recorded balance of subscriber. Like GetTransactions it indexes new blocks first,
+// so balance is actual at the current block
+func (p *Parser) GetBalance(ctx context.Context, address models.Address) (models.Balance, error) {
+	history, err := p.GetBalanceHistory(ctx, address)
+	if err != nil {
+		return models.Balance{}, err
+	}
+
+	if len(history) == 0 {
+		return models.Balance{}, models.ErrBalanceNotRecorded
+	}
+
+	return history[len(history)-1], nil
+}
+
+// GetBalanceHistory returns balances of subscriber since subscription, the first recorded balance goes first.
+// Balance is recorded at subscription and after every block with transactions of the subscriber
+func (p *Parser) GetBalanceHistory(ctx context.Context, address models.Address) ([]models.Balance, error) {
+	ctx, span := tracing.Start(ctx, "indexed_parser.GetBalanceHistory", attribute.String("address", address.String()))
+	defer span.End()
+
+	_, err := p.subscriberRepository.GetSubscriberByAddress(ctx, address)
+	if err != nil {
+		return nil, tracing.Error(span, err)
+	}
+
+	err = p.IndexNewBlocks(ctx)
+	if err != nil {
+		return nil, tracing.Error(span, err)
+	}
+
+	history, err := p.balanceRepository.GetBalanceHistory(ctx, address)
+	if err != nil {
+		return nil, tracing.Error(span, err)
+	}
+
+	return history, nil
+}
+
+// Run indexes new blocks every interval until ctx is done.
+// Errors of a single indexing pass are logged and do not stop the loop, because the next pass
+// will continue from the cursors that were successfully saved.
+func (p *Parser) Run(ctx context.Context, interval time.Duration) error {
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		err := p.IndexNewBlocks(ctx)
+		// Pass interrupted by ctx is not an error, not indexed blocks will be handled after restart
+		if err != nil && ctx.Err() == nil {
+			p.logger.ErrorContext(ctx, "indexing new blocks failed", "error", err.Error())
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// IndexNewBlocks handles every block between the oldest subscriber cursor and the current block in Ethereum Network.
+// Algorithm:
+// 1. Get all subscribers and find the oldest cursor between them (lastIndexedBlock)
+// 2. Iterate in range lastIndexedBlock+1..currentBlockNumber, for every block:
+// 2.1. Build a set of addresses of subscribers which cursor is behind the block
+// 2.2. Walk through block transactions once and look up From and To of every transaction in the set
+// 2.3. Save matched transactions grouped by address and move cursors of all matched subscribers to the block in one atomic operation
+// Comparing to other approaches block is requested only once regardless of subscribers count,
+// and every transaction is matched in O(1) against all of them.
+func (p *Parser) IndexNewBlocks(ctx context.Context) error {
+	ctx, span := tracing.Start(ctx, "index")
+	defer span.End()
+
+	p.indexMx.Lock()
+	defer p.indexMx.Unlock()
+
+	subscribers, err := p.subscriberRepository.GetSubscribers(ctx)
+	if err != nil {
+		return tracing.Error(span, err)
+	}
+
+	if len(subscribers) == 0 {
+		return nil
+	}
+
+	currentBlockNumberResp, err := p.ethereumJsonRPCClient.GetBlockNumber(ctx)
+	if err != nil {
+		return tracing.Error(span, err)
+	}
+
+	currentBlockNumber := uint64(currentBlockNumberResp.BlockNumber)
+
+	lastIndexedBlock := subscribers[0].SubscribeBlockNumber
+	for _, subscriber := range subscribers {
+		if subscriber.SubscribeBlockNumber < lastIndexedBlock {
+			lastIndexedBlock = subscriber.SubscribeBlockNumber
+		}
+	}
+
+	if lastIndexedBlock < currentBlockNumber {
+		p.logger.DebugContext(ctx, "indexing new blocks", "from_block", lastIndexedBlock+1, "to_block", currentBlockNumber,
+			"subscribers", len(subscribers))
+	}
+
+	span.SetAttributes(attribute.Int64("from_block", int64(lastIndexedBlock+1)), attribute.Int64("to_block", int64(currentBlockNumber)),
+		attribute.Int("subscribers", len(subscribers)))
+
+	for blockNumber := lastIndexedBlock + 1; blockNumber <= currentBlockNumber; blockNumber++ {
+		select {
+		case <-ctx.Done():
+			return tracing.Error(span, ctx.Err())
+		default:
+		}
+
+		err = p.indexBlock(ctx, blockNumber, subscribers)
+		if err != nil {
+			return tracing.Error(span, err)
+		}
+	}
+
+	return nil
+}
+
+// indexBlock matches all transactions of the block against subscribers that have not indexed it yet
+// and saves the result
+func (p *Parser) indexBlock(ctx context.Context, blockNumber uint64, subscribers []models.Subscriber) error {
+	ctx, span := tracing.Start(ctx, "index_block", attribute.Int64("block_number", int64(blockNumber)))
+	defer span.End()
+
+	// Cursors only move forward in IndexNewBlocks, so subscriber with cursor behind the block
+	// in the snapshot is still behind it. Every matched subscriber gets an entry, even an empty one,
+	// because repository moves cursors only for subscribers presented in the result.
+	// Subscribers registered after the snapshot are left for the next indexing pass.
+	// The same address can be subscribed by several tenants, so matched transaction is saved for every subscription of the address.
+	txsBySubscriber := make(map[models.SubscriberKey][]*models.Transaction, len(subscribers))
+	keysByAddress := make(map[models.Address][]models.SubscriberKey, len(subscribers))
+	for _, subscriber := range subscribers {
+		if subscriber.SubscribeBlockNumber < blockNumber {
+			txsBySubscriber[subscriber.Key()] = []*models.Transaction{}
+			keysByAddress[subscriber.Address] = append(keysByAddress[subscriber.Address], subscriber.Key())
+		}
+	}
+
+	if len(txsBySubscriber) == 0 {
+		return nil
+	}
+
+	blockResp, err := p.ethereumJsonRPCClient.GetBlockByNumber(ctx, &ethereum_jsonrpc.GetBlockByNumberReq{
+		BlockNumber: ethereum_jsonrpc_models.HexUint64(blockNumber),
+		IsGetFullTx: true,
+	})
+	if err != nil {
+		return tracing.Error(span, err)
+	}
+
+	metrics.AddBlocksProcessed(parserName, 1)
+
+	for _, tx := range blockResp.Block.Transactions {
+		from := models.NewAddress(tx.From)
+		to := models.NewAddress(tx.To)
+
+		for _, key := range keysByAddress[from] {
+			txsBySubscriber[key] = append(txsBySubscriber[key], models.ConvertJsonRPCTxToInternal(p.chainID, tx))
+			metrics.AddTransactionsMatched(parserName, 1)
+		}
+
+		// Transaction sent to itself should be saved only once
+		if to == from {
+			continue
+		}
+
+		for _, key := range keysByAddress[to] {
+			txsBySubscriber[key] = append(txsBySubscriber[key], models.ConvertJsonRPCTxToInternal(p.chainID, tx))
+			metrics.AddTransactionsMatched(parserName, 1)
+		}
+	}
+
+	// Balances are recorded before cursors are moved, so if recording fails the block is indexed again
+	// and repository skips balances that were already recorded.
+	// Balance of address is requested once and recorded for every tenant subscribed to it
+	balances := make(map[models.Address]models.Balance)
+	for key, txs := range txsBySubscriber {
+		if len(txs) == 0 {
+			continue
+		}
+
+		balance, ok := balances[key.Address]
+		if !ok {
+			balance, err = p.getBalance(ctx, key.Address, blockNumber)
+			if err != nil {
+				return tracing.Error(span, err)
+			}
+
+			balances[key.Address] = balance
+		}
+
+		err = p.balanceRepository.AddBalance(tenant.WithID(ctx, key.TenantID), key.Address, balance)
+		if err != nil {
+			return tracing.Error(span, err)
+		}
+	}
+
+	err = p.subscriberRepository.AddBlockTransactions(ctx, blockNumber, txsBySubscriber)
+	if err != nil {
+		return tracing.Error(span, err)
+	}
+
+	return tracing.Error(span, p.blockRepository.SetMaxCurrentBlock(ctx, blockNumber))
+}
+
+// getBalance requests balance of address at the end of the block from Ethereum Network
+func (p *Parser) getBalance(ctx context.Context, address models.Address, blockNumber uint64) (models.Balance, error) {
+	balanceResp, err := p.ethereumJsonRPCClient.GetBalance(ctx, &ethereum_jsonrpc.GetBalanceReq{
+		Address:     address.String(),
+		BlockNumber: ethereum_jsonrpc_models.HexUint64(blockNumber),
+	})
+	if err != nil {
+		return models.Balance{}, models.WrapError("error getting balance", err)
+	}
+
+	return models.Balance{BlockNumber: blockNumber, Balance: big.Int(balanceResp.Balance)}, nil
+}
@@ -0,0 +1,405 @@
+package indexed_parser
+
+import (
+	"context"
+	"errors"
+	ethereum_jsonrpc "github.com/bluntenpassant/ethereum_subscriber/internal/app/client/ethereum-jsonrpc"
+	ethereum_jsonrpc_models "github.com/bluntenpassant/ethereum_subscriber/internal/app/client/ethereum-jsonrpc/models"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/models"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/repository/greedy_memory_repository"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/tenant"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"log/slog"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+)
+
+const (
+	aliceAddress = models.Address("0x45849a974058661eb2128aceb60d2c6ed99e2a14")
+	bobAddress   = models.Address("0x7a250d5630b4cf539739df2c5dacb4c659f2488d")
+	otherAddress = models.Address("0x00000000219ab540356cbb839cbe05303d7705fa")
+)
+
+// ethereumJsonRPCClientMock serves blocks up to headBlock and balances of addresses, requests are recorded
+type ethereumJsonRPCClientMock struct {
+	mx        sync.Mutex
+	headBlock uint64
+	blocks    map[uint64][]*ethereum_jsonrpc.Transaction
+	balances  map[models.Address]int64
+	// balanceErr is returned by GetBalance of failingAddress
+	failingAddress models.Address
+	balanceErr     error
+
+	blockRequests   []uint64
+	balanceRequests []models.Address
+}
+
+func (c *ethereumJsonRPCClientMock) GetBlockByNumber(ctx context.Context, req *ethereum_jsonrpc.GetBlockByNumberReq) (*ethereum_jsonrpc.GetBlockByNumberResp, error) {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+
+	c.blockRequests = append(c.blockRequests, uint64(req.BlockNumber))
+
+	return &ethereum_jsonrpc.GetBlockByNumberResp{Block: ethereum_jsonrpc.Block{Transactions: c.blocks[uint64(req.BlockNumber)]}}, nil
+}
+
+func (c *ethereumJsonRPCClientMock) GetBlockNumber(ctx context.Context) (*ethereum_jsonrpc.GetBlockNumberResp, error) {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+
+	return &ethereum_jsonrpc.GetBlockNumberResp{BlockNumber: ethereum_jsonrpc_models.HexUint64(c.headBlock)}, nil
+}
+
+func (c *ethereumJsonRPCClientMock) GetBalance(ctx context.Context, req *ethereum_jsonrpc.GetBalanceReq) (*ethereum_jsonrpc.GetBalanceResp, error) {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+
+	address := models.NewAddress(req.Address)
+	c.balanceRequests = append(c.balanceRequests, address)
+
+	if address == c.failingAddress {
+		return nil, c.balanceErr
+	}
+
+	return &ethereum_jsonrpc.GetBalanceResp{Balance: ethereum_jsonrpc_models.HexBigInt(*big.NewInt(c.balances[address]))}, nil
+}
+
+func newTx(hash string, blockNumber uint64, from models.Address, to models.Address) *ethereum_jsonrpc.Transaction {
+	return &ethereum_jsonrpc.Transaction{Hash: hash, BlockNumber: ethereum_jsonrpc_models.HexUint64(blockNumber), From: from.String(), To: to.String()}
+}
+
+func newTestParser(client *ethereumJsonRPCClientMock, subscriberRepository SubscriberRepository) *Parser {
+	return NewParser(1, client, subscriberRepository, greedy_memory_repository.NewBlockRepository(), greedy_memory_repository.NewBalanceRepository(),
+		slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+func addSubscribers(t *testing.T, subscriberRepository SubscriberRepository, subscribers ...models.Subscriber) {
+	for _, subscriber := range subscribers {
+		assert.NoError(t, subscriberRepository.AddNewSubscriber(tenant.WithID(context.TODO(), subscriber.TenantID), subscriber))
+	}
+}
+
+func getHashes(t *testing.T, parser *Parser, tenantID string, address models.Address) []string {
+	txs, err := parser.GetTransactions(tenant.WithID(context.TODO(), tenantID), address)
+	assert.NoError(t, err)
+
+	hashes := make([]string, 0, len(txs))
+	for _, tx := range txs {
+		hashes = append(hashes, tx.Hash)
+	}
+
+	return hashes
+}
+
+func getCursors(t *testing.T, subscriberRepository SubscriberRepository) map[models.SubscriberKey]uint64 {
+	subscribers, err := subscriberRepository.GetSubscribers(context.TODO())
+	assert.NoError(t, err)
+
+	cursors := make(map[models.SubscriberKey]uint64, len(subscribers))
+	for _, subscriber := range subscribers {
+		cursors[subscriber.Key()] = subscriber.SubscribeBlockNumber
+	}
+
+	return cursors
+}
+
+func TestParser_IndexNewBlocks(t *testing.T) {
+	ctx := context.TODO()
+
+	client := &ethereumJsonRPCClientMock{
+		headBlock: 103,
+		blocks: map[uint64][]*ethereum_jsonrpc.Transaction{
+			101: {newTx("0x01", 101, aliceAddress, otherAddress)},
+			// Transaction to itself and contract creation without receiver
+			102: {newTx("0x02", 102, bobAddress, bobAddress), newTx("0x03", 102, aliceAddress, "")},
+			103: {newTx("0x04", 103, otherAddress, aliceAddress)},
+		},
+	}
+
+	subscriberRepository := greedy_memory_repository.NewSubscriberRepository()
+	parser := newTestParser(client, subscriberRepository)
+
+	// The same address is subscribed by two tenants with different cursors
+	addSubscribers(t, subscriberRepository,
+		models.Subscriber{TenantID: "tenant_a", ChainID: 1, Address: aliceAddress, SubscribeBlockNumber: 100},
+		models.Subscriber{TenantID: "tenant_b", ChainID: 1, Address: aliceAddress, SubscribeBlockNumber: 102},
+		models.Subscriber{TenantID: "tenant_a", ChainID: 1, Address: bobAddress, SubscribeBlockNumber: 101},
+	)
+
+	assert.NoError(t, parser.IndexNewBlocks(ctx))
+
+	// Every block is requested once for all subscribers
+	assert.Equal(t, []uint64{101, 102, 103}, client.blockRequests)
+
+	assert.Equal(t, []string{"0x04", "0x03", "0x01"}, getHashes(t, parser, "tenant_a", aliceAddress))
+	assert.Equal(t, []string{"0x04"}, getHashes(t, parser, "tenant_b", aliceAddress))
+	assert.Equal(t, []string{"0x02"}, getHashes(t, parser, "tenant_a", bobAddress))
+
+	assert.Equal(t, map[models.SubscriberKey]uint64{
+		{TenantID: "tenant_a", Address: aliceAddress}: 103,
+		{TenantID: "tenant_b", Address: aliceAddress}: 103,
+		{TenantID: "tenant_a", Address: bobAddress}:   103,
+	}, getCursors(t, subscriberRepository))
+
+	currentBlock, err := parser.GetCurrentBlock(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(103), currentBlock)
+
+	// Indexed blocks are not requested again
+	assert.NoError(t, parser.IndexNewBlocks(ctx))
+	assert.Equal(t, []uint64{101, 102, 103}, client.blockRequests)
+}
+
+func TestParser_IndexNewBlocksFailure(t *testing.T) {
+	ctx := context.TODO()
+
+	client := &ethereumJsonRPCClientMock{
+		headBlock: 101,
+		blocks: map[uint64][]*ethereum_jsonrpc.Transaction{
+			101: {newTx("0x01", 101, aliceAddress, bobAddress)},
+		},
+		failingAddress: bobAddress,
+		balanceErr:     errors.New("connection refused"),
+	}
+
+	subscriberRepository := greedy_memory_repository.NewSubscriberRepository()
+	parser := newTestParser(client, subscriberRepository)
+
+	addSubscribers(t, subscriberRepository,
+		models.Subscriber{ChainID: 1, Address: aliceAddress, SubscribeBlockNumber: 100},
+		models.Subscriber{ChainID: 1, Address: bobAddress, SubscribeBlockNumber: 100},
+	)
+
+	// Block is matched, but it fails before it is saved
+	err := parser.IndexNewBlocks(ctx)
+	assert.EqualError(t, err, "error getting balance cause: connection refused")
+
+	assert.Equal(t, map[models.SubscriberKey]uint64{{Address: aliceAddress}: 100, {Address: bobAddress}: 100},
+		getCursors(t, subscriberRepository))
+
+	txs, err := subscriberRepository.GetTransactionsReversed(ctx, aliceAddress)
+	assert.NoError(t, err)
+	assert.Empty(t, txs)
+
+	currentBlock, err := parser.GetCurrentBlock(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0), currentBlock)
+
+	// The next pass indexes the block again from the same cursors
+	client.failingAddress = ""
+
+	assert.NoError(t, parser.IndexNewBlocks(ctx))
+	assert.Equal(t, []string{"0x01"}, getHashes(t, parser, "", aliceAddress))
+	assert.Equal(t, []string{"0x01"}, getHashes(t, parser, "", bobAddress))
+	assert.Equal(t, map[models.SubscriberKey]uint64{{Address: aliceAddress}: 101, {Address: bobAddress}: 101},
+		getCursors(t, subscriberRepository))
+}
+
+func TestParser_ForEachTransaction(t *testing.T) {
+	ctx := context.TODO()
+
+	client := &ethereumJsonRPCClientMock{
+		headBlock: 102,
+		blocks: map[uint64][]*ethereum_jsonrpc.Transaction{
+			101: {newTx("0x01", 101, aliceAddress, bobAddress)},
+			102: {newTx("0x02", 102, bobAddress, aliceAddress)},
+		},
+	}
+
+	subscriberRepository := greedy_memory_repository.NewSubscriberRepository()
+	parser := newTestParser(client, subscriberRepository)
+
+	addSubscribers(t, subscriberRepository, models.Subscriber{ChainID: 1, Address: aliceAddress, SubscribeBlockNumber: 100})
+
+	// New blocks are indexed before iteration, transactions go in chronological order
+	hashes := make([]string, 0)
+	err := parser.ForEachTransaction(ctx, aliceAddress, func(tx *models.Transaction) error {
+		hashes = append(hashes, tx.Hash)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"0x01", "0x02"}, hashes)
+
+	err = parser.ForEachTransaction(ctx, bobAddress, func(tx *models.Transaction) error {
+		return nil
+	})
+	assert.ErrorIs(t, err, models.ErrNotSubscribed)
+}
+
+func TestParser_Run(t *testing.T) {
+	client := &ethereumJsonRPCClientMock{
+		headBlock: 101,
+		blocks: map[uint64][]*ethereum_jsonrpc.Transaction{
+			101: {newTx("0x01", 101, otherAddress, aliceAddress)},
+			102: {newTx("0x02", 102, otherAddress, aliceAddress)},
+		},
+	}
+
+	subscriberRepository := greedy_memory_repository.NewSubscriberRepository()
+	parser := newTestParser(client, subscriberRepository)
+
+	addSubscribers(t, subscriberRepository, models.Subscriber{ChainID: 1, Address: aliceAddress, SubscribeBlockNumber: 100})
+
+	ctx, cancel := context.WithCancel(context.TODO())
+
+	done := make(chan error)
+	go func() {
+		done <- parser.Run(ctx, 10*time.Millisecond)
+	}()
+
+	// The first pass runs at start, the next ones run every interval
+	assert.Eventually(t, func() bool {
+		return getCursors(t, subscriberRepository)[models.SubscriberKey{Address: aliceAddress}] == 101
+	}, time.Second, 5*time.Millisecond)
+
+	client.mx.Lock()
+	client.headBlock = 102
+	client.mx.Unlock()
+
+	assert.Eventually(t, func() bool {
+		return getCursors(t, subscriberRepository)[models.SubscriberKey{Address: aliceAddress}] == 102
+	}, time.Second, 5*time.Millisecond)
+
+	cancel()
+
+	select {
+	case err := <-done:
+		assert.ErrorIs(t, err, context.Canceled)
+	case <-time.After(time.Second):
+		t.Fatal("run is not stopped after ctx is done")
+	}
+
+	txs, err := subscriberRepository.GetTransactionsReversed(context.TODO(), aliceAddress)
+	assert.NoError(t, err)
+	assert.Len(t, txs, 2)
+}
+
+// failingSubscriberRepository fails saving of the next indexed block with err
+type failingSubscriberRepository struct {
+	*greedy_memory_repository.SubscriberRepository
+	err error
+}
+
+func (r *failingSubscriberRepository) AddBlockTransactions(ctx context.Context, blockNumber uint64, txsBySubscriber map[models.SubscriberKey][]*models.Transaction) error {
+	if r.err != nil {
+		err := r.err
+		r.err = nil
+
+		return err
+	}
+
+	return r.SubscriberRepository.AddBlockTransactions(ctx, blockNumber, txsBySubscriber)
+}
+
+// failingBalanceRepository fails adding of the next balance with err
+type failingBalanceRepository struct {
+	*greedy_memory_repository.BalanceRepository
+	err error
+}
+
+func (r *failingBalanceRepository) AddBalance(ctx context.Context, address models.Address, balance models.Balance) error {
+	if r.err != nil {
+		err := r.err
+		r.err = nil
+
+		return err
+	}
+
+	return r.BalanceRepository.AddBalance(ctx, address, balance)
+}
+
+// getBalances returns balance history of address as block number and balance pairs
+func getBalances(t *testing.T, parser *Parser, tenantID string, address models.Address) [][2]string {
+	history, err := parser.GetBalanceHistory(tenant.WithID(context.TODO(), tenantID), address)
+	assert.NoError(t, err)
+
+	balances := make([][2]string, 0, len(history))
+	for _, balance := range history {
+		balances = append(balances, [2]string{big.NewInt(int64(balance.BlockNumber)).String(), balance.Balance.String()})
+	}
+
+	return balances
+}
+
+func TestParser_Balances(t *testing.T) {
+	tenantACtx := tenant.WithID(context.TODO(), "tenant_a")
+	tenantBCtx := tenant.WithID(context.TODO(), "tenant_b")
+
+	client := &ethereumJsonRPCClientMock{
+		headBlock: 100,
+		blocks: map[uint64][]*ethereum_jsonrpc.Transaction{
+			101: {newTx("0x01", 101, aliceAddress, otherAddress)},
+			102: {newTx("0x02", 102, otherAddress, bobAddress)},
+			103: {newTx("0x03", 103, otherAddress, aliceAddress)},
+		},
+		balances: map[models.Address]int64{aliceAddress: 10},
+	}
+
+	subscriberRepository := &failingSubscriberRepository{SubscriberRepository: greedy_memory_repository.NewSubscriberRepository()}
+	parser := newTestParser(client, subscriberRepository)
+
+	_, err := parser.GetBalance(tenantACtx, aliceAddress)
+	assert.ErrorIs(t, err, models.ErrNotSubscribed)
+
+	// Balance at the current block is recorded at subscription
+	assert.NoError(t, parser.Subscribe(tenantACtx, aliceAddress, ""))
+	assert.NoError(t, parser.Subscribe(tenantBCtx, aliceAddress, ""))
+	assert.Equal(t, []models.Address{aliceAddress, aliceAddress}, client.balanceRequests)
+	assert.Equal(t, [][2]string{{"100", "10"}}, getBalances(t, parser, "tenant_a", aliceAddress))
+
+	// Balance is requested once for both tenants and only after block with transactions of the address
+	client.headBlock = 102
+	client.balances[aliceAddress] = 7
+	client.balanceRequests = nil
+
+	balance, err := parser.GetBalance(tenantACtx, aliceAddress)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(101), balance.BlockNumber)
+	assert.Equal(t, "7", balance.Balance.String())
+	assert.Equal(t, []models.Address{aliceAddress}, client.balanceRequests)
+
+	assert.Equal(t, [][2]string{{"100", "10"}, {"101", "7"}}, getBalances(t, parser, "tenant_a", aliceAddress))
+	assert.Equal(t, [][2]string{{"100", "10"}, {"101", "7"}}, getBalances(t, parser, "tenant_b", aliceAddress))
+
+	// Block that failed to be saved is indexed again, but its balance is not recorded twice
+	client.headBlock = 103
+	client.balances[aliceAddress] = 12
+	subscriberRepository.err = errors.New("connection refused")
+
+	err = parser.IndexNewBlocks(context.TODO())
+	assert.EqualError(t, err, "connection refused")
+
+	assert.NoError(t, parser.IndexNewBlocks(context.TODO()))
+	assert.Equal(t, [][2]string{{"100", "10"}, {"101", "7"}, {"103", "12"}}, getBalances(t, parser, "tenant_a", aliceAddress))
+	assert.Equal(t, [][2]string{{"100", "10"}, {"101", "7"}, {"103", "12"}}, getBalances(t, parser, "tenant_b", aliceAddress))
+
+	// Balance history is removed with subscription
+	assert.NoError(t, parser.Unsubscribe(tenantBCtx, aliceAddress))
+	assert.NoError(t, parser.Subscribe(tenantBCtx, aliceAddress, ""))
+	assert.Equal(t, [][2]string{{"103", "12"}}, getBalances(t, parser, "tenant_b", aliceAddress))
+}
+
+func TestParser_SubscribeRollback(t *testing.T) {
+	ctx := tenant.WithID(context.TODO(), "tenant_a")
+
+	client := &ethereumJsonRPCClientMock{headBlock: 100, balances: map[models.Address]int64{aliceAddress: 10}}
+
+	subscriberRepository := greedy_memory_repository.NewSubscriberRepository()
+	balanceRepository := &failingBalanceRepository{BalanceRepository: greedy_memory_repository.NewBalanceRepository(), err: errors.New("connection refused")}
+	parser := NewParser(1, client, subscriberRepository, greedy_memory_repository.NewBlockRepository(), balanceRepository,
+		slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	// Subscriber is removed if its first balance is not saved
+	err := parser.Subscribe(ctx, aliceAddress, "")
+	assert.EqualError(t, err, "connection refused")
+
+	_, err = parser.GetSubscriber(ctx, aliceAddress)
+	assert.ErrorIs(t, err, models.ErrNotSubscribed)
+
+	// So subscription can be retried
+	assert.NoError(t, parser.Subscribe(ctx, aliceAddress, ""))
+	assert.Equal(t, [][2]string{{"100", "10"}}, getBalances(t, parser, "tenant_a", aliceAddress))
+}
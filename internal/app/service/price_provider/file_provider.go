@@ -0,0 +1,103 @@
+package price_provider
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"io"
+	"math/big"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// pricePoint is ETH/USD price starting from timestamp
+type pricePoint struct {
+	timestamp time.Time
+	usd       *big.Rat
+}
+
+// FileProvider is a stub of price provider that reads ETH/USD prices from local csv file,
+// it is useful for development and tests without access to market data APIs.
+// File contains "timestamp,usd" rows with RFC 3339 timestamps, optional header row is skipped.
+// Price at some moment is the latest price at or before it, so file may be as sparse as needed
+type FileProvider struct {
+	// points are sorted by timestamp
+	points []pricePoint
+}
+
+// NewFileProvider reads prices from csv file at path
+func NewFileProvider(path string) (*FileProvider, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, errors.New("opening price file failed cause: " + err.Error())
+	}
+	defer file.Close()
+
+	points, err := readPricePoints(file)
+	if err != nil {
+		return nil, errors.New("reading price file " + path + " failed cause: " + err.Error())
+	}
+
+	return &FileProvider{points: points}, nil
+}
+
+// GetPrice returns ETH/USD price at the moment, error is returned if file has no price at or before it
+func (p *FileProvider) GetPrice(ctx context.Context, at time.Time) (*big.Rat, error) {
+	// index of the first point after the moment
+	i := sort.Search(len(p.points), func(i int) bool {
+		return p.points[i].timestamp.After(at)
+	})
+
+	if i == 0 {
+		return nil, errors.New("no ETH/USD price at " + at.UTC().Format(time.RFC3339))
+	}
+
+	return new(big.Rat).Set(p.points[i-1].usd), nil
+}
+
+func readPricePoints(r io.Reader) ([]pricePoint, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = 2
+	reader.TrimLeadingSpace = true
+
+	var points []pricePoint
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		timestamp, err := time.Parse(time.RFC3339, strings.TrimSpace(record[0]))
+		if err != nil {
+			// the first row may be a header
+			if line == 1 {
+				continue
+			}
+
+			return nil, errors.New("line " + strconv.Itoa(line) + ": invalid timestamp " + record[0])
+		}
+
+		usd, ok := new(big.Rat).SetString(strings.TrimSpace(record[1]))
+		if !ok || usd.Sign() < 0 {
+			return nil, errors.New("line " + strconv.Itoa(line) + ": invalid price " + record[1])
+		}
+
+		points = append(points, pricePoint{timestamp: timestamp, usd: usd})
+	}
+
+	if len(points) == 0 {
+		return nil, errors.New("no prices")
+	}
+
+	sort.Slice(points, func(i, j int) bool {
+		return points[i].timestamp.Before(points[j].timestamp)
+	})
+
+	return points, nil
+}
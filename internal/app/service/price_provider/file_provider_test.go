@@ -0,0 +1,73 @@
+package price_provider
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestFileProvider_GetPrice(t *testing.T) {
+	type TestCase struct {
+		Name          string
+		At            time.Time
+		ExpectedPrice string
+		ExpectedErr   string
+	}
+
+	path := filepath.Join(t.TempDir(), "prices.csv")
+	content := "timestamp,usd\n" +
+		"2023-02-01T00:00:00Z,1585.32\n" +
+		"2023-01-01T00:00:00Z, 1196.77\n" +
+		"2023-03-01T00:00:00Z,1663.42\n"
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	provider, err := NewFileProvider(path)
+	assert.NoError(t, err)
+
+	testCases := []TestCase{
+		{
+			Name:        "before first price",
+			At:          time.Date(2022, 12, 31, 23, 59, 59, 0, time.UTC),
+			ExpectedErr: "no ETH/USD price at 2022-12-31T23:59:59Z",
+		},
+		{
+			Name:          "exactly at price",
+			At:            time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+			ExpectedPrice: "1196.77",
+		},
+		{
+			Name:          "between prices",
+			At:            time.Date(2023, 2, 13, 12, 0, 0, 0, time.UTC),
+			ExpectedPrice: "1585.32",
+		},
+		{
+			Name:          "after last price",
+			At:            time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			ExpectedPrice: "1663.42",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			price, err := provider.GetPrice(context.TODO(), testCase.At)
+			if testCase.ExpectedErr != "" {
+				assert.EqualError(t, err, testCase.ExpectedErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.ExpectedPrice, price.FloatString(2))
+		})
+	}
+}
+
+func TestNewFileProvider_InvalidPrice(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "prices.csv")
+	assert.NoError(t, os.WriteFile(path, []byte("2023-01-01T00:00:00Z,cheap\n"), 0o600))
+
+	_, err := NewFileProvider(path)
+	assert.ErrorContains(t, err, "line 1: invalid price cheap")
+}
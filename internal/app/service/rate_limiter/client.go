@@ -0,0 +1,18 @@
+package rate_limiter
+
+import "context"
+
+type clientCtxKey struct{}
+
+// WithClient returns a copy of ctx carrying the client whose requests are limited,
+// so expensive parts of a request, like fields of GraphQL query, are charged to the same client
+func WithClient(ctx context.Context, client string) context.Context {
+	return context.WithValue(ctx, clientCtxKey{}, client)
+}
+
+// ClientFromContext returns the client attached to ctx, empty if there is none
+func ClientFromContext(ctx context.Context) string {
+	client, _ := ctx.Value(clientCtxKey{}).(string)
+
+	return client
+}
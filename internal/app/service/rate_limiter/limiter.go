@@ -0,0 +1,116 @@
+package rate_limiter
+
+import (
+	"github.com/bluntenpassant/ethereum_subscriber/config"
+	"math"
+	"sync"
+	"time"
+)
+
+// sweepInterval is an interval of dropping full buckets, a full bucket is the same as a missing one
+const sweepInterval = time.Minute
+
+type bucketKey struct {
+	client string
+	route  string
+}
+
+// bucket is a token bucket of a client on a route, tokens are refilled lazily on every request
+type bucket struct {
+	tokens   float64
+	updateAt time.Time
+}
+
+// refill adds tokens earned since the last update, bucket never holds more than burst tokens
+func (b *bucket) refill(limit config.Limit, now time.Time) {
+	b.tokens = math.Min(float64(limit.Burst), b.tokens+now.Sub(b.updateAt).Seconds()*limit.RequestsPerSecond)
+	b.updateAt = now
+}
+
+// Limiter limits requests of every client to every route by token bucket with limits from config.
+// Buckets are kept in memory, so every instance of API server limits its own requests
+type Limiter struct {
+	mx        sync.Mutex
+	config    config.RateLimit
+	buckets   map[bucketKey]*bucket
+	lastSweep time.Time
+
+	now func() time.Time
+}
+
+func NewLimiter(rateLimitConfig config.RateLimit) *Limiter {
+	return &Limiter{
+		config:  rateLimitConfig,
+		buckets: make(map[bucketKey]*bucket),
+		now:     time.Now,
+	}
+}
+
+// SetConfig applies new limits at runtime, buckets are reset, so clients start with full buckets of new limits
+func (l *Limiter) SetConfig(rateLimitConfig config.RateLimit) {
+	l.mx.Lock()
+	defer l.mx.Unlock()
+
+	l.config = rateLimitConfig
+	l.buckets = make(map[bucketKey]*bucket)
+}
+
+// MaxSubscriptionsPerTenant returns maximum count of subscriptions of a tenant, 0 means unlimited
+func (l *Limiter) MaxSubscriptionsPerTenant() int {
+	l.mx.Lock()
+	defer l.mx.Unlock()
+
+	return l.config.MaxSubscriptionsPerTenant
+}
+
+// Allow takes a token from bucket of the client on the route given by template. If bucket is empty, request is not allowed
+// and the time after which the next request will be allowed is returned. All requests are allowed when limiting is disabled
+func (l *Limiter) Allow(client string, route string) (bool, time.Duration) {
+	l.mx.Lock()
+	defer l.mx.Unlock()
+
+	if !l.config.Enabled {
+		return true, 0
+	}
+
+	now := l.now()
+	l.sweep(now)
+
+	limit := l.config.GetLimit(route)
+	key := bucketKey{client: client, route: route}
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(limit.Burst), updateAt: now}
+		l.buckets[key] = b
+	}
+
+	b.refill(limit, now)
+
+	if b.tokens < 1 {
+		retryAfter := time.Duration((1 - b.tokens) / limit.RequestsPerSecond * float64(time.Second))
+		return false, retryAfter
+	}
+
+	b.tokens--
+
+	return true, 0
+}
+
+// sweep drops buckets that are refilled up to burst, so memory does not grow with count of clients
+func (l *Limiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < sweepInterval {
+		return
+	}
+
+	for key, b := range l.buckets {
+		limit := l.config.GetLimit(key.route)
+
+		b.refill(limit, now)
+		if b.tokens >= float64(limit.Burst) {
+			delete(l.buckets, key)
+		}
+	}
+
+	l.lastSweep = now
+}
@@ -0,0 +1,132 @@
+package rate_limiter
+
+import (
+	"github.com/bluntenpassant/ethereum_subscriber/config"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+const transactionsRoute = "/get_transactions/{address}"
+
+func newTestLimiter(now *time.Time) *Limiter {
+	limiter := NewLimiter(config.RateLimit{
+		Enabled:           true,
+		RequestsPerSecond: 10,
+		Burst:             2,
+		Routes: map[string]config.Limit{
+			transactionsRoute: {RequestsPerSecond: 0.5, Burst: 1},
+		},
+	})
+	limiter.now = func() time.Time {
+		return *now
+	}
+
+	return limiter
+}
+
+func TestLimiter_Allow(t *testing.T) {
+	type Request struct {
+		Client string
+		Route  string
+		// Elapsed is a time passed since the previous request
+		Elapsed            time.Duration
+		ExpectedAllowed    bool
+		ExpectedRetryAfter time.Duration
+	}
+
+	type TestCase struct {
+		Name     string
+		Requests []Request
+	}
+
+	testCases := []TestCase{
+		{
+			Name: "burst of default limit",
+			Requests: []Request{
+				{Client: "a", Route: "/get_current_block", ExpectedAllowed: true},
+				{Client: "a", Route: "/get_current_block", ExpectedAllowed: true},
+				{Client: "a", Route: "/get_current_block", ExpectedRetryAfter: 100 * time.Millisecond},
+				{Client: "a", Route: "/get_current_block", Elapsed: 100 * time.Millisecond, ExpectedAllowed: true},
+			},
+		},
+		{
+			Name: "route limit",
+			Requests: []Request{
+				{Client: "a", Route: transactionsRoute, ExpectedAllowed: true},
+				{Client: "a", Route: transactionsRoute, Elapsed: time.Second, ExpectedRetryAfter: time.Second},
+				// Other routes have their own buckets
+				{Client: "a", Route: "/get_current_block", ExpectedAllowed: true},
+				{Client: "a", Route: transactionsRoute, Elapsed: time.Second, ExpectedAllowed: true},
+			},
+		},
+		{
+			Name: "clients have their own buckets",
+			Requests: []Request{
+				{Client: "a", Route: transactionsRoute, ExpectedAllowed: true},
+				{Client: "a", Route: transactionsRoute, ExpectedRetryAfter: 2 * time.Second},
+				{Client: "b", Route: transactionsRoute, ExpectedAllowed: true},
+			},
+		},
+		{
+			Name: "bucket is not refilled over burst",
+			Requests: []Request{
+				{Client: "a", Route: "/get_current_block", ExpectedAllowed: true},
+				{Client: "a", Route: "/get_current_block", Elapsed: time.Hour, ExpectedAllowed: true},
+				{Client: "a", Route: "/get_current_block", ExpectedAllowed: true},
+				{Client: "a", Route: "/get_current_block", ExpectedRetryAfter: 100 * time.Millisecond},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+			limiter := newTestLimiter(&now)
+
+			for i, request := range testCase.Requests {
+				now = now.Add(request.Elapsed)
+
+				allowed, retryAfter := limiter.Allow(request.Client, request.Route)
+				assert.Equal(t, request.ExpectedAllowed, allowed, "request %d", i)
+				assert.Equal(t, request.ExpectedRetryAfter, retryAfter, "request %d", i)
+			}
+		})
+	}
+}
+
+func TestLimiter_SetConfig(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	limiter := newTestLimiter(&now)
+
+	allowed, _ := limiter.Allow("a", transactionsRoute)
+	assert.True(t, allowed)
+	allowed, _ = limiter.Allow("a", transactionsRoute)
+	assert.False(t, allowed)
+
+	limiter.SetConfig(config.RateLimit{Enabled: false, MaxSubscriptionsPerTenant: 5})
+	assert.Equal(t, 5, limiter.MaxSubscriptionsPerTenant())
+
+	for i := 0; i < 10; i++ {
+		allowed, _ = limiter.Allow("a", transactionsRoute)
+		assert.True(t, allowed)
+	}
+}
+
+func TestLimiter_Sweep(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	limiter := newTestLimiter(&now)
+
+	limiter.Allow("a", "/get_current_block")
+
+	// Bucket of b needs 2s to be refilled, so it is not full yet on sweep, bucket of a is full long ago
+	now = now.Add(sweepInterval - time.Second)
+	limiter.Allow("b", transactionsRoute)
+
+	now = now.Add(time.Second)
+	limiter.Allow("c", "/get_current_block")
+
+	assert.Len(t, limiter.buckets, 2)
+	assert.Contains(t, limiter.buckets, bucketKey{client: "b", route: transactionsRoute})
+	assert.Contains(t, limiter.buckets, bucketKey{client: "c", route: "/get_current_block"})
+}
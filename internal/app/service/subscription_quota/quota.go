@@ -0,0 +1,107 @@
+package subscription_quota
+
+import (
+	"context"
+	"errors"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/models"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/tenant"
+	"strconv"
+	"sync"
+)
+
+type Parser interface {
+	Subscribe(ctx context.Context, address models.Address, ensName string) error
+	GetSubscriber(ctx context.Context, address models.Address) (models.Subscriber, error)
+	CountSubscribers(ctx context.Context) (int, error)
+}
+
+type Limits interface {
+	MaxSubscriptionsPerTenant() int
+}
+
+// Quota subscribes addresses on all chains keeping count of subscriptions of every tenant within the limit.
+// Subscriptions of a tenant are serialized in memory, so every instance of API server guards its own subscriptions
+type Quota struct {
+	// parsers are parsers of configured chains by chain ID
+	parsers map[uint64]Parser
+	limits  Limits
+
+	mx sync.Mutex
+	// tenantsMx serialize count and subscription of every tenant, so concurrent subscriptions do not exceed the limit
+	tenantsMx map[string]*sync.Mutex
+}
+
+func NewQuota(parsers map[uint64]Parser, limits Limits) *Quota {
+	return &Quota{
+		parsers:   parsers,
+		limits:    limits,
+		tenantsMx: make(map[string]*sync.Mutex),
+	}
+}
+
+// Subscribe subscribes address on the chain in the tenant of ctx. Subscription of already subscribed address fails with
+// models.ErrAlreadySubscribed regardless of quota, subscription beyond quota fails with models.ErrQuotaExceeded
+func (q *Quota) Subscribe(ctx context.Context, chainID uint64, address models.Address, ensName string) error {
+	parser, ok := q.parsers[chainID]
+	if !ok {
+		return errors.New("chain " + strconv.FormatUint(chainID, 10) + " is not configured")
+	}
+
+	tenantMx := q.tenantMx(tenant.FromContext(ctx))
+	tenantMx.Lock()
+	defer tenantMx.Unlock()
+
+	_, err := parser.GetSubscriber(ctx, address)
+	if err == nil {
+		return models.ErrAlreadySubscribed
+	}
+
+	if !errors.Is(err, models.ErrNotSubscribed) {
+		return models.WrapError("getting subscriber failed", err)
+	}
+
+	err = q.checkQuota(ctx)
+	if err != nil {
+		return err
+	}
+
+	return parser.Subscribe(ctx, address, ensName)
+}
+
+// checkQuota returns models.ErrQuotaExceeded if tenant of ctx has reached maximum count of subscriptions on all chains
+func (q *Quota) checkQuota(ctx context.Context) error {
+	maxSubscriptions := q.limits.MaxSubscriptionsPerTenant()
+	if maxSubscriptions <= 0 {
+		return nil
+	}
+
+	count := 0
+	for _, parser := range q.parsers {
+		chainCount, err := parser.CountSubscribers(ctx)
+		if err != nil {
+			return models.WrapError("counting subscriptions failed", err)
+		}
+
+		count += chainCount
+	}
+
+	if count >= maxSubscriptions {
+		return models.WrapError("maximum count of subscriptions "+strconv.Itoa(maxSubscriptions)+" is reached", models.ErrQuotaExceeded)
+	}
+
+	return nil
+}
+
+// tenantMx returns mutex of the tenant, it is created on the first subscription of the tenant
+func (q *Quota) tenantMx(tenantID string) *sync.Mutex {
+	q.mx.Lock()
+	defer q.mx.Unlock()
+
+	tenantMx, ok := q.tenantsMx[tenantID]
+	if !ok {
+		tenantMx = &sync.Mutex{}
+		q.tenantsMx[tenantID] = tenantMx
+	}
+
+	return tenantMx
+}
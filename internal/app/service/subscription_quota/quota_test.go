@@ -0,0 +1,167 @@
+package subscription_quota
+
+import (
+	"context"
+	"errors"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/models"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/tenant"
+	"github.com/stretchr/testify/assert"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+// mockParser keeps subscriptions of every tenant in memory
+type mockParser struct {
+	mx          sync.Mutex
+	subscribers map[models.SubscriberKey]models.Subscriber
+	countErr    error
+}
+
+func newMockParser() *mockParser {
+	return &mockParser{subscribers: make(map[models.SubscriberKey]models.Subscriber)}
+}
+
+func (p *mockParser) Subscribe(ctx context.Context, address models.Address, ensName string) error {
+	p.mx.Lock()
+	defer p.mx.Unlock()
+
+	subscriber := models.Subscriber{Address: address, ENSName: ensName, TenantID: tenant.FromContext(ctx)}
+	if _, ok := p.subscribers[subscriber.Key()]; ok {
+		return models.ErrAlreadySubscribed
+	}
+
+	p.subscribers[subscriber.Key()] = subscriber
+
+	return nil
+}
+
+func (p *mockParser) GetSubscriber(ctx context.Context, address models.Address) (models.Subscriber, error) {
+	p.mx.Lock()
+	defer p.mx.Unlock()
+
+	subscriber, ok := p.subscribers[models.SubscriberKey{TenantID: tenant.FromContext(ctx), Address: address}]
+	if !ok {
+		return models.Subscriber{}, models.ErrNotSubscribed
+	}
+
+	return subscriber, nil
+}
+
+func (p *mockParser) CountSubscribers(ctx context.Context) (int, error) {
+	p.mx.Lock()
+	defer p.mx.Unlock()
+
+	if p.countErr != nil {
+		return 0, p.countErr
+	}
+
+	count := 0
+	for key := range p.subscribers {
+		if key.TenantID == tenant.FromContext(ctx) {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+type mockLimits struct {
+	maxSubscriptions int
+}
+
+func (l mockLimits) MaxSubscriptionsPerTenant() int {
+	return l.maxSubscriptions
+}
+
+func TestQuota_Subscribe(t *testing.T) {
+	tenantACtx := tenant.WithID(context.TODO(), "tenant_a")
+	tenantBCtx := tenant.WithID(context.TODO(), "tenant_b")
+
+	const (
+		firstAddress  = models.Address("0xd8da6bf26964af9d7eed9e03e53415d37aa96045")
+		secondAddress = models.Address("0xdef1c0ded9bec7f1a1670819833240f027b25eff")
+	)
+
+	type TestCase struct {
+		name             string
+		maxSubscriptions int
+		countErr         error
+		ctx              context.Context
+		chainID          uint64
+		address          models.Address
+		expectedErr      error
+		expectedAnyErr   bool
+	}
+
+	// Every case starts with firstAddress subscribed by tenant_a on chain 1
+	testCases := []TestCase{
+		{name: "within quota on another chain", maxSubscriptions: 2, ctx: tenantACtx, chainID: 137, address: secondAddress},
+		{name: "unlimited", maxSubscriptions: 0, ctx: tenantACtx, chainID: 1, address: secondAddress},
+		{name: "quota is counted on all chains", maxSubscriptions: 1, ctx: tenantACtx, chainID: 137, address: secondAddress, expectedErr: models.ErrQuotaExceeded},
+		{name: "already subscribed at quota", maxSubscriptions: 1, ctx: tenantACtx, chainID: 1, address: firstAddress, expectedErr: models.ErrAlreadySubscribed},
+		{name: "quota of another tenant", maxSubscriptions: 1, ctx: tenantBCtx, chainID: 1, address: firstAddress},
+		{name: "counting failed", maxSubscriptions: 1, countErr: errors.New("connection refused"), ctx: tenantACtx, chainID: 1, address: secondAddress, expectedAnyErr: true},
+		{name: "unknown chain", maxSubscriptions: 2, ctx: tenantACtx, chainID: 10, address: secondAddress, expectedAnyErr: true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			mainnetParser := newMockParser()
+			polygonParser := newMockParser()
+			quota := NewQuota(map[uint64]Parser{1: mainnetParser, 137: polygonParser}, mockLimits{maxSubscriptions: testCase.maxSubscriptions})
+
+			assert.NoError(t, quota.Subscribe(tenantACtx, 1, firstAddress, ""))
+
+			mainnetParser.countErr = testCase.countErr
+			polygonParser.countErr = testCase.countErr
+
+			err := quota.Subscribe(testCase.ctx, testCase.chainID, testCase.address, "")
+			switch {
+			case testCase.expectedErr != nil:
+				assert.ErrorIs(t, err, testCase.expectedErr)
+			case testCase.expectedAnyErr:
+				assert.Error(t, err)
+				assert.NotErrorIs(t, err, models.ErrQuotaExceeded)
+			default:
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestQuota_Subscribe_Concurrent(t *testing.T) {
+	ctx := tenant.WithID(context.TODO(), "tenant_a")
+
+	parser := newMockParser()
+	quota := NewQuota(map[uint64]Parser{1: parser}, mockLimits{maxSubscriptions: 3})
+
+	const subscriptions = 20
+
+	errs := make(chan error, subscriptions)
+	wg := sync.WaitGroup{}
+	for i := 0; i < subscriptions; i++ {
+		address := models.Address("0x" + strconv.Itoa(i+1))
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			errs <- quota.Subscribe(ctx, 1, address, "")
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	subscribed := 0
+	for err := range errs {
+		if err == nil {
+			subscribed++
+			continue
+		}
+
+		assert.ErrorIs(t, err, models.ErrQuotaExceeded)
+	}
+
+	assert.Equal(t, 3, subscribed)
+}
@@ -2,25 +2,36 @@ package sync_greedy_parser
 
 import (
 	"context"
-	"errors"
 	ethereum_jsonrpc "github.com/bluntenpassant/ethereum_subscriber/internal/app/client/ethereum-jsonrpc"
 	ethereum_jsonrpc_models "github.com/bluntenpassant/ethereum_subscriber/internal/app/client/ethereum-jsonrpc/models"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/metrics"
 	"github.com/bluntenpassant/ethereum_subscriber/internal/app/models"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/tenant"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"log/slog"
 	"sync"
 )
 
+// parserName is a label of parser in metrics
+const parserName = "sync_greedy"
+
 type EthereumJsonRPCClient interface {
-	GetTxCount(req *ethereum_jsonrpc.GetTxCountReq) (*ethereum_jsonrpc.GetTxCountResp, error)
-	GetBlockByNumber(req *ethereum_jsonrpc.GetBlockByNumberReq) (*ethereum_jsonrpc.GetBlockByNumberResp, error)
-	GetBlockNumber() (*ethereum_jsonrpc.GetBlockNumberResp, error)
+	GetTxCount(ctx context.Context, req *ethereum_jsonrpc.GetTxCountReq) (*ethereum_jsonrpc.GetTxCountResp, error)
+	GetBlockByNumber(ctx context.Context, req *ethereum_jsonrpc.GetBlockByNumberReq) (*ethereum_jsonrpc.GetBlockByNumberResp, error)
+	GetBlockNumber(ctx context.Context) (*ethereum_jsonrpc.GetBlockNumberResp, error)
 }
 
 type SubscriberRepository interface {
-	GetTransactionsReversed(ctx context.Context, address string) ([]*models.Transaction, error)
+	GetTransactionsReversed(ctx context.Context, address models.Address) ([]*models.Transaction, error)
+	ForEachTransaction(ctx context.Context, address models.Address, fn func(tx *models.Transaction) error) error
 	AddNewSubscriber(ctx context.Context, subscriber models.Subscriber) error
-	GetSubscriberByAddress(ctx context.Context, address string) (models.Subscriber, error)
-	GetLastTransaction(ctx context.Context, address string) (*models.Transaction, error)
-	AddTransactions(ctx context.Context, address string, txs []*models.Transaction) error
+	GetSubscriberByAddress(ctx context.Context, address models.Address) (models.Subscriber, error)
+	GetSubscribers(ctx context.Context) ([]models.Subscriber, error)
+	CountSubscribers(ctx context.Context) (int, error)
+	DeleteSubscriber(ctx context.Context, address models.Address) error
+	GetLastTransaction(ctx context.Context, address models.Address) (*models.Transaction, error)
+	AddTransactions(ctx context.Context, address models.Address, txs []*models.Transaction) error
 }
 
 type BlockRepository interface {
@@ -29,16 +40,21 @@ type BlockRepository interface {
 }
 
 type Parser struct {
+	// chainID is stamped on subscribers and transactions handled by the parser
+	chainID               uint64
 	ethereumJsonRPCClient EthereumJsonRPCClient
 	subscriberRepository  SubscriberRepository
 	blockRepository       BlockRepository
+	logger                *slog.Logger
 }
 
-func NewParser(ethereumJsonRPCClient EthereumJsonRPCClient, subscriberRepository SubscriberRepository, blockRepository BlockRepository) *Parser {
+func NewParser(chainID uint64, ethereumJsonRPCClient EthereumJsonRPCClient, subscriberRepository SubscriberRepository, blockRepository BlockRepository, logger *slog.Logger) *Parser {
 	return &Parser{
+		chainID:               chainID,
 		ethereumJsonRPCClient: ethereumJsonRPCClient,
 		subscriberRepository:  subscriberRepository,
 		blockRepository:       blockRepository,
+		logger:                logger.With("parser", parserName, "chain_id", chainID),
 	}
 }
 
@@ -48,14 +64,44 @@ func (p *Parser) GetCurrentBlock(ctx context.Context) (uint64, error) {
 	return currentBlock, err
 }
 
-func (p *Parser) Subscribe(ctx context.Context, address string) error {
-	blockNumberResp, err := p.ethereumJsonRPCClient.GetBlockNumber()
+func (p *Parser) GetSubscribers(ctx context.Context) ([]models.Subscriber, error) {
+	subscribers, err := p.subscriberRepository.GetSubscribers(ctx)
+
+	return subscribers, err
+}
+
+// CountSubscribers returns count of subscriptions of the tenant from ctx
+func (p *Parser) CountSubscribers(ctx context.Context) (int, error) {
+	return p.subscriberRepository.CountSubscribers(ctx)
+}
+
+// GetSubscriber returns subscription of address by tenant from ctx
+func (p *Parser) GetSubscriber(ctx context.Context, address models.Address) (models.Subscriber, error) {
+	subscriber, err := p.subscriberRepository.GetSubscriberByAddress(ctx, address)
+
+	return subscriber, err
+}
+
+// Unsubscribe removes subscription of address by tenant from ctx with its collected transactions
+func (p *Parser) Unsubscribe(ctx context.Context, address models.Address) error {
+	err := p.subscriberRepository.DeleteSubscriber(ctx, address)
 	if err != nil {
-		return errors.New("error getting current block number cause: " + err.Error())
+		return err
 	}
 
-	txCountResp, err := p.ethereumJsonRPCClient.GetTxCount(&ethereum_jsonrpc.GetTxCountReq{
-		Address:  address,
+	p.logger.InfoContext(ctx, "unsubscribed", "address", address.String())
+
+	return nil
+}
+
+func (p *Parser) Subscribe(ctx context.Context, address models.Address, ensName string) error {
+	blockNumberResp, err := p.ethereumJsonRPCClient.GetBlockNumber(ctx)
+	if err != nil {
+		return models.WrapError("error getting current block number", err)
+	}
+
+	txCountResp, err := p.ethereumJsonRPCClient.GetTxCount(ctx, &ethereum_jsonrpc.GetTxCountReq{
+		Address:  address.String(),
 		EndBlock: blockNumberResp.BlockNumber,
 	})
 	if err != nil {
@@ -63,12 +109,21 @@ func (p *Parser) Subscribe(ctx context.Context, address string) error {
 	}
 
 	err = p.subscriberRepository.AddNewSubscriber(ctx, models.Subscriber{
+		ChainID:              p.chainID,
 		Address:              address,
 		SubscribeBlockNumber: uint64(blockNumberResp.BlockNumber),
 		SubscribeTxCount:     uint64(txCountResp.Nonce),
+		ENSName:              ensName,
+		TenantID:             tenant.FromContext(ctx),
 	})
+	if err != nil {
+		return err
+	}
+
+	p.logger.InfoContext(ctx, "subscribed", "address", address.String(), "ens_name", ensName,
+		"block", uint64(blockNumberResp.BlockNumber), "tx_count", uint64(txCountResp.Nonce))
 
-	return err
+	return nil
 }
 
 // GetTransactions uses for a getting a full list of inbound or outbounds transactions by user since subscription.
@@ -92,27 +147,105 @@ func (p *Parser) Subscribe(ctx context.Context, address string) error {
 // this method might require distributed storage (like Redis or PostgreSQL) instead of default memory storage
 // but it might significantly increase performance due to keeping already parsed transactions.
 // This approach aimed at long-term program execution with long lifetime.
-func (p *Parser) GetTransactions(ctx context.Context, address string) ([]*models.Transaction, error) {
+func (p *Parser) GetTransactions(ctx context.Context, address models.Address) ([]*models.Transaction, error) {
+	ctx, span := tracing.Start(ctx, "sync_greedy_parser.GetTransactions", attribute.String("address", address.String()))
+	defer span.End()
+
 	subscriber, err := p.subscriberRepository.GetSubscriberByAddress(ctx, address)
 	if err != nil {
-		return nil, err
+		return nil, tracing.Error(span, err)
+	}
+
+	currentBlockNumber, txCount, err := p.countNewTransactions(ctx, subscriber)
+	if err != nil {
+		return nil, tracing.Error(span, err)
+	}
+
+	transactions, err := p.scanBlocks(ctx, subscriber, currentBlockNumber, txCount)
+	if err != nil {
+		return nil, tracing.Error(span, err)
 	}
 
-	currentBlockNumberResp, err := p.ethereumJsonRPCClient.GetBlockNumber()
+	sharedTransactions, err := p.persistTransactions(ctx, address, transactions)
+	if err != nil {
+		return nil, tracing.Error(span, err)
+	}
+
+	return sharedTransactions, nil
+}
+
+// ForEachTransaction saves new transactions of subscriber like GetTransactions and calls fn for every stored transaction
+// in chronological order, so the whole history is not loaded in memory. Iteration stops on the first error of fn
+func (p *Parser) ForEachTransaction(ctx context.Context, address models.Address, fn func(tx *models.Transaction) error) error {
+	ctx, span := tracing.Start(ctx, "sync_greedy_parser.ForEachTransaction", attribute.String("address", address.String()))
+	defer span.End()
+
+	subscriber, err := p.subscriberRepository.GetSubscriberByAddress(ctx, address)
 	if err != nil {
-		return nil, err
+		return tracing.Error(span, err)
 	}
 
-	currentTxCountResp, err := p.ethereumJsonRPCClient.GetTxCount(&ethereum_jsonrpc.GetTxCountReq{
-		Address:  address,
+	currentBlockNumber, txCount, err := p.countNewTransactions(ctx, subscriber)
+	if err != nil {
+		return tracing.Error(span, err)
+	}
+
+	transactions, err := p.scanBlocks(ctx, subscriber, currentBlockNumber, txCount)
+	if err != nil {
+		return tracing.Error(span, err)
+	}
+
+	err = p.saveTransactions(ctx, address, transactions)
+	if err != nil {
+		return tracing.Error(span, err)
+	}
+
+	err = p.subscriberRepository.ForEachTransaction(ctx, address, fn)
+	if err != nil {
+		return tracing.Error(span, err)
+	}
+
+	return nil
+}
+
+// countNewTransactions returns the current block number in Ethereum Network and count of subscriber transactions
+// since the last parsed block (steps 1-3 of GetTransactions algorithm)
+func (p *Parser) countNewTransactions(ctx context.Context, subscriber models.Subscriber) (uint64, uint64, error) {
+	ctx, span := tracing.Start(ctx, "count")
+	defer span.End()
+
+	currentBlockNumberResp, err := p.ethereumJsonRPCClient.GetBlockNumber(ctx)
+	if err != nil {
+		return 0, 0, tracing.Error(span, err)
+	}
+
+	currentTxCountResp, err := p.ethereumJsonRPCClient.GetTxCount(ctx, &ethereum_jsonrpc.GetTxCountReq{
+		Address:  subscriber.Address.String(),
 		EndBlock: currentBlockNumberResp.BlockNumber,
 	})
 	if err != nil {
-		return nil, err
+		return 0, 0, tracing.Error(span, err)
 	}
 
 	txCount := uint64(currentTxCountResp.Nonce) - subscriber.SubscribeTxCount
 
+	span.SetAttributes(attribute.Int64("block_number", int64(currentBlockNumberResp.BlockNumber)), attribute.Int64("tx_count", int64(txCount)))
+
+	return uint64(currentBlockNumberResp.BlockNumber), txCount, nil
+}
+
+// scanBlocks collects txCount new subscriber transactions walking from currentBlockNumber back to the last parsed block
+// (step 4 of GetTransactions algorithm)
+func (p *Parser) scanBlocks(ctx context.Context, subscriber models.Subscriber, currentBlockNumber uint64, txCount uint64) ([]*models.Transaction, error) {
+	ctx, span := tracing.Start(ctx, "scan", attribute.Int64("from_block", int64(currentBlockNumber)),
+		attribute.Int64("to_block", int64(subscriber.SubscribeBlockNumber)))
+	defer span.End()
+
+	address := subscriber.Address
+
+	p.logger.DebugContext(ctx, "scanning blocks for transactions", "address", address.String(),
+		"from_block", currentBlockNumber, "to_block", subscriber.SubscribeBlockNumber, "tx_count", txCount)
+
 	transactions := make([]*models.Transaction, 0, txCount)
 
 	var txPool = sync.Pool{
@@ -123,24 +256,26 @@ func (p *Parser) GetTransactions(ctx context.Context, address string) ([]*models
 
 	lastTx, err := p.subscriberRepository.GetLastTransaction(ctx, address)
 	if err != nil {
-		return nil, err
+		return nil, tracing.Error(span, err)
 	}
 
-	for i := uint64(currentBlockNumberResp.BlockNumber); i >= subscriber.SubscribeBlockNumber && (txCount > 0); i-- {
+	for i := currentBlockNumber; i >= subscriber.SubscribeBlockNumber && (txCount > 0); i-- {
 		blockNumber := i
 
-		blockResp, err := p.ethereumJsonRPCClient.GetBlockByNumber(&ethereum_jsonrpc.GetBlockByNumberReq{
+		blockResp, err := p.ethereumJsonRPCClient.GetBlockByNumber(ctx, &ethereum_jsonrpc.GetBlockByNumberReq{
 			BlockNumber: ethereum_jsonrpc_models.HexUint64(blockNumber),
 			IsGetFullTx: true,
 		})
 		if err != nil {
-			return nil, err
+			return nil, tracing.Error(span, err)
 		}
 
-		if blockNumber == uint64(currentBlockNumberResp.BlockNumber) {
+		metrics.AddBlocksProcessed(parserName, 1)
+
+		if blockNumber == currentBlockNumber {
 			err = p.blockRepository.SetMaxCurrentBlock(ctx, blockNumber)
 			if err != nil {
-				return nil, err
+				return nil, tracing.Error(span, err)
 			}
 		}
 
@@ -149,24 +284,43 @@ func (p *Parser) GetTransactions(ctx context.Context, address string) ([]*models
 			if i == subscriber.SubscribeBlockNumber && lastTx != nil && (uint64(tx.TransactionIndex) < lastTx.TransactionIndex) {
 				continue
 			}
-			if tx.From == address || tx.To == address {
+			if models.NewAddress(tx.From) == address || models.NewAddress(tx.To) == address {
 				transaction := txPool.Get().(*models.Transaction)
-				*transaction = *models.ConvertJsonRPCTxToInternal(tx)
+				*transaction = *models.ConvertJsonRPCTxToInternal(p.chainID, tx)
 				transactions = append(transactions, transaction)
+				metrics.AddTransactionsMatched(parserName, 1)
 
 				txCount--
 			}
 		}
 	}
 
-	models.ReverseTransactionsByLink(transactions)
+	span.SetAttributes(attribute.Int("transactions", len(transactions)))
 
-	err = p.subscriberRepository.AddTransactions(ctx, address, transactions)
+	return transactions, nil
+}
+
+// persistTransactions saves new transactions and returns all subscriber transactions kept in storage
+func (p *Parser) persistTransactions(ctx context.Context, address models.Address, transactions []*models.Transaction) ([]*models.Transaction, error) {
+	ctx, span := tracing.Start(ctx, "persist", attribute.Int("transactions", len(transactions)))
+	defer span.End()
+
+	err := p.saveTransactions(ctx, address, transactions)
 	if err != nil {
-		return nil, err
+		return nil, tracing.Error(span, err)
 	}
 
 	sharedTransactions, err := p.subscriberRepository.GetTransactionsReversed(ctx, address)
+	if err != nil {
+		return nil, tracing.Error(span, err)
+	}
 
 	return sharedTransactions, nil
 }
+
+// saveTransactions appends new transactions found by scanBlocks (the latest goes first) to the stored ones in chronological order
+func (p *Parser) saveTransactions(ctx context.Context, address models.Address, transactions []*models.Transaction) error {
+	models.ReverseTransactionsByLink(transactions)
+
+	return p.subscriberRepository.AddTransactions(ctx, address, transactions)
+}
@@ -0,0 +1,178 @@
+package valuator
+
+import (
+	"context"
+	"errors"
+	ethereum_jsonrpc "github.com/bluntenpassant/ethereum_subscriber/internal/app/client/ethereum-jsonrpc"
+	jsonrpc_models "github.com/bluntenpassant/ethereum_subscriber/internal/app/client/ethereum-jsonrpc/models"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/models"
+	"math/big"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// maxCachedBlocks limits number of cached block timestamps, cache is dropped when it is full
+const maxCachedBlocks = 10000
+
+// usdDecimals is a number of decimals of USD values
+const usdDecimals = 2
+
+// maxParallelRequests limits number of block headers requested at a time by ValuesUSD
+const maxParallelRequests = 8
+
+type EthereumJsonRPCClient interface {
+	GetBlockHeaderByNumber(ctx context.Context, blockNumber jsonrpc_models.HexUint64) (*ethereum_jsonrpc.GetBlockHeaderByNumberResp, error)
+}
+
+// PriceProvider provides ETH/USD price at the moment, implementations are in price_provider package
+type PriceProvider interface {
+	GetPrice(ctx context.Context, at time.Time) (*big.Rat, error)
+}
+
+// blockKey identifies block between all chains
+type blockKey struct {
+	chainID     uint64
+	blockNumber uint64
+}
+
+// Valuator values transactions in USD by ETH/USD price at timestamp of transaction block
+type Valuator struct {
+	// ethereumJsonRPCClients are clients of chains by chain ID, only chains with ETH as native currency are valued
+	ethereumJsonRPCClients map[uint64]EthereumJsonRPCClient
+	priceProvider          PriceProvider
+
+	// blockTimestamps caches timestamps of blocks, they never change for finalized blocks
+	blockTimestamps map[blockKey]time.Time
+	mu              sync.Mutex
+}
+
+func NewValuator(ethereumJsonRPCClients map[uint64]EthereumJsonRPCClient, priceProvider PriceProvider) *Valuator {
+	return &Valuator{
+		ethereumJsonRPCClients: ethereumJsonRPCClients,
+		priceProvider:          priceProvider,
+		blockTimestamps:        make(map[blockKey]time.Time),
+	}
+}
+
+// ValueUSD returns value of transaction in USD with 2 decimals, like "1514.36".
+// Transactions of chains without client (native currency is not ETH) are not valued, empty string is returned
+func (v *Valuator) ValueUSD(ctx context.Context, tx *models.Transaction) (string, error) {
+	client, ok := v.ethereumJsonRPCClients[tx.ChainID]
+	if !ok {
+		return "", nil
+	}
+
+	timestamp, err := v.getBlockTimestamp(ctx, client, blockKey{chainID: tx.ChainID, blockNumber: tx.BlockNumber})
+	if err != nil {
+		return "", err
+	}
+
+	return v.valueUSD(ctx, tx, timestamp)
+}
+
+// ValuesUSD returns values of transactions in USD like ValueUSD. Timestamp of every distinct block is requested once,
+// at most maxParallelRequests blocks at a time. Value of transaction that failed to be valued is empty, its error is returned at the same index
+func (v *Valuator) ValuesUSD(ctx context.Context, transactions []*models.Transaction) ([]string, []error) {
+	timestamps, timestampErrs := v.getBlockTimestamps(ctx, transactions)
+
+	values := make([]string, len(transactions))
+	errs := make([]error, len(transactions))
+	for i, tx := range transactions {
+		if _, ok := v.ethereumJsonRPCClients[tx.ChainID]; !ok {
+			continue
+		}
+
+		block := blockKey{chainID: tx.ChainID, blockNumber: tx.BlockNumber}
+		if err, ok := timestampErrs[block]; ok {
+			errs[i] = err
+			continue
+		}
+
+		values[i], errs[i] = v.valueUSD(ctx, tx, timestamps[block])
+	}
+
+	return values, errs
+}
+
+// valueUSD returns value of transaction in USD by ETH/USD price at timestamp of its block
+func (v *Valuator) valueUSD(ctx context.Context, tx *models.Transaction, timestamp time.Time) (string, error) {
+	price, err := v.priceProvider.GetPrice(ctx, timestamp)
+	if err != nil {
+		return "", err
+	}
+
+	valueETH := new(big.Rat).SetFrac(&tx.Value, new(big.Int).Exp(big.NewInt(10), big.NewInt(models.EtherDecimals), nil))
+
+	return new(big.Rat).Mul(valueETH, price).FloatString(usdDecimals), nil
+}
+
+// getBlockTimestamps returns timestamps of distinct blocks of transactions of valued chains, blocks are requested
+// by at most maxParallelRequests goroutines. Errors are returned by blocks that failed to be requested
+func (v *Valuator) getBlockTimestamps(ctx context.Context, transactions []*models.Transaction) (map[blockKey]time.Time, map[blockKey]error) {
+	blocks := make(map[blockKey]EthereumJsonRPCClient)
+	for _, tx := range transactions {
+		client, ok := v.ethereumJsonRPCClients[tx.ChainID]
+		if ok {
+			blocks[blockKey{chainID: tx.ChainID, blockNumber: tx.BlockNumber}] = client
+		}
+	}
+
+	timestamps := make(map[blockKey]time.Time, len(blocks))
+	errs := make(map[blockKey]error)
+	mu := sync.Mutex{}
+
+	wg := sync.WaitGroup{}
+	semaphore := make(chan struct{}, maxParallelRequests)
+	for block, client := range blocks {
+		block, client := block, client
+
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+
+			timestamp, err := v.getBlockTimestamp(ctx, client, block)
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			if err != nil {
+				errs[block] = err
+				return
+			}
+
+			timestamps[block] = timestamp
+		}()
+	}
+	wg.Wait()
+
+	return timestamps, errs
+}
+
+func (v *Valuator) getBlockTimestamp(ctx context.Context, client EthereumJsonRPCClient, block blockKey) (time.Time, error) {
+	v.mu.Lock()
+	timestamp, ok := v.blockTimestamps[block]
+	v.mu.Unlock()
+
+	if ok {
+		return timestamp, nil
+	}
+
+	resp, err := client.GetBlockHeaderByNumber(ctx, jsonrpc_models.HexUint64(block.blockNumber))
+	if err != nil {
+		return time.Time{}, errors.New("error getting timestamp of block " + strconv.FormatUint(block.blockNumber, 10) + " cause: " + err.Error())
+	}
+
+	timestamp = time.Unix(int64(resp.BlockHeader.Timestamp), 0).UTC()
+
+	v.mu.Lock()
+	if len(v.blockTimestamps) >= maxCachedBlocks {
+		v.blockTimestamps = make(map[blockKey]time.Time)
+	}
+	v.blockTimestamps[block] = timestamp
+	v.mu.Unlock()
+
+	return timestamp, nil
+}
@@ -0,0 +1,169 @@
+package valuator
+
+import (
+	"context"
+	"errors"
+	ethereum_jsonrpc "github.com/bluntenpassant/ethereum_subscriber/internal/app/client/ethereum-jsonrpc"
+	jsonrpc_models "github.com/bluntenpassant/ethereum_subscriber/internal/app/client/ethereum-jsonrpc/models"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/models"
+	"github.com/stretchr/testify/assert"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+)
+
+// ethereumJsonRPCClientMock counts requests of block headers and the maximum number of requests at a time
+type ethereumJsonRPCClientMock struct {
+	timestamps map[uint64]uint64
+	delay      time.Duration
+
+	mu          sync.Mutex
+	calls       int
+	inFlight    int
+	maxInFlight int
+}
+
+func (c *ethereumJsonRPCClientMock) GetBlockHeaderByNumber(ctx context.Context, blockNumber jsonrpc_models.HexUint64) (*ethereum_jsonrpc.GetBlockHeaderByNumberResp, error) {
+	c.mu.Lock()
+	c.calls++
+	c.inFlight++
+	c.maxInFlight = max(c.maxInFlight, c.inFlight)
+	c.mu.Unlock()
+
+	time.Sleep(c.delay)
+
+	c.mu.Lock()
+	c.inFlight--
+	c.mu.Unlock()
+
+	timestamp, ok := c.timestamps[uint64(blockNumber)]
+	if !ok {
+		return nil, errors.New("block not found")
+	}
+
+	return &ethereum_jsonrpc.GetBlockHeaderByNumberResp{BlockHeader: ethereum_jsonrpc.BlockHeader{Timestamp: jsonrpc_models.HexUint64(timestamp)}}, nil
+}
+
+// priceProviderMock returns price 1500.50 since 2023-02-13
+type priceProviderMock struct{}
+
+func (p *priceProviderMock) GetPrice(ctx context.Context, at time.Time) (*big.Rat, error) {
+	if at.Before(time.Date(2023, 2, 13, 0, 0, 0, 0, time.UTC)) {
+		return nil, errors.New("no price")
+	}
+
+	return big.NewRat(150050, 100), nil
+}
+
+func TestValuator_ValueUSD(t *testing.T) {
+	type TestCase struct {
+		Name          string
+		ChainID       uint64
+		BlockNumber   uint64
+		Value         int64
+		ExpectedValue string
+		ExpectedErr   string
+	}
+
+	client := &ethereumJsonRPCClientMock{timestamps: map[uint64]uint64{
+		16614490: 1676296800, // 2023-02-13T14:00:00Z
+		1:        1438269988, // 2015-07-30
+	}}
+	valuator := NewValuator(map[uint64]EthereumJsonRPCClient{1: client}, &priceProviderMock{})
+
+	testCases := []TestCase{
+		{
+			Name:          "one ether",
+			ChainID:       1,
+			BlockNumber:   16614490,
+			Value:         1000000000000000000,
+			ExpectedValue: "1500.50",
+		},
+		{
+			Name:          "rounded value",
+			ChainID:       1,
+			BlockNumber:   16614490,
+			Value:         1234567890000000,
+			ExpectedValue: "1.85",
+		},
+		{
+			Name:        "no price at block timestamp",
+			ChainID:     1,
+			BlockNumber: 1,
+			Value:       1000000000000000000,
+			ExpectedErr: "no price",
+		},
+		{
+			Name:        "unknown block",
+			ChainID:     1,
+			BlockNumber: 2,
+			ExpectedErr: "error getting timestamp of block 2 cause: block not found",
+		},
+		{
+			Name:          "chain without ETH price",
+			ChainID:       137,
+			BlockNumber:   16614490,
+			Value:         1000000000000000000,
+			ExpectedValue: "",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			tx := &models.Transaction{ChainID: testCase.ChainID, BlockNumber: testCase.BlockNumber, Value: *big.NewInt(testCase.Value)}
+
+			value, err := valuator.ValueUSD(context.TODO(), tx)
+			if testCase.ExpectedErr != "" {
+				assert.EqualError(t, err, testCase.ExpectedErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.ExpectedValue, value)
+		})
+	}
+
+	// timestamp of block 16614490 is requested only once
+	assert.Equal(t, 3, client.calls)
+}
+
+func TestValuator_ValuesUSD(t *testing.T) {
+	timestamps := make(map[uint64]uint64)
+	transactions := make([]*models.Transaction, 0)
+	for blockNumber := uint64(16614490); blockNumber < 16614490+20; blockNumber++ {
+		timestamps[blockNumber] = 1676296800
+
+		// Every block has two transactions, its timestamp is requested once
+		for i := 0; i < 2; i++ {
+			transactions = append(transactions, &models.Transaction{ChainID: 1, BlockNumber: blockNumber, Value: *big.NewInt(1000000000000000000)})
+		}
+	}
+
+	transactions = append(transactions,
+		&models.Transaction{ChainID: 1, BlockNumber: 2, Value: *big.NewInt(1000000000000000000)},
+		&models.Transaction{ChainID: 137, BlockNumber: 16614490, Value: *big.NewInt(1000000000000000000)},
+	)
+
+	client := &ethereumJsonRPCClientMock{timestamps: timestamps, delay: 10 * time.Millisecond}
+	valuator := NewValuator(map[uint64]EthereumJsonRPCClient{1: client}, &priceProviderMock{})
+
+	values, errs := valuator.ValuesUSD(context.TODO(), transactions)
+
+	assert.Len(t, values, len(transactions))
+	assert.Len(t, errs, len(transactions))
+	for i := 0; i < 40; i++ {
+		assert.NoError(t, errs[i])
+		assert.Equal(t, "1500.50", values[i])
+	}
+
+	// Unknown block fails only its transaction, chain without ETH price is not valued
+	assert.EqualError(t, errs[40], "error getting timestamp of block 2 cause: block not found")
+	assert.Equal(t, "", values[40])
+	assert.NoError(t, errs[41])
+	assert.Equal(t, "", values[41])
+
+	assert.Equal(t, 21, client.calls)
+	assert.Greater(t, client.maxInFlight, 1)
+	assert.LessOrEqual(t, client.maxInFlight, maxParallelRequests)
+}
@@ -0,0 +1,28 @@
+// Package tenant carries ID of the tenant (API client owning subscriptions) through context.
+// Handlers attach tenant of authenticated request, repositories read it to isolate subscriptions of tenants.
+// Empty tenant ID is used when authentication is disabled, so all clients share one namespace as before
+package tenant
+
+import (
+	"context"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/models"
+)
+
+type ctxKey struct{}
+
+// WithID returns a copy of ctx carrying tenant ID
+func WithID(ctx context.Context, tenantID string) context.Context {
+	return context.WithValue(ctx, ctxKey{}, tenantID)
+}
+
+// FromContext returns tenant ID attached to ctx, empty if there is none
+func FromContext(ctx context.Context) string {
+	tenantID, _ := ctx.Value(ctxKey{}).(string)
+
+	return tenantID
+}
+
+// Key returns key of subscription of address by tenant attached to ctx
+func Key(ctx context.Context, address models.Address) models.SubscriberKey {
+	return models.SubscriberKey{TenantID: FromContext(ctx), Address: address}
+}
@@ -0,0 +1,87 @@
+// Package tracing configures OpenTelemetry tracing of the service.
+// Components create spans through global tracer provider (otel.Tracer), which is no-op until Init
+// configures OTLP exporter, so tracing costs nothing when it is disabled.
+package tracing
+
+import (
+	"context"
+	"github.com/bluntenpassant/ethereum_subscriber/config"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// defaultServiceName is used as service.name resource attribute when it is not configured
+const defaultServiceName = "ethereum_subscriber"
+
+// InstrumentationName is a name of tracer used by all components of the service
+const InstrumentationName = "github.com/bluntenpassant/ethereum_subscriber"
+
+// Init sets up global tracer provider exporting spans through OTLP/HTTP to configured endpoint
+// and W3C Trace Context propagation. If endpoint is empty, tracing stays no-op.
+// Returned shutdown function flushes buffered spans and should be called before exit
+func Init(ctx context.Context, tracingConfig config.Tracing) (func(ctx context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	if tracingConfig.Endpoint == "" {
+		return func(ctx context.Context) error { return nil }, nil
+	}
+
+	options := []otlptracehttp.Option{otlptracehttp.WithEndpoint(tracingConfig.Endpoint)}
+	if tracingConfig.Insecure {
+		options = append(options, otlptracehttp.WithInsecure())
+	}
+
+	exporter, err := otlptracehttp.New(ctx, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	serviceName := tracingConfig.ServiceName
+	if serviceName == "" {
+		serviceName = defaultServiceName
+	}
+
+	// Sample ratio 0 is treated as not configured, use endpoint = "" to disable tracing
+	sampler := sdktrace.AlwaysSample()
+	if tracingConfig.SampleRatio > 0 && tracingConfig.SampleRatio < 1 {
+		sampler = sdktrace.TraceIDRatioBased(tracingConfig.SampleRatio)
+	}
+
+	tracerProvider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sampler)),
+		sdktrace.WithResource(resource.NewSchemaless(semconv.ServiceName(serviceName))),
+	)
+
+	otel.SetTracerProvider(tracerProvider)
+
+	return tracerProvider.Shutdown, nil
+}
+
+// Start starts span with given attributes using the tracer of the service
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return StartWithKind(ctx, name, trace.SpanKindInternal, attrs...)
+}
+
+// StartWithKind is the same as Start but allows to set span kind, e.g. for client and server spans
+func StartWithKind(ctx context.Context, name string, kind trace.SpanKind, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(InstrumentationName).Start(ctx, name, trace.WithSpanKind(kind), trace.WithAttributes(attrs...))
+}
+
+// Error marks span as failed with err and returns err, so it can be used in return statements:
+// return nil, tracing.Error(span, err)
+func Error(span trace.Span, err error) error {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	return err
+}
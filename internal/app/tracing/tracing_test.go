@@ -0,0 +1,55 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"github.com/bluntenpassant/ethereum_subscriber/config"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"testing"
+)
+
+func TestInit(t *testing.T) {
+	shutdown, err := Init(context.TODO(), config.Tracing{})
+	assert.NoError(t, err)
+	assert.NoError(t, shutdown(context.TODO()))
+
+	// Without configured endpoint spans are not recorded
+	_, span := Start(context.TODO(), "noop")
+	assert.False(t, span.IsRecording())
+	span.End()
+}
+
+func TestStart(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tracerProvider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(tracerProvider)
+	defer otel.SetTracerProvider(previous)
+
+	ctx, parent := Start(context.TODO(), "sync_parser.GetTransactions", attribute.String("address", "0x45849a974058661eb2128aceb60d2c6ed99e2a14"))
+
+	_, child := Start(ctx, "scan")
+	assert.Equal(t, errors.New("block not found"), Error(child, errors.New("block not found")))
+	child.End()
+
+	assert.NoError(t, Error(parent, nil))
+	parent.End()
+
+	spans := recorder.Ended()
+	assert.Len(t, spans, 2)
+
+	assert.Equal(t, "scan", spans[0].Name())
+	assert.Equal(t, parent.SpanContext().SpanID(), spans[0].Parent().SpanID())
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	assert.Equal(t, "block not found", spans[0].Status().Description)
+
+	assert.Equal(t, "sync_parser.GetTransactions", spans[1].Name())
+	assert.Equal(t, codes.Unset, spans[1].Status().Code)
+	assert.Contains(t, spans[1].Attributes(), attribute.String("address", "0x45849a974058661eb2128aceb60d2c6ed99e2a14"))
+}
@@ -0,0 +1,52 @@
+package redis
+
+import (
+	"context"
+	"github.com/redis/go-redis/v9"
+	"log/slog"
+	"net"
+	"time"
+)
+
+// loggingHook logs every redis command on debug level with request ID from context,
+// redis.Nil is not treated as an error because it only means that key does not exist
+type loggingHook struct {
+	logger *slog.Logger
+}
+
+func (h *loggingHook) DialHook(next redis.DialHook) redis.DialHook {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		return next(ctx, network, addr)
+	}
+}
+
+func (h *loggingHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		start := time.Now()
+
+		err := next(ctx, cmd)
+		h.log(ctx, cmd.Name(), 1, time.Since(start), err)
+
+		return err
+	}
+}
+
+func (h *loggingHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		start := time.Now()
+
+		err := next(ctx, cmds)
+		h.log(ctx, "pipeline", len(cmds), time.Since(start), err)
+
+		return err
+	}
+}
+
+func (h *loggingHook) log(ctx context.Context, command string, commands int, duration time.Duration, err error) {
+	attrs := []any{"command", command, "commands", commands, "duration", duration}
+	if err != nil && err != redis.Nil {
+		attrs = append(attrs, "error", err.Error())
+	}
+
+	h.logger.DebugContext(ctx, "redis command", attrs...)
+}
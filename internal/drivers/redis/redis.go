@@ -3,10 +3,14 @@ package redis
 import (
 	"context"
 	"github.com/redis/go-redis/v9"
+	"log/slog"
 )
 
-func NewRedisClient(ctx context.Context, options *redis.Options) (*redis.Client, error) {
+// NewRedisClient returns redis client that is checked with Ping and logs every command on debug level
+func NewRedisClient(ctx context.Context, options *redis.Options, logger *slog.Logger) (*redis.Client, error) {
 	rdb := redis.NewClient(options)
+	rdb.AddHook(&tracingHook{})
+	rdb.AddHook(&loggingHook{logger: logger})
 
 	cmd := rdb.Ping(ctx)
 	err := cmd.Err()
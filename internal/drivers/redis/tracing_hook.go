@@ -0,0 +1,51 @@
+package redis
+
+import (
+	"context"
+	"github.com/bluntenpassant/ethereum_subscriber/internal/app/tracing"
+	"github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"net"
+)
+
+// tracingHook creates client span for every redis command and pipeline,
+// redis.Nil is not treated as an error because it only means that key does not exist
+type tracingHook struct{}
+
+func (h *tracingHook) DialHook(next redis.DialHook) redis.DialHook {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		return next(ctx, network, addr)
+	}
+}
+
+func (h *tracingHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		ctx, span := tracing.StartWithKind(ctx, "redis "+cmd.Name(), trace.SpanKindClient,
+			attribute.String("db.system", "redis"), attribute.String("db.operation", cmd.Name()))
+		defer span.End()
+
+		err := next(ctx, cmd)
+		if err != redis.Nil {
+			tracing.Error(span, err)
+		}
+
+		return err
+	}
+}
+
+func (h *tracingHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		ctx, span := tracing.StartWithKind(ctx, "redis pipeline", trace.SpanKindClient,
+			attribute.String("db.system", "redis"), attribute.String("db.operation", "pipeline"),
+			attribute.Int("db.redis.commands", len(cmds)))
+		defer span.End()
+
+		err := next(ctx, cmds)
+		if err != redis.Nil {
+			tracing.Error(span, err)
+		}
+
+		return err
+	}
+}
@@ -0,0 +1,4639 @@
+// Package client provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.3.0 DO NOT EDIT.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/oapi-codegen/runtime"
+)
+
+const (
+	AdminKeyScopes  = "adminKey.Scopes"
+	BearerKeyScopes = "bearerKey.Scopes"
+	HeaderKeyScopes = "headerKey.Scopes"
+)
+
+// Defines values for APIErrorCode.
+const (
+	AbiNotFound               APIErrorCode = "abi_not_found"
+	AlreadySubscribed         APIErrorCode = "already_subscribed"
+	EnsNotResolved            APIErrorCode = "ens_not_resolved"
+	Forbidden                 APIErrorCode = "forbidden"
+	Internal                  APIErrorCode = "internal"
+	InvalidAddress            APIErrorCode = "invalid_address"
+	InvalidRequest            APIErrorCode = "invalid_request"
+	LabelsNotFound            APIErrorCode = "labels_not_found"
+	MethodNotAllowed          APIErrorCode = "method_not_allowed"
+	NotFound                  APIErrorCode = "not_found"
+	NotReady                  APIErrorCode = "not_ready"
+	NotSubscribed             APIErrorCode = "not_subscribed"
+	RateLimited               APIErrorCode = "rate_limited"
+	SubscriptionsLimitReached APIErrorCode = "subscriptions_limit_reached"
+	Unauthorized              APIErrorCode = "unauthorized"
+	UnknownChain              APIErrorCode = "unknown_chain"
+	UpstreamError             APIErrorCode = "upstream_error"
+)
+
+// Defines values for ComponentStatusStatus.
+const (
+	ComponentStatusStatusFail ComponentStatusStatus = "fail"
+	ComponentStatusStatusOk   ComponentStatusStatus = "ok"
+)
+
+// Defines values for ContractABIRespSource.
+const (
+	Api       ContractABIRespSource = "api"
+	Directory ContractABIRespSource = "directory"
+)
+
+// Defines values for Direction.
+const (
+	Incoming Direction = "incoming"
+	Outgoing Direction = "outgoing"
+	Self     Direction = "self"
+)
+
+// Defines values for HealthReportStatus.
+const (
+	HealthReportStatusFail HealthReportStatus = "fail"
+	HealthReportStatusOk   HealthReportStatus = "ok"
+)
+
+// Defines values for HealthRespStatus.
+const (
+	Ok HealthRespStatus = "ok"
+)
+
+// Defines values for AmountsMode.
+const (
+	AmountsModeDecimal AmountsMode = "decimal"
+	AmountsModeRaw     AmountsMode = "raw"
+)
+
+// Defines values for AmountsModeV2.
+const (
+	AmountsModeV2Decimal AmountsModeV2 = "decimal"
+	AmountsModeV2Raw     AmountsModeV2 = "raw"
+)
+
+// Defines values for ExportTransactionsParamsFormat.
+const (
+	Csv     ExportTransactionsParamsFormat = "csv"
+	Ndjson  ExportTransactionsParamsFormat = "ndjson"
+	Parquet ExportTransactionsParamsFormat = "parquet"
+)
+
+// Defines values for GetTransactionsParamsAmounts.
+const (
+	GetTransactionsParamsAmountsDecimal GetTransactionsParamsAmounts = "decimal"
+	GetTransactionsParamsAmountsRaw     GetTransactionsParamsAmounts = "raw"
+)
+
+// Defines values for GetSubscriptionTransactionsParamsAmounts.
+const (
+	GetSubscriptionTransactionsParamsAmountsDecimal GetSubscriptionTransactionsParamsAmounts = "decimal"
+	GetSubscriptionTransactionsParamsAmountsRaw     GetSubscriptionTransactionsParamsAmounts = "raw"
+)
+
+// APIError defines model for APIError.
+type APIError struct {
+	// Code Stable code of the error, clients should rely on it instead of the message
+	Code APIErrorCode `json:"code"`
+
+	// Message Human readable description, it may change between versions
+	Message string `json:"message"`
+}
+
+// APIErrorCode Stable code of the error, clients should rely on it instead of the message
+type APIErrorCode string
+
+// APIKeyResp defines model for APIKeyResp.
+type APIKeyResp struct {
+	// ApiKey Raw key, returned only when key is created
+	ApiKey    *string   `json:"api_key,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	Id        string    `json:"id"`
+	TenantId  string    `json:"tenant_id"`
+}
+
+// Address Ethereum address, 20 bytes in hexadecimal with 0x prefix
+type Address = string
+
+// AddressLabelsResp defines model for AddressLabelsResp.
+type AddressLabelsResp struct {
+	// Address Ethereum address, 20 bytes in hexadecimal with 0x prefix
+	Address   Address   `json:"address"`
+	Labels    []string  `json:"labels"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// BalanceResp defines model for BalanceResp.
+type BalanceResp struct {
+	// Balance Unsigned integer of arbitrary size as a decimal string
+	Balance BigInteger `json:"balance"`
+
+	// BalanceEth Non-negative decimal number as a string
+	BalanceEth Decimal `json:"balanceEth"`
+
+	// BlockNumber Number of the block after which balance was recorded
+	BlockNumber uint64 `json:"blockNumber"`
+}
+
+// BigInteger Unsigned integer of arbitrary size as a decimal string
+type BigInteger = string
+
+// Chain defines model for Chain.
+type Chain struct {
+	// ChainId Chain ID (EIP-155), e.g. 1 for Ethereum Mainnet
+	ChainId uint64 `json:"chainId"`
+
+	// Name Short name of the chain used to select it
+	Name string `json:"name"`
+
+	// NativeCurrency Symbol of native currency of the chain
+	NativeCurrency string `json:"nativeCurrency"`
+}
+
+// ComponentStatus defines model for ComponentStatus.
+type ComponentStatus struct {
+	Details *string               `json:"details,omitempty"`
+	Error   *string               `json:"error,omitempty"`
+	Status  ComponentStatusStatus `json:"status"`
+}
+
+// ComponentStatusStatus defines model for ComponentStatus.Status.
+type ComponentStatusStatus string
+
+// ConfigResp defines model for ConfigResp.
+type ConfigResp struct {
+	// Config Effective config with the same keys as in config file, secrets are masked
+	Config map[string]interface{} `json:"config"`
+
+	// ReloadError Error of the last reload, omitted if it was successful
+	ReloadError *string `json:"reload_error,omitempty"`
+
+	// ReloadedAt Time of the last reload or start of the service
+	ReloadedAt time.Time `json:"reloaded_at"`
+}
+
+// ContractABIResp defines model for ContractABIResp.
+type ContractABIResp struct {
+	// Abi JSON ABI, it is returned only for a single contract
+	Abi *[]map[string]interface{} `json:"abi,omitempty"`
+
+	// Address Ethereum address, 20 bytes in hexadecimal with 0x prefix
+	Address Address `json:"address"`
+	Chain   string  `json:"chain"`
+	ChainId uint64  `json:"chain_id"`
+
+	// Methods Canonical signatures of functions that are decoded
+	Methods []string `json:"methods"`
+
+	// Source directory for ABI shared by all tenants, api for ABI uploaded by the tenant
+	Source    ContractABIRespSource `json:"source"`
+	UpdatedAt time.Time             `json:"updated_at"`
+}
+
+// ContractABIRespSource directory for ABI shared by all tenants, api for ABI uploaded by the tenant
+type ContractABIRespSource string
+
+// CreateAPIKeyReq defines model for CreateAPIKeyReq.
+type CreateAPIKeyReq struct {
+	// TenantId Lowercase letters, digits and '_'
+	TenantId string `json:"tenant_id"`
+}
+
+// CreateSubscriptionReq defines model for CreateSubscriptionReq.
+type CreateSubscriptionReq struct {
+	// Address Ethereum address or ENS name like vitalik.eth
+	Address string `json:"address"`
+}
+
+// Decimal Non-negative decimal number as a string
+type Decimal = string
+
+// DecimalTransaction Transaction with amounts as decimal strings, value in ETH and gas price in gwei, it is described relative to the subscriber
+type DecimalTransaction struct {
+	BlockHash   string `json:"blockHash"`
+	BlockNumber uint64 `json:"blockNumber"`
+	ChainId     uint64 `json:"chainId"`
+
+	// Counterparty Other side of transaction, it is the subscriber itself for self transactions and empty for contract creation
+	Counterparty OptionalAddress `json:"counterparty"`
+
+	// CounterpartyLabels Labels of counterparty in address book of the tenant
+	CounterpartyLabels []string `json:"counterpartyLabels"`
+
+	// DecodedCall Call of contract decoded by its ABI, it is null if contract has no ABI or input is not a call of its function
+	DecodedCall *DecodedCall `json:"decodedCall"`
+
+	// Direction Direction of transaction relative to the subscriber, self if subscriber sent it to itself
+	Direction Direction `json:"direction"`
+
+	// From Ethereum address, 20 bytes in hexadecimal with 0x prefix
+	From Address `json:"from"`
+
+	// Gas Unsigned integer of arbitrary size as a decimal string
+	Gas BigInteger `json:"gas"`
+
+	// GasPrice Unsigned integer of arbitrary size as a decimal string
+	GasPrice BigInteger `json:"gasPrice"`
+
+	// GasPriceGwei Non-negative decimal number as a string
+	GasPriceGwei Decimal `json:"gasPriceGwei"`
+	Hash         string  `json:"hash"`
+	Input        string  `json:"input"`
+	Nonce        uint64  `json:"nonce"`
+
+	// R Unsigned integer of arbitrary size as a decimal string
+	R BigInteger `json:"r"`
+
+	// S Unsigned integer of arbitrary size as a decimal string
+	S BigInteger `json:"s"`
+
+	// To Ethereum address or empty string, e.g. recipient of contract creation
+	To               OptionalAddress `json:"to"`
+	TransactionIndex uint64          `json:"transactionIndex"`
+
+	// V Unsigned integer of arbitrary size as a decimal string
+	V BigInteger `json:"v"`
+
+	// Value Unsigned integer of arbitrary size as a decimal string
+	Value BigInteger `json:"value"`
+
+	// ValueEth Non-negative decimal number as a string
+	ValueEth Decimal `json:"valueEth"`
+
+	// ValueUsd Value in USD at timestamp of the block, set only if price provider is configured
+	ValueUsd *Decimal `json:"valueUsd,omitempty"`
+}
+
+// DecodedArgument defines model for DecodedArgument.
+type DecodedArgument struct {
+	Name string `json:"name"`
+
+	// Type Canonical ABI type, tuples are rendered like (address,uint256)
+	Type string `json:"type"`
+
+	// Value Integers are decimal strings, addresses are EIP-55 checksum encoded, bytes are hex strings, arrays are lists of values
+	// and tuples are lists of DecodedArgument
+	Value interface{} `json:"value"`
+}
+
+// DecodedCall defines model for DecodedCall.
+type DecodedCall struct {
+	Arguments []DecodedArgument `json:"arguments"`
+	Method    string            `json:"method"`
+
+	// Selector The first 4 bytes of Keccak-256 hash of the signature the input starts with
+	Selector string `json:"selector"`
+
+	// Signature Canonical signature of the method
+	Signature string `json:"signature"`
+}
+
+// Direction Direction of transaction relative to the subscriber, self if subscriber sent it to itself
+type Direction string
+
+// ErrorResp defines model for ErrorResp.
+type ErrorResp struct {
+	Error APIError `json:"error"`
+}
+
+// GetABIsResp defines model for GetABIsResp.
+type GetABIsResp struct {
+	Abis []ContractABIResp `json:"abis"`
+}
+
+// GetAPIKeysResp defines model for GetAPIKeysResp.
+type GetAPIKeysResp struct {
+	Keys []APIKeyResp `json:"keys"`
+}
+
+// GetAddressLabelsResp defines model for GetAddressLabelsResp.
+type GetAddressLabelsResp struct {
+	Labels []AddressLabelsResp `json:"labels"`
+}
+
+// GetBalanceHistoryResp defines model for GetBalanceHistoryResp.
+type GetBalanceHistoryResp struct {
+	// Address Ethereum address, 20 bytes in hexadecimal with 0x prefix
+	Address Address       `json:"address"`
+	History []BalanceResp `json:"history"`
+}
+
+// GetBalanceResp defines model for GetBalanceResp.
+type GetBalanceResp struct {
+	// Address Ethereum address, 20 bytes in hexadecimal with 0x prefix
+	Address Address `json:"address"`
+
+	// Balance Unsigned integer of arbitrary size as a decimal string
+	Balance BigInteger `json:"balance"`
+
+	// BalanceEth Non-negative decimal number as a string
+	BalanceEth Decimal `json:"balanceEth"`
+
+	// BlockNumber Number of the block after which balance was recorded
+	BlockNumber uint64 `json:"blockNumber"`
+}
+
+// GetChainsResp defines model for GetChainsResp.
+type GetChainsResp struct {
+	Chains []Chain `json:"chains"`
+}
+
+// GetCurrentBlockResp defines model for GetCurrentBlockResp.
+type GetCurrentBlockResp struct {
+	CurrentBlock uint64 `json:"current_block"`
+}
+
+// GetDecimalTransactionsResp defines model for GetDecimalTransactionsResp.
+type GetDecimalTransactionsResp struct {
+	Transactions []DecimalTransaction `json:"transactions"`
+}
+
+// GetRawTransactionsResp defines model for GetRawTransactionsResp.
+type GetRawTransactionsResp struct {
+	Transactions []RawTransaction `json:"transactions"`
+}
+
+// GetSubscriptionsResp defines model for GetSubscriptionsResp.
+type GetSubscriptionsResp struct {
+	Subscriptions []SubscriptionResp `json:"subscriptions"`
+}
+
+// GraphQLError defines model for GraphQLError.
+type GraphQLError struct {
+	Extensions *struct {
+		Code *string `json:"code,omitempty"`
+	} `json:"extensions,omitempty"`
+	Locations *[]struct {
+		Column *int `json:"column,omitempty"`
+		Line   *int `json:"line,omitempty"`
+	} `json:"locations,omitempty"`
+	Message string         `json:"message"`
+	Path    *[]interface{} `json:"path,omitempty"`
+}
+
+// GraphQLRequest defines model for GraphQLRequest.
+type GraphQLRequest struct {
+	OperationName *string                 `json:"operationName,omitempty"`
+	Query         string                  `json:"query"`
+	Variables     *map[string]interface{} `json:"variables,omitempty"`
+}
+
+// GraphQLResponse defines model for GraphQLResponse.
+type GraphQLResponse struct {
+	Data   *map[string]interface{} `json:"data"`
+	Errors *[]GraphQLError         `json:"errors,omitempty"`
+}
+
+// HealthReport defines model for HealthReport.
+type HealthReport struct {
+	// Components Status of every checked dependency by its name (redis, ethereum_jsonrpc, indexing_lag)
+	Components map[string]ComponentStatus `json:"components"`
+	Status     HealthReportStatus         `json:"status"`
+}
+
+// HealthReportStatus defines model for HealthReport.Status.
+type HealthReportStatus string
+
+// HealthResp defines model for HealthResp.
+type HealthResp struct {
+	Status HealthRespStatus `json:"status"`
+}
+
+// HealthRespStatus defines model for HealthResp.Status.
+type HealthRespStatus string
+
+// OKResp defines model for OKResp.
+type OKResp struct {
+	IsOk bool `json:"is_ok"`
+}
+
+// OptionalAddress Ethereum address or empty string, e.g. recipient of contract creation
+type OptionalAddress = string
+
+// RawInteger Unsigned integer of arbitrary size as a JSON number, clients parsing numbers as 64-bit floats lose its precision
+type RawInteger = json.Number
+
+// RawTransaction Transaction with amounts in wei as JSON numbers, it is described relative to the subscriber
+type RawTransaction struct {
+	BlockHash   string `json:"blockHash"`
+	BlockNumber uint64 `json:"blockNumber"`
+	ChainId     uint64 `json:"chainId"`
+
+	// Counterparty Other side of transaction, it is the subscriber itself for self transactions and empty for contract creation
+	Counterparty OptionalAddress `json:"counterparty"`
+
+	// CounterpartyLabels Labels of counterparty in address book of the tenant
+	CounterpartyLabels []string `json:"counterpartyLabels"`
+
+	// DecodedCall Call of contract decoded by its ABI, it is null if contract has no ABI or input is not a call of its function
+	DecodedCall *DecodedCall `json:"decodedCall"`
+
+	// Direction Direction of transaction relative to the subscriber, self if subscriber sent it to itself
+	Direction Direction `json:"direction"`
+
+	// From Ethereum address, 20 bytes in hexadecimal with 0x prefix
+	From Address `json:"from"`
+
+	// Gas Unsigned integer of arbitrary size as a JSON number, clients parsing numbers as 64-bit floats lose its precision
+	Gas RawInteger `json:"gas"`
+
+	// GasPrice Unsigned integer of arbitrary size as a JSON number, clients parsing numbers as 64-bit floats lose its precision
+	GasPrice RawInteger `json:"gasPrice"`
+	Hash     string     `json:"hash"`
+	Input    string     `json:"input"`
+	Nonce    uint64     `json:"nonce"`
+
+	// R Unsigned integer of arbitrary size as a JSON number, clients parsing numbers as 64-bit floats lose its precision
+	R RawInteger `json:"r"`
+
+	// S Unsigned integer of arbitrary size as a JSON number, clients parsing numbers as 64-bit floats lose its precision
+	S RawInteger `json:"s"`
+
+	// To Ethereum address or empty string, e.g. recipient of contract creation
+	To               OptionalAddress `json:"to"`
+	TransactionIndex uint64          `json:"transactionIndex"`
+
+	// V Unsigned integer of arbitrary size as a JSON number, clients parsing numbers as 64-bit floats lose its precision
+	V RawInteger `json:"v"`
+
+	// Value Unsigned integer of arbitrary size as a JSON number, clients parsing numbers as 64-bit floats lose its precision
+	Value RawInteger `json:"value"`
+}
+
+// SetABIReq defines model for SetABIReq.
+type SetABIReq struct {
+	// Abi JSON ABI of the contract or compiler artifact (Hardhat, Truffle or Foundry) with abi field
+	Abi SetABIReq_Abi `json:"abi"`
+}
+
+// SetABIReqAbi0 defines model for .
+type SetABIReqAbi0 = []map[string]interface{}
+
+// SetABIReqAbi1 defines model for .
+type SetABIReqAbi1 = map[string]interface{}
+
+// SetABIReq_Abi JSON ABI of the contract or compiler artifact (Hardhat, Truffle or Foundry) with abi field
+type SetABIReq_Abi struct {
+	union json.RawMessage
+}
+
+// SetLabelsReq defines model for SetLabelsReq.
+type SetLabelsReq struct {
+	// Labels Labels that replace current labels of the address, they are trimmed and deduplicated
+	Labels []string `json:"labels"`
+}
+
+// SubscribeResp defines model for SubscribeResp.
+type SubscribeResp struct {
+	// Address Ethereum address, 20 bytes in hexadecimal with 0x prefix
+	Address Address `json:"address"`
+	IsOk    bool    `json:"is_ok"`
+}
+
+// SubscriptionResp defines model for SubscriptionResp.
+type SubscriptionResp struct {
+	// Address Ethereum address, 20 bytes in hexadecimal with 0x prefix
+	Address Address `json:"address"`
+	Chain   string  `json:"chain"`
+	ChainId uint64  `json:"chain_id"`
+
+	// EnsName ENS name used for subscription, omitted if address was subscribed
+	EnsName *string `json:"ens_name,omitempty"`
+}
+
+// AddressPath Ethereum address, 20 bytes in hexadecimal with 0x prefix
+type AddressPath = Address
+
+// AmountsMode defines model for AmountsMode.
+type AmountsMode string
+
+// AmountsModeV2 defines model for AmountsModeV2.
+type AmountsModeV2 string
+
+// ChainSelector defines model for ChainSelector.
+type ChainSelector = string
+
+// Error defines model for Error.
+type Error = ErrorResp
+
+// Transactions defines model for Transactions.
+type Transactions struct {
+	union json.RawMessage
+}
+
+// ExportTransactionsParams defines parameters for ExportTransactions.
+type ExportTransactionsParams struct {
+	// Format Format of file, csv by default
+	Format *ExportTransactionsParamsFormat `form:"format,omitempty" json:"format,omitempty"`
+
+	// Chain Name or chain ID of the chain, the default chain is used if it is not set
+	Chain *ChainSelector `form:"chain,omitempty" json:"chain,omitempty"`
+}
+
+// ExportTransactionsParamsFormat defines parameters for ExportTransactions.
+type ExportTransactionsParamsFormat string
+
+// GetBalanceParams defines parameters for GetBalance.
+type GetBalanceParams struct {
+	// Chain Name or chain ID of the chain, the default chain is used if it is not set
+	Chain *ChainSelector `form:"chain,omitempty" json:"chain,omitempty"`
+}
+
+// GetBalanceHistoryParams defines parameters for GetBalanceHistory.
+type GetBalanceHistoryParams struct {
+	// Chain Name or chain ID of the chain, the default chain is used if it is not set
+	Chain *ChainSelector `form:"chain,omitempty" json:"chain,omitempty"`
+}
+
+// GetCurrentBlockParams defines parameters for GetCurrentBlock.
+type GetCurrentBlockParams struct {
+	// Chain Name or chain ID of the chain, the default chain is used if it is not set
+	Chain *ChainSelector `form:"chain,omitempty" json:"chain,omitempty"`
+}
+
+// GetTransactionsParams defines parameters for GetTransactions.
+type GetTransactionsParams struct {
+	// Amounts Rendering of amounts, decimal returns decimal strings with values in ETH and gas prices in gwei and USD value
+	// if price provider is configured, raw returns amounts in wei as JSON numbers
+	Amounts *GetTransactionsParamsAmounts `form:"amounts,omitempty" json:"amounts,omitempty"`
+
+	// Chain Name or chain ID of the chain, the default chain is used if it is not set
+	Chain *ChainSelector `form:"chain,omitempty" json:"chain,omitempty"`
+}
+
+// GetTransactionsParamsAmounts defines parameters for GetTransactions.
+type GetTransactionsParamsAmounts string
+
+// SubscribeParams defines parameters for Subscribe.
+type SubscribeParams struct {
+	// Chain Name or chain ID of the chain, the default chain is used if it is not set
+	Chain *ChainSelector `form:"chain,omitempty" json:"chain,omitempty"`
+}
+
+// GetABIsParams defines parameters for GetABIs.
+type GetABIsParams struct {
+	// Chain Name or chain ID of the chain, the default chain is used if it is not set
+	Chain *ChainSelector `form:"chain,omitempty" json:"chain,omitempty"`
+}
+
+// DeleteABIParams defines parameters for DeleteABI.
+type DeleteABIParams struct {
+	// Chain Name or chain ID of the chain, the default chain is used if it is not set
+	Chain *ChainSelector `form:"chain,omitempty" json:"chain,omitempty"`
+}
+
+// GetABIParams defines parameters for GetABI.
+type GetABIParams struct {
+	// Chain Name or chain ID of the chain, the default chain is used if it is not set
+	Chain *ChainSelector `form:"chain,omitempty" json:"chain,omitempty"`
+}
+
+// SetABIParams defines parameters for SetABI.
+type SetABIParams struct {
+	// Chain Name or chain ID of the chain, the default chain is used if it is not set
+	Chain *ChainSelector `form:"chain,omitempty" json:"chain,omitempty"`
+}
+
+// GetCurrentBlockV2Params defines parameters for GetCurrentBlockV2.
+type GetCurrentBlockV2Params struct {
+	// Chain Name or chain ID of the chain, the default chain is used if it is not set
+	Chain *ChainSelector `form:"chain,omitempty" json:"chain,omitempty"`
+}
+
+// GetSubscriptionsParams defines parameters for GetSubscriptions.
+type GetSubscriptionsParams struct {
+	// Chain Name or chain ID of the chain, the default chain is used if it is not set
+	Chain *ChainSelector `form:"chain,omitempty" json:"chain,omitempty"`
+}
+
+// CreateSubscriptionParams defines parameters for CreateSubscription.
+type CreateSubscriptionParams struct {
+	// Chain Name or chain ID of the chain, the default chain is used if it is not set
+	Chain *ChainSelector `form:"chain,omitempty" json:"chain,omitempty"`
+}
+
+// DeleteSubscriptionParams defines parameters for DeleteSubscription.
+type DeleteSubscriptionParams struct {
+	// Chain Name or chain ID of the chain, the default chain is used if it is not set
+	Chain *ChainSelector `form:"chain,omitempty" json:"chain,omitempty"`
+}
+
+// GetSubscriptionParams defines parameters for GetSubscription.
+type GetSubscriptionParams struct {
+	// Chain Name or chain ID of the chain, the default chain is used if it is not set
+	Chain *ChainSelector `form:"chain,omitempty" json:"chain,omitempty"`
+}
+
+// GetSubscriptionTransactionsParams defines parameters for GetSubscriptionTransactions.
+type GetSubscriptionTransactionsParams struct {
+	// Amounts Rendering of amounts, decimal returns decimal strings with values in ETH and gas prices in gwei and USD value
+	// if price provider is configured, raw returns amounts in wei as JSON numbers
+	Amounts *GetSubscriptionTransactionsParamsAmounts `form:"amounts,omitempty" json:"amounts,omitempty"`
+
+	// Chain Name or chain ID of the chain, the default chain is used if it is not set
+	Chain *ChainSelector `form:"chain,omitempty" json:"chain,omitempty"`
+}
+
+// GetSubscriptionTransactionsParamsAmounts defines parameters for GetSubscriptionTransactions.
+type GetSubscriptionTransactionsParamsAmounts string
+
+// CreateAPIKeyJSONRequestBody defines body for CreateAPIKey for application/json ContentType.
+type CreateAPIKeyJSONRequestBody = CreateAPIKeyReq
+
+// GraphqlJSONRequestBody defines body for Graphql for application/json ContentType.
+type GraphqlJSONRequestBody = GraphQLRequest
+
+// SetABIJSONRequestBody defines body for SetABI for application/json ContentType.
+type SetABIJSONRequestBody = SetABIReq
+
+// SetLabelsJSONRequestBody defines body for SetLabels for application/json ContentType.
+type SetLabelsJSONRequestBody = SetLabelsReq
+
+// CreateSubscriptionJSONRequestBody defines body for CreateSubscription for application/json ContentType.
+type CreateSubscriptionJSONRequestBody = CreateSubscriptionReq
+
+// AsSetABIReqAbi0 returns the union data inside the SetABIReq_Abi as a SetABIReqAbi0
+func (t SetABIReq_Abi) AsSetABIReqAbi0() (SetABIReqAbi0, error) {
+	var body SetABIReqAbi0
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromSetABIReqAbi0 overwrites any union data inside the SetABIReq_Abi as the provided SetABIReqAbi0
+func (t *SetABIReq_Abi) FromSetABIReqAbi0(v SetABIReqAbi0) error {
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeSetABIReqAbi0 performs a merge with any union data inside the SetABIReq_Abi, using the provided SetABIReqAbi0
+func (t *SetABIReq_Abi) MergeSetABIReqAbi0(v SetABIReqAbi0) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JSONMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
+// AsSetABIReqAbi1 returns the union data inside the SetABIReq_Abi as a SetABIReqAbi1
+func (t SetABIReq_Abi) AsSetABIReqAbi1() (SetABIReqAbi1, error) {
+	var body SetABIReqAbi1
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromSetABIReqAbi1 overwrites any union data inside the SetABIReq_Abi as the provided SetABIReqAbi1
+func (t *SetABIReq_Abi) FromSetABIReqAbi1(v SetABIReqAbi1) error {
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeSetABIReqAbi1 performs a merge with any union data inside the SetABIReq_Abi, using the provided SetABIReqAbi1
+func (t *SetABIReq_Abi) MergeSetABIReqAbi1(v SetABIReqAbi1) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JSONMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
+func (t SetABIReq_Abi) MarshalJSON() ([]byte, error) {
+	b, err := t.union.MarshalJSON()
+	return b, err
+}
+
+func (t *SetABIReq_Abi) UnmarshalJSON(b []byte) error {
+	err := t.union.UnmarshalJSON(b)
+	return err
+}
+
+// AsGetDecimalTransactionsResp returns the union data inside the Transactions as a GetDecimalTransactionsResp
+func (t Transactions) AsGetDecimalTransactionsResp() (GetDecimalTransactionsResp, error) {
+	var body GetDecimalTransactionsResp
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromGetDecimalTransactionsResp overwrites any union data inside the Transactions as the provided GetDecimalTransactionsResp
+func (t *Transactions) FromGetDecimalTransactionsResp(v GetDecimalTransactionsResp) error {
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeGetDecimalTransactionsResp performs a merge with any union data inside the Transactions, using the provided GetDecimalTransactionsResp
+func (t *Transactions) MergeGetDecimalTransactionsResp(v GetDecimalTransactionsResp) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JSONMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
+// AsGetRawTransactionsResp returns the union data inside the Transactions as a GetRawTransactionsResp
+func (t Transactions) AsGetRawTransactionsResp() (GetRawTransactionsResp, error) {
+	var body GetRawTransactionsResp
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromGetRawTransactionsResp overwrites any union data inside the Transactions as the provided GetRawTransactionsResp
+func (t *Transactions) FromGetRawTransactionsResp(v GetRawTransactionsResp) error {
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeGetRawTransactionsResp performs a merge with any union data inside the Transactions, using the provided GetRawTransactionsResp
+func (t *Transactions) MergeGetRawTransactionsResp(v GetRawTransactionsResp) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JSONMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
+func (t Transactions) MarshalJSON() ([]byte, error) {
+	b, err := t.union.MarshalJSON()
+	return b, err
+}
+
+func (t *Transactions) UnmarshalJSON(b []byte) error {
+	err := t.union.UnmarshalJSON(b)
+	return err
+}
+
+// RequestEditorFn  is the function signature for the RequestEditor callback function
+type RequestEditorFn func(ctx context.Context, req *http.Request) error
+
+// Doer performs HTTP requests.
+//
+// The standard http.Client implements this interface.
+type HttpRequestDoer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Client which conforms to the OpenAPI3 specification for this service.
+type Client struct {
+	// The endpoint of the server conforming to this interface, with scheme,
+	// https://api.deepmap.com for example. This can contain a path relative
+	// to the server, such as https://api.deepmap.com/dev-test, and all the
+	// paths in the swagger spec will be appended to the server.
+	Server string
+
+	// Doer for performing requests, typically a *http.Client with any
+	// customized settings, such as certificate chains.
+	Client HttpRequestDoer
+
+	// A list of callbacks for modifying requests which are generated before sending over
+	// the network.
+	RequestEditors []RequestEditorFn
+}
+
+// ClientOption allows setting custom parameters during construction
+type ClientOption func(*Client) error
+
+// Creates a new Client, with reasonable defaults
+func NewClient(server string, opts ...ClientOption) (*Client, error) {
+	// create a client with sane default values
+	client := Client{
+		Server: server,
+	}
+	// mutate client and add all optional params
+	for _, o := range opts {
+		if err := o(&client); err != nil {
+			return nil, err
+		}
+	}
+	// ensure the server URL always has a trailing slash
+	if !strings.HasSuffix(client.Server, "/") {
+		client.Server += "/"
+	}
+	// create httpClient, if not already present
+	if client.Client == nil {
+		client.Client = &http.Client{}
+	}
+	return &client, nil
+}
+
+// WithHTTPClient allows overriding the default Doer, which is
+// automatically created using http.Client. This is useful for tests.
+func WithHTTPClient(doer HttpRequestDoer) ClientOption {
+	return func(c *Client) error {
+		c.Client = doer
+		return nil
+	}
+}
+
+// WithRequestEditorFn allows setting up a callback function, which will be
+// called right before sending the request. This can be used to mutate the request.
+func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
+	return func(c *Client) error {
+		c.RequestEditors = append(c.RequestEditors, fn)
+		return nil
+	}
+}
+
+// The interface specification for the client above.
+type ClientInterface interface {
+	// AdminConfig request
+	AdminConfig(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetAPIKeys request
+	GetAPIKeys(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CreateAPIKeyWithBody request with any body
+	CreateAPIKeyWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	CreateAPIKey(ctx context.Context, body CreateAPIKeyJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// RevokeAPIKey request
+	RevokeAPIKey(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetChains request
+	GetChains(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ExportTransactions request
+	ExportTransactions(ctx context.Context, address AddressPath, params *ExportTransactionsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetBalance request
+	GetBalance(ctx context.Context, address AddressPath, params *GetBalanceParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetBalanceHistory request
+	GetBalanceHistory(ctx context.Context, address AddressPath, params *GetBalanceHistoryParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetCurrentBlock request
+	GetCurrentBlock(ctx context.Context, params *GetCurrentBlockParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetTransactions request
+	GetTransactions(ctx context.Context, address AddressPath, params *GetTransactionsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GraphqlWithBody request with any body
+	GraphqlWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	Graphql(ctx context.Context, body GraphqlJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// Healthz request
+	Healthz(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// Metrics request
+	Metrics(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// Readyz request
+	Readyz(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// Subscribe request
+	Subscribe(ctx context.Context, address string, params *SubscribeParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetABIs request
+	GetABIs(ctx context.Context, params *GetABIsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeleteABI request
+	DeleteABI(ctx context.Context, address AddressPath, params *DeleteABIParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetABI request
+	GetABI(ctx context.Context, address AddressPath, params *GetABIParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// SetABIWithBody request with any body
+	SetABIWithBody(ctx context.Context, address AddressPath, params *SetABIParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	SetABI(ctx context.Context, address AddressPath, params *SetABIParams, body SetABIJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetCurrentBlockV2 request
+	GetCurrentBlockV2(ctx context.Context, params *GetCurrentBlockV2Params, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetAllLabels request
+	GetAllLabels(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeleteLabels request
+	DeleteLabels(ctx context.Context, address AddressPath, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetLabels request
+	GetLabels(ctx context.Context, address AddressPath, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// SetLabelsWithBody request with any body
+	SetLabelsWithBody(ctx context.Context, address AddressPath, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	SetLabels(ctx context.Context, address AddressPath, body SetLabelsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetSubscriptions request
+	GetSubscriptions(ctx context.Context, params *GetSubscriptionsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CreateSubscriptionWithBody request with any body
+	CreateSubscriptionWithBody(ctx context.Context, params *CreateSubscriptionParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	CreateSubscription(ctx context.Context, params *CreateSubscriptionParams, body CreateSubscriptionJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeleteSubscription request
+	DeleteSubscription(ctx context.Context, address AddressPath, params *DeleteSubscriptionParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetSubscription request
+	GetSubscription(ctx context.Context, address AddressPath, params *GetSubscriptionParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetSubscriptionTransactions request
+	GetSubscriptionTransactions(ctx context.Context, address AddressPath, params *GetSubscriptionTransactionsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+}
+
+func (c *Client) AdminConfig(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAdminConfigRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetAPIKeys(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetAPIKeysRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateAPIKeyWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateAPIKeyRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateAPIKey(ctx context.Context, body CreateAPIKeyJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateAPIKeyRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) RevokeAPIKey(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRevokeAPIKeyRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetChains(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetChainsRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ExportTransactions(ctx context.Context, address AddressPath, params *ExportTransactionsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewExportTransactionsRequest(c.Server, address, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetBalance(ctx context.Context, address AddressPath, params *GetBalanceParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetBalanceRequest(c.Server, address, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetBalanceHistory(ctx context.Context, address AddressPath, params *GetBalanceHistoryParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetBalanceHistoryRequest(c.Server, address, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetCurrentBlock(ctx context.Context, params *GetCurrentBlockParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetCurrentBlockRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetTransactions(ctx context.Context, address AddressPath, params *GetTransactionsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetTransactionsRequest(c.Server, address, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GraphqlWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGraphqlRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) Graphql(ctx context.Context, body GraphqlJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGraphqlRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) Healthz(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewHealthzRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) Metrics(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewMetricsRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) Readyz(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewReadyzRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) Subscribe(ctx context.Context, address string, params *SubscribeParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSubscribeRequest(c.Server, address, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetABIs(ctx context.Context, params *GetABIsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetABIsRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) DeleteABI(ctx context.Context, address AddressPath, params *DeleteABIParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteABIRequest(c.Server, address, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetABI(ctx context.Context, address AddressPath, params *GetABIParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetABIRequest(c.Server, address, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SetABIWithBody(ctx context.Context, address AddressPath, params *SetABIParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSetABIRequestWithBody(c.Server, address, params, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SetABI(ctx context.Context, address AddressPath, params *SetABIParams, body SetABIJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSetABIRequest(c.Server, address, params, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetCurrentBlockV2(ctx context.Context, params *GetCurrentBlockV2Params, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetCurrentBlockV2Request(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetAllLabels(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetAllLabelsRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) DeleteLabels(ctx context.Context, address AddressPath, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteLabelsRequest(c.Server, address)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetLabels(ctx context.Context, address AddressPath, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetLabelsRequest(c.Server, address)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SetLabelsWithBody(ctx context.Context, address AddressPath, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSetLabelsRequestWithBody(c.Server, address, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SetLabels(ctx context.Context, address AddressPath, body SetLabelsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSetLabelsRequest(c.Server, address, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetSubscriptions(ctx context.Context, params *GetSubscriptionsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetSubscriptionsRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateSubscriptionWithBody(ctx context.Context, params *CreateSubscriptionParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateSubscriptionRequestWithBody(c.Server, params, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateSubscription(ctx context.Context, params *CreateSubscriptionParams, body CreateSubscriptionJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateSubscriptionRequest(c.Server, params, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) DeleteSubscription(ctx context.Context, address AddressPath, params *DeleteSubscriptionParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteSubscriptionRequest(c.Server, address, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetSubscription(ctx context.Context, address AddressPath, params *GetSubscriptionParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetSubscriptionRequest(c.Server, address, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetSubscriptionTransactions(ctx context.Context, address AddressPath, params *GetSubscriptionTransactionsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetSubscriptionTransactionsRequest(c.Server, address, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// NewAdminConfigRequest generates requests for AdminConfig
+func NewAdminConfigRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/config")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetAPIKeysRequest generates requests for GetAPIKeys
+func NewGetAPIKeysRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/keys")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewCreateAPIKeyRequest calls the generic CreateAPIKey builder with application/json body
+func NewCreateAPIKeyRequest(server string, body CreateAPIKeyJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewCreateAPIKeyRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewCreateAPIKeyRequestWithBody generates requests for CreateAPIKey with any type of body
+func NewCreateAPIKeyRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/keys")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewRevokeAPIKeyRequest generates requests for RevokeAPIKey
+func NewRevokeAPIKeyRequest(server string, id string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/keys/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetChainsRequest generates requests for GetChains
+func NewGetChainsRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/chains")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewExportTransactionsRequest generates requests for ExportTransactions
+func NewExportTransactionsRequest(server string, address AddressPath, params *ExportTransactionsParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "address", runtime.ParamLocationPath, address)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/export/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Format != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "format", runtime.ParamLocationQuery, *params.Format); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Chain != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "chain", runtime.ParamLocationQuery, *params.Chain); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetBalanceRequest generates requests for GetBalance
+func NewGetBalanceRequest(server string, address AddressPath, params *GetBalanceParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "address", runtime.ParamLocationPath, address)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/get_balance/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Chain != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "chain", runtime.ParamLocationQuery, *params.Chain); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetBalanceHistoryRequest generates requests for GetBalanceHistory
+func NewGetBalanceHistoryRequest(server string, address AddressPath, params *GetBalanceHistoryParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "address", runtime.ParamLocationPath, address)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/get_balance_history/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Chain != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "chain", runtime.ParamLocationQuery, *params.Chain); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetCurrentBlockRequest generates requests for GetCurrentBlock
+func NewGetCurrentBlockRequest(server string, params *GetCurrentBlockParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/get_current_block")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Chain != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "chain", runtime.ParamLocationQuery, *params.Chain); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetTransactionsRequest generates requests for GetTransactions
+func NewGetTransactionsRequest(server string, address AddressPath, params *GetTransactionsParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "address", runtime.ParamLocationPath, address)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/get_transactions/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Amounts != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "amounts", runtime.ParamLocationQuery, *params.Amounts); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Chain != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "chain", runtime.ParamLocationQuery, *params.Chain); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGraphqlRequest calls the generic Graphql builder with application/json body
+func NewGraphqlRequest(server string, body GraphqlJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewGraphqlRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewGraphqlRequestWithBody generates requests for Graphql with any type of body
+func NewGraphqlRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/graphql")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewHealthzRequest generates requests for Healthz
+func NewHealthzRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/healthz")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewMetricsRequest generates requests for Metrics
+func NewMetricsRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/metrics")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewReadyzRequest generates requests for Readyz
+func NewReadyzRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/readyz")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewSubscribeRequest generates requests for Subscribe
+func NewSubscribeRequest(server string, address string, params *SubscribeParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "address", runtime.ParamLocationPath, address)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/subscribe/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Chain != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "chain", runtime.ParamLocationQuery, *params.Chain); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetABIsRequest generates requests for GetABIs
+func NewGetABIsRequest(server string, params *GetABIsParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v2/abis")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Chain != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "chain", runtime.ParamLocationQuery, *params.Chain); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewDeleteABIRequest generates requests for DeleteABI
+func NewDeleteABIRequest(server string, address AddressPath, params *DeleteABIParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "address", runtime.ParamLocationPath, address)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v2/abis/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Chain != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "chain", runtime.ParamLocationQuery, *params.Chain); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetABIRequest generates requests for GetABI
+func NewGetABIRequest(server string, address AddressPath, params *GetABIParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "address", runtime.ParamLocationPath, address)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v2/abis/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Chain != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "chain", runtime.ParamLocationQuery, *params.Chain); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewSetABIRequest calls the generic SetABI builder with application/json body
+func NewSetABIRequest(server string, address AddressPath, params *SetABIParams, body SetABIJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewSetABIRequestWithBody(server, address, params, "application/json", bodyReader)
+}
+
+// NewSetABIRequestWithBody generates requests for SetABI with any type of body
+func NewSetABIRequestWithBody(server string, address AddressPath, params *SetABIParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "address", runtime.ParamLocationPath, address)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v2/abis/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Chain != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "chain", runtime.ParamLocationQuery, *params.Chain); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetCurrentBlockV2Request generates requests for GetCurrentBlockV2
+func NewGetCurrentBlockV2Request(server string, params *GetCurrentBlockV2Params) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v2/blocks/current")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Chain != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "chain", runtime.ParamLocationQuery, *params.Chain); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetAllLabelsRequest generates requests for GetAllLabels
+func NewGetAllLabelsRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v2/labels")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewDeleteLabelsRequest generates requests for DeleteLabels
+func NewDeleteLabelsRequest(server string, address AddressPath) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "address", runtime.ParamLocationPath, address)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v2/labels/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetLabelsRequest generates requests for GetLabels
+func NewGetLabelsRequest(server string, address AddressPath) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "address", runtime.ParamLocationPath, address)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v2/labels/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewSetLabelsRequest calls the generic SetLabels builder with application/json body
+func NewSetLabelsRequest(server string, address AddressPath, body SetLabelsJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewSetLabelsRequestWithBody(server, address, "application/json", bodyReader)
+}
+
+// NewSetLabelsRequestWithBody generates requests for SetLabels with any type of body
+func NewSetLabelsRequestWithBody(server string, address AddressPath, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "address", runtime.ParamLocationPath, address)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v2/labels/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetSubscriptionsRequest generates requests for GetSubscriptions
+func NewGetSubscriptionsRequest(server string, params *GetSubscriptionsParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v2/subscriptions")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Chain != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "chain", runtime.ParamLocationQuery, *params.Chain); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewCreateSubscriptionRequest calls the generic CreateSubscription builder with application/json body
+func NewCreateSubscriptionRequest(server string, params *CreateSubscriptionParams, body CreateSubscriptionJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewCreateSubscriptionRequestWithBody(server, params, "application/json", bodyReader)
+}
+
+// NewCreateSubscriptionRequestWithBody generates requests for CreateSubscription with any type of body
+func NewCreateSubscriptionRequestWithBody(server string, params *CreateSubscriptionParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v2/subscriptions")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Chain != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "chain", runtime.ParamLocationQuery, *params.Chain); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewDeleteSubscriptionRequest generates requests for DeleteSubscription
+func NewDeleteSubscriptionRequest(server string, address AddressPath, params *DeleteSubscriptionParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "address", runtime.ParamLocationPath, address)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v2/subscriptions/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Chain != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "chain", runtime.ParamLocationQuery, *params.Chain); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetSubscriptionRequest generates requests for GetSubscription
+func NewGetSubscriptionRequest(server string, address AddressPath, params *GetSubscriptionParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "address", runtime.ParamLocationPath, address)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v2/subscriptions/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Chain != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "chain", runtime.ParamLocationQuery, *params.Chain); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetSubscriptionTransactionsRequest generates requests for GetSubscriptionTransactions
+func NewGetSubscriptionTransactionsRequest(server string, address AddressPath, params *GetSubscriptionTransactionsParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "address", runtime.ParamLocationPath, address)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v2/subscriptions/%s/transactions", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Amounts != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "amounts", runtime.ParamLocationQuery, *params.Amounts); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Chain != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "chain", runtime.ParamLocationQuery, *params.Chain); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
+	for _, r := range c.RequestEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	for _, r := range additionalEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ClientWithResponses builds on ClientInterface to offer response payloads
+type ClientWithResponses struct {
+	ClientInterface
+}
+
+// NewClientWithResponses creates a new ClientWithResponses, which wraps
+// Client with return type handling
+func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
+	client, err := NewClient(server, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientWithResponses{client}, nil
+}
+
+// WithBaseURL overrides the baseURL.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) error {
+		newBaseURL, err := url.Parse(baseURL)
+		if err != nil {
+			return err
+		}
+		c.Server = newBaseURL.String()
+		return nil
+	}
+}
+
+// ClientWithResponsesInterface is the interface specification for the client with responses above.
+type ClientWithResponsesInterface interface {
+	// AdminConfigWithResponse request
+	AdminConfigWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*AdminConfigResponse, error)
+
+	// GetAPIKeysWithResponse request
+	GetAPIKeysWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetAPIKeysResponse, error)
+
+	// CreateAPIKeyWithBodyWithResponse request with any body
+	CreateAPIKeyWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateAPIKeyResponse, error)
+
+	CreateAPIKeyWithResponse(ctx context.Context, body CreateAPIKeyJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateAPIKeyResponse, error)
+
+	// RevokeAPIKeyWithResponse request
+	RevokeAPIKeyWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*RevokeAPIKeyResponse, error)
+
+	// GetChainsWithResponse request
+	GetChainsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetChainsResponse, error)
+
+	// ExportTransactionsWithResponse request
+	ExportTransactionsWithResponse(ctx context.Context, address AddressPath, params *ExportTransactionsParams, reqEditors ...RequestEditorFn) (*ExportTransactionsResponse, error)
+
+	// GetBalanceWithResponse request
+	GetBalanceWithResponse(ctx context.Context, address AddressPath, params *GetBalanceParams, reqEditors ...RequestEditorFn) (*GetBalanceResponse, error)
+
+	// GetBalanceHistoryWithResponse request
+	GetBalanceHistoryWithResponse(ctx context.Context, address AddressPath, params *GetBalanceHistoryParams, reqEditors ...RequestEditorFn) (*GetBalanceHistoryResponse, error)
+
+	// GetCurrentBlockWithResponse request
+	GetCurrentBlockWithResponse(ctx context.Context, params *GetCurrentBlockParams, reqEditors ...RequestEditorFn) (*GetCurrentBlockResponse, error)
+
+	// GetTransactionsWithResponse request
+	GetTransactionsWithResponse(ctx context.Context, address AddressPath, params *GetTransactionsParams, reqEditors ...RequestEditorFn) (*GetTransactionsResponse, error)
+
+	// GraphqlWithBodyWithResponse request with any body
+	GraphqlWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*GraphqlResponse, error)
+
+	GraphqlWithResponse(ctx context.Context, body GraphqlJSONRequestBody, reqEditors ...RequestEditorFn) (*GraphqlResponse, error)
+
+	// HealthzWithResponse request
+	HealthzWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*HealthzResponse, error)
+
+	// MetricsWithResponse request
+	MetricsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*MetricsResponse, error)
+
+	// ReadyzWithResponse request
+	ReadyzWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ReadyzResponse, error)
+
+	// SubscribeWithResponse request
+	SubscribeWithResponse(ctx context.Context, address string, params *SubscribeParams, reqEditors ...RequestEditorFn) (*SubscribeResponse, error)
+
+	// GetABIsWithResponse request
+	GetABIsWithResponse(ctx context.Context, params *GetABIsParams, reqEditors ...RequestEditorFn) (*GetABIsResponse, error)
+
+	// DeleteABIWithResponse request
+	DeleteABIWithResponse(ctx context.Context, address AddressPath, params *DeleteABIParams, reqEditors ...RequestEditorFn) (*DeleteABIResponse, error)
+
+	// GetABIWithResponse request
+	GetABIWithResponse(ctx context.Context, address AddressPath, params *GetABIParams, reqEditors ...RequestEditorFn) (*GetABIResponse, error)
+
+	// SetABIWithBodyWithResponse request with any body
+	SetABIWithBodyWithResponse(ctx context.Context, address AddressPath, params *SetABIParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SetABIResponse, error)
+
+	SetABIWithResponse(ctx context.Context, address AddressPath, params *SetABIParams, body SetABIJSONRequestBody, reqEditors ...RequestEditorFn) (*SetABIResponse, error)
+
+	// GetCurrentBlockV2WithResponse request
+	GetCurrentBlockV2WithResponse(ctx context.Context, params *GetCurrentBlockV2Params, reqEditors ...RequestEditorFn) (*GetCurrentBlockV2Response, error)
+
+	// GetAllLabelsWithResponse request
+	GetAllLabelsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetAllLabelsResponse, error)
+
+	// DeleteLabelsWithResponse request
+	DeleteLabelsWithResponse(ctx context.Context, address AddressPath, reqEditors ...RequestEditorFn) (*DeleteLabelsResponse, error)
+
+	// GetLabelsWithResponse request
+	GetLabelsWithResponse(ctx context.Context, address AddressPath, reqEditors ...RequestEditorFn) (*GetLabelsResponse, error)
+
+	// SetLabelsWithBodyWithResponse request with any body
+	SetLabelsWithBodyWithResponse(ctx context.Context, address AddressPath, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SetLabelsResponse, error)
+
+	SetLabelsWithResponse(ctx context.Context, address AddressPath, body SetLabelsJSONRequestBody, reqEditors ...RequestEditorFn) (*SetLabelsResponse, error)
+
+	// GetSubscriptionsWithResponse request
+	GetSubscriptionsWithResponse(ctx context.Context, params *GetSubscriptionsParams, reqEditors ...RequestEditorFn) (*GetSubscriptionsResponse, error)
+
+	// CreateSubscriptionWithBodyWithResponse request with any body
+	CreateSubscriptionWithBodyWithResponse(ctx context.Context, params *CreateSubscriptionParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateSubscriptionResponse, error)
+
+	CreateSubscriptionWithResponse(ctx context.Context, params *CreateSubscriptionParams, body CreateSubscriptionJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateSubscriptionResponse, error)
+
+	// DeleteSubscriptionWithResponse request
+	DeleteSubscriptionWithResponse(ctx context.Context, address AddressPath, params *DeleteSubscriptionParams, reqEditors ...RequestEditorFn) (*DeleteSubscriptionResponse, error)
+
+	// GetSubscriptionWithResponse request
+	GetSubscriptionWithResponse(ctx context.Context, address AddressPath, params *GetSubscriptionParams, reqEditors ...RequestEditorFn) (*GetSubscriptionResponse, error)
+
+	// GetSubscriptionTransactionsWithResponse request
+	GetSubscriptionTransactionsWithResponse(ctx context.Context, address AddressPath, params *GetSubscriptionTransactionsParams, reqEditors ...RequestEditorFn) (*GetSubscriptionTransactionsResponse, error)
+}
+
+type AdminConfigResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ConfigResp
+}
+
+// Status returns HTTPResponse.Status
+func (r AdminConfigResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r AdminConfigResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetAPIKeysResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *GetAPIKeysResp
+}
+
+// Status returns HTTPResponse.Status
+func (r GetAPIKeysResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetAPIKeysResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CreateAPIKeyResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *APIKeyResp
+}
+
+// Status returns HTTPResponse.Status
+func (r CreateAPIKeyResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CreateAPIKeyResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type RevokeAPIKeyResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *OKResp
+}
+
+// Status returns HTTPResponse.Status
+func (r RevokeAPIKeyResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r RevokeAPIKeyResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetChainsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *GetChainsResp
+}
+
+// Status returns HTTPResponse.Status
+func (r GetChainsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetChainsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ExportTransactionsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r ExportTransactionsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ExportTransactionsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetBalanceResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *GetBalanceResp
+}
+
+// Status returns HTTPResponse.Status
+func (r GetBalanceResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetBalanceResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetBalanceHistoryResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *GetBalanceHistoryResp
+}
+
+// Status returns HTTPResponse.Status
+func (r GetBalanceHistoryResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetBalanceHistoryResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetCurrentBlockResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *GetCurrentBlockResp
+}
+
+// Status returns HTTPResponse.Status
+func (r GetCurrentBlockResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetCurrentBlockResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetTransactionsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Transactions
+}
+
+// Status returns HTTPResponse.Status
+func (r GetTransactionsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetTransactionsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GraphqlResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *GraphQLResponse
+	JSON400      *GraphQLResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GraphqlResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GraphqlResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type HealthzResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *HealthResp
+}
+
+// Status returns HTTPResponse.Status
+func (r HealthzResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r HealthzResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type MetricsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r MetricsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r MetricsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ReadyzResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *HealthReport
+	JSON503      *HealthReport
+}
+
+// Status returns HTTPResponse.Status
+func (r ReadyzResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ReadyzResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type SubscribeResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *SubscribeResp
+}
+
+// Status returns HTTPResponse.Status
+func (r SubscribeResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r SubscribeResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetABIsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *GetABIsResp
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetABIsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetABIsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteABIResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteABIResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteABIResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetABIResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ContractABIResp
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetABIResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetABIResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type SetABIResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ContractABIResp
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r SetABIResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r SetABIResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetCurrentBlockV2Response struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *GetCurrentBlockResp
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetCurrentBlockV2Response) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetCurrentBlockV2Response) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetAllLabelsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *GetAddressLabelsResp
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetAllLabelsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetAllLabelsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteLabelsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteLabelsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteLabelsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetLabelsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *AddressLabelsResp
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetLabelsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetLabelsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type SetLabelsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *AddressLabelsResp
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r SetLabelsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r SetLabelsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetSubscriptionsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *GetSubscriptionsResp
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetSubscriptionsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetSubscriptionsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CreateSubscriptionResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *SubscriptionResp
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r CreateSubscriptionResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CreateSubscriptionResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteSubscriptionResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteSubscriptionResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteSubscriptionResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetSubscriptionResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *SubscriptionResp
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetSubscriptionResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetSubscriptionResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetSubscriptionTransactionsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Transactions
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetSubscriptionTransactionsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetSubscriptionTransactionsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// AdminConfigWithResponse request returning *AdminConfigResponse
+func (c *ClientWithResponses) AdminConfigWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*AdminConfigResponse, error) {
+	rsp, err := c.AdminConfig(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAdminConfigResponse(rsp)
+}
+
+// GetAPIKeysWithResponse request returning *GetAPIKeysResponse
+func (c *ClientWithResponses) GetAPIKeysWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetAPIKeysResponse, error) {
+	rsp, err := c.GetAPIKeys(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetAPIKeysResponse(rsp)
+}
+
+// CreateAPIKeyWithBodyWithResponse request with arbitrary body returning *CreateAPIKeyResponse
+func (c *ClientWithResponses) CreateAPIKeyWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateAPIKeyResponse, error) {
+	rsp, err := c.CreateAPIKeyWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateAPIKeyResponse(rsp)
+}
+
+func (c *ClientWithResponses) CreateAPIKeyWithResponse(ctx context.Context, body CreateAPIKeyJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateAPIKeyResponse, error) {
+	rsp, err := c.CreateAPIKey(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateAPIKeyResponse(rsp)
+}
+
+// RevokeAPIKeyWithResponse request returning *RevokeAPIKeyResponse
+func (c *ClientWithResponses) RevokeAPIKeyWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*RevokeAPIKeyResponse, error) {
+	rsp, err := c.RevokeAPIKey(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRevokeAPIKeyResponse(rsp)
+}
+
+// GetChainsWithResponse request returning *GetChainsResponse
+func (c *ClientWithResponses) GetChainsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetChainsResponse, error) {
+	rsp, err := c.GetChains(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetChainsResponse(rsp)
+}
+
+// ExportTransactionsWithResponse request returning *ExportTransactionsResponse
+func (c *ClientWithResponses) ExportTransactionsWithResponse(ctx context.Context, address AddressPath, params *ExportTransactionsParams, reqEditors ...RequestEditorFn) (*ExportTransactionsResponse, error) {
+	rsp, err := c.ExportTransactions(ctx, address, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseExportTransactionsResponse(rsp)
+}
+
+// GetBalanceWithResponse request returning *GetBalanceResponse
+func (c *ClientWithResponses) GetBalanceWithResponse(ctx context.Context, address AddressPath, params *GetBalanceParams, reqEditors ...RequestEditorFn) (*GetBalanceResponse, error) {
+	rsp, err := c.GetBalance(ctx, address, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetBalanceResponse(rsp)
+}
+
+// GetBalanceHistoryWithResponse request returning *GetBalanceHistoryResponse
+func (c *ClientWithResponses) GetBalanceHistoryWithResponse(ctx context.Context, address AddressPath, params *GetBalanceHistoryParams, reqEditors ...RequestEditorFn) (*GetBalanceHistoryResponse, error) {
+	rsp, err := c.GetBalanceHistory(ctx, address, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetBalanceHistoryResponse(rsp)
+}
+
+// GetCurrentBlockWithResponse request returning *GetCurrentBlockResponse
+func (c *ClientWithResponses) GetCurrentBlockWithResponse(ctx context.Context, params *GetCurrentBlockParams, reqEditors ...RequestEditorFn) (*GetCurrentBlockResponse, error) {
+	rsp, err := c.GetCurrentBlock(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetCurrentBlockResponse(rsp)
+}
+
+// GetTransactionsWithResponse request returning *GetTransactionsResponse
+func (c *ClientWithResponses) GetTransactionsWithResponse(ctx context.Context, address AddressPath, params *GetTransactionsParams, reqEditors ...RequestEditorFn) (*GetTransactionsResponse, error) {
+	rsp, err := c.GetTransactions(ctx, address, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetTransactionsResponse(rsp)
+}
+
+// GraphqlWithBodyWithResponse request with arbitrary body returning *GraphqlResponse
+func (c *ClientWithResponses) GraphqlWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*GraphqlResponse, error) {
+	rsp, err := c.GraphqlWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGraphqlResponse(rsp)
+}
+
+func (c *ClientWithResponses) GraphqlWithResponse(ctx context.Context, body GraphqlJSONRequestBody, reqEditors ...RequestEditorFn) (*GraphqlResponse, error) {
+	rsp, err := c.Graphql(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGraphqlResponse(rsp)
+}
+
+// HealthzWithResponse request returning *HealthzResponse
+func (c *ClientWithResponses) HealthzWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*HealthzResponse, error) {
+	rsp, err := c.Healthz(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseHealthzResponse(rsp)
+}
+
+// MetricsWithResponse request returning *MetricsResponse
+func (c *ClientWithResponses) MetricsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*MetricsResponse, error) {
+	rsp, err := c.Metrics(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseMetricsResponse(rsp)
+}
+
+// ReadyzWithResponse request returning *ReadyzResponse
+func (c *ClientWithResponses) ReadyzWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ReadyzResponse, error) {
+	rsp, err := c.Readyz(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseReadyzResponse(rsp)
+}
+
+// SubscribeWithResponse request returning *SubscribeResponse
+func (c *ClientWithResponses) SubscribeWithResponse(ctx context.Context, address string, params *SubscribeParams, reqEditors ...RequestEditorFn) (*SubscribeResponse, error) {
+	rsp, err := c.Subscribe(ctx, address, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSubscribeResponse(rsp)
+}
+
+// GetABIsWithResponse request returning *GetABIsResponse
+func (c *ClientWithResponses) GetABIsWithResponse(ctx context.Context, params *GetABIsParams, reqEditors ...RequestEditorFn) (*GetABIsResponse, error) {
+	rsp, err := c.GetABIs(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetABIsResponse(rsp)
+}
+
+// DeleteABIWithResponse request returning *DeleteABIResponse
+func (c *ClientWithResponses) DeleteABIWithResponse(ctx context.Context, address AddressPath, params *DeleteABIParams, reqEditors ...RequestEditorFn) (*DeleteABIResponse, error) {
+	rsp, err := c.DeleteABI(ctx, address, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteABIResponse(rsp)
+}
+
+// GetABIWithResponse request returning *GetABIResponse
+func (c *ClientWithResponses) GetABIWithResponse(ctx context.Context, address AddressPath, params *GetABIParams, reqEditors ...RequestEditorFn) (*GetABIResponse, error) {
+	rsp, err := c.GetABI(ctx, address, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetABIResponse(rsp)
+}
+
+// SetABIWithBodyWithResponse request with arbitrary body returning *SetABIResponse
+func (c *ClientWithResponses) SetABIWithBodyWithResponse(ctx context.Context, address AddressPath, params *SetABIParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SetABIResponse, error) {
+	rsp, err := c.SetABIWithBody(ctx, address, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSetABIResponse(rsp)
+}
+
+func (c *ClientWithResponses) SetABIWithResponse(ctx context.Context, address AddressPath, params *SetABIParams, body SetABIJSONRequestBody, reqEditors ...RequestEditorFn) (*SetABIResponse, error) {
+	rsp, err := c.SetABI(ctx, address, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSetABIResponse(rsp)
+}
+
+// GetCurrentBlockV2WithResponse request returning *GetCurrentBlockV2Response
+func (c *ClientWithResponses) GetCurrentBlockV2WithResponse(ctx context.Context, params *GetCurrentBlockV2Params, reqEditors ...RequestEditorFn) (*GetCurrentBlockV2Response, error) {
+	rsp, err := c.GetCurrentBlockV2(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetCurrentBlockV2Response(rsp)
+}
+
+// GetAllLabelsWithResponse request returning *GetAllLabelsResponse
+func (c *ClientWithResponses) GetAllLabelsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetAllLabelsResponse, error) {
+	rsp, err := c.GetAllLabels(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetAllLabelsResponse(rsp)
+}
+
+// DeleteLabelsWithResponse request returning *DeleteLabelsResponse
+func (c *ClientWithResponses) DeleteLabelsWithResponse(ctx context.Context, address AddressPath, reqEditors ...RequestEditorFn) (*DeleteLabelsResponse, error) {
+	rsp, err := c.DeleteLabels(ctx, address, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteLabelsResponse(rsp)
+}
+
+// GetLabelsWithResponse request returning *GetLabelsResponse
+func (c *ClientWithResponses) GetLabelsWithResponse(ctx context.Context, address AddressPath, reqEditors ...RequestEditorFn) (*GetLabelsResponse, error) {
+	rsp, err := c.GetLabels(ctx, address, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetLabelsResponse(rsp)
+}
+
+// SetLabelsWithBodyWithResponse request with arbitrary body returning *SetLabelsResponse
+func (c *ClientWithResponses) SetLabelsWithBodyWithResponse(ctx context.Context, address AddressPath, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SetLabelsResponse, error) {
+	rsp, err := c.SetLabelsWithBody(ctx, address, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSetLabelsResponse(rsp)
+}
+
+func (c *ClientWithResponses) SetLabelsWithResponse(ctx context.Context, address AddressPath, body SetLabelsJSONRequestBody, reqEditors ...RequestEditorFn) (*SetLabelsResponse, error) {
+	rsp, err := c.SetLabels(ctx, address, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSetLabelsResponse(rsp)
+}
+
+// GetSubscriptionsWithResponse request returning *GetSubscriptionsResponse
+func (c *ClientWithResponses) GetSubscriptionsWithResponse(ctx context.Context, params *GetSubscriptionsParams, reqEditors ...RequestEditorFn) (*GetSubscriptionsResponse, error) {
+	rsp, err := c.GetSubscriptions(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetSubscriptionsResponse(rsp)
+}
+
+// CreateSubscriptionWithBodyWithResponse request with arbitrary body returning *CreateSubscriptionResponse
+func (c *ClientWithResponses) CreateSubscriptionWithBodyWithResponse(ctx context.Context, params *CreateSubscriptionParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateSubscriptionResponse, error) {
+	rsp, err := c.CreateSubscriptionWithBody(ctx, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateSubscriptionResponse(rsp)
+}
+
+func (c *ClientWithResponses) CreateSubscriptionWithResponse(ctx context.Context, params *CreateSubscriptionParams, body CreateSubscriptionJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateSubscriptionResponse, error) {
+	rsp, err := c.CreateSubscription(ctx, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateSubscriptionResponse(rsp)
+}
+
+// DeleteSubscriptionWithResponse request returning *DeleteSubscriptionResponse
+func (c *ClientWithResponses) DeleteSubscriptionWithResponse(ctx context.Context, address AddressPath, params *DeleteSubscriptionParams, reqEditors ...RequestEditorFn) (*DeleteSubscriptionResponse, error) {
+	rsp, err := c.DeleteSubscription(ctx, address, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteSubscriptionResponse(rsp)
+}
+
+// GetSubscriptionWithResponse request returning *GetSubscriptionResponse
+func (c *ClientWithResponses) GetSubscriptionWithResponse(ctx context.Context, address AddressPath, params *GetSubscriptionParams, reqEditors ...RequestEditorFn) (*GetSubscriptionResponse, error) {
+	rsp, err := c.GetSubscription(ctx, address, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetSubscriptionResponse(rsp)
+}
+
+// GetSubscriptionTransactionsWithResponse request returning *GetSubscriptionTransactionsResponse
+func (c *ClientWithResponses) GetSubscriptionTransactionsWithResponse(ctx context.Context, address AddressPath, params *GetSubscriptionTransactionsParams, reqEditors ...RequestEditorFn) (*GetSubscriptionTransactionsResponse, error) {
+	rsp, err := c.GetSubscriptionTransactions(ctx, address, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetSubscriptionTransactionsResponse(rsp)
+}
+
+// ParseAdminConfigResponse parses an HTTP response from a AdminConfigWithResponse call
+func ParseAdminConfigResponse(rsp *http.Response) (*AdminConfigResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &AdminConfigResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ConfigResp
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetAPIKeysResponse parses an HTTP response from a GetAPIKeysWithResponse call
+func ParseGetAPIKeysResponse(rsp *http.Response) (*GetAPIKeysResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetAPIKeysResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest GetAPIKeysResp
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseCreateAPIKeyResponse parses an HTTP response from a CreateAPIKeyWithResponse call
+func ParseCreateAPIKeyResponse(rsp *http.Response) (*CreateAPIKeyResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CreateAPIKeyResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest APIKeyResp
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseRevokeAPIKeyResponse parses an HTTP response from a RevokeAPIKeyWithResponse call
+func ParseRevokeAPIKeyResponse(rsp *http.Response) (*RevokeAPIKeyResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &RevokeAPIKeyResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest OKResp
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetChainsResponse parses an HTTP response from a GetChainsWithResponse call
+func ParseGetChainsResponse(rsp *http.Response) (*GetChainsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetChainsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest GetChainsResp
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseExportTransactionsResponse parses an HTTP response from a ExportTransactionsWithResponse call
+func ParseExportTransactionsResponse(rsp *http.Response) (*ExportTransactionsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ExportTransactionsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
+// ParseGetBalanceResponse parses an HTTP response from a GetBalanceWithResponse call
+func ParseGetBalanceResponse(rsp *http.Response) (*GetBalanceResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetBalanceResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest GetBalanceResp
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetBalanceHistoryResponse parses an HTTP response from a GetBalanceHistoryWithResponse call
+func ParseGetBalanceHistoryResponse(rsp *http.Response) (*GetBalanceHistoryResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetBalanceHistoryResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest GetBalanceHistoryResp
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetCurrentBlockResponse parses an HTTP response from a GetCurrentBlockWithResponse call
+func ParseGetCurrentBlockResponse(rsp *http.Response) (*GetCurrentBlockResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetCurrentBlockResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest GetCurrentBlockResp
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetTransactionsResponse parses an HTTP response from a GetTransactionsWithResponse call
+func ParseGetTransactionsResponse(rsp *http.Response) (*GetTransactionsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetTransactionsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Transactions
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGraphqlResponse parses an HTTP response from a GraphqlWithResponse call
+func ParseGraphqlResponse(rsp *http.Response) (*GraphqlResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GraphqlResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest GraphQLResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest GraphQLResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseHealthzResponse parses an HTTP response from a HealthzWithResponse call
+func ParseHealthzResponse(rsp *http.Response) (*HealthzResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &HealthzResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest HealthResp
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseMetricsResponse parses an HTTP response from a MetricsWithResponse call
+func ParseMetricsResponse(rsp *http.Response) (*MetricsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &MetricsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
+// ParseReadyzResponse parses an HTTP response from a ReadyzWithResponse call
+func ParseReadyzResponse(rsp *http.Response) (*ReadyzResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ReadyzResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest HealthReport
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 503:
+		var dest HealthReport
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON503 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseSubscribeResponse parses an HTTP response from a SubscribeWithResponse call
+func ParseSubscribeResponse(rsp *http.Response) (*SubscribeResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &SubscribeResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest SubscribeResp
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetABIsResponse parses an HTTP response from a GetABIsWithResponse call
+func ParseGetABIsResponse(rsp *http.Response) (*GetABIsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetABIsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest GetABIsResp
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDeleteABIResponse parses an HTTP response from a DeleteABIWithResponse call
+func ParseDeleteABIResponse(rsp *http.Response) (*DeleteABIResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteABIResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetABIResponse parses an HTTP response from a GetABIWithResponse call
+func ParseGetABIResponse(rsp *http.Response) (*GetABIResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetABIResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ContractABIResp
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseSetABIResponse parses an HTTP response from a SetABIWithResponse call
+func ParseSetABIResponse(rsp *http.Response) (*SetABIResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &SetABIResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ContractABIResp
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetCurrentBlockV2Response parses an HTTP response from a GetCurrentBlockV2WithResponse call
+func ParseGetCurrentBlockV2Response(rsp *http.Response) (*GetCurrentBlockV2Response, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetCurrentBlockV2Response{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest GetCurrentBlockResp
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetAllLabelsResponse parses an HTTP response from a GetAllLabelsWithResponse call
+func ParseGetAllLabelsResponse(rsp *http.Response) (*GetAllLabelsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetAllLabelsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest GetAddressLabelsResp
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDeleteLabelsResponse parses an HTTP response from a DeleteLabelsWithResponse call
+func ParseDeleteLabelsResponse(rsp *http.Response) (*DeleteLabelsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteLabelsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetLabelsResponse parses an HTTP response from a GetLabelsWithResponse call
+func ParseGetLabelsResponse(rsp *http.Response) (*GetLabelsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetLabelsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest AddressLabelsResp
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseSetLabelsResponse parses an HTTP response from a SetLabelsWithResponse call
+func ParseSetLabelsResponse(rsp *http.Response) (*SetLabelsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &SetLabelsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest AddressLabelsResp
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetSubscriptionsResponse parses an HTTP response from a GetSubscriptionsWithResponse call
+func ParseGetSubscriptionsResponse(rsp *http.Response) (*GetSubscriptionsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetSubscriptionsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest GetSubscriptionsResp
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseCreateSubscriptionResponse parses an HTTP response from a CreateSubscriptionWithResponse call
+func ParseCreateSubscriptionResponse(rsp *http.Response) (*CreateSubscriptionResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CreateSubscriptionResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest SubscriptionResp
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDeleteSubscriptionResponse parses an HTTP response from a DeleteSubscriptionWithResponse call
+func ParseDeleteSubscriptionResponse(rsp *http.Response) (*DeleteSubscriptionResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteSubscriptionResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetSubscriptionResponse parses an HTTP response from a GetSubscriptionWithResponse call
+func ParseGetSubscriptionResponse(rsp *http.Response) (*GetSubscriptionResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetSubscriptionResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest SubscriptionResp
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetSubscriptionTransactionsResponse parses an HTTP response from a GetSubscriptionTransactionsWithResponse call
+func ParseGetSubscriptionTransactionsResponse(rsp *http.Response) (*GetSubscriptionTransactionsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetSubscriptionTransactionsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Transactions
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
@@ -0,0 +1,17 @@
+// Package client is a Go client of ethereum_subscriber-api generated from internal/app/handlers/openapi.yaml.
+// Run make .generate-client after changing the spec.
+//
+// ClientWithResponses decodes responses into typed structs, for example:
+//
+//	c, err := client.NewClientWithResponses("http://localhost:8080", client.WithRequestEditorFn(
+//		func(ctx context.Context, req *http.Request) error {
+//			req.Header.Set("Authorization", "Bearer "+apiKey)
+//			return nil
+//		}))
+//	amounts := client.GetSubscriptionTransactionsParamsAmountsDecimal
+//	resp, err := c.GetSubscriptionTransactionsWithResponse(ctx, address, &client.GetSubscriptionTransactionsParams{Amounts: &amounts})
+//	transactions, err := resp.JSON200.AsGetDecimalTransactionsResp()
+//
+// Transactions are requested in decimal amounts mode, amounts in wei are decimal strings, so they keep precision.
+// In raw amounts mode they are decoded by AsGetRawTransactionsResp, amounts are json.Number in wei
+package client